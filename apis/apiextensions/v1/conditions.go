@@ -41,6 +41,14 @@ const (
 
 	// A TypeResponsive indicates whether the resource is responsive to changes.
 	TypeResponsive xpv1.ConditionType = "Responsive"
+
+	// A TypeCompatibleSchema XRD's schema is compatible with the schema of
+	// the CRD it has already applied for its composite resource.
+	TypeCompatibleSchema xpv1.ConditionType = "CompatibleSchema"
+
+	// A TypeValidConversion XRD's conversion function can convert its
+	// composite resources between versions.
+	TypeValidConversion xpv1.ConditionType = "ValidConversion"
 )
 
 // Reasons a resource is or is not established or offered.
@@ -56,6 +64,12 @@ const (
 
 	ReasonWatchCircuitOpen   xpv1.ConditionReason = "WatchCircuitOpen"
 	ReasonWatchCircuitClosed xpv1.ConditionReason = "WatchCircuitClosed"
+
+	ReasonCompatibleSchema      xpv1.ConditionReason = "CompatibleSchema"
+	ReasonBreakingSchemaChanges xpv1.ConditionReason = "BreakingSchemaChanges"
+
+	ReasonValidConversion   xpv1.ConditionReason = "ValidConversion"
+	ReasonInvalidConversion xpv1.ConditionReason = "InvalidConversion"
 )
 
 // WatchingComposite indicates that Crossplane has defined and is watching for a
@@ -146,6 +160,53 @@ func WatchCircuitClosed() xpv1.Condition {
 	}
 }
 
+// CompatibleSchema indicates that the XRD's schema doesn't make breaking
+// changes to the schema of its composite resource's CRD.
+func CompatibleSchema() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeCompatibleSchema,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonCompatibleSchema,
+	}
+}
+
+// BreakingSchemaChanges indicates that the XRD's schema makes breaking changes
+// to the schema of its composite resource's CRD, which may invalidate existing
+// composite resources.
+func BreakingSchemaChanges(message string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeCompatibleSchema,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonBreakingSchemaChanges,
+		Message:            message,
+	}
+}
+
+// ValidConversion indicates that the XRD's conversion function can convert its
+// composite resources between versions.
+func ValidConversion() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeValidConversion,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonValidConversion,
+	}
+}
+
+// InvalidConversion indicates that the XRD's conversion function can't convert
+// its composite resources between versions.
+func InvalidConversion(message string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeValidConversion,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonInvalidConversion,
+		Message:            message,
+	}
+}
+
 // IsSystemConditionType returns true if the condition type is a system
 // condition. This includes both crossplane-runtime system conditions and
 // apiextensions-specific system conditions like the circuit breaker.
@@ -38,6 +38,7 @@ const (
 // CompositeResourceDefinitionSpec specifies the desired state of the definition.
 // +kubebuilder:validation:XValidation:rule="self.scope == 'LegacyCluster' || !has(self.claimNames)",message="Only LegacyCluster composite resources can offer claims"
 // +kubebuilder:validation:XValidation:rule="self.scope == 'LegacyCluster' || !has(self.connectionSecretKeys)",message="Only LegacyCluster composite resources support connection secrets"
+// +kubebuilder:validation:XValidation:rule="!(has(self.conversion) && has(self.conversionFunction))",message="conversion and conversionFunction are mutually exclusive"
 type CompositeResourceDefinitionSpec struct {
 	// Group specifies the API group of the defined composite resource.
 	// Composite resources are served under `/apis/<group>/...`. Must match the
@@ -126,6 +127,14 @@ type CompositeResourceDefinitionSpec struct {
 	// +kubebuilder:validation:XValidation:rule="self.strategy == 'Webhook' && has(self.webhook)",message="Webhook configuration is required when conversion strategy is Webhook"
 	Conversion *extv1.CustomResourceConversion `json:"conversion,omitempty"`
 
+	// ConversionFunction configures Crossplane to convert the defined
+	// composite resource between versions by calling a function. Crossplane
+	// serves the CRD's conversion webhook, and delegates each conversion to
+	// the function. The function must have the conversion capability.
+	// Mutually exclusive with Conversion.
+	// +optional
+	ConversionFunction *ConversionFunction `json:"conversionFunction,omitempty"`
+
 	// Metadata specifies the desired metadata for the defined composite resource and claim CRD's.
 	// +optional
 	Metadata *CompositeResourceDefinitionSpecMetadata `json:"metadata,omitempty"`
@@ -156,6 +165,19 @@ type CompositeResourceDefinitionSpecMetadata struct {
 	Annotations map[string]string `json:"annotations,omitempty"`
 }
 
+// A ConversionFunction converts composite resources between versions.
+type ConversionFunction struct {
+	// FunctionRef is a reference to the function that converts composite
+	// resources.
+	FunctionRef FunctionReference `json:"functionRef"`
+
+	// Input is an optional, function-specific input.
+	// +optional
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:EmbeddedResource
+	Input *runtime.RawExtension `json:"input,omitempty"`
+}
+
 // CompositeResourceDefinitionVersion describes a version of an XR.
 type CompositeResourceDefinitionVersion struct {
 	// Name of this version, e.g. “v1”, “v2beta1”, etc. Composite resources are
@@ -153,6 +153,11 @@ func (in *CompositeResourceDefinitionSpec) DeepCopyInto(out *CompositeResourceDe
 		*out = new(apiextensionsv1.CustomResourceConversion)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ConversionFunction != nil {
+		in, out := &in.ConversionFunction, &out.ConversionFunction
+		*out = new(ConversionFunction)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Metadata != nil {
 		in, out := &in.Metadata, &out.Metadata
 		*out = new(CompositeResourceDefinitionSpecMetadata)
@@ -471,6 +476,27 @@ func (in *CompositionSpec) DeepCopy() *CompositionSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConversionFunction) DeepCopyInto(out *ConversionFunction) {
+	*out = *in
+	out.FunctionRef = in.FunctionRef
+	if in.Input != nil {
+		in, out := &in.Input, &out.Input
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConversionFunction.
+func (in *ConversionFunction) DeepCopy() *ConversionFunction {
+	if in == nil {
+		return nil
+	}
+	out := new(ConversionFunction)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FunctionCredentials) DeepCopyInto(out *FunctionCredentials) {
 	*out = *in
@@ -37,6 +37,7 @@ const (
 // CompositeResourceDefinitionSpec specifies the desired state of the definition.
 // +kubebuilder:validation:XValidation:rule="!has(self.claimNames)",message="Claims aren't supported in apiextensions.crossplane.io/v2"
 // +kubebuilder:validation:XValidation:rule="!has(self.connectionSecretKeys)",message="XR connection secrets aren't supported in apiextensions.crossplane.io/v2"
+// +kubebuilder:validation:XValidation:rule="!(has(self.conversion) && has(self.conversionFunction))",message="conversion and conversionFunction are mutually exclusive"
 type CompositeResourceDefinitionSpec struct {
 	// Group specifies the API group of the defined composite resource.
 	// Composite resources are served under `/apis/<group>/...`. Must match the
@@ -93,6 +94,14 @@ type CompositeResourceDefinitionSpec struct {
 	// +optional
 	Conversion *extv1.CustomResourceConversion `json:"conversion,omitempty"`
 
+	// ConversionFunction configures Crossplane to convert the defined
+	// composite resource between versions by calling a function. Crossplane
+	// serves the CRD's conversion webhook, and delegates each conversion to
+	// the function. The function must have the conversion capability.
+	// Mutually exclusive with Conversion.
+	// +optional
+	ConversionFunction *ConversionFunction `json:"conversionFunction,omitempty"`
+
 	// Metadata specifies the desired metadata for the defined composite resource and claim CRD's.
 	// +optional
 	Metadata *CompositeResourceDefinitionSpecMetadata `json:"metadata,omitempty"`
@@ -154,6 +163,25 @@ type CompositeResourceDefinitionSpecMetadata struct {
 	Annotations map[string]string `json:"annotations,omitempty"`
 }
 
+// A ConversionFunction converts composite resources between versions.
+type ConversionFunction struct {
+	// FunctionRef is a reference to the function that converts composite
+	// resources.
+	FunctionRef FunctionReference `json:"functionRef"`
+
+	// Input is an optional, function-specific input.
+	// +optional
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:EmbeddedResource
+	Input *runtime.RawExtension `json:"input,omitempty"`
+}
+
+// A FunctionReference references a function.
+type FunctionReference struct {
+	// Name of the referenced function.
+	Name string `json:"name"`
+}
+
 // CompositeResourceDefinitionVersion describes a version of an XR.
 type CompositeResourceDefinitionVersion struct {
 	// Name of this version, e.g. “v1”, “v2beta1”, etc. Composite resources are
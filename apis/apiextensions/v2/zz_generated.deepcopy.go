@@ -133,6 +133,11 @@ func (in *CompositeResourceDefinitionSpec) DeepCopyInto(out *CompositeResourceDe
 		*out = new(apiextensionsv1.CustomResourceConversion)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ConversionFunction != nil {
+		in, out := &in.ConversionFunction, &out.ConversionFunction
+		*out = new(ConversionFunction)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Metadata != nil {
 		in, out := &in.Metadata, &out.Metadata
 		*out = new(CompositeResourceDefinitionSpecMetadata)
@@ -277,6 +282,42 @@ func (in *CompositionReference) DeepCopy() *CompositionReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConversionFunction) DeepCopyInto(out *ConversionFunction) {
+	*out = *in
+	out.FunctionRef = in.FunctionRef
+	if in.Input != nil {
+		in, out := &in.Input, &out.Input
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConversionFunction.
+func (in *ConversionFunction) DeepCopy() *ConversionFunction {
+	if in == nil {
+		return nil
+	}
+	out := new(ConversionFunction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FunctionReference) DeepCopyInto(out *FunctionReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FunctionReference.
+func (in *FunctionReference) DeepCopy() *FunctionReference {
+	if in == nil {
+		return nil
+	}
+	out := new(FunctionReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TypeReference) DeepCopyInto(out *TypeReference) {
 	*out = *in
@@ -30,16 +30,31 @@ const (
 	// pipeline of functions, each of which is responsible for implementing
 	// its logic.
 	OperationModePipeline OperationMode = "Pipeline"
+
+	// OperationModeFanOut indicates that an Operation runs its pipeline of
+	// functions once for each resource matched by its fan-out targets.
+	OperationModeFanOut OperationMode = "FanOut"
 )
 
+// RequirementNameFanOutTarget is the requirement name used to inject the
+// current fan-out target into each pipeline step of a FanOut Operation.
+const RequirementNameFanOutTarget = "ops.crossplane.io/fan-out-target"
+
 // OperationSpec specifies desired state of an operation.
+// +kubebuilder:validation:XValidation:rule="self.mode != 'FanOut' || has(self.fanOut)",message="fanOut must be specified when mode is FanOut"
+// +kubebuilder:validation:XValidation:rule="self.mode != 'FanOut' || self.pipeline.all(s, !has(s.waitFor))",message="pipeline steps may not specify waitFor when mode is FanOut"
 type OperationSpec struct {
 	// Mode controls what type or "mode" of operation will be used.
 	//
 	// "Pipeline" indicates that an Operation specifies a pipeline of
 	// functions, each of which is responsible for implementing its logic.
 	//
-	// +kubebuilder:validation:Enum=Pipeline
+	// "FanOut" indicates that an Operation runs its pipeline once for each
+	// resource matched by spec.fanOut.targets. The matched resource is
+	// injected into every pipeline step as a required resource named
+	// "ops.crossplane.io/fan-out-target".
+	//
+	// +kubebuilder:validation:Enum=Pipeline;FanOut
 	// +kubebuilder:default=Pipeline
 	Mode OperationMode `json:"mode"`
 
@@ -56,6 +71,59 @@ type OperationSpec struct {
 	// +optional
 	// +kubebuilder:default:5
 	RetryLimit *int64 `json:"retryLimit,omitempty"`
+
+	// FanOut configures how a FanOut mode operation selects its targets and
+	// how many of them it processes at once. It's required when mode is
+	// FanOut, and ignored otherwise.
+	// +optional
+	FanOut *FanOut `json:"fanOut,omitempty"`
+}
+
+// FanOut configures an operation that runs its pipeline once per target.
+type FanOut struct {
+	// Targets selects the resources the pipeline will run against.
+	Targets FanOutTargets `json:"targets"`
+
+	// MaxConcurrency is the maximum number of targets the pipeline will run
+	// against at the same time.
+	// +optional
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=1
+	MaxConcurrency *int64 `json:"maxConcurrency,omitempty"`
+
+	// MaxUnavailable is the number of targets the pipeline may fail against
+	// before the operation stops processing new targets and is marked
+	// failed. Targets that fail aren't retried.
+	// +optional
+	// +kubebuilder:default=0
+	// +kubebuilder:validation:Minimum=0
+	MaxUnavailable *int64 `json:"maxUnavailable,omitempty"`
+
+	// TargetsPerMinute is the maximum number of targets the pipeline will
+	// start running against per minute. If unset the pipeline starts running
+	// against targets as fast as maxConcurrency allows.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	TargetsPerMinute *int64 `json:"targetsPerMinute,omitempty"`
+}
+
+// FanOutTargets selects the resources a FanOut operation runs against.
+type FanOutTargets struct {
+	// APIVersion of the resources to select.
+	APIVersion string `json:"apiVersion"`
+
+	// Kind of the resources to select.
+	Kind string `json:"kind"`
+
+	// MatchLabels selects resources by label. If empty, all resources of the
+	// specified kind are selected.
+	// +optional
+	MatchLabels map[string]string `json:"matchLabels,omitempty"`
+
+	// Namespace selects resources in a specific namespace. If empty, all
+	// namespaces are selected. Only applicable for namespaced resources.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
 }
 
 // A PipelineStep in an operation function pipeline.
@@ -87,6 +155,40 @@ type PipelineStep struct {
 	// request them first.
 	// +optional
 	Requirements *FunctionRequirements `json:"requirements,omitempty"`
+
+	// WaitFor makes the operation apply the desired resources produced by
+	// this and any earlier steps once this step has run, then wait until
+	// they satisfy a condition before running the next step.
+	//
+	// Each group of steps that ends with a step that waits starts with empty
+	// desired state and function context. Use requirements to pass resources
+	// applied by an earlier group of steps to a later one.
+	//
+	// Steps that wait aren't supported in FanOut mode.
+	// +optional
+	WaitFor *StepWait `json:"waitFor,omitempty"`
+}
+
+// StepWait specifies what a pipeline step waits for.
+// +kubebuilder:validation:XValidation:rule="!(has(self.conditionType) && has(self.expression))",message="Only one of conditionType or expression may be specified"
+type StepWait struct {
+	// ConditionType of a status condition that each applied resource must
+	// have with status "True". Defaults to "Ready" unless expression is
+	// specified.
+	// +optional
+	ConditionType *string `json:"conditionType,omitempty"`
+
+	// Expression is a CEL expression that must return true for each applied
+	// resource. The resource is available to the expression as self, for
+	// example "self.status.readyReplicas == self.spec.replicas".
+	// +optional
+	Expression *string `json:"expression,omitempty"`
+
+	// Timeout is how long to wait for the applied resources. The operation
+	// fails if they don't satisfy the condition within this time.
+	// +optional
+	// +kubebuilder:default="10m"
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
 }
 
 // A FunctionReference references an operation function that may be used in an
@@ -207,9 +309,105 @@ type OperationStatus struct {
 	Pipeline []PipelineStepStatus `json:"pipeline,omitempty"`
 
 	// AppliedResourceRefs references all resources the Operation applied.
+	// A FanOut operation records at most 100 applied resources.
 	AppliedResourceRefs []AppliedResourceRef `json:"appliedResourceRefs,omitempty"`
+
+	// Targets represents the result of running the pipeline against each
+	// target of a FanOut operation that the pipeline failed against. At most
+	// 100 targets are recorded.
+	// +optional
+	// +kubebuilder:validation:MaxItems=100
+	Targets []TargetStatus `json:"targets,omitempty"`
+
+	// SucceededTargets is the number of targets of a FanOut operation that
+	// the pipeline ran successfully against.
+	// +optional
+	SucceededTargets int64 `json:"succeededTargets,omitempty"`
+
+	// FailedTargets is the number of targets of a FanOut operation that the
+	// pipeline failed against.
+	// +optional
+	FailedTargets int64 `json:"failedTargets,omitempty"`
+
+	// LastCompletedTarget is the namespace and name of the last target of a
+	// FanOut operation, in the order targets are processed, that has a
+	// result along with every target before it. A FanOut operation that is
+	// interrupted resumes after this target.
+	// +optional
+	LastCompletedTarget string `json:"lastCompletedTarget,omitempty"`
+
+	// LastTargetStartTime is when the pipeline last started running against
+	// a target of a FanOut operation. It's used to limit how many targets
+	// the pipeline starts running against per minute.
+	// +optional
+	LastTargetStartTime *metav1.Time `json:"lastTargetStartTime,omitempty"`
 }
 
+// MaxTargetStatuses is the maximum number of failed fan-out targets recorded
+// in an Operation's status.
+const MaxTargetStatuses = 100
+
+// MaxFanOutAppliedResourceRefs is the maximum number of applied resources a
+// FanOut operation records in its status.
+const MaxFanOutAppliedResourceRefs = 100
+
+// A TargetResult is the result of running a pipeline against a fan-out target.
+type TargetResult string
+
+// Fan-out target results.
+const (
+	// TargetResultFailed indicates the pipeline failed against the target.
+	TargetResultFailed TargetResult = "Failed"
+)
+
+// TargetStatus represents the result of running the pipeline against an
+// individual fan-out target.
+type TargetStatus struct {
+	// APIVersion of the target.
+	APIVersion string `json:"apiVersion"`
+
+	// Kind of the target.
+	Kind string `json:"kind"`
+
+	// Namespace of the target.
+	// +optional
+	Namespace *string `json:"namespace,omitempty"`
+
+	// Name of the target.
+	Name string `json:"name"`
+
+	// Result of running the pipeline against the target.
+	Result TargetResult `json:"result"`
+
+	// Message explains why the pipeline failed against the target.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// Pipeline represents the output of the pipeline steps that ran against
+	// the target.
+	// +optional
+	Pipeline []PipelineStepStatus `json:"pipeline,omitempty"`
+}
+
+// Matches returns true if this TargetStatus is for the supplied target.
+func (s *TargetStatus) Matches(apiVersion, kind, namespace, name string) bool {
+	return s.APIVersion == apiVersion && s.Kind == kind && ptr.Deref(s.Namespace, "") == namespace && s.Name == name
+}
+
+// A PipelineStepPhase is the phase of a pipeline step that waits.
+type PipelineStepPhase string
+
+// Pipeline step phases.
+const (
+	// PipelineStepPhaseWaiting indicates a step has run, and is waiting for
+	// the resources it applied to satisfy its wait condition.
+	PipelineStepPhaseWaiting PipelineStepPhase = "Waiting"
+
+	// PipelineStepPhaseComplete indicates a step has run, and the resources
+	// it applied satisfied its wait condition.
+	PipelineStepPhaseComplete PipelineStepPhase = "Complete"
+)
+
 // PipelineStepStatus represents the status of an individual pipeline step.
 type PipelineStepStatus struct {
 	// Step name. Unique within its Pipeline.
@@ -218,6 +416,18 @@ type PipelineStepStatus struct {
 	// Output of this step.
 	// +kubebuilder:pruning:PreserveUnknownFields
 	Output *runtime.RawExtension `json:"output,omitempty"`
+
+	// Phase of this step. Only set for steps that wait.
+	// +optional
+	Phase PipelineStepPhase `json:"phase,omitempty"`
+
+	// WaitingSince is when this step started waiting.
+	// +optional
+	WaitingSince *metav1.Time `json:"waitingSince,omitempty"`
+
+	// WaitingFor references the resources this step waits for.
+	// +optional
+	WaitingFor []AppliedResourceRef `json:"waitingFor,omitempty"`
 }
 
 // An AppliedResourceRef is a reference to a resource an Operation applied.
@@ -33,6 +33,10 @@ const (
 	// used in an operation.
 	FunctionCapabilityOperation = "operation"
 
+	// FunctionCapabilityConversion is a capability key for a function that
+	// can convert composite resources between versions of an XRD.
+	FunctionCapabilityConversion = "conversion"
+
 	// ProviderCapabilitySafeStart is a capability key for a provider that
 	// supports "safe" starting of its controller gated on the existence of
 	// dependent kinds in the cluster.
@@ -36,6 +36,7 @@ type Pkg interface {
 	GetCrossplaneConstraints() *CrossplaneConstraints
 	GetDependencies() []Dependency
 	GetCapabilities() []string
+	GetLock() []LockedDependency
 }
 
 // GetCrossplaneConstraints gets the Configuration package's Crossplane version
@@ -54,6 +55,11 @@ func (c *Configuration) GetCapabilities() []string {
 	return c.Spec.Capabilities
 }
 
+// GetLock gets the Configuration package's locked dependencies.
+func (c *Configuration) GetLock() []LockedDependency {
+	return c.Spec.Lock
+}
+
 // GetCrossplaneConstraints gets the Provider package's Crossplane version
 // constraints.
 func (p *Provider) GetCrossplaneConstraints() *CrossplaneConstraints {
@@ -70,6 +76,11 @@ func (p *Provider) GetCapabilities() []string {
 	return p.Spec.Capabilities
 }
 
+// GetLock gets the Provider package's locked dependencies.
+func (p *Provider) GetLock() []LockedDependency {
+	return p.Spec.Lock
+}
+
 // GetCrossplaneConstraints gets the Function package's Crossplane version constraints.
 func (f *Function) GetCrossplaneConstraints() *CrossplaneConstraints {
 	return f.Spec.Crossplane
@@ -92,3 +103,8 @@ func (f *Function) GetCapabilities() []string {
 
 	return f.Spec.Capabilities
 }
+
+// GetLock gets the Function package's locked dependencies.
+func (f *Function) GetLock() []LockedDependency {
+	return f.Spec.Lock
+}
@@ -168,6 +168,21 @@ func (in *FunctionSpec) DeepCopy() *FunctionSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LockedDependency) DeepCopyInto(out *LockedDependency) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LockedDependency.
+func (in *LockedDependency) DeepCopy() *LockedDependency {
+	if in == nil {
+		return nil
+	}
+	out := new(LockedDependency)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MetaSpec) DeepCopyInto(out *MetaSpec) {
 	*out = *in
@@ -188,6 +203,11 @@ func (in *MetaSpec) DeepCopyInto(out *MetaSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Lock != nil {
+		in, out := &in.Lock, &out.Lock
+		*out = make([]LockedDependency, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetaSpec.
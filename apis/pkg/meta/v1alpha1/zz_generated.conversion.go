@@ -75,6 +75,15 @@ func (c *GeneratedFromHubConverter) v1DependencyToV1alpha1Dependency(source v1.D
 	v1alpha1Dependency.Version = source.Version
 	return v1alpha1Dependency
 }
+func (c *GeneratedFromHubConverter) v1LockedDependencyToV1alpha1LockedDependency(source v1.LockedDependency) LockedDependency {
+	var v1alpha1LockedDependency LockedDependency
+	v1alpha1LockedDependency.APIVersion = source.APIVersion
+	v1alpha1LockedDependency.Kind = source.Kind
+	v1alpha1LockedDependency.Package = source.Package
+	v1alpha1LockedDependency.Version = source.Version
+	v1alpha1LockedDependency.Digest = source.Digest
+	return v1alpha1LockedDependency
+}
 func (c *GeneratedFromHubConverter) v1MetaSpecToV1alpha1MetaSpec(source v1.MetaSpec) MetaSpec {
 	var v1alpha1MetaSpec MetaSpec
 	v1alpha1MetaSpec.Crossplane = c.pV1CrossplaneConstraintsToPV1alpha1CrossplaneConstraints(source.Crossplane)
@@ -90,6 +99,12 @@ func (c *GeneratedFromHubConverter) v1MetaSpecToV1alpha1MetaSpec(source v1.MetaS
 			v1alpha1MetaSpec.Capabilities[j] = source.Capabilities[j]
 		}
 	}
+	if source.Lock != nil {
+		v1alpha1MetaSpec.Lock = make([]LockedDependency, len(source.Lock))
+		for k := 0; k < len(source.Lock); k++ {
+			v1alpha1MetaSpec.Lock[k] = c.v1LockedDependencyToV1alpha1LockedDependency(source.Lock[k])
+		}
+	}
 	return v1alpha1MetaSpec
 }
 func (c *GeneratedFromHubConverter) v1ProviderSpecToV1alpha1ProviderSpec(source v1.ProviderSpec) ProviderSpec {
@@ -177,6 +192,15 @@ func (c *GeneratedToHubConverter) v1alpha1DependencyToV1Dependency(source Depend
 	v1Dependency.Version = source.Version
 	return v1Dependency
 }
+func (c *GeneratedToHubConverter) v1alpha1LockedDependencyToV1LockedDependency(source LockedDependency) v1.LockedDependency {
+	var v1LockedDependency v1.LockedDependency
+	v1LockedDependency.APIVersion = source.APIVersion
+	v1LockedDependency.Kind = source.Kind
+	v1LockedDependency.Package = source.Package
+	v1LockedDependency.Version = source.Version
+	v1LockedDependency.Digest = source.Digest
+	return v1LockedDependency
+}
 func (c *GeneratedToHubConverter) v1alpha1MetaSpecToV1MetaSpec(source MetaSpec) v1.MetaSpec {
 	var v1MetaSpec v1.MetaSpec
 	v1MetaSpec.Crossplane = c.pV1alpha1CrossplaneConstraintsToPV1CrossplaneConstraints(source.Crossplane)
@@ -192,6 +216,12 @@ func (c *GeneratedToHubConverter) v1alpha1MetaSpecToV1MetaSpec(source MetaSpec)
 			v1MetaSpec.Capabilities[j] = source.Capabilities[j]
 		}
 	}
+	if source.Lock != nil {
+		v1MetaSpec.Lock = make([]v1.LockedDependency, len(source.Lock))
+		for k := 0; k < len(source.Lock); k++ {
+			v1MetaSpec.Lock[k] = c.v1alpha1LockedDependencyToV1LockedDependency(source.Lock[k])
+		}
+	}
 	return v1MetaSpec
 }
 func (c *GeneratedToHubConverter) v1alpha1ProviderSpecToV1ProviderSpec(source ProviderSpec) v1.ProviderSpec {
@@ -64,6 +64,15 @@ func (c *GeneratedFromHubConverter) v1FunctionSpecToV1beta1FunctionSpec(source v
 	v1beta1FunctionSpec.MetaSpec = c.v1MetaSpecToV1beta1MetaSpec(source.MetaSpec)
 	return v1beta1FunctionSpec
 }
+func (c *GeneratedFromHubConverter) v1LockedDependencyToV1beta1LockedDependency(source v1.LockedDependency) LockedDependency {
+	var v1beta1LockedDependency LockedDependency
+	v1beta1LockedDependency.APIVersion = source.APIVersion
+	v1beta1LockedDependency.Kind = source.Kind
+	v1beta1LockedDependency.Package = source.Package
+	v1beta1LockedDependency.Version = source.Version
+	v1beta1LockedDependency.Digest = source.Digest
+	return v1beta1LockedDependency
+}
 func (c *GeneratedFromHubConverter) v1MetaSpecToV1beta1MetaSpec(source v1.MetaSpec) MetaSpec {
 	var v1beta1MetaSpec MetaSpec
 	v1beta1MetaSpec.Crossplane = c.pV1CrossplaneConstraintsToPV1beta1CrossplaneConstraints(source.Crossplane)
@@ -79,6 +88,12 @@ func (c *GeneratedFromHubConverter) v1MetaSpecToV1beta1MetaSpec(source v1.MetaSp
 			v1beta1MetaSpec.Capabilities[j] = source.Capabilities[j]
 		}
 	}
+	if source.Lock != nil {
+		v1beta1MetaSpec.Lock = make([]LockedDependency, len(source.Lock))
+		for k := 0; k < len(source.Lock); k++ {
+			v1beta1MetaSpec.Lock[k] = c.v1LockedDependencyToV1beta1LockedDependency(source.Lock[k])
+		}
+	}
 	return v1beta1MetaSpec
 }
 func (c *GeneratedFromHubConverter) v1TypeMetaToV1TypeMeta(source v11.TypeMeta) v11.TypeMeta {
@@ -150,6 +165,15 @@ func (c *GeneratedToHubConverter) v1beta1FunctionSpecToV1FunctionSpec(source Fun
 	v1FunctionSpec.MetaSpec = c.v1beta1MetaSpecToV1MetaSpec(source.MetaSpec)
 	return v1FunctionSpec
 }
+func (c *GeneratedToHubConverter) v1beta1LockedDependencyToV1LockedDependency(source LockedDependency) v1.LockedDependency {
+	var v1LockedDependency v1.LockedDependency
+	v1LockedDependency.APIVersion = source.APIVersion
+	v1LockedDependency.Kind = source.Kind
+	v1LockedDependency.Package = source.Package
+	v1LockedDependency.Version = source.Version
+	v1LockedDependency.Digest = source.Digest
+	return v1LockedDependency
+}
 func (c *GeneratedToHubConverter) v1beta1MetaSpecToV1MetaSpec(source MetaSpec) v1.MetaSpec {
 	var v1MetaSpec v1.MetaSpec
 	v1MetaSpec.Crossplane = c.pV1beta1CrossplaneConstraintsToPV1CrossplaneConstraints(source.Crossplane)
@@ -165,5 +189,11 @@ func (c *GeneratedToHubConverter) v1beta1MetaSpecToV1MetaSpec(source MetaSpec) v
 			v1MetaSpec.Capabilities[j] = source.Capabilities[j]
 		}
 	}
+	if source.Lock != nil {
+		v1MetaSpec.Lock = make([]v1.LockedDependency, len(source.Lock))
+		for k := 0; k < len(source.Lock); k++ {
+			v1MetaSpec.Lock[k] = c.v1beta1LockedDependencyToV1LockedDependency(source.Lock[k])
+		}
+	}
 	return v1MetaSpec
 }
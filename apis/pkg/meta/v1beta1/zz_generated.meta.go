@@ -30,6 +30,13 @@ type MetaSpec struct {
 	// may be meaningful to package consumers.
 	// +optional
 	Capabilities []string `json:"capabilities,omitempty"`
+
+	// Lock pins the package's dependencies, and their dependencies, to
+	// exact digests. Crossplane installs a locked dependency at its digest
+	// instead of resolving its version constraints. The lock is embedded
+	// from the package's crossplane.lock file when the package is built.
+	// +optional
+	Lock []LockedDependency `json:"lock,omitempty"`
 }
 
 // CrossplaneConstraints specifies a packages compatibility with Crossplane versions.
@@ -80,3 +87,22 @@ type Dependency struct {
 	// Version is the semantic version constraints of the dependency image.
 	Version string `json:"version"`
 }
+
+// A LockedDependency is a dependency pinned to an exact digest.
+type LockedDependency struct {
+	// APIVersion of the dependency.
+	APIVersion string `json:"apiVersion"`
+
+	// Kind of the dependency.
+	Kind string `json:"kind"`
+
+	// Package OCI reference of the dependency, without a tag or digest. It
+	// matches the reference used to depend on the package.
+	Package string `json:"package"`
+
+	// Version the dependency resolved to when it was locked.
+	Version string `json:"version"`
+
+	// Digest of the dependency's package image.
+	Digest string `json:"digest"`
+}
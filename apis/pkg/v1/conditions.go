@@ -70,6 +70,10 @@ const (
 	// ReasonVerificationFailed indicates that a package's signature
 	// verification failed.
 	ReasonVerificationFailed xpv1.ConditionReason = "SignatureVerificationFailed"
+	// ReasonVerificationAudited indicates that a package's signature
+	// verification failed checks that its verification policy records, but
+	// doesn't enforce.
+	ReasonVerificationAudited xpv1.ConditionReason = "SignatureVerificationAudited"
 )
 
 // Unpacking indicates that the package manager is waiting for a package
@@ -230,6 +234,19 @@ func VerificationFailed(imageConfig string, err error) xpv1.Condition {
 	}
 }
 
+// VerificationAudited returns a condition indicating that a package's
+// signature verification failed checks that the verification policy of the
+// supplied image config doesn't enforce.
+func VerificationAudited(imageConfig string, err error) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeVerified,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonVerificationAudited,
+		Message:            fmt.Sprintf("Signature verification using ImageConfig named %q failed checks that aren't enforced: %v", imageConfig, err),
+	}
+}
+
 // VerificationSkipped returns a condition indicating that signature
 // verification was skipped for a package.
 func VerificationSkipped() xpv1.Condition {
@@ -49,6 +49,7 @@ type Configuration struct {
 
 // ConfigurationSpec specifies details about a request to install a
 // configuration to Crossplane.
+// +kubebuilder:validation:XValidation:rule="!has(self.revisionActivationPolicy) || self.revisionActivationPolicy != 'Canary'",message="only Functions support the Canary revisionActivationPolicy"
 type ConfigurationSpec struct {
 	PackageSpec `json:",inline"`
 }
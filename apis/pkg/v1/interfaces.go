@@ -52,6 +52,20 @@ const (
 
 	// LabelRevision is used as the key for the package revision name label.
 	LabelRevision = "pkg.crossplane.io/revision"
+
+	// LabelCanary is used as the key for the canary label. The package
+	// manager adds it to an inactive FunctionRevision that is being rolled
+	// out alongside the active revision of a Function with the Canary
+	// activation policy.
+	LabelCanary = "pkg.crossplane.io/canary"
+
+	// CanaryProgressing indicates a canary revision is receiving part of its
+	// Function's traffic while the package manager analyzes it.
+	CanaryProgressing = "Progressing"
+
+	// CanaryRolledBack indicates a canary revision failed analysis. It no
+	// longer receives traffic and won't be promoted.
+	CanaryRolledBack = "RolledBack"
 )
 
 var (
@@ -61,8 +75,18 @@ var (
 	// ManualActivation indicates that a user will manually activate package
 	// revisions.
 	ManualActivation RevisionActivationPolicy = "Manual"
+	// CanaryActivation indicates that package should run a new revision
+	// alongside the active revision, and activate it only if it's healthy.
+	// Only Functions support canary activation.
+	CanaryActivation RevisionActivationPolicy = "Canary"
 )
 
+// IsCanary returns true if the supplied package revision is a canary that is
+// being rolled out alongside its package's active revision.
+func IsCanary(pr PackageRevision) bool {
+	return pr.GetDesiredState() == PackageRevisionInactive && pr.GetLabels()[LabelCanary] == CanaryProgressing
+}
+
 // RefNames converts a slice of LocalObjectReferences to a slice of strings.
 func RefNames(refs []corev1.LocalObjectReference) []string {
 	stringRefs := make([]string, len(refs))
@@ -89,6 +113,8 @@ type PackageWithRuntime interface {
 	GetTLSServerSecretName() *string
 
 	GetTLSClientSecretName() *string
+
+	GetHealthGate() *HealthGate
 }
 
 // SetAppliedImageConfigRefs sets applied image config refs, replacing any
@@ -169,6 +195,12 @@ type Package interface { //nolint:interfacebloat // TODO(negz): Could we break t
 
 	GetResolvedSource() string
 	SetResolvedSource(s string)
+
+	GetUpdatePolicy() *UpdatePolicy
+	SetUpdatePolicy(u *UpdatePolicy)
+
+	GetLastAutoUpdate() *PackageUpdate
+	SetLastAutoUpdate(u *PackageUpdate)
 }
 
 // GetCondition of this Provider.
@@ -306,6 +338,11 @@ func (p *Provider) GetTLSClientSecretName() *string {
 	return GetSecretNameWithSuffix(p.GetName(), TLSClientSecretNameSuffix)
 }
 
+// GetHealthGate of this Provider.
+func (p *Provider) GetHealthGate() *HealthGate {
+	return p.Spec.HealthGate
+}
+
 // GetAppliedImageConfigRefs of this Provider.
 func (p *Provider) GetAppliedImageConfigRefs() []ImageConfigRef {
 	return p.Status.AppliedImageConfigRefs
@@ -331,6 +368,26 @@ func (p *Provider) SetResolvedSource(s string) {
 	p.Status.ResolvedPackage = s
 }
 
+// GetUpdatePolicy of this Provider.
+func (p *Provider) GetUpdatePolicy() *UpdatePolicy {
+	return p.Spec.UpdatePolicy
+}
+
+// SetUpdatePolicy of this Provider.
+func (p *Provider) SetUpdatePolicy(u *UpdatePolicy) {
+	p.Spec.UpdatePolicy = u
+}
+
+// GetLastAutoUpdate of this Provider.
+func (p *Provider) GetLastAutoUpdate() *PackageUpdate {
+	return p.Status.LastAutoUpdate
+}
+
+// SetLastAutoUpdate of this Provider.
+func (p *Provider) SetLastAutoUpdate(u *PackageUpdate) {
+	p.Status.LastAutoUpdate = u
+}
+
 // GetCondition of this Configuration.
 func (p *Configuration) GetCondition(ct xpv1.ConditionType) xpv1.Condition {
 	return p.Status.GetCondition(ct)
@@ -471,6 +528,26 @@ func (p *Configuration) SetResolvedSource(s string) {
 	p.Status.ResolvedPackage = s
 }
 
+// GetUpdatePolicy of this Configuration.
+func (p *Configuration) GetUpdatePolicy() *UpdatePolicy {
+	return p.Spec.UpdatePolicy
+}
+
+// SetUpdatePolicy of this Configuration.
+func (p *Configuration) SetUpdatePolicy(u *UpdatePolicy) {
+	p.Spec.UpdatePolicy = u
+}
+
+// GetLastAutoUpdate of this Configuration.
+func (p *Configuration) GetLastAutoUpdate() *PackageUpdate {
+	return p.Status.LastAutoUpdate
+}
+
+// SetLastAutoUpdate of this Configuration.
+func (p *Configuration) SetLastAutoUpdate(u *PackageUpdate) {
+	p.Status.LastAutoUpdate = u
+}
+
 // PackageRevisionWithRuntime is the interface satisfied by revision of packages
 // with runtime types.
 // +k8s:deepcopy-gen=false
@@ -491,6 +568,12 @@ type PackageRevisionWithRuntime interface { //nolint:interfacebloat // TODO(negz
 
 	GetObservedTLSClientSecretName() *string
 	SetObservedTLSClientSecretName(n *string)
+
+	GetHealthGate() *HealthGate
+	SetHealthGate(g *HealthGate)
+
+	GetHealthGateStatus() *HealthGateStatus
+	SetHealthGateStatus(s *HealthGateStatus)
 }
 
 // SetAppliedImageConfigRefs sets applied image config refs, replacing any
@@ -733,6 +816,26 @@ func (p *ProviderRevision) SetObservedTLSClientSecretName(s *string) {
 	p.Status.TLSClientSecretName = s
 }
 
+// GetHealthGate of this ProviderRevision.
+func (p *ProviderRevision) GetHealthGate() *HealthGate {
+	return p.Spec.HealthGate
+}
+
+// SetHealthGate of this ProviderRevision.
+func (p *ProviderRevision) SetHealthGate(g *HealthGate) {
+	p.Spec.HealthGate = g
+}
+
+// GetHealthGateStatus of this ProviderRevision.
+func (p *ProviderRevision) GetHealthGateStatus() *HealthGateStatus {
+	return p.Status.HealthGate
+}
+
+// SetHealthGateStatus of this ProviderRevision.
+func (p *ProviderRevision) SetHealthGateStatus(s *HealthGateStatus) {
+	p.Status.HealthGate = s
+}
+
 // GetCommonLabels of this ProviderRevision.
 func (p *ProviderRevision) GetCommonLabels() map[string]string {
 	return p.Spec.CommonLabels
@@ -1134,6 +1237,11 @@ func (f *Function) GetTLSClientSecretName() *string {
 	return nil
 }
 
+// GetHealthGate of this Function.
+func (f *Function) GetHealthGate() *HealthGate {
+	return f.Spec.HealthGate
+}
+
 // GetAppliedImageConfigRefs of this Function.
 func (f *Function) GetAppliedImageConfigRefs() []ImageConfigRef {
 	return f.Status.AppliedImageConfigRefs
@@ -1159,6 +1267,26 @@ func (f *Function) SetResolvedSource(s string) {
 	f.Status.ResolvedPackage = s
 }
 
+// GetUpdatePolicy of this Function.
+func (f *Function) GetUpdatePolicy() *UpdatePolicy {
+	return f.Spec.UpdatePolicy
+}
+
+// SetUpdatePolicy of this Function.
+func (f *Function) SetUpdatePolicy(u *UpdatePolicy) {
+	f.Spec.UpdatePolicy = u
+}
+
+// GetLastAutoUpdate of this Function.
+func (f *Function) GetLastAutoUpdate() *PackageUpdate {
+	return f.Status.LastAutoUpdate
+}
+
+// SetLastAutoUpdate of this Function.
+func (f *Function) SetLastAutoUpdate(u *PackageUpdate) {
+	f.Status.LastAutoUpdate = u
+}
+
 // GetCondition of this FunctionRevision.
 func (r *FunctionRevision) GetCondition(ct xpv1.ConditionType) xpv1.Condition {
 	return r.Status.GetCondition(ct)
@@ -1306,6 +1434,26 @@ func (r *FunctionRevision) SetObservedTLSClientSecretName(s *string) {
 	r.Status.TLSClientSecretName = s
 }
 
+// GetHealthGate of this FunctionRevision.
+func (r *FunctionRevision) GetHealthGate() *HealthGate {
+	return r.Spec.HealthGate
+}
+
+// SetHealthGate of this FunctionRevision.
+func (r *FunctionRevision) SetHealthGate(g *HealthGate) {
+	r.Spec.HealthGate = g
+}
+
+// GetHealthGateStatus of this FunctionRevision.
+func (r *FunctionRevision) GetHealthGateStatus() *HealthGateStatus {
+	return r.Status.HealthGate
+}
+
+// SetHealthGateStatus of this FunctionRevision.
+func (r *FunctionRevision) SetHealthGateStatus(s *HealthGateStatus) {
+	r.Status.HealthGate = s
+}
+
 // GetObservedTLSClientSecretName of this FunctionRevision.
 func (r *FunctionRevision) GetObservedTLSClientSecretName() *string {
 	return r.Status.TLSClientSecretName
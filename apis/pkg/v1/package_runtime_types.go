@@ -16,6 +16,10 @@ limitations under the License.
 
 package v1
 
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
 // PackageRuntimeSpec specifies configuration for the runtime of a package.
 // Only used by packages that uses a runtime, i.e. by providers and functions
 // but not for configurations.
@@ -25,6 +29,46 @@ type PackageRuntimeSpec struct {
 	// +optional
 	// +kubebuilder:default={"name": "default"}
 	RuntimeConfigReference *RuntimeConfigReference `json:"runtimeConfigRef,omitempty"`
+
+	// HealthGate configures a period after a new package revision is
+	// activated during which its runtime is observed. If the revision breaches
+	// the health gate's thresholds the previous revision is activated again.
+	// Requires the --enable-package-health-gates feature flag.
+	// +optional
+	HealthGate *HealthGate `json:"healthGate,omitempty"`
+}
+
+// A HealthGate specifies how to decide whether a newly activated package
+// revision is healthy.
+type HealthGate struct {
+	// ObservationPeriod is how long to observe a package revision after it's
+	// activated.
+	// +optional
+	// +kubebuilder:default="10m"
+	ObservationPeriod *metav1.Duration `json:"observationPeriod,omitempty"`
+
+	// MaxRestarts is the maximum number of times the package revision's
+	// containers may restart during the observation period.
+	// +optional
+	// +kubebuilder:default=3
+	// +kubebuilder:validation:Minimum=0
+	MaxRestarts *int32 `json:"maxRestarts,omitempty"`
+
+	// MaxErrorRate is the maximum percentage of RunFunction calls that may
+	// fail during the observation period. A call fails if it returns an error
+	// or a fatal result. Only used by functions.
+	// +optional
+	// +kubebuilder:default=5
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	MaxErrorRate *int32 `json:"maxErrorRate,omitempty"`
+
+	// MinRequests is the minimum number of RunFunction calls a function must
+	// serve before its error rate is considered. Only used by functions.
+	// +optional
+	// +kubebuilder:default=10
+	// +kubebuilder:validation:Minimum=0
+	MinRequests *int64 `json:"minRequests,omitempty"`
 }
 
 // PackageRevisionRuntimeSpec specifies configuration for the runtime of a
@@ -56,6 +100,58 @@ type PackageRevisionRuntimeStatus struct {
 	// certificates of the Provider.
 	// +optional
 	TLSClientSecretName *string `json:"tlsClientSecretName,omitempty"`
+
+	// HealthGate is the observed state of the package revision's health gate.
+	// +optional
+	HealthGate *HealthGateStatus `json:"healthGate,omitempty"`
+}
+
+// A HealthGateResult is the result of observing a package revision.
+type HealthGateResult string
+
+// Health gate results.
+const (
+	// HealthGateObserving means the package revision is still being observed.
+	HealthGateObserving HealthGateResult = "Observing"
+
+	// HealthGatePassed means the package revision didn't breach any of its
+	// health gate's thresholds during the observation period.
+	HealthGatePassed HealthGateResult = "Passed"
+
+	// HealthGateFailed means the package revision breached one of its health
+	// gate's thresholds during the observation period.
+	HealthGateFailed HealthGateResult = "Failed"
+)
+
+// HealthGateStatus represents the observed state of a package revision's
+// health gate.
+type HealthGateStatus struct {
+	// StartTime is when the observation period started.
+	StartTime metav1.Time `json:"startTime"`
+
+	// Result of observing the package revision.
+	Result HealthGateResult `json:"result"`
+
+	// Message explains the result.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// Restarts is the number of times the package revision's containers
+	// restarted during the observation period.
+	// +optional
+	Restarts int32 `json:"restarts,omitempty"`
+
+	// InitialResponses is the number of RunFunction responses Crossplane had
+	// received from the function's endpoint when the observation period
+	// started. Only used by functions.
+	// +optional
+	InitialResponses int64 `json:"initialResponses,omitempty"`
+
+	// InitialFailedResponses is the number of failed RunFunction responses
+	// Crossplane had received from the function's endpoint when the
+	// observation period started. Only used by functions.
+	// +optional
+	InitialFailedResponses int64 `json:"initialFailedResponses,omitempty"`
 }
 
 // A RuntimeConfigReference to a runtime config resource that will be used
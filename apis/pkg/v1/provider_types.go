@@ -48,6 +48,7 @@ type Provider struct {
 
 // ProviderSpec specifies details about a request to install a provider to
 // Crossplane.
+// +kubebuilder:validation:XValidation:rule="!has(self.revisionActivationPolicy) || self.revisionActivationPolicy != 'Canary'",message="only Functions support the Canary revisionActivationPolicy"
 type ProviderSpec struct {
 	PackageSpec        `json:",inline"`
 	PackageRuntimeSpec `json:",inline"`
@@ -37,7 +37,8 @@ type CosignAuthority struct {
 	// +optional
 	Keyless *KeylessRef `json:"keyless,omitempty"`
 	// Attestations is a list of individual attestations for this authority,
-	// once the signature for this authority has been verified.
+	// once the signature for this authority has been verified. Every
+	// attestation must be satisfied for the authority to verify an image.
 	// +optional
 	Attestations []Attestation `json:"attestations,omitempty"`
 }
@@ -50,6 +51,8 @@ type CosignAuthority struct {
 //   the crossplane system namespace. It also includes the key to select to
 //   avoid randomly choosing one key different from the policy controller.
 // - HashAlgorithm is now required and defaults to sha256 if not explicitly set.
+// - Added InsecureIgnoreTlog to verify signatures that weren't uploaded to a
+//   transparency log, like those made by crossplane xpkg push --sign-key.
 
 // A KeyRef must specify a SecretRef and may specify a HashAlgorithm.
 type KeyRef struct {
@@ -58,6 +61,11 @@ type KeyRef struct {
 	// HashAlgorithm always defaults to sha256 if the algorithm hasn't been explicitly set
 	// +kubebuilder:default="sha256"
 	HashAlgorithm string `json:"hashAlgorithm"`
+	// InsecureIgnoreTlog omits verifying that the signature was uploaded to
+	// a transparency log. Signatures made by crossplane xpkg push --sign-key
+	// aren't uploaded to a transparency log.
+	// +optional
+	InsecureIgnoreTlog *bool `json:"insecureIgnoreTlog,omitempty"`
 }
 
 // Modifications over the original policy controller "KeylessRef" type: https://github.com/sigstore/policy-controller/blob/d73e188a4669780af82d3d168f40a6fff438345a/pkg/apis/policy/v1alpha1/clusterimagepolicy_types.go#L210
@@ -103,7 +111,8 @@ type Identity struct {
 }
 
 // Modifications over the original policy controller "Attestation" type: https://github.com/sigstore/policy-controller/blob/d73e188a4669780af82d3d168f40a6fff438345a/pkg/apis/policy/v1alpha1/clusterimagepolicy_types.go#L210
-// - Removed the Policy field as it is not supported in the initial implementation.
+// - Replaced the Policy field, which supports CUE and Rego policies, with a
+//   list of named CEL rules.
 
 // Attestation defines the type of attestation to validate and optionally
 // apply a policy decision to it. Authority block is used to verify the
@@ -115,4 +124,27 @@ type Attestation struct {
 	// PredicateType defines which predicate type to verify. Matches cosign
 	// verify-attestation options.
 	PredicateType string `json:"predicateType"`
+	// Policies are rules the attestation must satisfy once its signature has
+	// been verified. All rules must pass.
+	// +optional
+	Policies []AttestationPolicy `json:"policies,omitempty"`
+}
+
+// An AttestationPolicy is a rule an attestation must satisfy.
+type AttestationPolicy struct {
+	// Name of the rule. The package's Verified condition names the rule if
+	// it fails.
+	Name string `json:"name"`
+	// Expression is a CEL expression that must evaluate to true. The
+	// attestation's in-toto statement is available as self. Its evaluation
+	// cost is limited the same way as a CustomResourceDefinition validation
+	// rule's. For example, to require an SLSA provenance attestation from a
+	// particular builder:
+	//
+	//   self.predicate.builder.id.startsWith('https://github.com/crossplane/')
+	//
+	// Or to deny an SPDX SBOM attestation that lists a component:
+	//
+	//   !self.predicate.packages.exists(p, p.name == 'log4j-core')
+	Expression string `json:"expression"`
 }
@@ -29,6 +29,12 @@ type MatchType string
 const (
 	// Prefix is used to match the prefix of the image.
 	Prefix MatchType = "Prefix"
+
+	// Regex is used to match the image against a regular expression.
+	Regex MatchType = "Regex"
+
+	// Glob is used to match the image against a glob pattern.
+	Glob MatchType = "Glob"
 )
 
 // ImageVerificationProvider is the provider that should be used to verify the
@@ -39,6 +45,10 @@ const (
 	// ImageVerificationProviderCosign is the cosign provider that should be
 	// used to verify the image.
 	ImageVerificationProviderCosign ImageVerificationProvider = "Cosign"
+
+	// ImageVerificationProviderNotation is the Notation (Notary Project)
+	// provider that should be used to verify the image.
+	ImageVerificationProviderNotation ImageVerificationProvider = "Notation"
 )
 
 // +kubebuilder:object:root=true
@@ -71,9 +81,16 @@ type ImageConfigSpec struct {
 	// MatchImages is a list of image matching rules. This ImageConfig will
 	// match an image if any one of these rules is satisfied. In the case where
 	// multiple ImageConfigs match an image for a given purpose the one with the
-	// most specific match will be used. If multiple rules of equal specificity
-	// match an arbitrary one will be selected.
+	// most specific match will be used. Prefix and Glob matches are more
+	// specific the more literal (i.e. non-wildcard) characters they contain,
+	// and a Prefix match is more specific than a Glob match with the same
+	// number of literal characters. Regex matches are less specific than any
+	// Prefix or Glob match, and a longer regular expression is more specific
+	// than a shorter one. If multiple rules of equal specificity match an
+	// arbitrary one will be selected. An ImageConfig may have at most 64
+	// rules.
 	// +kubebuilder:validation:XValidation:rule="size(self) > 0",message="matchImages should have at least one element."
+	// +kubebuilder:validation:MaxItems=64
 	MatchImages []ImageMatch `json:"matchImages"`
 	// Registry is the configuration for the registry.
 	// +optional
@@ -84,21 +101,52 @@ type ImageConfigSpec struct {
 	// RewriteImage defines how a matched image's path should be rewritten.
 	// +optional
 	RewriteImage *ImageRewrite `json:"rewriteImage,omitempty"`
+	// Mirrors is an ordered list of mirrors of a matched image. If the image
+	// can't be pulled from the path it would otherwise be pulled from, each
+	// mirror is tried in order. Mirrors are matched against the path the
+	// image is pulled from, i.e. after any rewrite. Each mirror rewrites that
+	// path the same way as RewriteImage. Mirrors that recently failed are
+	// tried after mirrors that didn't.
+	// +optional
+	// +kubebuilder:validation:MaxItems=10
+	Mirrors []ImageRewrite `json:"mirrors,omitempty"`
 	// Runtime allows configuration of runtime options for the image.
 	// +optional
 	Runtime *ImageRuntime `json:"runtime,omitempty"`
 }
 
 // ImageMatch defines a rule for matching image.
+// +kubebuilder:validation:XValidation:rule="(has(self.type) && self.type != 'Prefix') || has(self.prefix)",message="prefix is required when type is Prefix"
+// +kubebuilder:validation:XValidation:rule="!has(self.type) || self.type != 'Regex' || has(self.regex)",message="regex is required when type is Regex"
+// +kubebuilder:validation:XValidation:rule="!has(self.type) || self.type != 'Glob' || has(self.glob)",message="glob is required when type is Glob"
 type ImageMatch struct {
 	// Type is the type of match.
 	// +optional
-	// +kubebuilder:validation:Enum=Prefix
+	// +kubebuilder:validation:Enum=Prefix;Regex;Glob
 	// +kubebuilder:default=Prefix
 	Type MatchType `json:"type,omitempty"`
 	// Prefix is the prefix that should be matched. When multiple prefix rules
-	// match an image path, the longest one takes precedence.
-	Prefix string `json:"prefix"`
+	// match an image path, the longest one takes precedence. Required when
+	// type is Prefix.
+	// +optional
+	Prefix string `json:"prefix,omitempty"`
+	// Regex is a regular expression that should match the entire image path,
+	// e.g. "registry-(us|eu)[.]example[.]com/(.*)". Its capture groups may be
+	// referenced by the image rewrite replacement. It must be a valid RE2
+	// regular expression of at most 256 characters. Required when type is
+	// Regex.
+	// +optional
+	// +kubebuilder:validation:MaxLength=256
+	// +kubebuilder:validation:XValidation:rule="type(''.matches('^(?:' + self + ')$')) == bool",message="regex must be a valid RE2 regular expression"
+	Regex string `json:"regex,omitempty"`
+	// Glob is a glob pattern that should match the entire image path, e.g.
+	// "registry-*.example.com/crossplane/**". A '*' matches any sequence of
+	// characters except '/', a '**' matches any sequence of characters, and a
+	// '?' matches any single character except '/'. Each wildcard is a capture
+	// group that may be referenced by the image rewrite replacement, numbered
+	// from 1 in the order they appear. Required when type is Glob.
+	// +optional
+	Glob string `json:"glob,omitempty"`
 }
 
 // RegistryAuthentication contains the authentication information for a registry.
@@ -116,13 +164,75 @@ type RegistryConfig struct {
 }
 
 // ImageVerification contains the configuration for verifying the image.
+// +kubebuilder:validation:XValidation:rule="self.provider != 'Cosign' || has(self.cosign)",message="cosign is required when provider is Cosign"
+// +kubebuilder:validation:XValidation:rule="self.provider != 'Notation' || has(self.notation)",message="notation is required when provider is Notation"
 type ImageVerification struct {
 	// Provider is the provider that should be used to verify the image.
-	// +kubebuilder:validation:Enum=Cosign
+	// +kubebuilder:validation:Enum=Cosign;Notation
 	Provider ImageVerificationProvider `json:"provider"`
 	// Cosign is the configuration for verifying the image using cosign.
 	// +optional
 	Cosign *CosignVerificationConfig `json:"cosign,omitempty"`
+	// Notation is the configuration for verifying the image using Notation.
+	// +optional
+	Notation *NotationVerificationConfig `json:"notation,omitempty"`
+}
+
+// NotationTrustStoreType is the type of a Notation trust store.
+type NotationTrustStoreType string
+
+const (
+	// NotationTrustStoreTypeCA is a trust store of certificate authorities.
+	NotationTrustStoreTypeCA NotationTrustStoreType = "ca"
+
+	// NotationTrustStoreTypeSigningAuthority is a trust store of signing
+	// authorities.
+	NotationTrustStoreTypeSigningAuthority NotationTrustStoreType = "signingAuthority"
+
+	// NotationTrustStoreTypeTSA is a trust store of timestamp authorities.
+	// A signature whose signing certificate chain isn't valid at the time
+	// of verification is only accepted if it's countersigned by a trusted
+	// timestamp authority.
+	NotationTrustStoreTypeTSA NotationTrustStoreType = "tsa"
+)
+
+// NotationVerificationConfig contains the configuration for verifying the
+// image using Notation.
+type NotationVerificationConfig struct {
+	// TrustPolicyRef references a ConfigMap key containing a Notation trust
+	// policy document, i.e. the content of a trustpolicy.json file. The
+	// ConfigMap must be in the Crossplane namespace.
+	TrustPolicyRef LocalConfigMapKeySelector `json:"trustPolicyRef"`
+
+	// TrustStores contains the certificates of the trust stores referenced
+	// by the trust policy.
+	// +kubebuilder:validation:MinItems=1
+	TrustStores []NotationTrustStore `json:"trustStores"`
+}
+
+// NotationTrustStore is a named set of trusted certificates.
+// +kubebuilder:validation:XValidation:rule="has(self.secretRef) != has(self.configMapRef)",message="exactly one of secretRef or configMapRef must be set"
+type NotationTrustStore struct {
+	// Type of the trust store.
+	// +optional
+	// +kubebuilder:validation:Enum=ca;signingAuthority;tsa
+	// +kubebuilder:default=ca
+	Type NotationTrustStoreType `json:"type,omitempty"`
+
+	// Name of the trust store. A trust policy references this trust store as
+	// type:name, for example ca:acme.
+	Name string `json:"name"`
+
+	// SecretRef references a Secret key containing one or more PEM encoded
+	// certificates. The Secret must be in the Crossplane namespace.
+	// +optional
+	SecretRef *LocalSecretKeySelector `json:"secretRef,omitempty"`
+
+	// ConfigMapRef references a ConfigMap key containing one or more PEM
+	// encoded certificates. The ConfigMap must be in the Crossplane
+	// namespace.
+	// +optional
+	ConfigMapRef *LocalConfigMapKeySelector `json:"configMapRef,omitempty"`
 }
 
 // CosignVerificationConfig contains the configuration for verifying the image
@@ -141,12 +251,31 @@ type LocalSecretKeySelector struct {
 	Key string `json:"key"`
 }
 
+// A LocalConfigMapKeySelector is a reference to a ConfigMap key in a
+// predefined namespace.
+type LocalConfigMapKeySelector struct {
+	// Name of the ConfigMap.
+	Name string `json:"name"`
+
+	// The key to select.
+	Key string `json:"key"`
+}
+
 // ImageRewrite defines how an image's path should be rewritten.
+// +kubebuilder:validation:XValidation:rule="has(self.prefix) || has(self.replacement)",message="either prefix or replacement is required"
 type ImageRewrite struct {
 	// Prefix is the prefix that will replace the portion of the image's path
 	// matched by the prefix in the ImageMatch. If multiple prefixes matched,
-	// the longest one will be replaced.
-	Prefix string `json:"prefix"`
+	// the longest one will be replaced. Required to rewrite images matched by
+	// a Prefix match.
+	// +optional
+	Prefix string `json:"prefix,omitempty"`
+	// Replacement is the image path that will replace an image matched by a
+	// Regex or Glob match. It may reference the match's capture groups, e.g.
+	// "mirror.example.com/$1/${2}". Required to rewrite images matched by a
+	// Regex or Glob match.
+	// +optional
+	Replacement string `json:"replacement,omitempty"`
 }
 
 // ImageRuntime allows configuration of runtime options for an image.
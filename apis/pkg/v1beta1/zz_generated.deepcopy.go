@@ -24,12 +24,18 @@ import (
 	commonv1 "github.com/crossplane/crossplane-runtime/v2/apis/common/v1"
 	"k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Attestation) DeepCopyInto(out *Attestation) {
 	*out = *in
+	if in.Policies != nil {
+		in, out := &in.Policies, &out.Policies
+		*out = make([]AttestationPolicy, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Attestation.
@@ -42,6 +48,51 @@ func (in *Attestation) DeepCopy() *Attestation {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AttestationPolicy) DeepCopyInto(out *AttestationPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AttestationPolicy.
+func (in *AttestationPolicy) DeepCopy() *AttestationPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(AttestationPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CanaryAnalysis) DeepCopyInto(out *CanaryAnalysis) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.MinRequests != nil {
+		in, out := &in.MinRequests, &out.MinRequests
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxErrorRate != nil {
+		in, out := &in.MaxErrorRate, &out.MaxErrorRate
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CanaryAnalysis.
+func (in *CanaryAnalysis) DeepCopy() *CanaryAnalysis {
+	if in == nil {
+		return nil
+	}
+	out := new(CanaryAnalysis)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ControllerReference) DeepCopyInto(out *ControllerReference) {
 	*out = *in
@@ -73,7 +124,9 @@ func (in *CosignAuthority) DeepCopyInto(out *CosignAuthority) {
 	if in.Attestations != nil {
 		in, out := &in.Attestations, &out.Attestations
 		*out = make([]Attestation, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
@@ -284,6 +337,36 @@ func (in *Function) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FunctionCanarySpec) DeepCopyInto(out *FunctionCanarySpec) {
+	*out = *in
+	if in.Weight != nil {
+		in, out := &in.Weight, &out.Weight
+		*out = new(int32)
+		**out = **in
+	}
+	if in.CompositionSelector != nil {
+		in, out := &in.CompositionSelector, &out.CompositionSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Analysis != nil {
+		in, out := &in.Analysis, &out.Analysis
+		*out = new(CanaryAnalysis)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FunctionCanarySpec.
+func (in *FunctionCanarySpec) DeepCopy() *FunctionCanarySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FunctionCanarySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FunctionList) DeepCopyInto(out *FunctionList) {
 	*out = *in
@@ -414,6 +497,11 @@ func (in *FunctionSpec) DeepCopyInto(out *FunctionSpec) {
 	*out = *in
 	in.PackageSpec.DeepCopyInto(&out.PackageSpec)
 	in.PackageRuntimeSpec.DeepCopyInto(&out.PackageRuntimeSpec)
+	if in.Canary != nil {
+		in, out := &in.Canary, &out.Canary
+		*out = new(FunctionCanarySpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FunctionSpec.
@@ -443,6 +531,57 @@ func (in *FunctionStatus) DeepCopy() *FunctionStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HealthGate) DeepCopyInto(out *HealthGate) {
+	*out = *in
+	if in.ObservationPeriod != nil {
+		in, out := &in.ObservationPeriod, &out.ObservationPeriod
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.MaxRestarts != nil {
+		in, out := &in.MaxRestarts, &out.MaxRestarts
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxErrorRate != nil {
+		in, out := &in.MaxErrorRate, &out.MaxErrorRate
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MinRequests != nil {
+		in, out := &in.MinRequests, &out.MinRequests
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HealthGate.
+func (in *HealthGate) DeepCopy() *HealthGate {
+	if in == nil {
+		return nil
+	}
+	out := new(HealthGate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HealthGateStatus) DeepCopyInto(out *HealthGateStatus) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HealthGateStatus.
+func (in *HealthGateStatus) DeepCopy() *HealthGateStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(HealthGateStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Identity) DeepCopyInto(out *Identity) {
 	*out = *in
@@ -554,6 +693,11 @@ func (in *ImageConfigSpec) DeepCopyInto(out *ImageConfigSpec) {
 		*out = new(ImageRewrite)
 		**out = **in
 	}
+	if in.Mirrors != nil {
+		in, out := &in.Mirrors, &out.Mirrors
+		*out = make([]ImageRewrite, len(*in))
+		copy(*out, *in)
+	}
 	if in.Runtime != nil {
 		in, out := &in.Runtime, &out.Runtime
 		*out = new(ImageRuntime)
@@ -629,6 +773,11 @@ func (in *ImageVerification) DeepCopyInto(out *ImageVerification) {
 		*out = new(CosignVerificationConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Notation != nil {
+		in, out := &in.Notation, &out.Notation
+		*out = new(NotationVerificationConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageVerification.
@@ -645,6 +794,11 @@ func (in *ImageVerification) DeepCopy() *ImageVerification {
 func (in *KeyRef) DeepCopyInto(out *KeyRef) {
 	*out = *in
 	in.SecretRef.DeepCopyInto(&out.SecretRef)
+	if in.InsecureIgnoreTlog != nil {
+		in, out := &in.InsecureIgnoreTlog, &out.InsecureIgnoreTlog
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeyRef.
@@ -682,6 +836,21 @@ func (in *KeylessRef) DeepCopy() *KeylessRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocalConfigMapKeySelector) DeepCopyInto(out *LocalConfigMapKeySelector) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalConfigMapKeySelector.
+func (in *LocalConfigMapKeySelector) DeepCopy() *LocalConfigMapKeySelector {
+	if in == nil {
+		return nil
+	}
+	out := new(LocalConfigMapKeySelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LocalSecretKeySelector) DeepCopyInto(out *LocalSecretKeySelector) {
 	*out = *in
@@ -821,6 +990,75 @@ func (in *LockStatus) DeepCopy() *LockStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
+	*out = *in
+	if in.Days != nil {
+		in, out := &in.Days, &out.Days
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotationTrustStore) DeepCopyInto(out *NotationTrustStore) {
+	*out = *in
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(LocalSecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConfigMapRef != nil {
+		in, out := &in.ConfigMapRef, &out.ConfigMapRef
+		*out = new(LocalConfigMapKeySelector)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotationTrustStore.
+func (in *NotationTrustStore) DeepCopy() *NotationTrustStore {
+	if in == nil {
+		return nil
+	}
+	out := new(NotationTrustStore)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotationVerificationConfig) DeepCopyInto(out *NotationVerificationConfig) {
+	*out = *in
+	out.TrustPolicyRef = in.TrustPolicyRef
+	if in.TrustStores != nil {
+		in, out := &in.TrustStores, &out.TrustStores
+		*out = make([]NotationTrustStore, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotationVerificationConfig.
+func (in *NotationVerificationConfig) DeepCopy() *NotationVerificationConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(NotationVerificationConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ObjectMeta) DeepCopyInto(out *ObjectMeta) {
 	*out = *in
@@ -894,6 +1132,11 @@ func (in *PackageRevisionRuntimeStatus) DeepCopyInto(out *PackageRevisionRuntime
 		*out = new(string)
 		**out = **in
 	}
+	if in.HealthGate != nil {
+		in, out := &in.HealthGate, &out.HealthGate
+		*out = new(HealthGateStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageRevisionRuntimeStatus.
@@ -987,6 +1230,11 @@ func (in *PackageRuntimeSpec) DeepCopyInto(out *PackageRuntimeSpec) {
 		*out = new(RuntimeConfigReference)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.HealthGate != nil {
+		in, out := &in.HealthGate, &out.HealthGate
+		*out = new(HealthGate)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageRuntimeSpec.
@@ -1039,6 +1287,11 @@ func (in *PackageSpec) DeepCopyInto(out *PackageSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.UpdatePolicy != nil {
+		in, out := &in.UpdatePolicy, &out.UpdatePolicy
+		*out = new(UpdatePolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageSpec.
@@ -1059,6 +1312,11 @@ func (in *PackageStatus) DeepCopyInto(out *PackageStatus) {
 		*out = make([]ImageConfigRef, len(*in))
 		copy(*out, *in)
 	}
+	if in.LastAutoUpdate != nil {
+		in, out := &in.LastAutoUpdate, &out.LastAutoUpdate
+		*out = new(PackageUpdate)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageStatus.
@@ -1071,6 +1329,22 @@ func (in *PackageStatus) DeepCopy() *PackageStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PackageUpdate) DeepCopyInto(out *PackageUpdate) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageUpdate.
+func (in *PackageUpdate) DeepCopy() *PackageUpdate {
+	if in == nil {
+		return nil
+	}
+	out := new(PackageUpdate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RegistryAuthentication) DeepCopyInto(out *RegistryAuthentication) {
 	*out = *in
@@ -1171,3 +1445,33 @@ func (in *ServiceTemplate) DeepCopy() *ServiceTemplate {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpdatePolicy) DeepCopyInto(out *UpdatePolicy) {
+	*out = *in
+	if in.Constraint != nil {
+		in, out := &in.Constraint, &out.Constraint
+		*out = new(string)
+		**out = **in
+	}
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.MaintenanceWindow != nil {
+		in, out := &in.MaintenanceWindow, &out.MaintenanceWindow
+		*out = new(MaintenanceWindow)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpdatePolicy.
+func (in *UpdatePolicy) DeepCopy() *UpdatePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(UpdatePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
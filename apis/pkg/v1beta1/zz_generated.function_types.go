@@ -52,6 +52,61 @@ type FunctionSpec struct {
 	PackageSpec `json:",inline"`
 
 	PackageRuntimeSpec `json:",inline"`
+
+	// Canary configures how the package manager rolls out a new revision of
+	// the Function when its revisionActivationPolicy is Canary. It's ignored
+	// for other activation policies.
+	// +optional
+	Canary *FunctionCanarySpec `json:"canary,omitempty"`
+}
+
+// FunctionCanarySpec configures how a canary FunctionRevision is rolled out.
+type FunctionCanarySpec struct {
+	// Weight is the percentage of composite resources whose function pipeline
+	// calls are routed to the canary revision. Composite resources are
+	// bucketed by their UID, so a composite resource consistently calls
+	// the same revision. Ignored if compositionSelector is set.
+	// +optional
+	// +kubebuilder:default=10
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	Weight *int32 `json:"weight,omitempty"`
+
+	// CompositionSelector selects the Compositions whose composite resources
+	// call the canary revision. Composite resources that use any other
+	// Composition call the active revision.
+	// +optional
+	CompositionSelector *metav1.LabelSelector `json:"compositionSelector,omitempty"`
+
+	// Analysis configures how the package manager decides whether to promote
+	// or roll back the canary revision.
+	// +optional
+	Analysis *CanaryAnalysis `json:"analysis,omitempty"`
+}
+
+// CanaryAnalysis configures how a canary FunctionRevision is analyzed.
+type CanaryAnalysis struct {
+	// Interval is how long the canary revision must run before it's
+	// analyzed.
+	// +optional
+	// +kubebuilder:default="10m"
+	Interval *metav1.Duration `json:"interval,omitempty"`
+
+	// MinRequests is the number of responses the canary revision must return
+	// before it's analyzed.
+	// +optional
+	// +kubebuilder:default=10
+	// +kubebuilder:validation:Minimum=1
+	MinRequests *int64 `json:"minRequests,omitempty"`
+
+	// MaxErrorRate is the highest percentage of failed responses the canary
+	// revision may return and still be promoted. A response fails if the
+	// gRPC call returns an error, or if it contains a fatal result.
+	// +optional
+	// +kubebuilder:default=5
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	MaxErrorRate *int32 `json:"maxErrorRate,omitempty"`
 }
 
 // FunctionStatus represents the observed state of a Function.
@@ -18,7 +18,10 @@ limitations under the License.
 
 package v1beta1
 
-import corev1 "k8s.io/api/core/v1"
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
 
 // RevisionActivationPolicy indicates how a package should activate its
 // revisions.
@@ -33,8 +36,8 @@ type PackageSpec struct {
 	Package string `json:"package"`
 
 	// RevisionActivationPolicy specifies how the package controller should
-	// update from one revision to the next. Options are Automatic or Manual.
-	// Default is Automatic.
+	// update from one revision to the next. Options are Automatic, Manual, or
+	// Canary. Default is Automatic. Only Functions support Canary.
 	// +optional
 	// +kubebuilder:default=Automatic
 	RevisionActivationPolicy *RevisionActivationPolicy `json:"revisionActivationPolicy,omitempty"`
@@ -78,6 +81,75 @@ type PackageSpec struct {
 	// More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/labels/
 	// +optional
 	CommonLabels map[string]string `json:"commonLabels,omitempty"`
+
+	// UpdatePolicy configures the package manager to automatically update the
+	// package to newer versions published to its registry. The package
+	// manager updates the package by changing the tag of spec.package. It
+	// doesn't update packages that specify a digest.
+	// +optional
+	UpdatePolicy *UpdatePolicy `json:"updatePolicy,omitempty"`
+}
+
+// An UpdateChannel determines which newer versions of a package the package
+// manager may automatically update it to.
+type UpdateChannel string
+
+const (
+	// UpdateChannelPatch updates a package to newer versions with the same
+	// major and minor version.
+	UpdateChannelPatch UpdateChannel = "Patch"
+
+	// UpdateChannelMinor updates a package to newer versions with the same
+	// major version.
+	UpdateChannelMinor UpdateChannel = "Minor"
+
+	// UpdateChannelMajor updates a package to any newer version.
+	UpdateChannelMajor UpdateChannel = "Major"
+)
+
+// UpdatePolicy configures how the package manager automatically updates a
+// package.
+type UpdatePolicy struct {
+	// Channel determines which newer versions the package may be updated to.
+	// Patch updates to versions with the same major and minor version. Minor
+	// updates to versions with the same major version. Major updates to any
+	// newer version. The package's current tag must be a semantic version.
+	// +optional
+	// +kubebuilder:validation:Enum=Patch;Minor;Major
+	// +kubebuilder:default=Patch
+	Channel UpdateChannel `json:"channel,omitempty"`
+
+	// Constraint is a semantic version constraint, for example "<v2.0.0",
+	// that versions must also satisfy to be updated to.
+	// +optional
+	Constraint *string `json:"constraint,omitempty"`
+
+	// Interval is how often the package manager checks the registry for newer
+	// versions.
+	// +optional
+	// +kubebuilder:default="1h"
+	Interval *metav1.Duration `json:"interval,omitempty"`
+
+	// MaintenanceWindow restricts when the package manager may update the
+	// package. The package may be updated at any time if it's omitted.
+	// +optional
+	MaintenanceWindow *MaintenanceWindow `json:"maintenanceWindow,omitempty"`
+}
+
+// A MaintenanceWindow is a recurring period of time.
+type MaintenanceWindow struct {
+	// Days of the week the window opens, for example Saturday. The window
+	// opens every day if days is empty.
+	// +optional
+	// +kubebuilder:validation:items:Enum=Monday;Tuesday;Wednesday;Thursday;Friday;Saturday;Sunday
+	Days []string `json:"days,omitempty"`
+
+	// Start is the UTC time of day the window opens, in 24 hour HH:MM format.
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	Start string `json:"start"`
+
+	// Duration is how long the window stays open.
+	Duration metav1.Duration `json:"duration"`
 }
 
 // PackageStatus represents the observed state of a Package.
@@ -103,6 +175,23 @@ type PackageStatus struct {
 	// resolution. It may be different from spec.package if the package path was
 	// rewritten using an image config.
 	ResolvedPackage string `json:"resolvedPackage,omitempty"`
+
+	// LastAutoUpdate records the most recent time the package manager
+	// automatically updated the package.
+	// +optional
+	LastAutoUpdate *PackageUpdate `json:"lastAutoUpdate,omitempty"`
+}
+
+// A PackageUpdate records an automatic update of a package.
+type PackageUpdate struct {
+	// From is the package the package manager updated from.
+	From string `json:"from"`
+
+	// To is the package the package manager updated to.
+	To string `json:"to"`
+
+	// Time is when the package manager updated the package.
+	Time metav1.Time `json:"time"`
 }
 
 // ImageConfigRef is a reference to an image config that indicates how the
@@ -130,4 +219,7 @@ const (
 	// ImageConfigReasonRuntime indicates an image config was used to configure
 	// the package's runtime.
 	ImageConfigReasonRuntime ImageConfigRefReason = "ConfigureRuntime"
+	// ImageConfigReasonMirror indicates an image config was used to pull the
+	// image from a mirror.
+	ImageConfigReasonMirror ImageConfigRefReason = "PullFromMirror"
 )
@@ -18,4 +18,8 @@ package v1beta1
 
 import "github.com/crossplane/crossplane/v2/internal/protection"
 
-var _ protection.Usage = &ClusterUsage{}
+var (
+	_ protection.Usage       = &ClusterUsage{}
+	_ protection.Expiring    = &ClusterUsage{}
+	_ protection.Conditional = &ClusterUsage{}
+)
@@ -47,6 +47,8 @@ type ClusterUsage struct {
 
 // ClusterUsageSpec defines the desired state of a ClusterUsage.
 // +kubebuilder:validation:XValidation:rule="has(self.by) || has(self.reason)",message="either \"spec.by\" or \"spec.reason\" must be specified."
+// +kubebuilder:validation:XValidation:rule="!(has(self.expiresAt) && has(self.ttl))",message="only one of \"spec.expiresAt\" or \"spec.ttl\" may be specified."
+// +kubebuilder:validation:XValidation:rule="!has(self.while) || has(self.by)",message="\"spec.while\" requires \"spec.by\"."
 type ClusterUsageSpec struct {
 	// Of is the resource that is "being used".
 	// +kubebuilder:validation:XValidation:rule="has(self.resourceRef) || has(self.resourceSelector)",message="either a resource reference or a resource selector should be set."
@@ -64,6 +66,23 @@ type ClusterUsageSpec struct {
 	// ReplayDeletion will trigger a deletion on the used resource during the deletion of the usage itself, if it was attempted to be deleted at least once.
 	// +optional
 	ReplayDeletion *bool `json:"replayDeletion,omitempty"`
+
+	// ExpiresAt is the time at which the Usage expires. An expired Usage
+	// doesn't block deletion, and is deleted by Crossplane unless it's
+	// composed by a composite resource.
+	// +optional
+	ExpiresAt *metav1.Time `json:"expiresAt,omitempty"`
+
+	// TTL is how long after its creation the Usage expires. An expired Usage
+	// doesn't block deletion, and is deleted by Crossplane unless it's
+	// composed by a composite resource.
+	// +optional
+	TTL *metav1.Duration `json:"ttl,omitempty"`
+
+	// While configures the Usage to block deletion only while the using
+	// resource has the supplied condition. Requires "spec.by".
+	// +optional
+	While *UsageCondition `json:"while,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -19,6 +19,8 @@ limitations under the License.
 package v1beta1
 
 import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
 	xpv1 "github.com/crossplane/crossplane-runtime/v2/apis/common/v1"
 
 	"github.com/crossplane/crossplane/v2/internal/protection"
@@ -82,6 +84,47 @@ func (u *Usage) SetReplayDeletion(replay *bool) {
 	u.Spec.ReplayDeletion = replay
 }
 
+// GetExpiresAt gets the time at which this Usage expires.
+func (u *Usage) GetExpiresAt() *metav1.Time {
+	return u.Spec.ExpiresAt
+}
+
+// SetExpiresAt sets the time at which this Usage expires.
+func (u *Usage) SetExpiresAt(t *metav1.Time) {
+	u.Spec.ExpiresAt = t
+}
+
+// GetTTL gets how long after its creation this Usage expires.
+func (u *Usage) GetTTL() *metav1.Duration {
+	return u.Spec.TTL
+}
+
+// SetTTL sets how long after its creation this Usage expires.
+func (u *Usage) SetTTL(ttl *metav1.Duration) {
+	u.Spec.TTL = ttl
+}
+
+// GetWhile gets the condition the using resource must have for this Usage to
+// block deletion.
+func (u *Usage) GetWhile() *protection.Condition {
+	if u.Spec.While == nil {
+		return nil
+	}
+
+	return &protection.Condition{Type: u.Spec.While.Type, Status: u.Spec.While.Status}
+}
+
+// SetWhile sets the condition the using resource must have for this Usage to
+// block deletion.
+func (u *Usage) SetWhile(c *protection.Condition) {
+	if c == nil {
+		u.Spec.While = nil
+		return
+	}
+
+	u.Spec.While = &UsageCondition{Type: c.Type, Status: c.Status}
+}
+
 // GetCondition of this Usage.
 func (u *Usage) GetCondition(ct xpv1.ConditionType) xpv1.Condition {
 	return u.Status.GetCondition(ct)
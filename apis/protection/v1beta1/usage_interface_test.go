@@ -18,4 +18,8 @@ package v1beta1
 
 import "github.com/crossplane/crossplane/v2/internal/protection"
 
-var _ protection.Usage = &Usage{}
+var (
+	_ protection.Usage       = &Usage{}
+	_ protection.Expiring    = &Usage{}
+	_ protection.Conditional = &Usage{}
+)
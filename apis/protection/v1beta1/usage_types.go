@@ -117,9 +117,23 @@ type NamespacedResource struct {
 	ResourceSelector *NamespacedResourceSelector `json:"resourceSelector,omitempty"`
 }
 
+// UsageCondition is a condition of the resource that uses another resource.
+type UsageCondition struct {
+	// Type of the condition, for example Ready.
+	Type string `json:"type"`
+
+	// Status of the condition.
+	// +optional
+	// +kubebuilder:default="True"
+	// +kubebuilder:validation:Enum="True";"False";"Unknown"
+	Status string `json:"status,omitempty"`
+}
+
 // UsageSpec defines the desired state of Usage.
 // +kubebuilder:validation:XValidation:rule="has(self.by) || has(self.reason)",message="either \"spec.by\" or \"spec.reason\" must be specified."
 // +kubebuilder:validation:XValidation:rule="has(self.by) || (!has(self.of.resourceRef) || !has(self.of.resourceRef.__namespace__)) && (!has(self.of.resourceSelector) || !has(self.of.resourceSelector.__namespace__))",message="cross-namespace \"spec.of\" is not allowed without \"spec.by\" resource."
+// +kubebuilder:validation:XValidation:rule="!(has(self.expiresAt) && has(self.ttl))",message="only one of \"spec.expiresAt\" or \"spec.ttl\" may be specified."
+// +kubebuilder:validation:XValidation:rule="!has(self.while) || has(self.by)",message="\"spec.while\" requires \"spec.by\"."
 type UsageSpec struct {
 	// Of is the resource that is "being used".
 	// +kubebuilder:validation:XValidation:rule="has(self.resourceRef) || has(self.resourceSelector)",message="either a resource reference or a resource selector should be set."
@@ -137,6 +151,23 @@ type UsageSpec struct {
 	// ReplayDeletion will trigger a deletion on the used resource during the deletion of the usage itself, if it was attempted to be deleted at least once.
 	// +optional
 	ReplayDeletion *bool `json:"replayDeletion,omitempty"`
+
+	// ExpiresAt is the time at which the Usage expires. An expired Usage
+	// doesn't block deletion, and is deleted by Crossplane unless it's
+	// composed by a composite resource.
+	// +optional
+	ExpiresAt *metav1.Time `json:"expiresAt,omitempty"`
+
+	// TTL is how long after its creation the Usage expires. An expired Usage
+	// doesn't block deletion, and is deleted by Crossplane unless it's
+	// composed by a composite resource.
+	// +optional
+	TTL *metav1.Duration `json:"ttl,omitempty"`
+
+	// While configures the Usage to block deletion only while the using
+	// resource has the supplied condition. Requires "spec.by".
+	// +optional
+	While *UsageCondition `json:"while,omitempty"`
 }
 
 // UsageStatus defines the observed state of Usage.
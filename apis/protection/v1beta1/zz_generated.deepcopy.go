@@ -21,6 +21,7 @@ limitations under the License.
 package v1beta1
 
 import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -102,6 +103,20 @@ func (in *ClusterUsageSpec) DeepCopyInto(out *ClusterUsageSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.ExpiresAt != nil {
+		in, out := &in.ExpiresAt, &out.ExpiresAt
+		*out = (*in).DeepCopy()
+	}
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.While != nil {
+		in, out := &in.While, &out.While
+		*out = new(UsageCondition)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterUsageSpec.
@@ -315,6 +330,21 @@ func (in *Usage) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UsageCondition) DeepCopyInto(out *UsageCondition) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UsageCondition.
+func (in *UsageCondition) DeepCopy() *UsageCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(UsageCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UsageList) DeepCopyInto(out *UsageList) {
 	*out = *in
@@ -366,6 +396,20 @@ func (in *UsageSpec) DeepCopyInto(out *UsageSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.ExpiresAt != nil {
+		in, out := &in.ExpiresAt, &out.ExpiresAt
+		*out = (*in).DeepCopy()
+	}
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.While != nil {
+		in, out := &in.While, &out.While
+		*out = new(UsageCondition)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UsageSpec.
@@ -21,9 +21,13 @@ package beta
 
 import (
 	"github.com/crossplane/crossplane/v2/cmd/crank/beta/convert"
+	"github.com/crossplane/crossplane/v2/cmd/crank/beta/lint"
+	"github.com/crossplane/crossplane/v2/cmd/crank/beta/pkg"
 	"github.com/crossplane/crossplane/v2/cmd/crank/beta/top"
 	"github.com/crossplane/crossplane/v2/cmd/crank/beta/trace"
+	"github.com/crossplane/crossplane/v2/cmd/crank/beta/usages"
 	"github.com/crossplane/crossplane/v2/cmd/crank/beta/validate"
+	"github.com/crossplane/crossplane/v2/cmd/crank/beta/xrd"
 )
 
 // Cmd contains beta commands.
@@ -31,9 +35,13 @@ type Cmd struct {
 	// Subcommands and flags will appear in the CLI help output in the same
 	// order they're specified here. Keep them in alphabetical order.
 	Convert  convert.Cmd  `cmd:"" help:"Convert a Crossplane resource to a newer version or kind."`
+	Lint     lint.Cmd     `cmd:"" help:"Check a package's Compositions and XRDs for issues."`
+	Pkg      pkg.Cmd      `cmd:"" help:"Explain and simulate package dependency resolution."`
 	Top      top.Cmd      `cmd:"" help:"Display resource (CPU/memory) usage by Crossplane related pods."`
 	Trace    trace.Cmd    `cmd:"" help:"Trace a Crossplane resource to get a detailed output of its relationships, helpful for troubleshooting."`
+	Usages   usages.Cmd   `cmd:"" help:"Show the graph of resources that Usages protect from deletion."`
 	Validate validate.Cmd `cmd:"" help:"Validate Crossplane resources."`
+	XRD      xrd.Cmd      `cmd:"" help:"Work with CompositeResourceDefinitions (XRDs)."`
 }
 
 // Help output for crossplane beta.
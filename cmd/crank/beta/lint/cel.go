@@ -0,0 +1,182 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lint
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/ext"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/yaml"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+)
+
+// CELRules is a file of custom rules.
+type CELRules struct {
+	Rules []CELRuleSpec `json:"rules"`
+}
+
+// A CELRuleSpec specifies a custom rule. The rule is checked against each
+// object in the package that matches its apiVersion and kind.
+type CELRuleSpec struct {
+	// Name of the rule.
+	Name string `json:"name"`
+
+	// APIVersion of the objects to check. Objects of any API version are
+	// checked if this is empty.
+	APIVersion string `json:"apiVersion,omitempty"`
+
+	// Kind of the objects to check. Objects of any kind are checked if this
+	// is empty.
+	Kind string `json:"kind,omitempty"`
+
+	// Expression is a CEL expression that must return a bool. The object
+	// being checked is available as self. The rule reports an issue if the
+	// expression returns false.
+	Expression string `json:"expression"`
+
+	// Message describing the issue the rule reports. Defaults to the
+	// expression.
+	Message string `json:"message,omitempty"`
+
+	// Severity of the issue the rule reports. Defaults to Error.
+	Severity Severity `json:"severity,omitempty"`
+}
+
+// A CELRule is a custom rule that checks package objects using a CEL
+// expression.
+type CELRule struct {
+	spec CELRuleSpec
+	gv   schema.GroupVersion
+	prg  cel.Program
+}
+
+// NewCELRule compiles the supplied rule.
+func NewCELRule(s CELRuleSpec) (*CELRule, error) {
+	if s.Name == "" {
+		return nil, errors.New("rule has no name")
+	}
+
+	switch s.Severity {
+	case "":
+		s.Severity = SeverityError
+	case SeverityError, SeverityWarning:
+	default:
+		return nil, errors.Errorf("rule %q has unknown severity %q", s.Name, s.Severity)
+	}
+
+	var gv schema.GroupVersion
+
+	if s.APIVersion != "" {
+		v, err := schema.ParseGroupVersion(s.APIVersion)
+		if err != nil {
+			return nil, errors.Wrapf(err, "rule %q has invalid apiVersion", s.Name)
+		}
+
+		gv = v
+	}
+
+	env, err := cel.NewEnv(cel.Variable("self", cel.DynType), ext.Strings())
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot create CEL environment")
+	}
+
+	ast, iss := env.Compile(s.Expression)
+	if iss.Err() != nil {
+		return nil, errors.Wrapf(iss.Err(), "cannot compile rule %q", s.Name)
+	}
+
+	if !ast.OutputType().IsExactType(cel.BoolType) && !ast.OutputType().IsExactType(cel.DynType) {
+		return nil, errors.Errorf("rule %q expression must return a bool, not %s", s.Name, ast.OutputType())
+	}
+
+	prg, err := env.Program(ast)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot compile rule %q", s.Name)
+	}
+
+	return &CELRule{spec: s, gv: gv, prg: prg}, nil
+}
+
+// LoadCELRules loads and compiles the custom rules in the supplied file.
+func LoadCELRules(path string) ([]Rule, error) {
+	b, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot read rules from %s", path)
+	}
+
+	f := &CELRules{}
+	if err := yaml.UnmarshalStrict(b, f); err != nil {
+		return nil, errors.Wrapf(err, "cannot parse rules from %s", path)
+	}
+
+	rules := make([]Rule, 0, len(f.Rules))
+
+	for _, s := range f.Rules {
+		r, err := NewCELRule(s)
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot load rules from %s", path)
+		}
+
+		rules = append(rules, r)
+	}
+
+	return rules, nil
+}
+
+// Check the supplied package's objects against the rule. It never returns an
+// error.
+func (r *CELRule) Check(p *Package) ([]Issue, error) {
+	var issues []Issue
+
+	for _, m := range p.Objects {
+		gvk := m.Resource.GroupVersionKind()
+		if r.spec.APIVersion != "" && gvk.GroupVersion() != r.gv {
+			continue
+		}
+
+		if r.spec.Kind != "" && gvk.Kind != r.spec.Kind {
+			continue
+		}
+
+		// An object the expression can't be evaluated against, for
+		// example because it lacks a field the expression expects, is an
+		// issue with the object rather than the rule.
+		out, _, err := r.prg.Eval(map[string]any{"self": m.Resource.Object})
+		if err != nil {
+			issues = append(issues, NewIssue(r.spec.Name, r.spec.Severity, m, fmt.Sprintf("cannot evaluate %s: %v", r.spec.Expression, err)))
+			continue
+		}
+
+		if ok, _ := out.Value().(bool); ok {
+			continue
+		}
+
+		msg := r.spec.Message
+		if msg == "" {
+			msg = fmt.Sprintf("%s is false", r.spec.Expression)
+		}
+
+		issues = append(issues, NewIssue(r.spec.Name, r.spec.Severity, m, msg))
+	}
+
+	return issues, nil
+}
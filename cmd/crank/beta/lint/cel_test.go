@@ -0,0 +1,110 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lint
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/test"
+
+	"github.com/crossplane/crossplane/v2/cmd/crank/common/load"
+)
+
+func TestCELRule(t *testing.T) {
+	type want struct {
+		issues []Issue
+		err    error
+	}
+
+	cases := map[string]struct {
+		reason string
+		spec   CELRuleSpec
+		want   want
+	}{
+		"NoName": {
+			reason: "A rule must have a name.",
+			spec:   CELRuleSpec{Expression: "true"},
+			want:   want{err: errors.New("rule has no name")},
+		},
+		"UnknownSeverity": {
+			reason: "A rule must have a known severity.",
+			spec:   CELRuleSpec{Name: "cool", Expression: "true", Severity: "Fatal"},
+			want:   want{err: errors.New(`rule "cool" has unknown severity "Fatal"`)},
+		},
+		"NotBool": {
+			reason: "A rule's expression must return a bool.",
+			spec:   CELRuleSpec{Name: "cool", Expression: "'nope'"},
+			want:   want{err: errors.New(`rule "cool" expression must return a bool, not string`)},
+		},
+		"Passes": {
+			reason: "Objects that satisfy the rule's expression shouldn't have issues.",
+			spec:   CELRuleSpec{Name: "cool", Kind: "Composition", Expression: "self.spec.mode == 'Pipeline'"},
+			want:   want{},
+		},
+		"Fails": {
+			reason: "Objects of the rule's kind that don't satisfy its expression should have issues.",
+			spec: CELRuleSpec{
+				Name:       "described",
+				APIVersion: "apiextensions.crossplane.io/v1",
+				Kind:       "Composition",
+				Expression: "has(self.metadata.annotations)",
+				Message:    "Compositions must have annotations.",
+				Severity:   SeverityWarning,
+			},
+			want: want{issues: []Issue{{
+				Rule:       "described",
+				Severity:   SeverityWarning,
+				Message:    "Compositions must have annotations.",
+				APIVersion: "apiextensions.crossplane.io/v1",
+				Kind:       "Composition",
+				Name:       "cool-composition",
+				File:       "composition.yaml",
+				Line:       1,
+			}}},
+		},
+		"OtherAPIVersion": {
+			reason: "Objects of other API versions shouldn't be checked.",
+			spec:   CELRuleSpec{Name: "cool", APIVersion: "apiextensions.crossplane.io/v2", Expression: "false"},
+			want:   want{},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r, err := NewCELRule(tc.spec)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Fatalf("\n%s\nNewCELRule(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+
+			if err != nil {
+				return
+			}
+
+			got, err := r.Check(&Package{Objects: []load.Manifest{manifest(t, "composition.yaml", 1, composition)}})
+			if err != nil {
+				t.Fatalf("\n%s\nCheck(...): unexpected error: %v", tc.reason, err)
+			}
+
+			if diff := cmp.Diff(tc.want.issues, got); diff != "" {
+				t.Errorf("\n%s\nCheck(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
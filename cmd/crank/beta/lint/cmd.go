@@ -0,0 +1,256 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package lint implements static analysis of Crossplane Compositions and XRDs.
+package lint
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/alecthomas/kong"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/logging"
+
+	"github.com/crossplane/crossplane/v2/cmd/crank/common/load"
+)
+
+const (
+	outputText = "text"
+	outputJSON = "json"
+)
+
+const errWriteOutput = "cannot write output"
+
+// Cmd arguments and flags for the lint subcommand.
+type Cmd struct {
+	// Flags. Keep them in alphabetical order.
+	ExamplesRoot string   `default:"./examples"                                                         help:"A directory of example YAML files. Used to check that Secrets the package references have examples." predictor:"directory" short:"e" type:"path"`
+	Output       string   `default:"text"                                                               enum:"text,json"                                                                                            help:"Output format. One of: text, json." short:"o"`
+	PackageRoot  string   `default:"."                                                                  help:"The directory that contains the package's crossplane.yaml file."                                      predictor:"directory" short:"f" type:"existingdir"`
+	Rules        []string `help:"Files of custom CEL rules to check in addition to the built-in rules." placeholder:"PATH"                                                                                          predictor:"file"      type:"existingfile"`
+}
+
+// Help prints out the help for the lint command.
+func (c *Cmd) Help() string {
+	return `
+This command checks the Compositions and XRDs of a package for issues before
+anything runs. It loads every YAML file under the package root, and every
+example under the examples root. The built-in rules flag:
+
+  function-dependency           Pipeline steps that use a Function the package
+                                doesn't depend on.
+  function-credentials-example  Pipeline step credentials that use a Secret
+                                with no example.
+  composite-type-ref            Compositions whose compositeTypeRef doesn't
+                                match a version of an XRD in the package.
+  required-resource-selector    Required resource selectors with neither a
+                                name nor matchLabels.
+  duplicate-step                Pipeline steps with the same name.
+
+Custom rules are CEL expressions that each matching object must satisfy. The
+object is available as self. For example:
+
+  rules:
+  - name: composition-has-description
+    apiVersion: apiextensions.crossplane.io/v1
+    kind: Composition
+    expression: "has(self.metadata.annotations) && 'description' in self.metadata.annotations"
+    message: Compositions must have a description annotation.
+    severity: Warning
+
+The command returns a non zero exit code if it finds any issue with severity
+Error.
+
+Examples:
+
+  # Lint the package in the current directory.
+  crossplane beta lint
+
+  # Lint a package with custom rules, and output the issues as JSON.
+  crossplane beta lint -f package/ -e package/examples --rules rules.yaml -o json
+`
+}
+
+// Run lint.
+func (c *Cmd) Run(k *kong.Context, _ logging.Logger) error {
+	root, err := filepath.Abs(c.PackageRoot)
+	if err != nil {
+		return errors.Wrap(err, "cannot get absolute path of package root")
+	}
+
+	examples, err := filepath.Abs(c.ExamplesRoot)
+	if err != nil {
+		return errors.Wrap(err, "cannot get absolute path of examples root")
+	}
+
+	p := &Package{}
+
+	if p.Objects, err = loadDir(root, examples); err != nil {
+		return errors.Wrap(err, "cannot load package")
+	}
+
+	if p.Examples, err = loadDir(examples, ""); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return errors.Wrap(err, "cannot load examples")
+	}
+
+	rules := DefaultRules()
+
+	for _, f := range c.Rules {
+		r, err := LoadCELRules(f)
+		if err != nil {
+			return err
+		}
+
+		rules = append(rules, r...)
+	}
+
+	issues, err := NewLinter(rules...).Lint(p)
+	if err != nil {
+		return errors.Wrap(err, "cannot lint package")
+	}
+
+	if err := writeIssues(k.Stdout, c.Output, relativeTo(root, issues)); err != nil {
+		return err
+	}
+
+	for _, i := range issues {
+		if i.Severity == SeverityError {
+			return errors.New("package has lint errors")
+		}
+	}
+
+	return nil
+}
+
+// loadDir loads the YAML files under the supplied directory, skipping hidden
+// directories and the supplied directory to skip.
+func loadDir(dir, skip string) ([]load.Manifest, error) {
+	var out []load.Manifest
+
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			if path != dir && (path == skip || strings.HasPrefix(d.Name(), ".")) {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		if ext := filepath.Ext(path); ext != ".yaml" && ext != ".yml" {
+			return nil
+		}
+
+		ms, err := loadFile(path)
+		if err != nil {
+			return errors.Wrapf(err, "cannot load %s", path)
+		}
+
+		out = append(out, ms...)
+
+		return nil
+	})
+
+	return out, err
+}
+
+func loadFile(path string) ([]load.Manifest, error) {
+	f, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close() //nolint:errcheck // Only open for reading.
+
+	docs, err := load.YamlDocuments(f)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]load.Manifest, 0, len(docs))
+
+	for _, d := range docs {
+		u := &unstructured.Unstructured{}
+		if err := yaml.Unmarshal(d.Bytes, &u.Object); err != nil {
+			return nil, errors.Wrapf(err, "cannot parse YAML document on line %d", d.Line)
+		}
+
+		// Skip documents that are empty, or only comments.
+		if len(u.Object) == 0 {
+			continue
+		}
+
+		out = append(out, load.Manifest{Resource: u, File: path, Line: d.Line})
+	}
+
+	return out, nil
+}
+
+// relativeTo makes the files of the supplied issues relative to the supplied
+// root, where possible.
+func relativeTo(root string, issues []Issue) []Issue {
+	for i := range issues {
+		if rel, err := filepath.Rel(root, issues[i].File); err == nil && !strings.HasPrefix(rel, "..") {
+			issues[i].File = rel
+		}
+	}
+
+	return issues
+}
+
+func writeIssues(w io.Writer, format string, issues []Issue) error {
+	switch format {
+	case outputText:
+	case outputJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+
+		return errors.Wrap(enc.Encode(struct {
+			Issues []Issue `json:"issues"`
+		}{Issues: issues}), errWriteOutput)
+	default:
+		return errors.Errorf("unknown output format %q", format)
+	}
+
+	errs, warns := 0, 0
+
+	for _, i := range issues {
+		if i.Severity == SeverityError {
+			errs++
+		} else {
+			warns++
+		}
+
+		if _, err := fmt.Fprintf(w, "%s:%d: [%s] %s %s: %s (%s)\n", i.File, i.Line, i.Severity, i.Kind, i.Name, i.Message, i.Rule); err != nil {
+			return errors.Wrap(err, errWriteOutput)
+		}
+	}
+
+	_, err := fmt.Fprintf(w, "Found %d errors and %d warnings\n", errs, warns)
+
+	return errors.Wrap(err, errWriteOutput)
+}
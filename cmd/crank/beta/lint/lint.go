@@ -0,0 +1,191 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lint
+
+import (
+	"cmp"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+
+	v1 "github.com/crossplane/crossplane/v2/apis/apiextensions/v1"
+	pkgmetav1 "github.com/crossplane/crossplane/v2/apis/pkg/meta/v1"
+	"github.com/crossplane/crossplane/v2/cmd/crank/common/load"
+)
+
+// A Severity indicates how serious an issue is.
+type Severity string
+
+// Issue severities. Only errors cause linting to fail.
+const (
+	SeverityError   Severity = "Error"
+	SeverityWarning Severity = "Warning"
+)
+
+// An Issue is a problem a Rule found in a package.
+type Issue struct {
+	// Rule that found the issue.
+	Rule string `json:"rule"`
+
+	// Severity of the issue.
+	Severity Severity `json:"severity"`
+
+	// Message describing the issue.
+	Message string `json:"message"`
+
+	// The object the issue was found in.
+	APIVersion string `json:"apiVersion,omitempty"`
+	Kind       string `json:"kind,omitempty"`
+	Name       string `json:"name,omitempty"`
+
+	// File and line the object was loaded from.
+	File string `json:"file,omitempty"`
+	Line int    `json:"line,omitempty"`
+}
+
+// NewIssue returns an issue found by the supplied rule in the supplied
+// manifest.
+func NewIssue(rule string, s Severity, m load.Manifest, message string) Issue {
+	return Issue{
+		Rule:       rule,
+		Severity:   s,
+		Message:    message,
+		APIVersion: m.Resource.GetAPIVersion(),
+		Kind:       m.Resource.GetKind(),
+		Name:       m.Resource.GetName(),
+		File:       m.File,
+		Line:       m.Line,
+	}
+}
+
+// A Package is a set of Crossplane resources to lint, typically the contents of
+// a Configuration package.
+type Package struct {
+	// Objects in the package.
+	Objects []load.Manifest
+
+	// Examples of how to use the package.
+	Examples []load.Manifest
+}
+
+// A Composition in a package, and the manifest it was loaded from.
+type Composition struct {
+	*v1.Composition
+
+	Manifest load.Manifest
+}
+
+// Meta returns the package's Configuration metadata, if it has any.
+func (p *Package) Meta() (*pkgmetav1.Configuration, error) {
+	for _, m := range p.Objects {
+		gvk := m.Resource.GroupVersionKind()
+		if gvk.Group != pkgmetav1.Group || gvk.Kind != pkgmetav1.ConfigurationKind {
+			continue
+		}
+
+		c := &pkgmetav1.Configuration{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(m.Resource.Object, c); err != nil {
+			return nil, errors.Wrapf(err, "cannot parse %s", m.File)
+		}
+
+		return c, nil
+	}
+
+	return nil, nil //nolint:nilnil // A package without metadata isn't an error.
+}
+
+// Compositions returns the package's Compositions.
+func (p *Package) Compositions() ([]Composition, error) {
+	var out []Composition
+
+	for _, m := range p.Objects {
+		if m.Resource.GroupVersionKind() != v1.CompositionGroupVersionKind {
+			continue
+		}
+
+		c := &v1.Composition{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(m.Resource.Object, c); err != nil {
+			return nil, errors.Wrapf(err, "cannot parse Composition %q", m.Resource.GetName())
+		}
+
+		out = append(out, Composition{Composition: c, Manifest: m})
+	}
+
+	return out, nil
+}
+
+// ObjectsOfKind returns the package's objects of the supplied API group and
+// kind, at any version.
+func ObjectsOfKind(ms []load.Manifest, group, kind string) []load.Manifest {
+	var out []load.Manifest
+
+	for _, m := range ms {
+		gvk := m.Resource.GroupVersionKind()
+		if gvk.Group == group && gvk.Kind == kind {
+			out = append(out, m)
+		}
+	}
+
+	return out
+}
+
+// A Rule checks a package for issues.
+type Rule interface {
+	// Check the supplied package. It returns an error only if the rule
+	// couldn't be checked, not if it found issues.
+	Check(p *Package) ([]Issue, error)
+}
+
+// A RuleFn is a function that satisfies the Rule interface.
+type RuleFn func(p *Package) ([]Issue, error)
+
+// Check the supplied package.
+func (fn RuleFn) Check(p *Package) ([]Issue, error) {
+	return fn(p)
+}
+
+// A Linter checks packages against a set of rules.
+type Linter struct {
+	rules []Rule
+}
+
+// NewLinter returns a Linter that checks the supplied rules.
+func NewLinter(rules ...Rule) *Linter {
+	return &Linter{rules: rules}
+}
+
+// Lint the supplied package. Issues are sorted by where they were found.
+func (l *Linter) Lint(p *Package) ([]Issue, error) {
+	issues := make([]Issue, 0)
+
+	for _, r := range l.rules {
+		i, err := r.Check(p)
+		if err != nil {
+			return nil, err
+		}
+
+		issues = append(issues, i...)
+	}
+
+	slices.SortStableFunc(issues, func(a, b Issue) int {
+		return cmp.Or(cmp.Compare(a.File, b.File), cmp.Compare(a.Line, b.Line))
+	})
+
+	return issues, nil
+}
@@ -0,0 +1,242 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lint
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	v1 "github.com/crossplane/crossplane/v2/apis/apiextensions/v1"
+	pkgv1 "github.com/crossplane/crossplane/v2/apis/pkg/v1"
+	"github.com/crossplane/crossplane/v2/internal/xpkg"
+)
+
+// Names of the built-in rules.
+const (
+	RuleFunctionDependency       = "function-dependency"
+	RuleFunctionCredentials      = "function-credentials-example"
+	RuleCompositeTypeRef         = "composite-type-ref"
+	RuleRequiredResourceSelector = "required-resource-selector"
+	RuleDuplicateStep            = "duplicate-step"
+)
+
+// DefaultRules returns the built-in rules.
+func DefaultRules() []Rule {
+	return []Rule{
+		RuleFn(FunctionDependencies),
+		RuleFn(FunctionCredentialsExamples),
+		RuleFn(CompositeTypeRefs),
+		RuleFn(RequiredResourceSelectors),
+		RuleFn(DuplicateSteps),
+	}
+}
+
+// FunctionDependencies flags pipeline steps that reference a Function the
+// package doesn't depend on. Functions are assumed to be named after their
+// package's repository, as they are when installed as dependencies. Packages
+// without metadata aren't checked.
+func FunctionDependencies(p *Package) ([]Issue, error) {
+	meta, err := p.Meta()
+	if err != nil || meta == nil {
+		return nil, err
+	}
+
+	fns := map[string]bool{}
+
+	for _, d := range meta.Spec.DependsOn {
+		pkg := ""
+
+		switch {
+		case d.Function != nil:
+			pkg = *d.Function
+		case d.Package != nil && d.Kind != nil && *d.Kind == pkgv1.FunctionKind:
+			pkg = *d.Package
+		default:
+			continue
+		}
+
+		fns[xpkg.ToDNSLabel(pkg)] = true
+		if ref, err := name.ParseReference(pkg); err == nil {
+			fns[xpkg.ToDNSLabel(ref.Context().RepositoryStr())] = true
+		}
+	}
+
+	comps, err := p.Compositions()
+	if err != nil {
+		return nil, err
+	}
+
+	var issues []Issue
+
+	for _, c := range comps {
+		for _, s := range c.Spec.Pipeline {
+			if fns[s.FunctionRef.Name] {
+				continue
+			}
+
+			issues = append(issues, NewIssue(RuleFunctionDependency, SeverityError, c.Manifest,
+				fmt.Sprintf("pipeline step %q uses Function %q, which isn't in the package's dependsOn", s.Step, s.FunctionRef.Name)))
+		}
+	}
+
+	return issues, nil
+}
+
+// FunctionCredentialsExamples flags pipeline steps that load credentials from
+// a Secret that isn't among the package's examples.
+func FunctionCredentialsExamples(p *Package) ([]Issue, error) {
+	comps, err := p.Compositions()
+	if err != nil {
+		return nil, err
+	}
+
+	secrets := map[string]bool{}
+	for _, m := range ObjectsOfKind(p.Examples, "", "Secret") {
+		secrets[m.Resource.GetNamespace()+"/"+m.Resource.GetName()] = true
+	}
+
+	var issues []Issue
+
+	for _, c := range comps {
+		for _, s := range c.Spec.Pipeline {
+			for _, cr := range s.Credentials {
+				if cr.Source != v1.FunctionCredentialsSourceSecret || cr.SecretRef == nil {
+					continue
+				}
+
+				if secrets[cr.SecretRef.Namespace+"/"+cr.SecretRef.Name] {
+					continue
+				}
+
+				issues = append(issues, NewIssue(RuleFunctionCredentials, SeverityWarning, c.Manifest,
+					fmt.Sprintf("pipeline step %q credentials %q use Secret %s/%s, which has no example", s.Step, cr.Name, cr.SecretRef.Namespace, cr.SecretRef.Name)))
+			}
+		}
+	}
+
+	return issues, nil
+}
+
+// CompositeTypeRefs flags Compositions whose compositeTypeRef doesn't match a
+// version of an XRD in the package. A Composition of a kind no XRD in the
+// package defines is only a warning, because the XRD may come from a
+// dependency.
+func CompositeTypeRefs(p *Package) ([]Issue, error) {
+	comps, err := p.Compositions()
+	if err != nil {
+		return nil, err
+	}
+
+	// Versions of each kind of XR defined by the package's XRDs. Both v1
+	// and v2 XRDs share these fields.
+	versions := map[schema.GroupKind][]string{}
+
+	for _, m := range ObjectsOfKind(p.Objects, v1.Group, v1.CompositeResourceDefinitionKind) {
+		group, _, _ := unstructured.NestedString(m.Resource.Object, "spec", "group")
+		kind, _, _ := unstructured.NestedString(m.Resource.Object, "spec", "names", "kind")
+		vs, _, _ := unstructured.NestedSlice(m.Resource.Object, "spec", "versions")
+
+		gk := schema.GroupKind{Group: group, Kind: kind}
+		for _, v := range vs {
+			if v, ok := v.(map[string]any); ok {
+				n, _ := v["name"].(string)
+				versions[gk] = append(versions[gk], n)
+			}
+		}
+	}
+
+	var issues []Issue
+
+	for _, c := range comps {
+		ref := c.Spec.CompositeTypeRef
+		gvk := schema.FromAPIVersionAndKind(ref.APIVersion, ref.Kind)
+
+		vs, ok := versions[gvk.GroupKind()]
+
+		switch {
+		case !ok:
+			issues = append(issues, NewIssue(RuleCompositeTypeRef, SeverityWarning, c.Manifest,
+				fmt.Sprintf("compositeTypeRef %s isn't defined by any XRD in the package", gvk.GroupKind())))
+		case !slices.Contains(vs, gvk.Version):
+			issues = append(issues, NewIssue(RuleCompositeTypeRef, SeverityError, c.Manifest,
+				fmt.Sprintf("compositeTypeRef %s has version %q, but its XRD only defines versions %v", gvk.GroupKind(), gvk.Version, vs)))
+		}
+	}
+
+	return issues, nil
+}
+
+// RequiredResourceSelectors flags pipeline steps with required resource
+// selectors that specify neither a name nor labels to match.
+func RequiredResourceSelectors(p *Package) ([]Issue, error) {
+	comps, err := p.Compositions()
+	if err != nil {
+		return nil, err
+	}
+
+	var issues []Issue
+
+	for _, c := range comps {
+		for _, s := range c.Spec.Pipeline {
+			if s.Requirements == nil {
+				continue
+			}
+
+			for _, rr := range s.Requirements.RequiredResources {
+				if rr.Name != nil || len(rr.MatchLabels) > 0 {
+					continue
+				}
+
+				issues = append(issues, NewIssue(RuleRequiredResourceSelector, SeverityError, c.Manifest,
+					fmt.Sprintf("pipeline step %q required resource %q specifies neither a name nor matchLabels", s.Step, rr.RequirementName)))
+			}
+		}
+	}
+
+	return issues, nil
+}
+
+// DuplicateSteps flags Compositions with more than one pipeline step of the
+// same name.
+func DuplicateSteps(p *Package) ([]Issue, error) {
+	comps, err := p.Compositions()
+	if err != nil {
+		return nil, err
+	}
+
+	var issues []Issue
+
+	for _, c := range comps {
+		seen := map[string]int{}
+
+		for _, s := range c.Spec.Pipeline {
+			seen[s.Step]++
+
+			// Only report each duplicate name once.
+			if seen[s.Step] == 2 {
+				issues = append(issues, NewIssue(RuleDuplicateStep, SeverityError, c.Manifest,
+					fmt.Sprintf("pipeline step name %q is used more than once", s.Step)))
+			}
+		}
+	}
+
+	return issues, nil
+}
@@ -0,0 +1,282 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lint
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/test"
+
+	"github.com/crossplane/crossplane/v2/cmd/crank/common/load"
+)
+
+func manifest(t *testing.T, file string, line int, y string) load.Manifest {
+	t.Helper()
+
+	u := &unstructured.Unstructured{}
+	if err := yaml.Unmarshal([]byte(y), &u.Object); err != nil {
+		t.Fatal(err)
+	}
+
+	return load.Manifest{Resource: u, File: file, Line: line}
+}
+
+const (
+	meta = `
+apiVersion: meta.pkg.crossplane.io/v1
+kind: Configuration
+metadata:
+  name: cool-config
+spec:
+  dependsOn:
+  - function: xpkg.crossplane.io/crossplane-contrib/function-patch-and-transform
+    version: ">=v0.1.0"
+  - apiVersion: pkg.crossplane.io/v1
+    kind: Function
+    package: xpkg.crossplane.io/crossplane-contrib/function-go-templating
+    version: ">=v0.1.0"
+`
+
+	xrd = `
+apiVersion: apiextensions.crossplane.io/v2
+kind: CompositeResourceDefinition
+metadata:
+  name: xcools.example.org
+spec:
+  group: example.org
+  names:
+    kind: XCool
+    plural: xcools
+  versions:
+  - name: v1alpha1
+  - name: v1beta1
+`
+
+	composition = `
+apiVersion: apiextensions.crossplane.io/v1
+kind: Composition
+metadata:
+  name: cool-composition
+spec:
+  compositeTypeRef:
+    apiVersion: example.org/v1beta1
+    kind: XCool
+  mode: Pipeline
+  pipeline:
+  - step: patch
+    functionRef:
+      name: crossplane-contrib-function-patch-and-transform
+    credentials:
+    - name: creds
+      source: Secret
+      secretRef:
+        namespace: crossplane-system
+        name: cool-secret
+  - step: template
+    functionRef:
+      name: crossplane-contrib-function-go-templating
+    requirements:
+      requiredResources:
+      - requirementName: config
+        apiVersion: v1
+        kind: ConfigMap
+        name: cool-config
+`
+
+	badComposition = `
+apiVersion: apiextensions.crossplane.io/v1
+kind: Composition
+metadata:
+  name: bad-composition
+spec:
+  compositeTypeRef:
+    apiVersion: example.org/v1
+    kind: XCool
+  mode: Pipeline
+  pipeline:
+  - step: patch
+    functionRef:
+      name: function-auto-ready
+    credentials:
+    - name: creds
+      source: Secret
+      secretRef:
+        namespace: crossplane-system
+        name: missing-secret
+  - step: patch
+    functionRef:
+      name: crossplane-contrib-function-go-templating
+    requirements:
+      requiredResources:
+      - requirementName: config
+        apiVersion: v1
+        kind: ConfigMap
+  - step: patch
+    functionRef:
+      name: crossplane-contrib-function-go-templating
+`
+
+	secret = `
+apiVersion: v1
+kind: Secret
+metadata:
+  namespace: crossplane-system
+  name: cool-secret
+`
+)
+
+func TestDefaultRules(t *testing.T) {
+	type want struct {
+		issues []Issue
+		err    error
+	}
+
+	cases := map[string]struct {
+		reason string
+		p      func(t *testing.T) *Package
+		want   want
+	}{
+		"NoIssues": {
+			reason: "A well formed package should have no issues.",
+			p: func(t *testing.T) *Package {
+				t.Helper()
+				return &Package{
+					Objects: []load.Manifest{
+						manifest(t, "crossplane.yaml", 1, meta),
+						manifest(t, "xrd.yaml", 1, xrd),
+						manifest(t, "composition.yaml", 1, composition),
+					},
+					Examples: []load.Manifest{manifest(t, "examples/secret.yaml", 1, secret)},
+				}
+			},
+			want: want{issues: []Issue{}},
+		},
+		"Issues": {
+			reason: "Each built-in rule should flag issues in a badly formed Composition.",
+			p: func(t *testing.T) *Package {
+				t.Helper()
+				return &Package{
+					Objects: []load.Manifest{
+						manifest(t, "crossplane.yaml", 1, meta),
+						manifest(t, "xrd.yaml", 1, xrd),
+						manifest(t, "composition.yaml", 3, badComposition),
+					},
+				}
+			},
+			want: want{issues: []Issue{
+				{
+					Rule:       RuleFunctionDependency,
+					Severity:   SeverityError,
+					Message:    `pipeline step "patch" uses Function "function-auto-ready", which isn't in the package's dependsOn`,
+					APIVersion: "apiextensions.crossplane.io/v1",
+					Kind:       "Composition",
+					Name:       "bad-composition",
+					File:       "composition.yaml",
+					Line:       3,
+				},
+				{
+					Rule:       RuleFunctionCredentials,
+					Severity:   SeverityWarning,
+					Message:    `pipeline step "patch" credentials "creds" use Secret crossplane-system/missing-secret, which has no example`,
+					APIVersion: "apiextensions.crossplane.io/v1",
+					Kind:       "Composition",
+					Name:       "bad-composition",
+					File:       "composition.yaml",
+					Line:       3,
+				},
+				{
+					Rule:       RuleCompositeTypeRef,
+					Severity:   SeverityError,
+					Message:    `compositeTypeRef XCool.example.org has version "v1", but its XRD only defines versions [v1alpha1 v1beta1]`,
+					APIVersion: "apiextensions.crossplane.io/v1",
+					Kind:       "Composition",
+					Name:       "bad-composition",
+					File:       "composition.yaml",
+					Line:       3,
+				},
+				{
+					Rule:       RuleRequiredResourceSelector,
+					Severity:   SeverityError,
+					Message:    `pipeline step "patch" required resource "config" specifies neither a name nor matchLabels`,
+					APIVersion: "apiextensions.crossplane.io/v1",
+					Kind:       "Composition",
+					Name:       "bad-composition",
+					File:       "composition.yaml",
+					Line:       3,
+				},
+				{
+					Rule:       RuleDuplicateStep,
+					Severity:   SeverityError,
+					Message:    `pipeline step name "patch" is used more than once`,
+					APIVersion: "apiextensions.crossplane.io/v1",
+					Kind:       "Composition",
+					Name:       "bad-composition",
+					File:       "composition.yaml",
+					Line:       3,
+				},
+			}},
+		},
+		"NoXRDOrMeta": {
+			reason: "A Composition of an XR the package doesn't define should only be a warning, and packages without metadata shouldn't be checked for dependencies.",
+			p: func(t *testing.T) *Package {
+				t.Helper()
+				return &Package{
+					Objects: []load.Manifest{manifest(t, "composition.yaml", 1, composition)},
+				}
+			},
+			want: want{issues: []Issue{
+				{
+					Rule:       RuleFunctionCredentials,
+					Severity:   SeverityWarning,
+					Message:    `pipeline step "patch" credentials "creds" use Secret crossplane-system/cool-secret, which has no example`,
+					APIVersion: "apiextensions.crossplane.io/v1",
+					Kind:       "Composition",
+					Name:       "cool-composition",
+					File:       "composition.yaml",
+					Line:       1,
+				},
+				{
+					Rule:       RuleCompositeTypeRef,
+					Severity:   SeverityWarning,
+					Message:    "compositeTypeRef XCool.example.org isn't defined by any XRD in the package",
+					APIVersion: "apiextensions.crossplane.io/v1",
+					Kind:       "Composition",
+					Name:       "cool-composition",
+					File:       "composition.yaml",
+					Line:       1,
+				},
+			}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := NewLinter(DefaultRules()...).Lint(tc.p(t))
+			if diff := cmp.Diff(tc.want.issues, got); diff != "" {
+				t.Errorf("\n%s\nLint(...): -want, +got:\n%s", tc.reason, diff)
+			}
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nLint(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
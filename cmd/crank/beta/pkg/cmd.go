@@ -0,0 +1,84 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package pkg contains commands that explain and simulate how Crossplane
+// resolves package dependencies.
+package pkg
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+
+	"github.com/crossplane/crossplane/v2/apis/pkg/v1beta1"
+)
+
+// The name of the Lock Crossplane uses to track installed packages.
+const lockName = "lock"
+
+const (
+	errKubeConfig     = "failed to get kubeconfig"
+	errInitKubeClient = "cannot init kubeclient"
+	errGetLock        = "cannot get package lock"
+	errBuildDAG       = "cannot build DAG"
+	errSortDAG        = "cannot sort DAG"
+	errCliOutput      = "cannot print output"
+)
+
+// Cmd contains commands that explain package dependency resolution.
+type Cmd struct {
+	// Subcommands and flags will appear in the CLI help output in the same
+	// order they're specified here. Keep them in alphabetical order.
+	WhatIf whatIfCmd `cmd:"" help:"Simulate installing a package and show which packages would change." name:"what-if"`
+	Why    whyCmd    `cmd:"" help:"Explain why a package is installed at its current version."`
+}
+
+// Help output for crossplane beta pkg.
+func (c *Cmd) Help() string {
+	return `
+These commands help troubleshoot how Crossplane resolves package dependencies.
+They read the package Lock, which tracks every installed package and its
+dependencies, from the current Kubernetes context.
+`
+}
+
+// getLock returns the package Lock from the supplied Kubernetes context.
+func getLock(ctx context.Context, kubeContext string) (*v1beta1.Lock, error) {
+	kubeconfig, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+		clientcmd.NewDefaultClientConfigLoadingRules(),
+		&clientcmd.ConfigOverrides{CurrentContext: kubeContext},
+	).ClientConfig()
+	if err != nil {
+		return nil, errors.Wrap(err, errKubeConfig)
+	}
+
+	s := runtime.NewScheme()
+	_ = v1beta1.AddToScheme(s)
+
+	kube, err := client.New(kubeconfig, client.Options{Scheme: s})
+	if err != nil {
+		return nil, errors.Wrap(err, errInitKubeClient)
+	}
+
+	lock := &v1beta1.Lock{}
+
+	return lock, errors.Wrap(kube.Get(ctx, types.NamespacedName{Name: lockName}, lock), errGetLock)
+}
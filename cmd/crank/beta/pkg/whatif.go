@@ -0,0 +1,584 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pkg
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/semver"
+	"github.com/alecthomas/kong"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	conregv1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/cli-runtime/pkg/printers"
+	"k8s.io/utils/ptr"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/logging"
+
+	pkgmetav1 "github.com/crossplane/crossplane/v2/apis/pkg/meta/v1"
+	pkgv1 "github.com/crossplane/crossplane/v2/apis/pkg/v1"
+	"github.com/crossplane/crossplane/v2/apis/pkg/v1beta1"
+	"github.com/crossplane/crossplane/v2/internal/controller/pkg/resolver"
+	"github.com/crossplane/crossplane/v2/internal/dag"
+	"github.com/crossplane/crossplane/v2/internal/xpkg"
+)
+
+// Crossplane resolves one missing or invalid dependency at a time. A
+// simulation that takes more steps than this probably never converges.
+const maxSimulationSteps = 100
+
+const (
+	errFmtParsePackage      = "cannot parse %q: packages must be in the form <package>@<version>"
+	errFmtFetchPackage      = "cannot fetch package %s"
+	errFmtListTags          = "cannot list tags of package %s"
+	errFmtInvalidConstraint = "invalid version constraint on dependency %s"
+	errFmtNoValidVersion    = "no version of dependency %s satisfies constraint %q"
+	errFmtNotDependency     = "missing package %s is not a dependency"
+	errFmtNoConvergence     = "dependencies are not resolved after %d steps"
+	errFmtUnknownMeta       = "package %s is not a Configuration, Provider, or Function"
+	errFmtFindUpdate        = "cannot find a version of %s to update to"
+)
+
+// whatIfCmd simulates installing packages.
+type whatIfCmd struct {
+	// Flags. Keep sorted alphabetically.
+	Context                           string        `default:""                                                                                          help:"Kubernetes context."                                    name:"context"              predictor:"context" short:"c"`
+	EnableDependencyVersionDowngrades bool          `help:"Simulate Crossplane with dependency version upgrades and downgrades enabled."`
+	EnableDependencyVersionUpgrades   bool          `help:"Simulate Crossplane with dependency version upgrades enabled."`
+	InsecureSkipTLSVerify             bool          `help:"[INSECURE] Skip verifying TLS certificates."`
+	Install                           []string      `help:"A package to install, in the form <package>@<version>. Repeat to install several packages." placeholder:"PACKAGE@VERSION"                              required:""`
+	Timeout                           time.Duration `default:"5m"                                                                                        help:"How long to wait for the simulation to complete."`
+}
+
+// Help returns help message for the what-if command.
+func (c *whatIfCmd) Help() string {
+	return `
+This command simulates installing or updating packages, and shows which
+packages would change as a result. It starts from the package Lock of the
+current Kubernetes context and resolves dependencies the same way Crossplane
+does, fetching package metadata and tags from their registries. Nothing is
+changed in the cluster.
+
+Crossplane only changes the version of an installed dependency when dependency
+version upgrades are enabled. Use the --enable-dependency-version-upgrades and
+--enable-dependency-version-downgrades flags to simulate a Crossplane with these
+alpha features enabled. Dependencies that would be left at a version that
+doesn't satisfy the constraints of a package that depends on them are shown as
+invalid.
+
+ImageConfigs aren't considered, so packages are always fetched from their
+original registries.
+
+Examples:
+  # Show what would change if configuration-a v1.2.0 was installed.
+  crossplane beta pkg what-if --install xpkg.crossplane.io/acme/configuration-a@v1.2.0
+
+  # Simulate the same install with dependency version upgrades enabled.
+  crossplane beta pkg what-if --enable-dependency-version-upgrades \
+    --install xpkg.crossplane.io/acme/configuration-a@v1.2.0
+`
+}
+
+// Run runs the what-if command.
+func (c *whatIfCmd) Run(k *kong.Context, logger logging.Logger) error {
+	ctx, cancel := context.WithTimeout(context.Background(), c.Timeout)
+	defer cancel()
+
+	pkgs := make([]Package, len(c.Install))
+	for i, s := range c.Install {
+		p, err := ParsePackage(s)
+		if err != nil {
+			return err
+		}
+
+		pkgs[i] = p
+	}
+
+	lock, err := getLock(ctx, c.Context)
+	if err != nil {
+		return err
+	}
+
+	var opts []SimulatorOption
+	if c.EnableDependencyVersionUpgrades || c.EnableDependencyVersionDowngrades {
+		opts = append(opts, WithUpgrades())
+	}
+
+	if c.EnableDependencyVersionDowngrades {
+		opts = append(opts, WithDowngrades())
+	}
+
+	t := &http.Transport{
+		TLSClientConfig: &tls.Config{
+			InsecureSkipVerify: c.InsecureSkipTLSVerify, //nolint:gosec // we need to support insecure connections if requested
+		},
+	}
+
+	s := NewSimulator(NewRemoteRegistry(remote.WithAuthFromKeychain(authn.DefaultKeychain), remote.WithTransport(t)), opts...)
+
+	sim, err := s.Install(ctx, lock, pkgs...)
+	if err != nil {
+		return err
+	}
+
+	logger.Debug("Simulated install", "changes", len(sim.Changes), "invalid", len(sim.Invalid))
+
+	return errors.Wrap(sim.Print(k.Stdout), errCliOutput)
+}
+
+// A Package is a version of a package.
+type Package struct {
+	// Source is the OCI image name without a tag or digest.
+	Source string
+
+	// Version is the tag or digest of the OCI image.
+	Version string
+}
+
+// ParsePackage parses a package in the form <package>@<version>. The version
+// may be a tag or a digest.
+func ParsePackage(s string) (Package, error) {
+	i := strings.LastIndex(s, "@")
+	if i < 1 || i == len(s)-1 {
+		return Package{}, errors.Errorf(errFmtParsePackage, s)
+	}
+
+	p := Package{Source: s[:i], Version: s[i+1:]}
+
+	// Digests take the form sha256:..., which isn't a valid tag.
+	if _, err := conregv1.NewHash(p.Version); err == nil {
+		return p, nil
+	}
+
+	if _, err := name.NewTag(p.Source + ":" + p.Version); err != nil {
+		return Package{}, errors.Wrapf(err, errFmtParsePackage, s)
+	}
+
+	return p, nil
+}
+
+// A Registry fetches package metadata and tags.
+type Registry interface {
+	// Tags returns the tags of the supplied package source.
+	Tags(ctx context.Context, source string) ([]string, error)
+
+	// Meta returns the metadata of the supplied version of the supplied
+	// package source.
+	Meta(ctx context.Context, source, version string) (pkgmetav1.Pkg, error)
+}
+
+// A RemoteRegistry fetches package metadata and tags from OCI registries.
+type RemoteRegistry struct {
+	opts []remote.Option
+}
+
+// NewRemoteRegistry returns a registry that fetches package metadata and tags
+// from OCI registries.
+func NewRemoteRegistry(opts ...remote.Option) *RemoteRegistry {
+	return &RemoteRegistry{opts: opts}
+}
+
+// Tags returns the tags of the supplied package source.
+func (r *RemoteRegistry) Tags(ctx context.Context, source string) ([]string, error) {
+	// Dependency identifiers may omit the registry, so we can't enforce
+	// strict validation.
+	repo, err := name.NewRepository(source)
+	if err != nil {
+		return nil, errors.Wrapf(err, errFmtListTags, source)
+	}
+
+	tags, err := remote.List(repo, append(r.opts, remote.WithContext(ctx))...)
+
+	return tags, errors.Wrapf(err, errFmtListTags, source)
+}
+
+// Meta returns the metadata of the supplied version of the supplied package
+// source.
+func (r *RemoteRegistry) Meta(ctx context.Context, source, version string) (pkgmetav1.Pkg, error) {
+	repo, err := name.NewRepository(source)
+	if err != nil {
+		return nil, errors.Wrapf(err, errFmtFetchPackage, source)
+	}
+
+	var ref name.Reference = repo.Tag(version)
+	if _, err := conregv1.NewHash(version); err == nil {
+		ref = repo.Digest(version)
+	}
+
+	img, err := remote.Image(ref, append(r.opts, remote.WithContext(ctx))...)
+	if err != nil {
+		return nil, errors.Wrapf(err, errFmtFetchPackage, ref)
+	}
+
+	meta, err := xpkg.PackageMeta(ctx, img)
+
+	return meta, errors.Wrapf(err, errFmtFetchPackage, ref)
+}
+
+// A Simulator simulates how Crossplane resolves package dependencies.
+type Simulator struct {
+	registry   Registry
+	upgrades   bool
+	downgrades bool
+}
+
+// A SimulatorOption configures a Simulator.
+type SimulatorOption func(s *Simulator)
+
+// WithUpgrades simulates Crossplane with dependency version upgrades enabled.
+func WithUpgrades() SimulatorOption {
+	return func(s *Simulator) {
+		s.upgrades = true
+	}
+}
+
+// WithDowngrades simulates Crossplane with dependency version downgrades
+// enabled. Downgrades only take effect when upgrades are enabled too.
+func WithDowngrades() SimulatorOption {
+	return func(s *Simulator) {
+		s.downgrades = true
+	}
+}
+
+// NewSimulator returns a Simulator that fetches packages from the supplied
+// registry.
+func NewSimulator(r Registry, opts ...SimulatorOption) *Simulator {
+	s := &Simulator{registry: r}
+	for _, fn := range opts {
+		fn(s)
+	}
+
+	return s
+}
+
+// A Change is a package whose version would change.
+type Change struct {
+	// Package is the package that would change.
+	Package string
+
+	// From is the installed version of the package. It's empty if the package
+	// isn't installed.
+	From string
+
+	// To is the version the package would change to.
+	To string
+}
+
+// Action describes the change.
+func (c Change) Action() string {
+	if c.From == "" {
+		return "Install"
+	}
+
+	from, ferr := semver.NewVersion(c.From)
+	to, terr := semver.NewVersion(c.To)
+
+	switch {
+	case ferr != nil || terr != nil:
+		return "Update"
+	case to.GreaterThan(from):
+		return "Upgrade"
+	case to.LessThan(from):
+		return "Downgrade"
+	default:
+		return "Update"
+	}
+}
+
+// An InvalidDependency is a dependency whose version wouldn't satisfy the
+// constraint a package that depends on it places on it.
+type InvalidDependency struct {
+	// Package is the dependency.
+	Package string
+
+	// Version is the version the dependency would be installed at.
+	Version string
+
+	// RequiredBy is the package that depends on the dependency.
+	RequiredBy string
+
+	// Constraint is the constraint the dependency doesn't satisfy.
+	Constraint string
+}
+
+// A Simulation is the result of simulating a package install.
+type Simulation struct {
+	// Changes are the packages that would change.
+	Changes []Change
+
+	// Invalid are the dependencies that would be invalid.
+	Invalid []InvalidDependency
+}
+
+// Install simulates installing the supplied packages alongside the supplied
+// lock. Dependencies are resolved one at a time, the same way Crossplane
+// resolves them, until none are missing. If upgrades are enabled installed
+// dependencies that don't satisfy their constraints are updated too.
+func (s *Simulator) Install(ctx context.Context, lock *v1beta1.Lock, pkgs ...Package) (*Simulation, error) {
+	installed := make(map[string]string, len(lock.Packages))
+	for _, lp := range lock.Packages {
+		installed[lp.Source] = lp.Version
+	}
+
+	sim := slices.Clone(lock.Packages)
+
+	for _, p := range pkgs {
+		lp, err := s.load(ctx, p.Source, p.Version)
+		if err != nil {
+			return nil, err
+		}
+
+		sim = upsert(sim, lp)
+	}
+
+	for range maxSimulationSteps {
+		d := dag.NewMapDag()
+		if s.upgrades {
+			d = dag.NewUpgradingMapDag()
+		}
+
+		implied, err := d.Init(v1beta1.ToNodes(sim...))
+		if err != nil {
+			return nil, errors.Wrap(err, errBuildDAG)
+		}
+
+		if _, err := d.Sort(); err != nil {
+			return nil, errors.Wrap(err, errSortDAG)
+		}
+
+		if len(implied) == 0 {
+			return result(installed, sim), nil
+		}
+
+		// Crossplane only resolves the first missing dependency. It'll
+		// resolve the next one once the first is added to the lock.
+		dep, ok := implied[0].(*v1beta1.Dependency)
+		if !ok {
+			return nil, errors.Errorf(errFmtNotDependency, implied[0].Identifier())
+		}
+
+		version, err := s.resolve(ctx, d, dep, sim)
+		if err != nil {
+			return nil, err
+		}
+
+		lp, err := s.load(ctx, dep.Identifier(), version)
+		if err != nil {
+			return nil, err
+		}
+
+		sim = upsert(sim, lp)
+	}
+
+	return nil, errors.Errorf(errFmtNoConvergence, maxSimulationSteps)
+}
+
+// resolve returns the version of the supplied dependency Crossplane would
+// install.
+func (s *Simulator) resolve(ctx context.Context, d dag.DAG, dep *v1beta1.Dependency, sim []v1beta1.LockPackage) (string, error) {
+	i := slices.IndexFunc(sim, func(lp v1beta1.LockPackage) bool { return lp.Source == dep.Identifier() })
+
+	// The dependency isn't installed.
+	if i < 0 {
+		if digest, err := conregv1.NewHash(dep.Constraints); err == nil {
+			return digest.String(), nil
+		}
+
+		c, err := semver.NewConstraint(dep.Constraints)
+		if err != nil {
+			return "", errors.Wrapf(err, errFmtInvalidConstraint, dep.Identifier())
+		}
+
+		tags, err := s.registry.Tags(ctx, dep.Identifier())
+		if err != nil {
+			return "", err
+		}
+
+		v := resolver.FindVersionToInstall(c, tags)
+		if v == "" {
+			return "", errors.Errorf(errFmtNoValidVersion, dep.Identifier(), dep.Constraints)
+		}
+
+		return v, nil
+	}
+
+	// The dependency is installed, but doesn't satisfy all of the constraints
+	// of the packages that depend on it. We only get here when upgrades are
+	// enabled.
+	n, err := d.GetNode(dep.Identifier())
+	if err != nil {
+		return "", errors.Wrapf(err, errFmtFindUpdate, dep.Identifier())
+	}
+
+	digest, err := resolver.FindDigestToUpdate(n)
+	if err != nil {
+		return "", errors.Wrapf(err, errFmtFindUpdate, dep.Identifier())
+	}
+
+	if digest != "" {
+		return digest, nil
+	}
+
+	tags, err := s.registry.Tags(ctx, dep.Identifier())
+	if err != nil {
+		return "", err
+	}
+
+	v, err := resolver.FindVersionToUpdate(n, sim[i].Version, tags, s.downgrades)
+
+	return v, errors.Wrapf(err, errFmtFindUpdate, dep.Identifier())
+}
+
+// load returns the supplied version of the supplied package as a lock package.
+func (s *Simulator) load(ctx context.Context, source, version string) (v1beta1.LockPackage, error) {
+	lp := v1beta1.LockPackage{Source: source, Version: version}
+
+	meta, err := s.registry.Meta(ctx, source, version)
+	if err != nil {
+		return lp, err
+	}
+
+	var gvk schema.GroupVersionKind
+
+	switch meta.(type) {
+	case *pkgmetav1.Configuration:
+		gvk = pkgv1.ConfigurationGroupVersionKind
+	case *pkgmetav1.Provider:
+		gvk = pkgv1.ProviderGroupVersionKind
+	case *pkgmetav1.Function:
+		gvk = pkgv1.FunctionGroupVersionKind
+	default:
+		return lp, errors.Errorf(errFmtUnknownMeta, source)
+	}
+
+	deps, err := xpkg.ToDependencies(meta.GetDependencies())
+	if err != nil {
+		return lp, errors.Wrapf(err, errFmtFetchPackage, source)
+	}
+
+	lp.APIVersion = ptr.To(gvk.GroupVersion().String())
+	lp.Kind = ptr.To(gvk.Kind)
+	lp.Dependencies = xpkg.PinDependencies(deps, meta.GetLock())
+
+	return lp, nil
+}
+
+// upsert adds the supplied package to the supplied packages, replacing any
+// package with the same source.
+func upsert(pkgs []v1beta1.LockPackage, lp v1beta1.LockPackage) []v1beta1.LockPackage {
+	for i := range pkgs {
+		if pkgs[i].Source == lp.Source {
+			lp.Name = pkgs[i].Name
+			pkgs[i] = lp
+
+			return pkgs
+		}
+	}
+
+	return append(pkgs, lp)
+}
+
+// result compares the simulated packages to the installed ones.
+func result(installed map[string]string, sim []v1beta1.LockPackage) *Simulation {
+	r := &Simulation{}
+
+	versions := make(map[string]string, len(sim))
+	for _, lp := range sim {
+		versions[lp.Source] = lp.Version
+
+		if installed[lp.Source] != lp.Version {
+			r.Changes = append(r.Changes, Change{Package: lp.Source, From: installed[lp.Source], To: lp.Version})
+		}
+	}
+
+	for _, lp := range sim {
+		for _, dep := range lp.Dependencies {
+			if v := versions[dep.Package]; !satisfies(v, dep.Constraints) {
+				r.Invalid = append(r.Invalid, InvalidDependency{Package: dep.Package, Version: v, RequiredBy: lp.Source, Constraint: dep.Constraints})
+			}
+		}
+	}
+
+	sort.Slice(r.Changes, func(i, j int) bool { return r.Changes[i].Package < r.Changes[j].Package })
+	sort.Slice(r.Invalid, func(i, j int) bool {
+		if r.Invalid[i].Package != r.Invalid[j].Package {
+			return r.Invalid[i].Package < r.Invalid[j].Package
+		}
+
+		return r.Invalid[i].RequiredBy < r.Invalid[j].RequiredBy
+	})
+
+	return r
+}
+
+// Print prints the simulation.
+func (s *Simulation) Print(w io.Writer) error {
+	if len(s.Changes) == 0 {
+		if _, err := fmt.Fprintln(w, "No packages would change."); err != nil {
+			return err
+		}
+	}
+
+	tw := printers.GetNewTabWriter(w)
+
+	if len(s.Changes) > 0 {
+		if _, err := fmt.Fprintln(tw, "PACKAGE\tCURRENT\tPROPOSED\tCHANGE"); err != nil {
+			return err
+		}
+
+		for _, c := range s.Changes {
+			if _, err := fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", c.Package, c.From, c.To, c.Action()); err != nil {
+				return err
+			}
+		}
+
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+	}
+
+	if len(s.Invalid) == 0 {
+		return nil
+	}
+
+	if _, err := fmt.Fprintln(w, "\nThese dependencies would be invalid:"); err != nil {
+		return err
+	}
+
+	if _, err := fmt.Fprintln(tw, "PACKAGE\tVERSION\tREQUIRED BY\tCONSTRAINT"); err != nil {
+		return err
+	}
+
+	for _, i := range s.Invalid {
+		if _, err := fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", i.Package, i.Version, i.RequiredBy, i.Constraint); err != nil {
+			return err
+		}
+	}
+
+	return tw.Flush()
+}
@@ -0,0 +1,273 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pkg
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"k8s.io/utils/ptr"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+
+	pkgmetav1 "github.com/crossplane/crossplane/v2/apis/pkg/meta/v1"
+	"github.com/crossplane/crossplane/v2/apis/pkg/v1beta1"
+)
+
+const (
+	configuration = "xpkg.crossplane.io/acme/configuration-a"
+	provider      = "xpkg.crossplane.io/acme/provider-a"
+)
+
+type fakeRegistry struct {
+	tags map[string][]string
+	meta map[string]pkgmetav1.Pkg
+}
+
+func (r *fakeRegistry) Tags(_ context.Context, source string) ([]string, error) {
+	return r.tags[source], nil
+}
+
+func (r *fakeRegistry) Meta(_ context.Context, source, version string) (pkgmetav1.Pkg, error) {
+	m, ok := r.meta[source+"@"+version]
+	if !ok {
+		return nil, errors.Errorf("%s@%s not found", source, version)
+	}
+
+	return m, nil
+}
+
+func configurationMeta(deps ...pkgmetav1.Dependency) *pkgmetav1.Configuration {
+	return &pkgmetav1.Configuration{Spec: pkgmetav1.ConfigurationSpec{MetaSpec: pkgmetav1.MetaSpec{DependsOn: deps}}}
+}
+
+func installedConfiguration(version, constraints string) v1beta1.LockPackage {
+	return v1beta1.LockPackage{
+		Source:       configuration,
+		Version:      version,
+		Dependencies: []v1beta1.Dependency{{Package: provider, Type: ptr.To(v1beta1.ProviderPackageType), Constraints: constraints}},
+	}
+}
+
+func TestSimulatorInstall(t *testing.T) {
+	reg := &fakeRegistry{
+		tags: map[string][]string{
+			// The incomplete v1 tag should never be installed.
+			provider: {"v1", "v1.0.0", "v1.1.0", "v1.2.0"},
+		},
+		meta: map[string]pkgmetav1.Pkg{
+			configuration + "@v1.0.0": configurationMeta(pkgmetav1.Dependency{Provider: ptr.To(provider), Version: ">=v1.0.0"}),
+			configuration + "@v2.0.0": configurationMeta(pkgmetav1.Dependency{Provider: ptr.To(provider), Version: ">=v1.1.0"}),
+			configuration + "@v3.0.0": configurationMeta(pkgmetav1.Dependency{Provider: ptr.To(provider), Version: "<v1.2.0"}),
+			configuration + "@v4.0.0": configurationMeta(pkgmetav1.Dependency{Provider: ptr.To(provider), Version: ">=v2.0.0"}),
+			configuration + "@v5.0.0": &pkgmetav1.Configuration{Spec: pkgmetav1.ConfigurationSpec{MetaSpec: pkgmetav1.MetaSpec{
+				DependsOn: []pkgmetav1.Dependency{{Provider: ptr.To(provider), Version: ">=v1.0.0"}},
+				Lock: []pkgmetav1.LockedDependency{{
+					APIVersion: "pkg.crossplane.io/v1",
+					Kind:       "Provider",
+					Package:    provider,
+					Version:    "v1.0.0",
+					Digest:     digest,
+				}},
+			}}},
+			provider + "@v1.0.0":    &pkgmetav1.Provider{},
+			provider + "@v1.1.0":    &pkgmetav1.Provider{},
+			provider + "@v1.2.0":    &pkgmetav1.Provider{},
+			provider + "@" + digest: &pkgmetav1.Provider{},
+		},
+	}
+
+	type args struct {
+		opts []SimulatorOption
+		lock *v1beta1.Lock
+		pkgs []Package
+	}
+
+	type want struct {
+		sim *Simulation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"Install": {
+			reason: "Installing a package should install the highest version of each missing dependency that satisfies its constraints.",
+			args: args{
+				lock: &v1beta1.Lock{},
+				pkgs: []Package{{Source: configuration, Version: "v1.0.0"}},
+			},
+			want: want{
+				sim: &Simulation{Changes: []Change{
+					{Package: configuration, To: "v1.0.0"},
+					{Package: provider, To: "v1.2.0"},
+				}},
+			},
+		},
+		"InstallLocked": {
+			reason: "Dependencies locked by a package should be installed at their pinned digests.",
+			args: args{
+				lock: &v1beta1.Lock{},
+				pkgs: []Package{{Source: configuration, Version: "v5.0.0"}},
+			},
+			want: want{
+				sim: &Simulation{Changes: []Change{
+					{Package: configuration, To: "v5.0.0"},
+					{Package: provider, To: digest},
+				}},
+			},
+		},
+		"UpgradesDisabled": {
+			reason: "Without upgrades, installed dependencies that don't satisfy their new constraints should be invalid.",
+			args: args{
+				lock: &v1beta1.Lock{Packages: []v1beta1.LockPackage{
+					installedConfiguration("v1.0.0", ">=v1.0.0"),
+					{Source: provider, Version: "v1.0.0"},
+				}},
+				pkgs: []Package{{Source: configuration, Version: "v2.0.0"}},
+			},
+			want: want{
+				sim: &Simulation{
+					Changes: []Change{{Package: configuration, From: "v1.0.0", To: "v2.0.0"}},
+					Invalid: []InvalidDependency{{Package: provider, Version: "v1.0.0", RequiredBy: configuration, Constraint: ">=v1.1.0"}},
+				},
+			},
+		},
+		"UpgradesEnabled": {
+			reason: "With upgrades, installed dependencies should be upgraded to the lowest version that satisfies their constraints.",
+			args: args{
+				opts: []SimulatorOption{WithUpgrades()},
+				lock: &v1beta1.Lock{Packages: []v1beta1.LockPackage{
+					installedConfiguration("v1.0.0", ">=v1.0.0"),
+					{Source: provider, Version: "v1.0.0"},
+				}},
+				pkgs: []Package{{Source: configuration, Version: "v2.0.0"}},
+			},
+			want: want{
+				sim: &Simulation{Changes: []Change{
+					{Package: configuration, From: "v1.0.0", To: "v2.0.0"},
+					{Package: provider, From: "v1.0.0", To: "v1.1.0"},
+				}},
+			},
+		},
+		"DowngradesDisabled": {
+			reason: "Without downgrades, resolution should fail if only a lower version satisfies the constraints.",
+			args: args{
+				opts: []SimulatorOption{WithUpgrades()},
+				lock: &v1beta1.Lock{Packages: []v1beta1.LockPackage{
+					installedConfiguration("v1.0.0", ">=v1.0.0"),
+					{Source: provider, Version: "v1.2.0"},
+				}},
+				pkgs: []Package{{Source: configuration, Version: "v3.0.0"}},
+			},
+			want: want{
+				err: cmpopts.AnyError,
+			},
+		},
+		"DowngradesEnabled": {
+			reason: "With downgrades, installed dependencies should be downgraded to the highest lower version that satisfies their constraints.",
+			args: args{
+				opts: []SimulatorOption{WithUpgrades(), WithDowngrades()},
+				lock: &v1beta1.Lock{Packages: []v1beta1.LockPackage{
+					installedConfiguration("v1.0.0", ">=v1.0.0"),
+					{Source: provider, Version: "v1.2.0"},
+				}},
+				pkgs: []Package{{Source: configuration, Version: "v3.0.0"}},
+			},
+			want: want{
+				sim: &Simulation{Changes: []Change{
+					{Package: configuration, From: "v1.0.0", To: "v3.0.0"},
+					{Package: provider, From: "v1.2.0", To: "v1.1.0"},
+				}},
+			},
+		},
+		"NoValidVersion": {
+			reason: "We should return an error if no version of a missing dependency satisfies its constraints.",
+			args: args{
+				lock: &v1beta1.Lock{},
+				pkgs: []Package{{Source: configuration, Version: "v4.0.0"}},
+			},
+			want: want{
+				err: cmpopts.AnyError,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			sim, err := NewSimulator(reg, tc.args.opts...).Install(context.Background(), tc.args.lock, tc.args.pkgs...)
+			if diff := cmp.Diff(tc.want.err, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nInstall(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+
+			if diff := cmp.Diff(tc.want.sim, sim); diff != "" {
+				t.Errorf("\n%s\nInstall(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestParsePackage(t *testing.T) {
+	type want struct {
+		p   Package
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		s      string
+		want   want
+	}{
+		"Tag": {
+			reason: "We should parse a package with a tag.",
+			s:      provider + "@v1.0.0",
+			want:   want{p: Package{Source: provider, Version: "v1.0.0"}},
+		},
+		"Digest": {
+			reason: "We should parse a package with a digest.",
+			s:      provider + "@" + digest,
+			want:   want{p: Package{Source: provider, Version: digest}},
+		},
+		"NoVersion": {
+			reason: "We should return an error if the package has no version.",
+			s:      provider,
+			want:   want{err: cmpopts.AnyError},
+		},
+		"InvalidTag": {
+			reason: "We should return an error if the version isn't a valid tag.",
+			s:      provider + "@v1.0.0+build",
+			want:   want{err: cmpopts.AnyError},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			p, err := ParsePackage(tc.s)
+			if diff := cmp.Diff(tc.want.err, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nParsePackage(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+
+			if diff := cmp.Diff(tc.want.p, p); diff != "" {
+				t.Errorf("\n%s\nParsePackage(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
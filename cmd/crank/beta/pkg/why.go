@@ -0,0 +1,219 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pkg
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/Masterminds/semver"
+	"github.com/alecthomas/kong"
+	conregv1 "github.com/google/go-containerregistry/pkg/v1"
+	"k8s.io/cli-runtime/pkg/printers"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+
+	"github.com/crossplane/crossplane/v2/apis/pkg/v1beta1"
+	"github.com/crossplane/crossplane/v2/internal/dag"
+)
+
+const errFmtNotInLock = "package %s is not in the lock"
+
+// whyCmd explains why a package is installed at its current version.
+type whyCmd struct {
+	Package string `arg:"" help:"The package to explain, without a tag or digest. For example xpkg.crossplane.io/crossplane-contrib/provider-nop."`
+
+	Context string `default:"" help:"Kubernetes context." name:"context" predictor:"context" short:"c"`
+}
+
+// Help returns help message for the why command.
+func (c *whyCmd) Help() string {
+	return `
+This command explains why a package is installed at its current version. It
+rebuilds the dependency graph Crossplane uses to resolve dependencies from the
+package Lock, and shows the version constraint each package that depends on the
+package places on it.
+
+A constraint that isn't satisfied is why a package reports invalid
+dependencies. Unless dependency version upgrades are enabled Crossplane won't
+change the version of an installed package to satisfy it.
+
+Examples:
+  # Explain why provider-nop is installed at its current version.
+  crossplane beta pkg why xpkg.crossplane.io/crossplane-contrib/provider-nop
+`
+}
+
+// Run runs the why command.
+func (c *whyCmd) Run(k *kong.Context) error {
+	lock, err := getLock(context.Background(), c.Context)
+	if err != nil {
+		return err
+	}
+
+	e, err := Explain(lock, strings.TrimSpace(c.Package))
+	if err != nil {
+		return err
+	}
+
+	return errors.Wrap(e.Print(k.Stdout), errCliOutput)
+}
+
+// A Constraint is a version constraint one package places on another.
+type Constraint struct {
+	// Package is the package that places the constraint.
+	Package string
+
+	// Version is the version of the package that places the constraint.
+	Version string
+
+	// Constraint is a semantic version constraint or a digest.
+	Constraint string
+
+	// Satisfied is true if the constrained package's version satisfies the
+	// constraint.
+	Satisfied bool
+}
+
+// An Explanation explains why a package is installed at its version.
+type Explanation struct {
+	// Package is the explained package.
+	Package string
+
+	// Version is the installed version of the package. It's empty if the
+	// package isn't installed yet.
+	Version string
+
+	// Constraints are the constraints the packages that depend on the package
+	// place on it.
+	Constraints []Constraint
+}
+
+// Explain explains why the supplied package is installed at its version. It
+// returns the constraint each package in the supplied lock that depends on the
+// package places on it.
+func Explain(lock *v1beta1.Lock, pkg string) (*Explanation, error) {
+	d := dag.NewUpgradingMapDag()
+	if _, err := d.Init(v1beta1.ToNodes(lock.Packages...)); err != nil {
+		return nil, errors.Wrap(err, errBuildDAG)
+	}
+
+	n, err := d.GetNode(pkg)
+	if err != nil {
+		return nil, errors.Errorf(errFmtNotInLock, pkg)
+	}
+
+	e := &Explanation{Package: pkg}
+
+	// A dependency that isn't a lock package is implied. It's not installed.
+	if lp, ok := n.(*v1beta1.LockPackage); ok {
+		e.Version = lp.Version
+	}
+
+	for _, lp := range lock.Packages {
+		deps, err := d.NodeNeighbors(lp.Identifier())
+		if err != nil {
+			return nil, errors.Wrap(err, errBuildDAG)
+		}
+
+		for _, dep := range deps {
+			if dep.Identifier() != pkg {
+				continue
+			}
+
+			e.Constraints = append(e.Constraints, Constraint{
+				Package:    lp.Identifier(),
+				Version:    lp.Version,
+				Constraint: dep.GetConstraints(),
+				Satisfied:  satisfies(e.Version, dep.GetConstraints()),
+			})
+		}
+	}
+
+	sort.Slice(e.Constraints, func(i, j int) bool { return e.Constraints[i].Package < e.Constraints[j].Package })
+
+	return e, nil
+}
+
+// Print prints the explanation.
+func (e *Explanation) Print(w io.Writer) error {
+	version := e.Version
+	if version == "" {
+		version = "(not installed)"
+	}
+
+	if _, err := fmt.Fprintf(w, "%s@%s\n", e.Package, version); err != nil {
+		return err
+	}
+
+	if len(e.Constraints) == 0 {
+		_, err := fmt.Fprintln(w, "No installed package depends on this package.")
+		return err
+	}
+
+	if _, err := fmt.Fprintln(w); err != nil {
+		return err
+	}
+
+	tw := printers.GetNewTabWriter(w)
+
+	if _, err := fmt.Fprintln(tw, "REQUIRED BY\tVERSION\tCONSTRAINT\tSATISFIED"); err != nil {
+		return err
+	}
+
+	for _, c := range e.Constraints {
+		if _, err := fmt.Fprintf(tw, "%s\t%s\t%s\t%t\n", c.Package, c.Version, c.Constraint, c.Satisfied); err != nil {
+			return err
+		}
+	}
+
+	return tw.Flush()
+}
+
+// satisfies returns true if the supplied version satisfies the supplied
+// constraint. It checks constraints the same way the package revision
+// controller does when it looks for invalid dependencies.
+func satisfies(version, constraint string) bool {
+	if version == "" {
+		return false
+	}
+
+	if d, err := conregv1.NewHash(constraint); err == nil {
+		return version == d.String()
+	}
+
+	// A package installed at a digest was pinned. We can't check a digest
+	// against semantic version constraints.
+	if _, err := conregv1.NewHash(version); err == nil {
+		return true
+	}
+
+	c, err := semver.NewConstraint(constraint)
+	if err != nil {
+		return false
+	}
+
+	v, err := semver.NewVersion(version)
+	if err != nil {
+		return false
+	}
+
+	return c.Check(v)
+}
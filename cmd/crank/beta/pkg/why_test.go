@@ -0,0 +1,166 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pkg
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/crossplane/crossplane/v2/apis/pkg/v1beta1"
+)
+
+const digest = "sha256:ecc25c121431dfc7058754427f97c034ecde26d4aafa0da16d50bd80c9c84a05"
+
+func TestExplain(t *testing.T) {
+	type args struct {
+		lock *v1beta1.Lock
+		pkg  string
+	}
+
+	type want struct {
+		e   *Explanation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"NotInLock": {
+			reason: "We should return an error if the package isn't in the lock.",
+			args: args{
+				lock: &v1beta1.Lock{},
+				pkg:  "xpkg.crossplane.io/acme/provider-a",
+			},
+			want: want{
+				err: cmpopts.AnyError,
+			},
+		},
+		"Satisfied": {
+			reason: "We should explain the constraint each package that depends on the package places on it.",
+			args: args{
+				lock: &v1beta1.Lock{Packages: []v1beta1.LockPackage{
+					{
+						Source:  "xpkg.crossplane.io/acme/configuration-b",
+						Version: "v1.0.0",
+						Dependencies: []v1beta1.Dependency{
+							{Package: "xpkg.crossplane.io/acme/provider-a", Constraints: ">=v1.0.0"},
+						},
+					},
+					{
+						Source:  "xpkg.crossplane.io/acme/configuration-a",
+						Version: "v2.0.0",
+						Dependencies: []v1beta1.Dependency{
+							{Package: "xpkg.crossplane.io/acme/provider-a", Constraints: "<v2.0.0"},
+							{Package: "xpkg.crossplane.io/acme/function-a", Constraints: ">=v0.1.0"},
+						},
+					},
+					{
+						Source:  "xpkg.crossplane.io/acme/provider-a",
+						Version: "v1.2.0",
+					},
+				}},
+				pkg: "xpkg.crossplane.io/acme/provider-a",
+			},
+			want: want{
+				e: &Explanation{
+					Package: "xpkg.crossplane.io/acme/provider-a",
+					Version: "v1.2.0",
+					Constraints: []Constraint{
+						{Package: "xpkg.crossplane.io/acme/configuration-a", Version: "v2.0.0", Constraint: "<v2.0.0", Satisfied: true},
+						{Package: "xpkg.crossplane.io/acme/configuration-b", Version: "v1.0.0", Constraint: ">=v1.0.0", Satisfied: true},
+					},
+				},
+			},
+		},
+		"NotSatisfied": {
+			reason: "We should explain which constraints the installed version doesn't satisfy.",
+			args: args{
+				lock: &v1beta1.Lock{Packages: []v1beta1.LockPackage{
+					{
+						Source:  "xpkg.crossplane.io/acme/configuration-a",
+						Version: "v1.0.0",
+						Dependencies: []v1beta1.Dependency{
+							{Package: "xpkg.crossplane.io/acme/provider-a", Constraints: ">=v2.0.0"},
+						},
+					},
+					{
+						Source:  "xpkg.crossplane.io/acme/configuration-b",
+						Version: "v1.0.0",
+						Dependencies: []v1beta1.Dependency{
+							{Package: "xpkg.crossplane.io/acme/provider-a", Constraints: digest},
+						},
+					},
+					{
+						Source:  "xpkg.crossplane.io/acme/provider-a",
+						Version: "v1.0.0",
+					},
+				}},
+				pkg: "xpkg.crossplane.io/acme/provider-a",
+			},
+			want: want{
+				e: &Explanation{
+					Package: "xpkg.crossplane.io/acme/provider-a",
+					Version: "v1.0.0",
+					Constraints: []Constraint{
+						{Package: "xpkg.crossplane.io/acme/configuration-a", Version: "v1.0.0", Constraint: ">=v2.0.0"},
+						{Package: "xpkg.crossplane.io/acme/configuration-b", Version: "v1.0.0", Constraint: digest},
+					},
+				},
+			},
+		},
+		"NotInstalled": {
+			reason: "A dependency that isn't installed yet should have no version, and satisfy no constraints.",
+			args: args{
+				lock: &v1beta1.Lock{Packages: []v1beta1.LockPackage{
+					{
+						Source:  "xpkg.crossplane.io/acme/configuration-a",
+						Version: "v1.0.0",
+						Dependencies: []v1beta1.Dependency{
+							{Package: "xpkg.crossplane.io/acme/provider-a", Constraints: ">=v1.0.0"},
+						},
+					},
+				}},
+				pkg: "xpkg.crossplane.io/acme/provider-a",
+			},
+			want: want{
+				e: &Explanation{
+					Package: "xpkg.crossplane.io/acme/provider-a",
+					Constraints: []Constraint{
+						{Package: "xpkg.crossplane.io/acme/configuration-a", Version: "v1.0.0", Constraint: ">=v1.0.0"},
+					},
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e, err := Explain(tc.args.lock, tc.args.pkg)
+			if diff := cmp.Diff(tc.want.err, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nExplain(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+
+			if diff := cmp.Diff(tc.want.e, e); diff != "" {
+				t.Errorf("\n%s\nExplain(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
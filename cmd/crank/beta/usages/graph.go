@@ -0,0 +1,313 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package usages
+
+import (
+	"fmt"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+
+	legacy "github.com/crossplane/crossplane/v2/apis/apiextensions/v1beta1"
+	"github.com/crossplane/crossplane/v2/apis/protection/v1beta1"
+	"github.com/crossplane/crossplane/v2/internal/protection"
+)
+
+// A Resource in the usage graph.
+type Resource struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name"`
+}
+
+// ID uniquely identifies the resource. Resources with the same group but a
+// different version are the same resource.
+func (r Resource) ID() string {
+	gv, _ := schema.ParseGroupVersion(r.APIVersion)
+	return fmt.Sprintf("%s.%s.%s.%s", gv.Group, r.Kind, r.Name, r.Namespace)
+}
+
+// String returns a human-readable representation of the resource.
+func (r Resource) String() string {
+	if r.Namespace == "" {
+		return r.Kind + "/" + r.Name
+	}
+	return r.Kind + "/" + r.Namespace + "/" + r.Name
+}
+
+// A Node is a resource that is either used, or uses another resource.
+type Node struct {
+	Resource
+
+	// Blocked is true if an attempt to delete the resource was blocked
+	// because it's in use.
+	Blocked bool `json:"blocked"`
+
+	// DeletionAttempt is the propagation policy of the blocked deletion
+	// attempt, if any.
+	DeletionAttempt string `json:"deletionAttempt,omitempty"`
+}
+
+// A UsageRef identifies the usage that produced an edge.
+type UsageRef struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name"`
+}
+
+// String returns a human-readable representation of the usage.
+func (u UsageRef) String() string {
+	if u.Namespace == "" {
+		return u.Kind + "/" + u.Name
+	}
+	return u.Kind + "/" + u.Namespace + "/" + u.Name
+}
+
+// An Edge represents a usage. It points from the using resource to the used
+// resource. A usage that has a reason rather than a using resource has no By.
+type Edge struct {
+	Usage  UsageRef  `json:"usage"`
+	Of     Resource  `json:"of"`
+	By     *Resource `json:"by,omitempty"`
+	Reason string    `json:"reason,omitempty"`
+}
+
+// A Graph of usages.
+type Graph struct {
+	// Nodes sorted by ID.
+	Nodes []*Node `json:"nodes"`
+
+	// Edges sorted by usage.
+	Edges []Edge `json:"edges"`
+
+	// Cycles of resources that use each other, directly or indirectly.
+	// None of the resources in a cycle can be deleted until one of the
+	// usages in the cycle is deleted.
+	Cycles [][]Resource `json:"cycles,omitempty"`
+
+	// Unresolved usages that select rather than reference the resource
+	// they use (or are used by), and haven't yet resolved their selector.
+	Unresolved []UsageRef `json:"unresolved,omitempty"`
+}
+
+// NewGraph builds a graph from the supplied usages. It resolves namespaces
+// the same way the usage webhook does.
+func NewGraph(usages []protection.Usage) *Graph {
+	g := &Graph{Nodes: []*Node{}, Edges: []Edge{}}
+	nodes := map[string]*Node{}
+
+	node := func(r Resource) {
+		if _, ok := nodes[r.ID()]; !ok {
+			nodes[r.ID()] = &Node{Resource: r}
+		}
+	}
+
+	for _, u := range usages {
+		ref := usageRef(u)
+
+		of := u.GetUserOf()
+		if of.ResourceRef == nil || of.ResourceRef.Name == "" {
+			g.Unresolved = append(g.Unresolved, ref)
+			continue
+		}
+
+		e := Edge{
+			Usage:  ref,
+			Of:     Resource{APIVersion: of.APIVersion, Kind: of.Kind, Name: of.ResourceRef.Name},
+			Reason: ptr.Deref(u.GetReason(), ""),
+		}
+
+		// Only namespaced Usages may use namespaced resources. The used
+		// resource defaults to the Usage's namespace.
+		if _, ok := u.(*v1beta1.Usage); ok {
+			e.Of.Namespace = ptr.Deref(of.ResourceRef.Namespace, u.GetNamespace())
+		}
+
+		if by := u.GetUsedBy(); by != nil {
+			if by.ResourceRef == nil || by.ResourceRef.Name == "" {
+				g.Unresolved = append(g.Unresolved, ref)
+				continue
+			}
+			// The using resource is always in the Usage's namespace.
+			e.By = &Resource{APIVersion: by.APIVersion, Kind: by.Kind, Namespace: u.GetNamespace(), Name: by.ResourceRef.Name}
+			node(*e.By)
+		}
+
+		node(e.Of)
+		g.Edges = append(g.Edges, e)
+	}
+
+	for _, n := range nodes {
+		g.Nodes = append(g.Nodes, n)
+	}
+
+	sort.Slice(g.Nodes, func(i, j int) bool { return g.Nodes[i].ID() < g.Nodes[j].ID() })
+	sort.SliceStable(g.Edges, func(i, j int) bool { return g.Edges[i].Usage.String() < g.Edges[j].Usage.String() })
+	sort.SliceStable(g.Unresolved, func(i, j int) bool { return g.Unresolved[i].String() < g.Unresolved[j].String() })
+
+	g.Cycles = g.findCycles()
+
+	return g
+}
+
+// Node returns the node for the supplied resource, or nil if it isn't in the
+// graph.
+func (g *Graph) Node(r Resource) *Node {
+	for _, n := range g.Nodes {
+		if n.ID() == r.ID() {
+			return n
+		}
+	}
+	return nil
+}
+
+// Used returns the nodes that are used by at least one usage.
+func (g *Graph) Used() []*Node {
+	used := map[string]bool{}
+	for _, e := range g.Edges {
+		used[e.Of.ID()] = true
+	}
+
+	out := make([]*Node, 0, len(used))
+	for _, n := range g.Nodes {
+		if used[n.ID()] {
+			out = append(out, n)
+		}
+	}
+
+	return out
+}
+
+// findCycles returns the strongly connected components of the graph that
+// contain a cycle, using Tarjan's algorithm. Each cycle is sorted by resource
+// ID, and cycles are sorted by their first resource.
+func (g *Graph) findCycles() [][]Resource { //nolint:gocognit // Tarjan's algorithm is easier to read in one place.
+	adj := map[string][]string{}
+	self := map[string]bool{}
+	for _, e := range g.Edges {
+		if e.By == nil {
+			continue
+		}
+		adj[e.By.ID()] = append(adj[e.By.ID()], e.Of.ID())
+		if e.By.ID() == e.Of.ID() {
+			self[e.By.ID()] = true
+		}
+	}
+
+	resources := map[string]Resource{}
+	for _, n := range g.Nodes {
+		resources[n.ID()] = n.Resource
+	}
+
+	index := 0
+	indices := map[string]int{}
+	lowlink := map[string]int{}
+	onStack := map[string]bool{}
+	stack := []string{}
+	cycles := [][]Resource{}
+
+	var connect func(v string)
+	connect = func(v string) {
+		indices[v] = index
+		lowlink[v] = index
+		index++
+		stack = append(stack, v)
+		onStack[v] = true
+
+		for _, w := range adj[v] {
+			if _, visited := indices[w]; !visited {
+				connect(w)
+				lowlink[v] = min(lowlink[v], lowlink[w])
+			} else if onStack[w] {
+				lowlink[v] = min(lowlink[v], indices[w])
+			}
+		}
+
+		if lowlink[v] != indices[v] {
+			return
+		}
+
+		scc := []string{}
+		for {
+			w := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[w] = false
+			scc = append(scc, w)
+			if w == v {
+				break
+			}
+		}
+
+		if len(scc) == 1 && !self[v] {
+			return
+		}
+
+		sort.Strings(scc)
+		cycle := make([]Resource, len(scc))
+		for i, id := range scc {
+			cycle[i] = resources[id]
+		}
+		cycles = append(cycles, cycle)
+	}
+
+	for _, n := range g.Nodes {
+		if _, visited := indices[n.ID()]; !visited {
+			connect(n.ID())
+		}
+	}
+
+	sort.Slice(cycles, func(i, j int) bool { return cycles[i][0].ID() < cycles[j][0].ID() })
+
+	return cycles
+}
+
+// InCycle returns true if the supplied edge is part of a cycle.
+func (g *Graph) InCycle(e Edge) bool {
+	if e.By == nil {
+		return false
+	}
+	for _, c := range g.Cycles {
+		by, of := false, false
+		for _, r := range c {
+			by = by || r.ID() == e.By.ID()
+			of = of || r.ID() == e.Of.ID()
+		}
+		if by && of {
+			return true
+		}
+	}
+	return false
+}
+
+func usageRef(u protection.Usage) UsageRef {
+	ref := UsageRef{Namespace: u.GetNamespace(), Name: u.GetName()}
+
+	// Typed objects read from the API server don't have their GVK set.
+	switch u.(type) {
+	case *v1beta1.Usage:
+		ref.APIVersion, ref.Kind = v1beta1.SchemeGroupVersion.String(), v1beta1.UsageKind
+	case *v1beta1.ClusterUsage:
+		ref.APIVersion, ref.Kind = v1beta1.SchemeGroupVersion.String(), v1beta1.ClusterUsageKind
+	case *legacy.Usage: //nolint:staticcheck // Usage is deprecated but we still need to support it.
+		ref.APIVersion, ref.Kind = legacy.SchemeGroupVersion.String(), legacy.UsageKind
+	}
+
+	return ref
+}
@@ -0,0 +1,154 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package usages
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/crossplane/crossplane/v2/apis/protection/v1beta1"
+	"github.com/crossplane/crossplane/v2/internal/protection"
+)
+
+func usage(namespace, name string, of v1beta1.NamespacedResource, by *v1beta1.Resource, reason *string) *v1beta1.Usage {
+	return &v1beta1.Usage{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Spec:       v1beta1.UsageSpec{Of: of, By: by, Reason: reason},
+	}
+}
+
+func of(kind, name string) v1beta1.NamespacedResource {
+	return v1beta1.NamespacedResource{APIVersion: "example.org/v1", Kind: kind, ResourceRef: &v1beta1.NamespacedResourceRef{Name: name}}
+}
+
+func by(kind, name string) *v1beta1.Resource {
+	return &v1beta1.Resource{APIVersion: "example.org/v1", Kind: kind, ResourceRef: &v1beta1.ResourceRef{Name: name}}
+}
+
+func res(kind, namespace, name string) Resource {
+	return Resource{APIVersion: "example.org/v1", Kind: kind, Namespace: namespace, Name: name}
+}
+
+func ref(namespace, name string) UsageRef {
+	return UsageRef{APIVersion: v1beta1.SchemeGroupVersion.String(), Kind: v1beta1.UsageKind, Namespace: namespace, Name: name}
+}
+
+func TestNewGraph(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		usages []protection.Usage
+		want   *Graph
+	}{
+		"Empty": {
+			reason: "No usages should produce an empty graph.",
+			want:   &Graph{Nodes: []*Node{}, Edges: []Edge{}, Cycles: [][]Resource{}},
+		},
+		"ByAndReason": {
+			reason: "Usages by a resource and with a reason should produce edges, with namespaces defaulted to the usage's.",
+			usages: []protection.Usage{
+				usage("default", "app-uses-db", of("DB", "db"), by("App", "app"), nil),
+				usage("default", "protect-db", v1beta1.NamespacedResource{
+					APIVersion:  "example.org/v1",
+					Kind:        "DB",
+					ResourceRef: &v1beta1.NamespacedResourceRef{Name: "db", Namespace: ptr.To("default")},
+				}, nil, ptr.To("production")),
+			},
+			want: &Graph{
+				Nodes: []*Node{
+					{Resource: res("App", "default", "app")},
+					{Resource: res("DB", "default", "db")},
+				},
+				Edges: []Edge{
+					{Usage: ref("default", "app-uses-db"), Of: res("DB", "default", "db"), By: ptr.To(res("App", "default", "app"))},
+					{Usage: ref("default", "protect-db"), Of: res("DB", "default", "db"), Reason: "production"},
+				},
+				Cycles: [][]Resource{},
+			},
+		},
+		"Unresolved": {
+			reason: "Usages that haven't resolved their selectors should be reported as unresolved.",
+			usages: []protection.Usage{
+				usage("default", "selects", v1beta1.NamespacedResource{
+					APIVersion:       "example.org/v1",
+					Kind:             "DB",
+					ResourceSelector: &v1beta1.NamespacedResourceSelector{MatchLabels: map[string]string{"cool": "true"}},
+				}, by("App", "app"), nil),
+			},
+			want: &Graph{
+				Nodes:      []*Node{},
+				Edges:      []Edge{},
+				Cycles:     [][]Resource{},
+				Unresolved: []UsageRef{ref("default", "selects")},
+			},
+		},
+		"Cycle": {
+			reason: "Resources that use each other should be reported as a cycle.",
+			usages: []protection.Usage{
+				usage("default", "a-uses-b", of("X", "b"), by("X", "a"), nil),
+				usage("default", "b-uses-c", of("X", "c"), by("X", "b"), nil),
+				usage("default", "c-uses-a", of("X", "a"), by("X", "c"), nil),
+				usage("default", "d-uses-a", of("X", "a"), by("X", "d"), nil),
+			},
+			want: &Graph{
+				Nodes: []*Node{
+					{Resource: res("X", "default", "a")},
+					{Resource: res("X", "default", "b")},
+					{Resource: res("X", "default", "c")},
+					{Resource: res("X", "default", "d")},
+				},
+				Edges: []Edge{
+					{Usage: ref("default", "a-uses-b"), Of: res("X", "default", "b"), By: ptr.To(res("X", "default", "a"))},
+					{Usage: ref("default", "b-uses-c"), Of: res("X", "default", "c"), By: ptr.To(res("X", "default", "b"))},
+					{Usage: ref("default", "c-uses-a"), Of: res("X", "default", "a"), By: ptr.To(res("X", "default", "c"))},
+					{Usage: ref("default", "d-uses-a"), Of: res("X", "default", "a"), By: ptr.To(res("X", "default", "d"))},
+				},
+				Cycles: [][]Resource{
+					{res("X", "default", "a"), res("X", "default", "b"), res("X", "default", "c")},
+				},
+			},
+		},
+		"SelfUse": {
+			reason: "A resource that uses itself should be reported as a cycle.",
+			usages: []protection.Usage{
+				usage("default", "a-uses-a", of("X", "a"), by("X", "a"), nil),
+			},
+			want: &Graph{
+				Nodes: []*Node{
+					{Resource: res("X", "default", "a")},
+				},
+				Edges: []Edge{
+					{Usage: ref("default", "a-uses-a"), Of: res("X", "default", "a"), By: ptr.To(res("X", "default", "a"))},
+				},
+				Cycles: [][]Resource{
+					{res("X", "default", "a")},
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := NewGraph(tc.usages)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nNewGraph(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
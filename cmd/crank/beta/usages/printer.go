@@ -0,0 +1,204 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package usages
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/emicklei/dot"
+	"k8s.io/cli-runtime/pkg/printers"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+)
+
+const (
+	errFmtUnknownPrinterType = "unknown printer output type: %s"
+	errWriteHeader           = "cannot write header"
+	errWriteRow              = "cannot write row"
+	errFlushTabWriter        = "cannot flush tab writer"
+	errCannotMarshalJSON     = "cannot marshal usage graph as JSON"
+)
+
+// Type represents the type of printer.
+type Type string
+
+// Implemented PrinterTypes.
+const (
+	TypeDefault Type = "default"
+	TypeJSON    Type = "json"
+	TypeDot     Type = "dot"
+)
+
+// A Printer prints a usage graph.
+type Printer interface {
+	Print(w io.Writer, g *Graph) error
+}
+
+// NewPrinter creates a new printer based on the specified type.
+func NewPrinter(typeStr string) (Printer, error) {
+	switch Type(typeStr) {
+	case TypeDefault:
+		return &DefaultPrinter{}, nil
+	case TypeJSON:
+		return &JSONPrinter{}, nil
+	case TypeDot:
+		return &DotPrinter{}, nil
+	default:
+		return nil, errors.Errorf(errFmtUnknownPrinterType, typeStr)
+	}
+}
+
+// DefaultPrinter prints a usage graph as a table, followed by any cycles and
+// unresolved usages.
+type DefaultPrinter struct{}
+
+var _ Printer = &DefaultPrinter{}
+
+// Print implements the Printer interface.
+func (p *DefaultPrinter) Print(w io.Writer, g *Graph) error {
+	tw := printers.GetNewTabWriter(w)
+
+	if _, err := fmt.Fprintln(tw, strings.Join([]string{"USAGE", "OF", "BY", "BLOCKED"}, "\t")); err != nil {
+		return errors.Wrap(err, errWriteHeader)
+	}
+
+	for _, e := range g.Edges {
+		by := fmt.Sprintf("reason: %q", e.Reason)
+		if e.By != nil {
+			by = e.By.String()
+		}
+
+		blocked := false
+		if n := g.Node(e.Of); n != nil {
+			blocked = n.Blocked
+		}
+
+		if _, err := fmt.Fprintln(tw, strings.Join([]string{e.Usage.String(), e.Of.String(), by, strconv.FormatBool(blocked)}, "\t")); err != nil {
+			return errors.Wrap(err, errWriteRow)
+		}
+	}
+
+	if err := tw.Flush(); err != nil {
+		return errors.Wrap(err, errFlushTabWriter)
+	}
+
+	if len(g.Cycles) > 0 {
+		if _, err := fmt.Fprintf(w, "\nFound %d cycle(s). Resources in a cycle can't be deleted until a usage in the cycle is deleted:\n", len(g.Cycles)); err != nil {
+			return errors.Wrap(err, errWriteRow)
+		}
+		for _, c := range g.Cycles {
+			names := make([]string, len(c))
+			for i, r := range c {
+				names[i] = r.String()
+			}
+			if _, err := fmt.Fprintf(w, "  %s\n", strings.Join(names, ", ")); err != nil {
+				return errors.Wrap(err, errWriteRow)
+			}
+		}
+	}
+
+	if len(g.Unresolved) > 0 {
+		if _, err := fmt.Fprintf(w, "\nFound %d usage(s) with unresolved selectors:\n", len(g.Unresolved)); err != nil {
+			return errors.Wrap(err, errWriteRow)
+		}
+		for _, u := range g.Unresolved {
+			if _, err := fmt.Fprintf(w, "  %s\n", u); err != nil {
+				return errors.Wrap(err, errWriteRow)
+			}
+		}
+	}
+
+	return nil
+}
+
+// JSONPrinter prints a usage graph as JSON.
+type JSONPrinter struct{}
+
+var _ Printer = &JSONPrinter{}
+
+// Print implements the Printer interface.
+func (p *JSONPrinter) Print(w io.Writer, g *Graph) error {
+	out, err := json.MarshalIndent(g, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, errCannotMarshalJSON)
+	}
+
+	_, err = fmt.Fprintln(w, string(out))
+
+	return err
+}
+
+// DotPrinter prints a usage graph in DOT format. Edges point from the using
+// resource to the used resource. Blocked resources and edges that are part of
+// a cycle are highlighted in red.
+type DotPrinter struct{}
+
+var _ Printer = &DotPrinter{}
+
+// Print implements the Printer interface.
+func (p *DotPrinter) Print(w io.Writer, g *Graph) error {
+	d := dot.NewGraph(dot.Directed)
+
+	nodes := map[string]dot.Node{}
+	for i, n := range g.Nodes {
+		label := []string{
+			"Name: " + n.Kind + "/" + n.Name,
+			"ApiVersion: " + n.APIVersion,
+		}
+		if n.Namespace != "" {
+			label = append(label, "Namespace: "+n.Namespace)
+		}
+		label = append(label, "Blocked: "+strconv.FormatBool(n.Blocked))
+
+		dn := d.Node(strconv.Itoa(i)).Label(strings.Join(label, "\n")+"\n").Attr("penwidth", "2")
+		if n.Blocked {
+			dn.Attr("color", "red")
+		}
+		nodes[n.ID()] = dn
+	}
+
+	reasons := 0
+	for _, e := range g.Edges {
+		of := nodes[e.Of.ID()]
+
+		if e.By == nil {
+			// Usages with a reason don't have a using resource. Show
+			// the reason as a note pointing at the used resource.
+			rn := d.Node(fmt.Sprintf("reason-%d", reasons)).Label(fmt.Sprintf("Reason: %s\n", e.Reason)).Attr("shape", "note")
+			reasons++
+			d.Edge(rn, of).Label(e.Usage.String())
+			continue
+		}
+
+		de := d.Edge(nodes[e.By.ID()], of).Label(e.Usage.String())
+		if g.InCycle(e) {
+			de.Attr("color", "red")
+		}
+	}
+
+	if d.String() == "" {
+		return errors.New("graph is empty")
+	}
+
+	d.Write(w)
+
+	return nil
+}
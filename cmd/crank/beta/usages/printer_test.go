@@ -0,0 +1,86 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package usages
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/utils/ptr"
+
+	"github.com/crossplane/crossplane/v2/apis/protection/v1beta1"
+	"github.com/crossplane/crossplane/v2/internal/protection"
+)
+
+func testGraph() *Graph {
+	g := NewGraph([]protection.Usage{
+		usage("default", "a-uses-b", of("X", "b"), by("X", "a"), nil),
+		usage("default", "b-uses-a", of("X", "a"), by("X", "b"), nil),
+		usage("default", "protect-b", of("X", "b"), nil, ptr.To("important")),
+		usage("default", "selects", v1beta1.NamespacedResource{APIVersion: "example.org/v1", Kind: "X"}, by("X", "a"), nil),
+	})
+	g.Node(res("X", "default", "b")).Blocked = true
+
+	return g
+}
+
+func TestDefaultPrinter(t *testing.T) {
+	want := `USAGE                     OF            BY                    BLOCKED
+Usage/default/a-uses-b    X/default/b   X/default/a           true
+Usage/default/b-uses-a    X/default/a   X/default/b           false
+Usage/default/protect-b   X/default/b   reason: "important"   true
+
+Found 1 cycle(s). Resources in a cycle can't be deleted until a usage in the cycle is deleted:
+  X/default/a, X/default/b
+
+Found 1 usage(s) with unresolved selectors:
+  Usage/default/selects
+`
+
+	buf := &bytes.Buffer{}
+	if err := (&DefaultPrinter{}).Print(buf, testGraph()); err != nil {
+		t.Fatalf("Print(...): %s", err)
+	}
+
+	if diff := cmp.Diff(want, buf.String()); diff != "" {
+		t.Errorf("Print(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestDotPrinter(t *testing.T) {
+	want := `digraph  {
+	
+	n1[label="Name: X/a\nApiVersion: example.org/v1\nNamespace: default\nBlocked: false\n",penwidth="2"];
+	n2[color="red",label="Name: X/b\nApiVersion: example.org/v1\nNamespace: default\nBlocked: true\n",penwidth="2"];
+	n3[label="Reason: important\n",shape="note"];
+	n1->n2[color="red",label="Usage/default/a-uses-b"];
+	n2->n1[color="red",label="Usage/default/b-uses-a"];
+	n3->n2[label="Usage/default/protect-b"];
+	
+}
+`
+
+	buf := &bytes.Buffer{}
+	if err := (&DotPrinter{}).Print(buf, testGraph()); err != nil {
+		t.Fatalf("Print(...): %s", err)
+	}
+
+	if diff := cmp.Diff(want, buf.String()); diff != "" {
+		t.Errorf("Print(...): -want, +got:\n%s", diff)
+	}
+}
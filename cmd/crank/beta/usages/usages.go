@@ -0,0 +1,196 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package usages contains the usages command.
+package usages
+
+import (
+	"context"
+
+	"github.com/alecthomas/kong"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	kunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/logging"
+
+	legacy "github.com/crossplane/crossplane/v2/apis/apiextensions/v1beta1"
+	"github.com/crossplane/crossplane/v2/apis/protection/v1beta1"
+	"github.com/crossplane/crossplane/v2/internal/protection"
+)
+
+const (
+	errKubeConfig     = "failed to get kubeconfig"
+	errInitKubeClient = "cannot init kubeclient"
+	errInitPrinter    = "cannot init new printer"
+	errListUsages     = "cannot list usages"
+	errMarkBlocked    = "cannot determine which resources are blocked"
+	errCliOutput      = "cannot print output"
+)
+
+// Cmd shows the graph of resources that are protected from deletion by
+// usages.
+type Cmd struct {
+	Context   string `default:""        help:"Kubernetes context."                                                             name:"context"                                    predictor:"context"   short:"c"`
+	Namespace string `default:""        help:"Only show namespaced Usages in this namespace. ClusterUsages are always shown." name:"namespace"                                  predictor:"namespace" short:"n"`
+	Output    string `default:"default" enum:"default,json,dot"                                                                help:"Output format. One of: default, json, dot." name:"output"             short:"o"`
+}
+
+// Help returns help message for the usages command.
+func (c *Cmd) Help() string {
+	return `
+This command shows the graph of resources that are protected from deletion by
+Usages and ClusterUsages, including the deprecated apiextensions.crossplane.io
+Usage.
+
+Each usage is an edge from the resource that uses another resource (or the
+usage's reason) to the resource it uses. Resources that someone tried and
+failed to delete because they're in use are shown as blocked. Resources that
+use each other, directly or indirectly, form a cycle. None of the resources in
+a cycle can be deleted until one of the usages in the cycle is deleted.
+
+Examples:
+  # Show all usages
+  crossplane beta usages
+
+  # Show usages in the namespace 'my-ns', and all ClusterUsages
+  crossplane beta usages -n my-ns
+
+  # Output a graph in dot format and pipe to dot to generate a png
+  crossplane beta usages -o dot | dot -Tpng -o output.png
+
+  # Output the graph as JSON and use jq to list cycles
+  crossplane beta usages -o json | jq '.cycles'
+`
+}
+
+// Run runs the usages command.
+func (c *Cmd) Run(k *kong.Context, logger logging.Logger) error {
+	ctx := context.Background()
+
+	p, err := NewPrinter(c.Output)
+	if err != nil {
+		return errors.Wrap(err, errInitPrinter)
+	}
+
+	kubeconfig, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+		clientcmd.NewDefaultClientConfigLoadingRules(),
+		&clientcmd.ConfigOverrides{CurrentContext: c.Context},
+	).ClientConfig()
+	if err != nil {
+		return errors.Wrap(err, errKubeConfig)
+	}
+
+	if kubeconfig.QPS == 0 {
+		kubeconfig.QPS = 20
+	}
+
+	if kubeconfig.Burst == 0 {
+		kubeconfig.Burst = 30
+	}
+
+	s := runtime.NewScheme()
+	_ = v1beta1.AddToScheme(s)
+	_ = legacy.AddToScheme(s)
+
+	kube, err := client.New(kubeconfig, client.Options{Scheme: s})
+	if err != nil {
+		return errors.Wrap(err, errInitKubeClient)
+	}
+
+	usages, err := ListUsages(ctx, kube, c.Namespace)
+	if err != nil {
+		return errors.Wrap(err, errListUsages)
+	}
+
+	logger.Debug("Found usages", "count", len(usages))
+
+	g := NewGraph(usages)
+
+	if err := MarkBlocked(ctx, kube, g); err != nil {
+		return errors.Wrap(err, errMarkBlocked)
+	}
+
+	return errors.Wrap(p.Print(k.Stdout, g), errCliOutput)
+}
+
+// ListUsages lists all known types of usage. Namespaced Usages are limited to
+// the supplied namespace, unless it's empty.
+func ListUsages(ctx context.Context, c client.Reader, namespace string) ([]protection.Usage, error) {
+	usages := make([]protection.Usage, 0)
+
+	ul := &v1beta1.UsageList{}
+	if err := c.List(ctx, ul, client.InNamespace(namespace)); err != nil {
+		return nil, errors.Wrapf(err, "cannot list %s", v1beta1.UsageGroupVersionKind)
+	}
+
+	for i := range ul.Items {
+		usages = append(usages, &ul.Items[i])
+	}
+
+	cul := &v1beta1.ClusterUsageList{}
+	if err := c.List(ctx, cul); err != nil {
+		return nil, errors.Wrapf(err, "cannot list %s", v1beta1.ClusterUsageGroupVersionKind)
+	}
+
+	for i := range cul.Items {
+		usages = append(usages, &cul.Items[i])
+	}
+
+	lul := &legacy.UsageList{} //nolint:staticcheck // It's deprecated but we still need to support it.
+	if err := c.List(ctx, lul); err != nil {
+		// The legacy Usage may not be installed.
+		if !kerrors.IsNotFound(err) && !meta.IsNoMatchError(err) {
+			return nil, errors.Wrapf(err, "cannot list %s", legacy.UsageGroupVersionKind)
+		}
+	}
+
+	for i := range lul.Items {
+		usages = append(usages, &lul.Items[i])
+	}
+
+	return usages, nil
+}
+
+// MarkBlocked marks the used resources in the supplied graph that the usage
+// webhook blocked an attempt to delete. Used resources that don't exist are
+// ignored.
+func MarkBlocked(ctx context.Context, c client.Reader, g *Graph) error {
+	for _, n := range g.Used() {
+		u := &kunstructured.Unstructured{}
+		u.SetAPIVersion(n.APIVersion)
+		u.SetKind(n.Kind)
+
+		if err := c.Get(ctx, types.NamespacedName{Namespace: n.Namespace, Name: n.Name}, u); err != nil {
+			if kerrors.IsNotFound(err) {
+				continue
+			}
+			return errors.Wrapf(err, "cannot get %s", n)
+		}
+
+		if policy, ok := u.GetAnnotations()[protection.AnnotationKeyDeletionAttempt]; ok {
+			n.Blocked = true
+			n.DeletionAttempt = policy
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package usages
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	kunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/test"
+
+	"github.com/crossplane/crossplane/v2/internal/protection"
+)
+
+func TestMarkBlocked(t *testing.T) {
+	graph := func() *Graph {
+		return &Graph{
+			Nodes: []*Node{
+				{Resource: res("App", "default", "app")},
+				{Resource: res("DB", "default", "db")},
+				{Resource: res("DB", "default", "gone")},
+			},
+			Edges: []Edge{
+				{Of: res("DB", "default", "db"), By: ptr.To(res("App", "default", "app"))},
+				{Of: res("DB", "default", "gone"), By: ptr.To(res("App", "default", "app"))},
+			},
+		}
+	}
+
+	type want struct {
+		nodes []*Node
+		err   error
+	}
+
+	cases := map[string]struct {
+		reason string
+		get    test.MockGetFn
+		want   want
+	}{
+		"GetError": {
+			reason: "We should return an error if we can't get a used resource.",
+			get:    test.NewMockGetFn(errors.New("boom")),
+			want: want{
+				nodes: graph().Nodes,
+				err:   cmpopts.AnyError,
+			},
+		},
+		"Blocked": {
+			reason: "We should mark used resources with a deletion attempt as blocked, and ignore used resources that don't exist.",
+			get: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+				if key.Name == "gone" {
+					return kerrors.NewNotFound(schema.GroupResource{}, key.Name)
+				}
+				if key.Name == "db" {
+					obj.(*kunstructured.Unstructured).SetAnnotations(map[string]string{protection.AnnotationKeyDeletionAttempt: "Background"})
+				}
+				return nil
+			},
+			want: want{
+				nodes: []*Node{
+					{Resource: res("App", "default", "app")},
+					{Resource: res("DB", "default", "db"), Blocked: true, DeletionAttempt: "Background"},
+					{Resource: res("DB", "default", "gone")},
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			g := graph()
+			err := MarkBlocked(context.Background(), &test.MockClient{MockGet: tc.get}, g)
+			if diff := cmp.Diff(tc.want.err, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nMarkBlocked(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.nodes, g.Nodes); diff != "" {
+				t.Errorf("\n%s\nMarkBlocked(...): -want nodes, +got nodes:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
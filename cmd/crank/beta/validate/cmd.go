@@ -43,6 +43,7 @@ type Cmd struct {
 	// Flags. Keep them in alphabetical order.
 	CacheDir              string `default:"~/.crossplane/cache"                                                       help:"Absolute path to the cache directory where downloaded schemas are stored." predictor:"directory"`
 	CleanCache            bool   `help:"Clean the cache directory before downloading package schemas."`
+	Output                string `default:"text"                                                                      enum:"text,json,sarif,junit"                                                      help:"Output format. One of: text, json, sarif, junit." short:"o"`
 	SkipSuccessResults    bool   `help:"Skip printing success results."`
 	CrossplaneImage       string `help:"Specify the Crossplane image to be used for validating the built-in schemas."`
 	ErrorOnMissingSchemas bool   `default:"false"                                                                     help:"Return non zero exit code if not all schemas are provided."`
@@ -56,8 +57,11 @@ This command validates the provided Crossplane resources against the schemas of
 CRDs, providers, functions and configurations. The output of the "crossplane render" command can be
 piped to this validate command in order to rapidly validate on the outputs of the composition development experience.
 
-If providers or configurations are provided as extensions, they will be downloaded and loaded as CRDs before performing
-validation. If the cache directory is not provided, it will default to "~/.crossplane/cache".
+If providers, functions or configurations are provided as extensions, they will be downloaded and loaded as CRDs before
+performing validation. If the cache directory is not provided, it will default to "~/.crossplane/cache".
+
+The inputs of Composition and Operation pipeline steps are validated against the input CRDs that function packages
+publish. Provide the functions, or a configuration that depends on them, as extensions to validate function inputs.
 Cache directory can be cleaned before downloading schemas by setting the "clean-cache" flag.
 
 All validation is performed offline locally using the Kubernetes API server's validation library, so it does not require
@@ -78,6 +82,10 @@ Examples:
   # success logs
   crossplane beta validate extensionsDir/ resourceDir/ --skip-success-results
 
+  # Validate all resources in the resourceDir folder and write the results as a SARIF log, which code review tools
+  # can use to annotate the resources inline
+  crossplane beta validate extensionsDir/ resourceDir/ --output=sarif > results.sarif
+
   # Validate the output of the render command against the extensions in the extensionsDir folder
   crossplane render xr.yaml composition.yaml func.yaml --include-full-xr | crossplane beta validate extensionsDir/ -
 
@@ -120,7 +128,7 @@ func (c *Cmd) Run(k *kong.Context, _ logging.Logger) error {
 		return errors.Wrapf(err, "cannot load resources from %q", c.Resources)
 	}
 
-	resources, err := resourceLoader.Load()
+	resources, err := resourceLoader.LoadManifests()
 	if err != nil {
 		return errors.Wrapf(err, "cannot load resources from %q", c.Resources)
 	}
@@ -130,7 +138,19 @@ func (c *Cmd) Run(k *kong.Context, _ logging.Logger) error {
 		c.CacheDir = filepath.Join(homeDir, c.CacheDir[2:])
 	}
 
-	m := NewManager(c.CacheDir, c.fs, k.Stdout, WithCrossplaneImage(c.CrossplaneImage))
+	rw, err := NewResultWriter(c.Output, c.SkipSuccessResults, c.ErrorOnMissingSchemas)
+	if err != nil {
+		return err
+	}
+
+	// Only the results should be written to stdout when they're machine
+	// readable.
+	progress := k.Stdout
+	if c.Output != OutputText {
+		progress = k.Stderr
+	}
+
+	m := NewManager(c.CacheDir, c.fs, progress, WithCrossplaneImage(c.CrossplaneImage))
 
 	// Convert XRDs/CRDs to CRDs and add package dependencies
 	if err := m.PrepExtensions(extensions); err != nil {
@@ -143,7 +163,16 @@ func (c *Cmd) Run(k *kong.Context, _ logging.Logger) error {
 	}
 
 	// Validate resources against schemas
-	if err := SchemaValidation(context.Background(), resources, m.crds, c.ErrorOnMissingSchemas, c.SkipSuccessResults, k.Stdout); err != nil {
+	results, err := Validate(context.Background(), resources, m.crds)
+	if err != nil {
+		return errors.Wrapf(err, "cannot validate resources")
+	}
+
+	if err := rw.Write(k.Stdout, results); err != nil {
+		return err
+	}
+
+	if err := ResultsError(results, c.ErrorOnMissingSchemas); err != nil {
 		return errors.Wrapf(err, "cannot validate resources")
 	}
 
@@ -0,0 +1,402 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validate
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	runtimeschema "k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+)
+
+// Output formats.
+const (
+	OutputText  = "text"
+	OutputJSON  = "json"
+	OutputSARIF = "sarif"
+	OutputJUnit = "junit"
+)
+
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	toolName     = "crossplane beta validate"
+	toolInfoURI  = "https://docs.crossplane.io/latest/cli/command-reference/#beta-validate"
+)
+
+// A ResultWriter writes validation results.
+type ResultWriter interface {
+	Write(w io.Writer, results []Result) error
+}
+
+// NewResultWriter returns a ResultWriter for the supplied output format. Success
+// results are omitted if skipSuccessResults is true. Missing schemas are
+// reported as errors rather than warnings if errorOnMissingSchemas is true.
+func NewResultWriter(format string, skipSuccessResults, errorOnMissingSchemas bool) (ResultWriter, error) {
+	switch format {
+	case OutputText, "":
+		return &TextWriter{SkipSuccessResults: skipSuccessResults}, nil
+	case OutputJSON:
+		return &JSONWriter{SkipSuccessResults: skipSuccessResults}, nil
+	case OutputSARIF:
+		return &SARIFWriter{ErrorOnMissingSchemas: errorOnMissingSchemas}, nil
+	case OutputJUnit:
+		return &JUnitWriter{SkipSuccessResults: skipSuccessResults, ErrorOnMissingSchemas: errorOnMissingSchemas}, nil
+	default:
+		return nil, errors.Errorf("unknown output format %q", format)
+	}
+}
+
+// A TextWriter writes validation results as human-readable lines.
+type TextWriter struct {
+	SkipSuccessResults bool
+}
+
+// Write the supplied results.
+func (tw *TextWriter) Write(w io.Writer, results []Result) error {
+	for _, r := range results {
+		gvk := runtimeschema.FromAPIVersionAndKind(r.APIVersion, r.Kind).String()
+		name := displayName(r)
+
+		if r.MissingSchema() {
+			if _, err := fmt.Fprintf(w, "[!] %s\n", r.Errors[0].Message); err != nil {
+				return errors.Wrap(err, errWriteOutput)
+			}
+
+			continue
+		}
+
+		for _, warn := range r.Warnings {
+			if _, err := fmt.Fprintf(w, "[!] %s, %s: %s\n", gvk, name, warn); err != nil {
+				return errors.Wrap(err, errWriteOutput)
+			}
+		}
+
+		for _, e := range r.Errors {
+			kind := "schema"
+			if e.Type == ErrorTypeCEL {
+				kind = "CEL"
+			}
+
+			if _, err := fmt.Fprintf(w, "[x] %s validation error %s, %s : %s: %s\n", kind, gvk, name, e.Field, e.Message); err != nil {
+				return errors.Wrap(err, errWriteOutput)
+			}
+		}
+
+		if len(r.Errors) == 0 && !tw.SkipSuccessResults {
+			if _, err := fmt.Fprintf(w, "[✓] %s, %s validated successfully\n", gvk, name); err != nil {
+				return errors.Wrap(err, errWriteOutput)
+			}
+		}
+	}
+
+	s := Summarize(results)
+	if _, err := fmt.Fprintf(w, "Total %d resources: %d missing schemas, %d success cases, %d failure cases\n", s.Total, s.MissingSchemas, s.Success, s.Failure); err != nil {
+		return errors.Wrap(err, errWriteOutput)
+	}
+
+	return nil
+}
+
+// A JSONWriter writes validation results as a JSON document.
+type JSONWriter struct {
+	SkipSuccessResults bool
+}
+
+type jsonOutput struct {
+	Summary Summary  `json:"summary"`
+	Results []Result `json:"results"`
+}
+
+// Write the supplied results.
+func (jw *JSONWriter) Write(w io.Writer, results []Result) error {
+	out := jsonOutput{Summary: Summarize(results), Results: make([]Result, 0, len(results))}
+
+	for _, r := range results {
+		if jw.SkipSuccessResults && len(r.Errors) == 0 {
+			continue
+		}
+
+		out.Results = append(out.Results, r)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return errors.Wrap(enc.Encode(out), errWriteOutput)
+}
+
+// A SARIFWriter writes validation errors as a SARIF log, which many code
+// review tools can annotate inline. Successful results are never written.
+type SARIFWriter struct {
+	ErrorOnMissingSchemas bool
+}
+
+type sarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+// Write the supplied results.
+func (sw *SARIFWriter) Write(w io.Writer, results []Result) error {
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           toolName,
+			InformationURI: toolInfoURI,
+			Rules: []sarifRule{
+				{ID: string(ErrorTypeSchema), ShortDescription: sarifMessage{Text: "Resource doesn't match its OpenAPI schema."}},
+				{ID: string(ErrorTypeCEL), ShortDescription: sarifMessage{Text: "Resource doesn't satisfy a CEL validation rule."}},
+				{ID: string(ErrorTypeUnknownField), ShortDescription: sarifMessage{Text: "Resource has a field that isn't in its schema."}},
+				{ID: string(ErrorTypeMissingSchema), ShortDescription: sarifMessage{Text: "Resource has no CRD or XRD to validate it against."}},
+			},
+		}},
+		Results: make([]sarifResult, 0),
+	}
+
+	for _, r := range results {
+		var locs []sarifLocation
+		if r.File != "" {
+			l := sarifLocation{PhysicalLocation: sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: filepath.ToSlash(r.File)}}}
+			if r.Line > 0 {
+				l.PhysicalLocation.Region = &sarifRegion{StartLine: r.Line}
+			}
+
+			locs = []sarifLocation{l}
+		}
+
+		for _, e := range r.Errors {
+			level := "error"
+			if e.Type == ErrorTypeMissingSchema && !sw.ErrorOnMissingSchemas {
+				level = "warning"
+			}
+
+			run.Results = append(run.Results, sarifResult{
+				RuleID:    string(e.Type),
+				Level:     level,
+				Message:   sarifMessage{Text: errorSummary(r, e)},
+				Locations: locs,
+			})
+		}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return errors.Wrap(enc.Encode(sarifLog{Version: sarifVersion, Schema: sarifSchema, Runs: []sarifRun{run}}), errWriteOutput)
+}
+
+// A JUnitWriter writes validation results as a JUnit XML report, with one test
+// case per resource.
+type JUnitWriter struct {
+	SkipSuccessResults    bool
+	ErrorOnMissingSchemas bool
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	File      string        `xml:"file,attr,omitempty"`
+	Line      int           `xml:"line,attr,omitempty"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	Skipped   *junitSkipped `xml:"skipped,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+type junitSkipped struct {
+	Message string `xml:"message,attr"`
+}
+
+// Write the supplied results.
+func (jw *JUnitWriter) Write(w io.Writer, results []Result) error {
+	suite := junitTestSuite{Name: toolName}
+
+	for _, r := range results {
+		if jw.SkipSuccessResults && len(r.Errors) == 0 {
+			continue
+		}
+
+		tc := junitTestCase{
+			Name:      resourceID(r),
+			ClassName: r.File,
+			File:      r.File,
+			Line:      r.Line,
+		}
+
+		switch {
+		case r.MissingSchema() && !jw.ErrorOnMissingSchemas:
+			tc.Skipped = &junitSkipped{Message: r.Errors[0].Message}
+			suite.Skipped++
+		case len(r.Errors) > 0:
+			lines := make([]string, 0, len(r.Errors))
+			for _, e := range r.Errors {
+				lines = append(lines, fmt.Sprintf("%s: %s", e.Type, errorMessage(e)))
+			}
+
+			tc.Failure = &junitFailure{
+				Message: fmt.Sprintf("%d validation error(s)", len(r.Errors)),
+				Type:    string(r.Errors[0].Type),
+				Text:    strings.Join(lines, "\n"),
+			}
+			suite.Failures++
+		}
+
+		if tc.ClassName == "" {
+			tc.ClassName = r.APIVersion
+		}
+
+		suite.TestCases = append(suite.TestCases, tc)
+		suite.Tests++
+	}
+
+	out := junitTestSuites{
+		Name:     toolName,
+		Tests:    suite.Tests,
+		Failures: suite.Failures,
+		Skipped:  suite.Skipped,
+		Suites:   []junitTestSuite{suite},
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return errors.Wrap(err, errWriteOutput)
+	}
+
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+
+	if err := enc.Encode(out); err != nil {
+		return errors.Wrap(err, errWriteOutput)
+	}
+
+	_, err := io.WriteString(w, "\n")
+
+	return errors.Wrap(err, errWriteOutput)
+}
+
+// displayName returns the name of the resource a result is for. Resources
+// without a name that were extracted from another resource, like function
+// inputs, are named after where they were extracted from.
+func displayName(r Result) string {
+	if r.Name != "" || r.Owner == "" {
+		return r.Name
+	}
+
+	return fmt.Sprintf("%s %s", r.Owner, r.FieldPath)
+}
+
+// resourceID identifies the resource a result is for.
+func resourceID(r Result) string {
+	gvk := runtimeschema.FromAPIVersionAndKind(r.APIVersion, r.Kind).String()
+
+	name := displayName(r)
+	if name == "" {
+		return gvk
+	}
+
+	return fmt.Sprintf("%s, %s", gvk, name)
+}
+
+// errorMessage returns the supplied error's message, prefixed with its field
+// path if it has one.
+func errorMessage(e ResultError) string {
+	if e.Field == "" {
+		return e.Message
+	}
+
+	return fmt.Sprintf("%s: %s", e.Field, e.Message)
+}
+
+// errorSummary describes the supplied error and the resource it was found in.
+func errorSummary(r Result, e ResultError) string {
+	return fmt.Sprintf("%s: %s", resourceID(r), errorMessage(e))
+}
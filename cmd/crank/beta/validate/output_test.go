@@ -0,0 +1,220 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validate
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+var (
+	resultValid = Result{APIVersion: "test.org/v1alpha1", Kind: "Test", Name: "valid", File: "resources/valid.yaml", Line: 2}
+
+	resultInvalid = Result{
+		APIVersion: "test.org/v1alpha1",
+		Kind:       "Test",
+		Name:       "invalid",
+		File:       "resources/invalid.yaml",
+		Line:       5,
+		Errors: []ResultError{
+			{Type: ErrorTypeSchema, Field: "spec.replicas", Message: "Required value"},
+			{Type: ErrorTypeCEL, Field: "spec", Message: "Invalid value: \"object\": too many replicas"},
+		},
+	}
+
+	resultMissingSchema = Result{
+		APIVersion: "other.org/v1",
+		Kind:       "Other",
+		Name:       "other",
+		Errors:     []ResultError{{Type: ErrorTypeMissingSchema, Message: "could not find CRD/XRD for: other.org/v1, Kind=Other"}},
+	}
+)
+
+func TestTextWriter(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		tw     *TextWriter
+		want   string
+	}{
+		"AllResults": {
+			reason: "We should write a line per error or success, and a summary.",
+			tw:     &TextWriter{},
+			want: `[✓] test.org/v1alpha1, Kind=Test, valid validated successfully
+[x] schema validation error test.org/v1alpha1, Kind=Test, invalid : spec.replicas: Required value
+[x] CEL validation error test.org/v1alpha1, Kind=Test, invalid : spec: Invalid value: "object": too many replicas
+[!] could not find CRD/XRD for: other.org/v1, Kind=Other
+Total 3 resources: 1 missing schemas, 1 success cases, 1 failure cases
+`,
+		},
+		"SkipSuccessResults": {
+			reason: "We shouldn't write success lines if asked to skip them.",
+			tw:     &TextWriter{SkipSuccessResults: true},
+			want: `[x] schema validation error test.org/v1alpha1, Kind=Test, invalid : spec.replicas: Required value
+[x] CEL validation error test.org/v1alpha1, Kind=Test, invalid : spec: Invalid value: "object": too many replicas
+[!] could not find CRD/XRD for: other.org/v1, Kind=Other
+Total 3 resources: 1 missing schemas, 1 success cases, 1 failure cases
+`,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			w := &bytes.Buffer{}
+			if err := tc.tw.Write(w, []Result{resultValid, resultInvalid, resultMissingSchema}); err != nil {
+				t.Fatalf("Write(...): unexpected error: %v", err)
+			}
+
+			if diff := cmp.Diff(tc.want, w.String()); diff != "" {
+				t.Errorf("\n%s\nWrite(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestJSONWriter(t *testing.T) {
+	w := &bytes.Buffer{}
+	if err := (&JSONWriter{SkipSuccessResults: true}).Write(w, []Result{resultValid, resultInvalid}); err != nil {
+		t.Fatalf("Write(...): unexpected error: %v", err)
+	}
+
+	got := jsonOutput{}
+	if err := json.Unmarshal(w.Bytes(), &got); err != nil {
+		t.Fatalf("json.Unmarshal(...): unexpected error: %v", err)
+	}
+
+	want := jsonOutput{
+		Summary: Summary{Total: 2, Success: 1, Failure: 1},
+		Results: []Result{resultInvalid},
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Write(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestSARIFWriter(t *testing.T) {
+	type want struct {
+		results []sarifResult
+	}
+
+	cases := map[string]struct {
+		reason string
+		sw     *SARIFWriter
+		want   want
+	}{
+		"MissingSchemaWarning": {
+			reason: "We should write a result per error, located where the resource was loaded from. Missing schemas should be warnings.",
+			sw:     &SARIFWriter{},
+			want: want{
+				results: []sarifResult{
+					{
+						RuleID:    "Schema",
+						Level:     "error",
+						Message:   sarifMessage{Text: "test.org/v1alpha1, Kind=Test, invalid: spec.replicas: Required value"},
+						Locations: []sarifLocation{{PhysicalLocation: sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: "resources/invalid.yaml"}, Region: &sarifRegion{StartLine: 5}}}},
+					},
+					{
+						RuleID:    "CEL",
+						Level:     "error",
+						Message:   sarifMessage{Text: `test.org/v1alpha1, Kind=Test, invalid: spec: Invalid value: "object": too many replicas`},
+						Locations: []sarifLocation{{PhysicalLocation: sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: "resources/invalid.yaml"}, Region: &sarifRegion{StartLine: 5}}}},
+					},
+					{
+						RuleID:  "MissingSchema",
+						Level:   "warning",
+						Message: sarifMessage{Text: "other.org/v1, Kind=Other, other: could not find CRD/XRD for: other.org/v1, Kind=Other"},
+					},
+				},
+			},
+		},
+		"MissingSchemaError": {
+			reason: "Missing schemas should be errors if asked to error on missing schemas.",
+			sw:     &SARIFWriter{ErrorOnMissingSchemas: true},
+			want: want{
+				results: []sarifResult{
+					{
+						RuleID:    "Schema",
+						Level:     "error",
+						Message:   sarifMessage{Text: "test.org/v1alpha1, Kind=Test, invalid: spec.replicas: Required value"},
+						Locations: []sarifLocation{{PhysicalLocation: sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: "resources/invalid.yaml"}, Region: &sarifRegion{StartLine: 5}}}},
+					},
+					{
+						RuleID:    "CEL",
+						Level:     "error",
+						Message:   sarifMessage{Text: `test.org/v1alpha1, Kind=Test, invalid: spec: Invalid value: "object": too many replicas`},
+						Locations: []sarifLocation{{PhysicalLocation: sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: "resources/invalid.yaml"}, Region: &sarifRegion{StartLine: 5}}}},
+					},
+					{
+						RuleID:  "MissingSchema",
+						Level:   "error",
+						Message: sarifMessage{Text: "other.org/v1, Kind=Other, other: could not find CRD/XRD for: other.org/v1, Kind=Other"},
+					},
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			w := &bytes.Buffer{}
+			if err := tc.sw.Write(w, []Result{resultValid, resultInvalid, resultMissingSchema}); err != nil {
+				t.Fatalf("Write(...): unexpected error: %v", err)
+			}
+
+			got := sarifLog{}
+			if err := json.Unmarshal(w.Bytes(), &got); err != nil {
+				t.Fatalf("json.Unmarshal(...): unexpected error: %v", err)
+			}
+
+			if got.Version != sarifVersion || len(got.Runs) != 1 {
+				t.Fatalf("\n%s\nWrite(...): want one SARIF %s run, got version %q with %d runs", tc.reason, sarifVersion, got.Version, len(got.Runs))
+			}
+
+			if diff := cmp.Diff(tc.want.results, got.Runs[0].Results); diff != "" {
+				t.Errorf("\n%s\nWrite(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestJUnitWriter(t *testing.T) {
+	w := &bytes.Buffer{}
+	if err := (&JUnitWriter{}).Write(w, []Result{resultValid, resultInvalid, resultMissingSchema}); err != nil {
+		t.Fatalf("Write(...): unexpected error: %v", err)
+	}
+
+	want := `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites name="crossplane beta validate" tests="3" failures="1" skipped="1">
+  <testsuite name="crossplane beta validate" tests="3" failures="1" skipped="1">
+    <testcase name="test.org/v1alpha1, Kind=Test, valid" classname="resources/valid.yaml" file="resources/valid.yaml" line="2"></testcase>
+    <testcase name="test.org/v1alpha1, Kind=Test, invalid" classname="resources/invalid.yaml" file="resources/invalid.yaml" line="5">
+      <failure message="2 validation error(s)" type="Schema">Schema: spec.replicas: Required value&#xA;CEL: spec: Invalid value: &#34;object&#34;: too many replicas</failure>
+    </testcase>
+    <testcase name="other.org/v1, Kind=Other, other" classname="other.org/v1">
+      <skipped message="could not find CRD/XRD for: other.org/v1, Kind=Other"></skipped>
+    </testcase>
+  </testsuite>
+</testsuites>
+`
+
+	if diff := cmp.Diff(want, w.String()); diff != "" {
+		t.Errorf("Write(...): -want, +got:\n%s", diff)
+	}
+}
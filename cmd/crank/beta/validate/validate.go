@@ -34,6 +34,7 @@ import (
 
 	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
 
+	"github.com/crossplane/crossplane/v2/cmd/crank/common/load"
 	"github.com/crossplane/crossplane/v2/internal/xcrd"
 )
 
@@ -95,87 +96,196 @@ func newValidatorsAndStructurals(crds []*extv1.CustomResourceDefinition) (map[ru
 	return validators, structurals, nil
 }
 
+// An ErrorType is the type of error found while validating a resource.
+type ErrorType string
+
+// Types of error found while validating a resource.
+const (
+	ErrorTypeSchema        ErrorType = "Schema"
+	ErrorTypeCEL           ErrorType = "CEL"
+	ErrorTypeUnknownField  ErrorType = "UnknownField"
+	ErrorTypeMissingSchema ErrorType = "MissingSchema"
+)
+
+// A ResultError is an error found while validating a resource.
+type ResultError struct {
+	// Type of the error.
+	Type ErrorType `json:"type"`
+
+	// Field path the error was found at, if any.
+	Field string `json:"field,omitempty"`
+
+	// Message describing the error.
+	Message string `json:"message"`
+}
+
+// A Result is the result of validating a resource.
+type Result struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Name       string `json:"name,omitempty"`
+
+	// File and line the resource was loaded from, if known.
+	File string `json:"file,omitempty"`
+	Line int    `json:"line,omitempty"`
+
+	// Owner of the resource, if it was extracted from another resource. For
+	// example the Composition a function input was extracted from, as
+	// Kind/name.
+	Owner string `json:"owner,omitempty"`
+
+	// FieldPath of the resource within its owner.
+	FieldPath string `json:"fieldPath,omitempty"`
+
+	// Errors found while validating the resource. A resource without a
+	// schema has exactly one error, of type MissingSchema.
+	Errors []ResultError `json:"errors,omitempty"`
+
+	// Warnings about the resource that don't fail validation.
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// MissingSchema returns true if the resource couldn't be validated because its
+// schema wasn't found.
+func (r Result) MissingSchema() bool {
+	return len(r.Errors) == 1 && r.Errors[0].Type == ErrorTypeMissingSchema
+}
+
+// Failed returns true if the resource failed validation.
+func (r Result) Failed() bool {
+	return len(r.Errors) > 0 && !r.MissingSchema()
+}
+
+// A Summary summarizes a set of validation results.
+type Summary struct {
+	Total          int `json:"total"`
+	MissingSchemas int `json:"missingSchemas"`
+	Success        int `json:"success"`
+	Failure        int `json:"failure"`
+}
+
+// Summarize the supplied validation results.
+func Summarize(results []Result) Summary {
+	s := Summary{Total: len(results)}
+
+	for _, r := range results {
+		switch {
+		case r.MissingSchema():
+			s.MissingSchemas++
+		case r.Failed():
+			s.Failure++
+		default:
+			s.Success++
+		}
+	}
+
+	return s
+}
+
 // SchemaValidation validates the resources against the given CRDs.
-func SchemaValidation(ctx context.Context, resources []*unstructured.Unstructured, crds []*extv1.CustomResourceDefinition, errorOnMissingSchemas bool, skipSuccessLogs bool, w io.Writer) error { //nolint:gocognit // printing the output increases the cyclomatic complexity a little bit
+func SchemaValidation(ctx context.Context, resources []*unstructured.Unstructured, crds []*extv1.CustomResourceDefinition, errorOnMissingSchemas bool, skipSuccessLogs bool, w io.Writer) error {
+	ms := make([]load.Manifest, len(resources))
+	for i := range resources {
+		ms[i] = load.Manifest{Resource: resources[i]}
+	}
+
+	results, err := Validate(ctx, ms, crds)
+	if err != nil {
+		return err
+	}
+
+	if err := (&TextWriter{SkipSuccessResults: skipSuccessLogs}).Write(w, results); err != nil {
+		return err
+	}
+
+	return ResultsError(results, errorOnMissingSchemas)
+}
+
+// ResultsError returns an error if any of the supplied results failed
+// validation, or if any resource's schema was missing and
+// errorOnMissingSchemas is true.
+func ResultsError(results []Result, errorOnMissingSchemas bool) error {
+	s := Summarize(results)
+
+	if s.Failure > 0 {
+		return errors.New("could not validate all resources")
+	}
+
+	if errorOnMissingSchemas && s.MissingSchemas > 0 {
+		return errors.New("could not validate all resources, schema(s) missing")
+	}
+
+	return nil
+}
+
+// Validate validates the supplied manifests against the given CRDs. It returns
+// one result per manifest, in the order the manifests were supplied.
+func Validate(ctx context.Context, manifests []load.Manifest, crds []*extv1.CustomResourceDefinition) ([]Result, error) {
 	schemaValidators, structurals, err := newValidatorsAndStructurals(crds)
 	if err != nil {
-		return errors.Wrap(err, "cannot create schema validators")
+		return nil, errors.Wrap(err, "cannot create schema validators")
 	}
 
-	failure, missingSchemas := 0, 0
+	results := make([]Result, 0, len(manifests))
 
-	for _, r := range resources {
+	for _, m := range manifests {
+		r := m.Resource
 		gvk := r.GetObjectKind().GroupVersionKind()
 		sv, ok := schemaValidators[gvk]
 		s := structurals[gvk] // if we have a schema validator, we should also have a structural
 
-		if !ok {
-			missingSchemas++
+		res := Result{
+			APIVersion: r.GetAPIVersion(),
+			Kind:       r.GetKind(),
+			Name:       getResourceName(r),
+			File:       m.File,
+			Line:       m.Line,
+			FieldPath:  m.FieldPath,
+		}
 
-			if _, err := fmt.Fprintf(w, "[!] could not find CRD/XRD for: %s\n", r.GroupVersionKind().String()); err != nil {
-				return errors.Wrap(err, errWriteOutput)
+		if m.Owner != nil {
+			res.Owner = fmt.Sprintf("%s/%s", m.Owner.GetKind(), m.Owner.GetName())
+		}
+
+		if !ok {
+			msg := "could not find CRD/XRD for: " + gvk.String()
+			if m.Owner != nil {
+				// Function packages publish the CRDs of their inputs.
+				msg += " (include the Function's package in the extensions to validate its input)"
 			}
 
+			res.Errors = []ResultError{{Type: ErrorTypeMissingSchema, Message: msg}}
+			results = append(results, res)
+
 			continue
 		}
 
 		if err := applyDefaults(r, gvk, crds); err != nil {
-			if _, err := fmt.Fprintf(w, "[!] failed to apply defaults for %s, %s: %v\n", r.GroupVersionKind().String(), getResourceName(r), err); err != nil {
-				return errors.Wrap(err, errWriteOutput)
-			}
+			res.Warnings = append(res.Warnings, fmt.Sprintf("failed to apply defaults: %v", err))
 		}
 
-		rf := 0
-
-		re := field.ErrorList{}
 		for _, v := range sv {
-			re = append(re, validation.ValidateCustomResource(nil, r, *v)...)
-
-			re = append(re, validateUnknownFields(r.UnstructuredContent(), s)...)
-			for _, e := range re {
-				rf++
-
-				if _, err := fmt.Fprintf(w, "[x] schema validation error %s, %s : %s\n", r.GroupVersionKind().String(), getResourceName(r), e.Error()); err != nil {
-					return errors.Wrap(err, errWriteOutput)
-				}
-			}
+			res.Errors = append(res.Errors, resultErrors(ErrorTypeSchema, validation.ValidateCustomResource(nil, r, *v))...)
+			res.Errors = append(res.Errors, resultErrors(ErrorTypeUnknownField, validateUnknownFields(r.UnstructuredContent(), s))...)
 
 			celValidator := cel.NewValidator(s, true, celconfig.PerCallLimit)
-
-			re, _ = celValidator.Validate(ctx, nil, s, r.Object, nil, celconfig.PerCallLimit)
-			for _, e := range re {
-				rf++
-
-				if _, err := fmt.Fprintf(w, "[x] CEL validation error %s, %s : %s\n", r.GroupVersionKind().String(), getResourceName(r), e.Error()); err != nil {
-					return errors.Wrap(err, errWriteOutput)
-				}
-			}
-
-			if rf == 0 {
-				if !skipSuccessLogs {
-					if _, err := fmt.Fprintf(w, "[✓] %s, %s validated successfully\n", r.GroupVersionKind().String(), getResourceName(r)); err != nil {
-						return errors.Wrap(err, errWriteOutput)
-					}
-				}
-			} else {
-				failure++
-			}
+			re, _ := celValidator.Validate(ctx, nil, s, r.Object, nil, celconfig.PerCallLimit)
+			res.Errors = append(res.Errors, resultErrors(ErrorTypeCEL, re)...)
 		}
-	}
 
-	if _, err := fmt.Fprintf(w, "Total %d resources: %d missing schemas, %d success cases, %d failure cases\n", len(resources), missingSchemas, len(resources)-failure-missingSchemas, failure); err != nil {
-		return errors.Wrap(err, errWriteOutput)
+		results = append(results, res)
 	}
 
-	if failure > 0 {
-		return errors.New("could not validate all resources")
-	}
+	return results, nil
+}
 
-	if errorOnMissingSchemas && missingSchemas > 0 {
-		return errors.New("could not validate all resources, schema(s) missing")
+func resultErrors(t ErrorType, errs field.ErrorList) []ResultError {
+	out := make([]ResultError, 0, len(errs))
+	for _, e := range errs {
+		out = append(out, ResultError{Type: t, Field: e.Field, Message: e.ErrorBody()})
 	}
 
-	return nil
+	return out
 }
 
 func getResourceName(r *unstructured.Unstructured) string {
@@ -32,6 +32,8 @@ import (
 
 	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/test"
+
+	"github.com/crossplane/crossplane/v2/cmd/crank/common/load"
 )
 
 var (
@@ -1710,3 +1712,114 @@ func TestApplyDefaults(t *testing.T) {
 		})
 	}
 }
+
+func TestValidate(t *testing.T) {
+	newTest := func(spec map[string]interface{}) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "test.org/v1alpha1",
+			"kind":       "Test",
+			"metadata":   map[string]interface{}{"name": "test"},
+			"spec":       spec,
+		}}
+	}
+
+	type args struct {
+		manifests []load.Manifest
+		crds      []*extv1.CustomResourceDefinition
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   []Result
+	}{
+		"Valid": {
+			reason: "A valid resource should have a result without errors that records where it was loaded from.",
+			args: args{
+				manifests: []load.Manifest{{Resource: newTest(map[string]interface{}{"replicas": 1}), File: "test.yaml", Line: 3}},
+				crds:      []*extv1.CustomResourceDefinition{testCRD},
+			},
+			want: []Result{{APIVersion: "test.org/v1alpha1", Kind: "Test", Name: "test", File: "test.yaml", Line: 3}},
+		},
+		"MissingSchema": {
+			reason: "A resource without a schema should have a single MissingSchema error.",
+			args: args{
+				manifests: []load.Manifest{{Resource: newTest(map[string]interface{}{"replicas": 1})}},
+			},
+			want: []Result{{
+				APIVersion: "test.org/v1alpha1",
+				Kind:       "Test",
+				Name:       "test",
+				Errors:     []ResultError{{Type: ErrorTypeMissingSchema, Message: "could not find CRD/XRD for: test.org/v1alpha1, Kind=Test"}},
+			}},
+		},
+		"SchemaAndUnknownFieldErrors": {
+			reason: "Schema errors and unknown fields should be reported with their field paths.",
+			args: args{
+				manifests: []load.Manifest{{Resource: newTest(map[string]interface{}{"replicas": "1", "unknown": true})}},
+				crds:      []*extv1.CustomResourceDefinition{testCRD},
+			},
+			want: []Result{{
+				APIVersion: "test.org/v1alpha1",
+				Kind:       "Test",
+				Name:       "test",
+				Errors: []ResultError{
+					{Type: ErrorTypeSchema, Field: "spec.replicas", Message: `Invalid value: "string": spec.replicas in body must be of type integer: "string"`},
+					{Type: ErrorTypeUnknownField, Field: "spec.unknown", Message: `Invalid value: "unknown": unknown field: "unknown"`},
+				},
+			}},
+		},
+		"FunctionInputMissingSchema": {
+			reason: "A function input without a schema should record where it was extracted from.",
+			args: args{
+				manifests: []load.Manifest{{
+					Resource:  &unstructured.Unstructured{Object: map[string]interface{}{"apiVersion": "pt.fn.crossplane.io/v1beta1", "kind": "Resources"}},
+					File:      "composition.yaml",
+					Line:      1,
+					Owner:     newTest(nil),
+					FieldPath: "spec.pipeline[0].input",
+				}},
+			},
+			want: []Result{{
+				APIVersion: "pt.fn.crossplane.io/v1beta1",
+				Kind:       "Resources",
+				File:       "composition.yaml",
+				Line:       1,
+				Owner:      "Test/test",
+				FieldPath:  "spec.pipeline[0].input",
+				Errors: []ResultError{{
+					Type:    ErrorTypeMissingSchema,
+					Message: "could not find CRD/XRD for: pt.fn.crossplane.io/v1beta1, Kind=Resources (include the Function's package in the extensions to validate its input)",
+				}},
+			}},
+		},
+		"CELError": {
+			reason: "CEL validation errors should be reported as CEL errors.",
+			args: args{
+				manifests: []load.Manifest{{Resource: newTest(map[string]interface{}{"replicas": 5, "minReplicas": 1, "maxReplicas": 3})}},
+				crds:      []*extv1.CustomResourceDefinition{testCRDWithCEL},
+			},
+			want: []Result{{
+				APIVersion: "test.org/v1alpha1",
+				Kind:       "Test",
+				Name:       "test",
+				Errors: []ResultError{
+					{Type: ErrorTypeCEL, Field: "spec", Message: `Invalid value: "object": replicas should be in between minReplicas and maxReplicas`},
+				},
+			}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := Validate(context.Background(), tc.args.manifests, tc.args.crds)
+			if err != nil {
+				t.Fatalf("Validate(...): unexpected error: %v", err)
+			}
+
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("%s\nValidate(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
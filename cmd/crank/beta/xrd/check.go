@@ -0,0 +1,229 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package xrd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/alecthomas/kong"
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	un "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/logging"
+
+	"github.com/crossplane/crossplane/v2/cmd/crank/common/crd"
+	"github.com/crossplane/crossplane/v2/cmd/crank/common/load"
+	"github.com/crossplane/crossplane/v2/internal/xcrd"
+)
+
+const (
+	errFmtLoad       = "cannot load %s"
+	errFmtConvert    = "cannot convert %s to CRDs"
+	errWriteOutput   = "cannot write output"
+	errBreakingFound = "found breaking schema changes"
+)
+
+// A Change to a CRD, or to the CRD an XRD defines.
+type Change struct {
+	// Name of the CRD that changed.
+	Name string `json:"name"`
+
+	xcrd.VersionChange
+
+	// Allowed is true if the new XRD or CRD allows breaking changes.
+	Allowed bool `json:"allowed,omitempty"`
+}
+
+// checkCmd checks XRDs for breaking schema changes.
+type checkCmd struct {
+	// Arguments.
+	Old string `arg:"" help:"The old XRDs or CRDs. A file, a directory, a comma-separated list of both, or '-' for stdin." predictor:"yaml_file_or_directory"`
+	New string `arg:"" help:"The new XRDs or CRDs. A file, a directory, a comma-separated list of both, or '-' for stdin." predictor:"yaml_file_or_directory"`
+
+	// Flags. Keep them in alphabetical order.
+	Output string `default:"text" enum:"text,json" help:"Output format. One of: text, json." short:"o"`
+}
+
+// Help prints out the help for the check command.
+func (c *checkCmd) Help() string {
+	return `
+This command compares the old and new revisions of XRDs and CRDs, and reports
+changes to the schemas of the versions they serve. It performs the same check
+Crossplane performs before it updates the CRD an XRD defines, so it can catch
+breaking changes in CI before they're applied.
+
+XRDs and CRDs are matched by name. Removing a spec field, changing its type,
+making it required, narrowing its enum, tightening its validation, and no
+longer serving a version are breaking changes. They invalidate existing
+resources. Changes to status and to the spec fields Crossplane adds to every
+composite resource aren't reported.
+
+Crossplane warns about breaking changes. When started with
+--enable-breaking-change-rejection it refuses to apply them unless the new XRD
+has the annotation:
+
+  apiextensions.crossplane.io/allow-breaking-changes: "true"
+
+The command returns a non zero exit code if it finds any breaking change that
+isn't allowed by this annotation.
+
+Examples:
+
+  # Check an XRD against the version on the main branch.
+  git show main:apis/xrd.yaml > /tmp/xrd.yaml
+  crossplane beta xrd check /tmp/xrd.yaml apis/xrd.yaml
+
+  # Check a directory of XRDs, and output the changes as JSON.
+  crossplane beta xrd check old/apis/ apis/ -o json
+`
+}
+
+// Run the check command.
+func (c *checkCmd) Run(k *kong.Context, _ logging.Logger) error {
+	o, err := loadCRDs(c.Old)
+	if err != nil {
+		return err
+	}
+
+	n, err := loadCRDs(c.New)
+	if err != nil {
+		return err
+	}
+
+	changes := Compare(o, n)
+
+	if err := writeChanges(k.Stdout, c.Output, changes); err != nil {
+		return errors.Wrap(err, errWriteOutput)
+	}
+
+	for _, ch := range changes {
+		if ch.Breaking && !ch.Allowed {
+			return errors.New(errBreakingFound)
+		}
+	}
+
+	return nil
+}
+
+// CRDs are CRDs keyed by name, and the names of CRDs that allow breaking
+// changes.
+type CRDs struct {
+	CRDs    map[string]*extv1.CustomResourceDefinition
+	Allowed sets.Set[string]
+}
+
+func loadCRDs(input string) (*CRDs, error) {
+	l, err := load.NewLoader(input)
+	if err != nil {
+		return nil, errors.Wrapf(err, errFmtLoad, input)
+	}
+
+	us, err := l.Load()
+	if err != nil {
+		return nil, errors.Wrapf(err, errFmtLoad, input)
+	}
+
+	return NewCRDs(us)
+}
+
+// NewCRDs converts the supplied XRDs and CRDs to CRDs. Other objects are
+// ignored.
+func NewCRDs(us []*un.Unstructured) (*CRDs, error) {
+	out := &CRDs{CRDs: map[string]*extv1.CustomResourceDefinition{}, Allowed: sets.New[string]()}
+
+	for _, u := range us {
+		crds, err := crd.ConvertToCRDs([]*un.Unstructured{u})
+		if err != nil {
+			return nil, errors.Wrapf(err, errFmtConvert, u.GetName())
+		}
+
+		for _, c := range crds {
+			out.CRDs[c.GetName()] = c
+
+			if xcrd.AllowsBreakingChanges(u) {
+				out.Allowed.Insert(c.GetName())
+			}
+		}
+	}
+
+	return out, nil
+}
+
+// Compare returns the changes between the supplied old and new CRDs. CRDs
+// that were removed or added aren't changes.
+func Compare(o, n *CRDs) []Change {
+	var changes []Change
+
+	for _, name := range sets.List(sets.KeySet(o.CRDs)) {
+		nc, ok := n.CRDs[name]
+		if !ok {
+			continue
+		}
+
+		for _, vc := range xcrd.CompareCRDs(o.CRDs[name], nc) {
+			changes = append(changes, Change{Name: name, VersionChange: vc, Allowed: vc.Breaking && n.Allowed.Has(name)})
+		}
+	}
+
+	return changes
+}
+
+func writeChanges(w io.Writer, format string, changes []Change) error {
+	if format == "json" {
+		if changes == nil {
+			changes = []Change{}
+		}
+
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+
+		return enc.Encode(struct {
+			Changes []Change `json:"changes"`
+		}{Changes: changes})
+	}
+
+	breaking := 0
+
+	for _, c := range changes {
+		line := fmt.Sprintf("%s %s", c.Name, c.Version)
+		if c.Path != "" {
+			line += " " + c.Path
+		}
+
+		line += ": " + c.Message
+
+		switch {
+		case c.Breaking && c.Allowed:
+			line += " (breaking, allowed)"
+		case c.Breaking:
+			breaking++
+			line += " (breaking)"
+		}
+
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+
+	_, err := fmt.Fprintf(w, "%d changes, %d breaking\n", len(changes), breaking)
+
+	return err
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package xrd
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	un "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+
+	"github.com/crossplane/crossplane/v2/internal/xcrd"
+)
+
+func xrd(t *testing.T, annotations map[string]any, props map[string]any) *un.Unstructured {
+	t.Helper()
+
+	u := &un.Unstructured{}
+	if err := yaml.Unmarshal([]byte(`
+apiVersion: apiextensions.crossplane.io/v2
+kind: CompositeResourceDefinition
+metadata:
+  name: xdatabases.example.org
+spec:
+  group: example.org
+  scope: Namespaced
+  names:
+    kind: XDatabase
+    plural: xdatabases
+  versions:
+  - name: v1
+    served: true
+    referenceable: true
+    schema:
+      openAPIV3Schema:
+        type: object
+        properties:
+          spec:
+            type: object
+`), &u.Object); err != nil {
+		t.Fatal(err)
+	}
+
+	if annotations != nil {
+		_ = un.SetNestedMap(u.Object, annotations, "metadata", "annotations")
+	}
+
+	vs, _, _ := un.NestedSlice(u.Object, "spec", "versions")
+	_ = un.SetNestedMap(vs[0].(map[string]any), props, "schema", "openAPIV3Schema", "properties", "spec", "properties")
+	_ = un.SetNestedSlice(u.Object, vs, "spec", "versions")
+
+	return u
+}
+
+func TestCompare(t *testing.T) {
+	size := map[string]any{"size": map[string]any{"type": "integer"}}
+	region := map[string]any{"region": map[string]any{"type": "string"}}
+	allow := map[string]any{xcrd.AnnotationKeyAllowBreakingChanges: "true"}
+
+	type args struct {
+		old []*un.Unstructured
+		new []*un.Unstructured
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   []Change
+	}{
+		"Unchanged": {
+			reason: "An unchanged XRD should have no changes.",
+			args: args{
+				old: []*un.Unstructured{xrd(t, nil, size)},
+				new: []*un.Unstructured{xrd(t, nil, size)},
+			},
+			want: nil,
+		},
+		"Breaking": {
+			reason: "Removing a field from an XRD is a breaking change.",
+			args: args{
+				old: []*un.Unstructured{xrd(t, nil, size)},
+				new: []*un.Unstructured{xrd(t, nil, region)},
+			},
+			want: []Change{
+				{Name: "xdatabases.example.org", VersionChange: xcrd.VersionChange{Version: "v1", SchemaChange: xcrd.SchemaChange{Path: "spec.region", Message: "field added"}}},
+				{Name: "xdatabases.example.org", VersionChange: xcrd.VersionChange{Version: "v1", SchemaChange: xcrd.SchemaChange{Path: "spec.size", Message: "field removed", Breaking: true}}},
+			},
+		},
+		"BreakingAllowed": {
+			reason: "Breaking changes should be allowed if the new XRD is annotated to allow them.",
+			args: args{
+				old: []*un.Unstructured{xrd(t, nil, size)},
+				new: []*un.Unstructured{xrd(t, allow, map[string]any{})},
+			},
+			want: []Change{
+				{Name: "xdatabases.example.org", VersionChange: xcrd.VersionChange{Version: "v1", SchemaChange: xcrd.SchemaChange{Path: "spec.size", Message: "field removed", Breaking: true}}, Allowed: true},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			o, err := NewCRDs(tc.args.old)
+			if err != nil {
+				t.Fatalf("NewCRDs(...): %v", err)
+			}
+
+			n, err := NewCRDs(tc.args.new)
+			if err != nil {
+				t.Fatalf("NewCRDs(...): %v", err)
+			}
+
+			got := Compare(o, n)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nCompare(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
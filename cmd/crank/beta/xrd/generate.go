@@ -0,0 +1,376 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package xrd
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/alecthomas/kong"
+	"github.com/gobuffalo/flect"
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	structuralschema "k8s.io/apiextensions-apiserver/pkg/apiserver/schema"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	un "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/yaml"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/logging"
+
+	v1 "github.com/crossplane/crossplane/v2/apis/apiextensions/v1"
+	v2 "github.com/crossplane/crossplane/v2/apis/apiextensions/v2"
+	"github.com/crossplane/crossplane/v2/cmd/crank/common/crd"
+	"github.com/crossplane/crossplane/v2/cmd/crank/common/load"
+	"github.com/crossplane/crossplane/v2/internal/xcrd"
+)
+
+const (
+	// maxPrinterColumns is the most spec fields that are printed as columns.
+	maxPrinterColumns = 4
+
+	errFmtMismatchedExamples = "example %q is a %s, not a %s"
+	errMissingGVK            = "cannot determine the composite resource's group, version, and kind: set --group, --version, and --kind"
+	errMarshalSchema         = "cannot marshal schema"
+	errMarshalXRD            = "cannot marshal XRD"
+	errDeriveCRD             = "cannot derive CRD from generated XRD"
+	errFmtNotStructural      = "generated schema for version %s isn't structural"
+	errFmtConvertSchema      = "cannot convert generated schema for version %s"
+)
+
+// generateCmd generates an XRD from example XRs or Go types.
+type generateCmd struct {
+	// Arguments.
+	Input string `arg:"" help:"Example XRs, or Go source when --type is set. A file, a directory, a comma-separated list of both, or '-' for stdin." predictor:"yaml_file_or_directory"`
+
+	// Flags. Keep them in alphabetical order.
+	Group   string `help:"The API group of the XR. Derived from the example XRs by default."`
+	Kind    string `help:"The kind of the XR. Derived from the example XRs, or the Go type, by default."`
+	Plural  string `help:"The plural name of the XR. Derived from its kind by default."`
+	Scope   string `default:"Namespaced"                                                               enum:"Namespaced,Cluster" help:"The scope of the XR. One of: Namespaced, Cluster."`
+	Type    string `help:"Generate the schema from this Go struct type, declared in the input source."`
+	Version string `help:"The API version of the XR. Derived from the example XRs by default."`
+}
+
+// Help prints out the help for the generate command.
+func (c *generateCmd) Help() string {
+	return `
+This command generates a CompositeResourceDefinition (XRD) from example
+composite resources (XRs), or from Go struct types. It writes the XRD to
+stdout.
+
+When generating from example XRs, the schema of each field is inferred from
+the values in the examples. The more examples you supply, the better the
+inferred schema:
+
+  - A field is required if it's set in every example that sets its parent.
+    Nothing is required if there's only one example.
+  - A string field is an enum if it has at most 5 distinct values, and at
+    least one value appears more than once.
+  - A field that's an integer in some examples and a string in others is an
+    int-or-string.
+
+When generating from Go, the XRD's schema is generated from the struct type
+named by --type. Its spec and status fields are used if it has them. Otherwise
+it's used as the spec. Like controller-gen, fields are required unless they're
+omitempty or have an +optional marker. The +kubebuilder:default marker and
+most +kubebuilder:validation markers are supported.
+
+The top-level scalar spec fields are added as printer columns. The generated
+XRD is checked to make sure it produces a structural schema.
+
+Examples:
+
+  # Generate an XRD from example XRs.
+  crossplane beta xrd generate examples/ > apis/xrd.yaml
+
+  # Generate an XRD from the App Go type.
+  crossplane beta xrd generate apis/v1alpha1/ --type=App \
+    --group=platform.example.org --version=v1alpha1
+`
+}
+
+// Run the generate command.
+func (c *generateCmd) Run(k *kong.Context, _ logging.Logger) error {
+	gvk, s, err := c.schema()
+	if err != nil {
+		return err
+	}
+
+	if c.Group != "" {
+		gvk.Group = c.Group
+	}
+
+	if c.Version != "" {
+		gvk.Version = c.Version
+	}
+
+	if c.Kind != "" {
+		gvk.Kind = c.Kind
+	}
+
+	if gvk.Group == "" || gvk.Version == "" || gvk.Kind == "" {
+		return errors.New(errMissingGVK)
+	}
+
+	xrd, err := NewXRD(gvk, c.Plural, v2.CompositeResourceScope(c.Scope), s)
+	if err != nil {
+		return err
+	}
+
+	if err := Validate(xrd); err != nil {
+		return err
+	}
+
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(xrd)
+	if err != nil {
+		return errors.Wrap(err, errMarshalXRD)
+	}
+
+	// The XRD's status isn't meaningful until Crossplane reconciles it.
+	delete(u, "status")
+
+	b, err := yaml.Marshal(u)
+	if err != nil {
+		return errors.Wrap(err, errMarshalXRD)
+	}
+
+	_, err = k.Stdout.Write(b)
+
+	return errors.Wrap(err, errWriteOutput)
+}
+
+func (c *generateCmd) schema() (schema.GroupVersionKind, *extv1.JSONSchemaProps, error) {
+	if c.Type != "" {
+		t, err := LoadGoTypes(c.Input)
+		if err != nil {
+			return schema.GroupVersionKind{}, nil, err
+		}
+
+		s, err := t.Schema(c.Type)
+		if err != nil {
+			return schema.GroupVersionKind{}, nil, err
+		}
+
+		return schema.GroupVersionKind{Kind: c.Type}, XRSchema(s), nil
+	}
+
+	l, err := load.NewLoader(c.Input)
+	if err != nil {
+		return schema.GroupVersionKind{}, nil, errors.Wrapf(err, errFmtLoad, c.Input)
+	}
+
+	examples, err := l.Load()
+	if err != nil {
+		return schema.GroupVersionKind{}, nil, errors.Wrapf(err, errFmtLoad, c.Input)
+	}
+
+	if len(examples) == 0 {
+		return schema.GroupVersionKind{}, nil, errors.New(errNoExamples)
+	}
+
+	gvk := examples[0].GroupVersionKind()
+	for _, xr := range examples {
+		if xr.GroupVersionKind() != gvk {
+			return schema.GroupVersionKind{}, nil, errors.Errorf(errFmtMismatchedExamples, xr.GetName(), xr.GroupVersionKind(), gvk)
+		}
+	}
+
+	s, err := InferSchema(examples)
+
+	return gvk, s, err
+}
+
+// XRSchema returns the schema of an XR's spec and status from the supplied
+// schema. If the schema has no spec property it's treated as the schema of the
+// spec.
+func XRSchema(s *extv1.JSONSchemaProps) *extv1.JSONSchemaProps {
+	if _, ok := s.Properties["spec"]; !ok {
+		return &extv1.JSONSchemaProps{
+			Type:        typeObject,
+			Description: s.Description,
+			Properties:  map[string]extv1.JSONSchemaProps{"spec": *s},
+			Required:    []string{"spec"},
+		}
+	}
+
+	out := &extv1.JSONSchemaProps{Type: typeObject, Description: s.Description, Properties: map[string]extv1.JSONSchemaProps{}}
+
+	for _, k := range []string{"spec", "status"} {
+		if p, ok := s.Properties[k]; ok {
+			out.Properties[k] = p
+		}
+	}
+
+	return out
+}
+
+// NewXRD returns a v2 XRD that defines an XR of the supplied kind and schema.
+// Fields Crossplane adds to every XR are removed from the schema, and the
+// top-level scalar fields of the XR's spec are added as printer columns.
+func NewXRD(gvk schema.GroupVersionKind, plural string, scope v2.CompositeResourceScope, s *extv1.JSONSchemaProps) (*v2.CompositeResourceDefinition, error) {
+	if plural == "" {
+		plural = flect.Pluralize(strings.ToLower(gvk.Kind))
+	}
+
+	omitCrossplaneFields(s, "spec", xcrd.CompositeResourceSpecProps(v1.CompositeResourceScope(scope), nil))
+	omitCrossplaneFields(s, "status", xcrd.CompositeResourceStatusProps(v1.CompositeResourceScope(scope)))
+
+	raw, err := json.Marshal(s)
+	if err != nil {
+		return nil, errors.Wrap(err, errMarshalSchema)
+	}
+
+	xrd := &v2.CompositeResourceDefinition{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: v2.SchemeGroupVersion.String(),
+			Kind:       v2.CompositeResourceDefinitionKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{Name: plural + "." + gvk.Group},
+		Spec: v2.CompositeResourceDefinitionSpec{
+			Scope: scope,
+			Group: gvk.Group,
+			Names: extv1.CustomResourceDefinitionNames{
+				Kind:   gvk.Kind,
+				Plural: plural,
+			},
+			Versions: []v2.CompositeResourceDefinitionVersion{{
+				Name:                     gvk.Version,
+				Served:                   true,
+				Referenceable:            true,
+				Schema:                   &v2.CompositeResourceValidation{OpenAPIV3Schema: runtime.RawExtension{Raw: raw}},
+				AdditionalPrinterColumns: PrinterColumns(s.Properties["spec"]),
+			}},
+		},
+	}
+
+	return xrd, nil
+}
+
+// PrinterColumns returns printer columns for the top-level scalar fields of
+// the supplied spec schema, in alphabetical order.
+func PrinterColumns(spec extv1.JSONSchemaProps) []extv1.CustomResourceColumnDefinition {
+	names := make([]string, 0, len(spec.Properties))
+	for k := range spec.Properties {
+		names = append(names, k)
+	}
+
+	sort.Strings(names)
+
+	var cols []extv1.CustomResourceColumnDefinition
+
+	for _, k := range names {
+		p := spec.Properties[k]
+
+		t := p.Type
+		if p.XIntOrString {
+			t = typeString
+		}
+
+		switch t {
+		case typeString, typeInteger, typeNumber, typeBoolean:
+		default:
+			continue
+		}
+
+		cols = append(cols, extv1.CustomResourceColumnDefinition{
+			Name:     strings.ToUpper(k),
+			Type:     t,
+			JSONPath: fmt.Sprintf(".spec.%s", k),
+		})
+
+		if len(cols) == maxPrinterColumns {
+			break
+		}
+	}
+
+	return cols
+}
+
+// omitCrossplaneFields removes the supplied Crossplane fields from the named
+// property of the supplied schema.
+func omitCrossplaneFields(s *extv1.JSONSchemaProps, name string, fields map[string]extv1.JSONSchemaProps) {
+	p, ok := s.Properties[name]
+	if !ok {
+		return
+	}
+
+	required := make([]string, 0, len(p.Required))
+
+	for _, r := range p.Required {
+		if _, ok := fields[r]; !ok {
+			required = append(required, r)
+		}
+	}
+
+	for k := range fields {
+		delete(p.Properties, k)
+	}
+
+	p.Required = required
+	if len(p.Required) == 0 {
+		p.Required = nil
+	}
+
+	s.Properties[name] = p
+}
+
+// Validate returns an error if the supplied XRD doesn't produce a valid CRD
+// with a structural schema.
+func Validate(xrd *v2.CompositeResourceDefinition) error {
+	u := &un.Unstructured{}
+
+	b, err := json.Marshal(xrd)
+	if err != nil {
+		return errors.Wrap(err, errMarshalXRD)
+	}
+
+	if err := u.UnmarshalJSON(b); err != nil {
+		return errors.Wrap(err, errMarshalXRD)
+	}
+
+	crds, err := crd.ConvertToCRDs([]*un.Unstructured{u})
+	if err != nil {
+		return errors.Wrap(err, errDeriveCRD)
+	}
+
+	for _, c := range crds {
+		for i, v := range c.Spec.Versions {
+			in := &apiextensions.JSONSchemaProps{}
+			if err := extv1.Convert_v1_JSONSchemaProps_To_apiextensions_JSONSchemaProps(v.Schema.OpenAPIV3Schema, in, nil); err != nil {
+				return errors.Wrapf(err, errFmtConvertSchema, v.Name)
+			}
+
+			ss, err := structuralschema.NewStructural(in)
+			if err != nil {
+				return errors.Wrapf(err, errFmtNotStructural, v.Name)
+			}
+
+			fp := field.NewPath("spec", "versions").Index(i).Child("schema", "openAPIV3Schema")
+			if errs := structuralschema.ValidateStructural(fp, ss); len(errs) > 0 {
+				return errors.Wrapf(errs.ToAggregate(), errFmtNotStructural, v.Name)
+			}
+		}
+	}
+
+	return nil
+}
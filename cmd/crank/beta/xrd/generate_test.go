@@ -0,0 +1,436 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package xrd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	un "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/yaml"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/test"
+
+	v2 "github.com/crossplane/crossplane/v2/apis/apiextensions/v2"
+)
+
+func examples(t *testing.T, ys ...string) []*un.Unstructured {
+	t.Helper()
+
+	out := make([]*un.Unstructured, 0, len(ys))
+
+	for _, y := range ys {
+		j, err := yaml.YAMLToJSON([]byte("apiVersion: example.org/v1\nkind: App\n" + y))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Unmarshal the same way the loader does, so integers are int64.
+		u := &un.Unstructured{Object: map[string]any{}}
+		if err := u.UnmarshalJSON(j); err != nil {
+			t.Fatal(err)
+		}
+
+		out = append(out, u)
+	}
+
+	return out
+}
+
+func TestInferSchema(t *testing.T) {
+	type want struct {
+		s   *extv1.JSONSchemaProps
+		err error
+	}
+
+	cases := map[string]struct {
+		reason   string
+		examples []string
+		want     want
+	}{
+		"NoExamples": {
+			reason: "We should return an error if there are no examples.",
+			want: want{
+				err: errors.New(errNoExamples),
+			},
+		},
+		"SingleExample": {
+			reason: "We should infer types, but no required fields or enums, from a single example.",
+			examples: []string{`
+spec:
+  region: us-east-1
+  size: 3
+  tags: []
+  labels: {}
+`},
+			want: want{
+				s: &extv1.JSONSchemaProps{
+					Type: "object",
+					Properties: map[string]extv1.JSONSchemaProps{
+						"spec": {
+							Type: "object",
+							Properties: map[string]extv1.JSONSchemaProps{
+								"region": {Type: "string"},
+								"size":   {Type: "integer"},
+								"tags": {
+									Type:  "array",
+									Items: &extv1.JSONSchemaPropsOrArray{Schema: &extv1.JSONSchemaProps{XPreserveUnknownFields: ptr.To(true)}},
+								},
+								"labels": {Type: "object", XPreserveUnknownFields: ptr.To(true)},
+							},
+						},
+					},
+				},
+			},
+		},
+		"MultipleExamples": {
+			reason: "We should infer required fields, enums, numbers, and int-or-strings from multiple examples.",
+			examples: []string{`
+spec:
+  region: us-east-1
+  cpu: 1
+  port: 80
+status:
+  url: http://a
+`, `
+spec:
+  region: us-east-1
+  cpu: 0.5
+  port: http
+`, `
+spec:
+  region: eu-west-1
+`},
+			want: want{
+				s: &extv1.JSONSchemaProps{
+					Type: "object",
+					Properties: map[string]extv1.JSONSchemaProps{
+						"spec": {
+							Type: "object",
+							Properties: map[string]extv1.JSONSchemaProps{
+								"region": {
+									Type: "string",
+									Enum: []extv1.JSON{{Raw: []byte(`"eu-west-1"`)}, {Raw: []byte(`"us-east-1"`)}},
+								},
+								"cpu":  {Type: "number"},
+								"port": {XIntOrString: true},
+							},
+							Required: []string{"region"},
+						},
+						"status": {
+							Type: "object",
+							Properties: map[string]extv1.JSONSchemaProps{
+								"url": {Type: "string"},
+							},
+						},
+					},
+				},
+			},
+		},
+		"ConflictingTypes": {
+			reason: "We should return an error if a field has conflicting types.",
+			examples: []string{`
+spec:
+  size: 3
+`, `
+spec:
+  size: true
+`},
+			want: want{
+				err: errors.Errorf(errFmtConflictingTypes, "spec.size", []string{"boolean", "integer"}),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s, err := InferSchema(examples(t, tc.examples...))
+			if diff := cmp.Diff(tc.want.s, s); diff != "" {
+				t.Errorf("\n%s\nInferSchema(...): -want, +got:\n%s", tc.reason, diff)
+			}
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nInferSchema(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestGoTypesSchema(t *testing.T) {
+	type want struct {
+		s   *extv1.JSONSchemaProps
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		src    string
+		typ    string
+		want   want
+	}{
+		"UnknownType": {
+			reason: "We should return an error if the type doesn't exist.",
+			src:    `package v1`,
+			typ:    "App",
+			want: want{
+				err: errors.Errorf(errFmtUnknownType, "App"),
+			},
+		},
+		"RecursiveType": {
+			reason: "We should return an error if the type is recursive.",
+			src: `package v1
+type Node struct {
+	Children []Node ` + "`json:\"children\"`" + `
+}`,
+			typ: "Node",
+			want: want{
+				err: errors.Errorf(errFmtRecursiveType, "Node.children[*]", "Node"),
+			},
+		},
+		"UnsupportedType": {
+			reason: "We should return an error if a field's type isn't supported.",
+			src: `package v1
+import corev1 "k8s.io/api/core/v1"
+type App struct {
+	Secret corev1.Secret ` + "`json:\"secret\"`" + `
+}`,
+			typ: "App",
+			want: want{
+				err: errors.Errorf(errFmtUnsupportedType, "App.secret", "corev1.Secret"),
+			},
+		},
+		"Struct": {
+			reason: "We should generate a schema that honors json tags, comments, and markers.",
+			src: `package v1
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+// An App is a web application.
+type App struct {
+	metav1.TypeMeta   ` + "`json:\",inline\"`" + `
+	metav1.ObjectMeta ` + "`json:\"metadata,omitempty\"`" + `
+
+	Common ` + "`json:\",inline\"`" + `
+
+	// Replicas of the app.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=1
+	// +optional
+	Replicas *int32 ` + "`json:\"replicas\"`" + `
+
+	Region Region ` + "`json:\"region\"`" + `
+
+	Env map[string]string ` + "`json:\"env,omitempty\"`" + `
+
+	Data []byte ` + "`json:\"data,omitempty\"`" + `
+
+	Ignored string ` + "`json:\"-\"`" + `
+
+	unexported string
+}
+
+type Common struct {
+	Image string
+}
+
+// A Region to deploy to.
+// +kubebuilder:validation:Enum=us-east-1;eu-west-1
+type Region string
+`,
+			typ: "App",
+			want: want{
+				s: &extv1.JSONSchemaProps{
+					Type:        "object",
+					Description: "An App is a web application.",
+					Properties: map[string]extv1.JSONSchemaProps{
+						"Image": {Type: "string"},
+						"replicas": {
+							Type:        "integer",
+							Format:      "int32",
+							Description: "Replicas of the app.",
+							Minimum:     ptr.To[float64](1),
+							Default:     &extv1.JSON{Raw: []byte("1")},
+						},
+						"region": {
+							Type:        "string",
+							Description: "A Region to deploy to.",
+							Enum:        []extv1.JSON{{Raw: []byte(`"us-east-1"`)}, {Raw: []byte(`"eu-west-1"`)}},
+						},
+						"env": {
+							Type:                 "object",
+							AdditionalProperties: &extv1.JSONSchemaPropsOrBool{Allows: true, Schema: &extv1.JSONSchemaProps{Type: "string"}},
+						},
+						"data": {Type: "string", Format: "byte"},
+					},
+					Required: []string{"Image", "region"},
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "types.go")
+			if err := os.WriteFile(path, []byte(tc.src), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			types, err := LoadGoTypes(path)
+			if err != nil {
+				t.Fatalf("LoadGoTypes(...): %v", err)
+			}
+
+			s, err := types.Schema(tc.typ)
+			if diff := cmp.Diff(tc.want.s, s); diff != "" {
+				t.Errorf("\n%s\nSchema(...): -want, +got:\n%s", tc.reason, diff)
+			}
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nSchema(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestNewXRD(t *testing.T) {
+	gvk := schema.GroupVersionKind{Group: "example.org", Version: "v1", Kind: "App"}
+
+	s := &extv1.JSONSchemaProps{
+		Type: "object",
+		Properties: map[string]extv1.JSONSchemaProps{
+			"spec": {
+				Type: "object",
+				Properties: map[string]extv1.JSONSchemaProps{
+					"image":      {Type: "string"},
+					"port":       {XIntOrString: true},
+					"tags":       {Type: "array", Items: &extv1.JSONSchemaPropsOrArray{Schema: &extv1.JSONSchemaProps{Type: "string"}}},
+					"crossplane": {Type: "object", XPreserveUnknownFields: ptr.To(true)},
+				},
+				Required: []string{"crossplane", "image"},
+			},
+			"status": {
+				Type: "object",
+				Properties: map[string]extv1.JSONSchemaProps{
+					"conditions": {Type: "array", Items: &extv1.JSONSchemaPropsOrArray{Schema: &extv1.JSONSchemaProps{XPreserveUnknownFields: ptr.To(true)}}},
+					"url":        {Type: "string"},
+				},
+			},
+		},
+	}
+
+	xrd, err := NewXRD(gvk, "", v2.CompositeResourceScopeNamespaced, s)
+	if err != nil {
+		t.Fatalf("NewXRD(...): %v", err)
+	}
+
+	if err := Validate(xrd); err != nil {
+		t.Errorf("Validate(...): generated XRD should be valid: %v", err)
+	}
+
+	if diff := cmp.Diff("apps.example.org", xrd.GetName()); diff != "" {
+		t.Errorf("NewXRD(...): -want name, +got name:\n%s", diff)
+	}
+
+	wantCols := []extv1.CustomResourceColumnDefinition{
+		{Name: "IMAGE", Type: "string", JSONPath: ".spec.image"},
+		{Name: "PORT", Type: "string", JSONPath: ".spec.port"},
+	}
+	if diff := cmp.Diff(wantCols, xrd.Spec.Versions[0].AdditionalPrinterColumns); diff != "" {
+		t.Errorf("NewXRD(...): -want printer columns, +got printer columns:\n%s", diff)
+	}
+
+	got := &extv1.JSONSchemaProps{}
+	if err := json.Unmarshal(xrd.Spec.Versions[0].Schema.OpenAPIV3Schema.Raw, got); err != nil {
+		t.Fatal(err)
+	}
+
+	want := &extv1.JSONSchemaProps{
+		Type: "object",
+		Properties: map[string]extv1.JSONSchemaProps{
+			"spec": {
+				Type: "object",
+				Properties: map[string]extv1.JSONSchemaProps{
+					"image": {Type: "string"},
+					"port":  {XIntOrString: true},
+					"tags":  {Type: "array", Items: &extv1.JSONSchemaPropsOrArray{Schema: &extv1.JSONSchemaProps{Type: "string"}}},
+				},
+				Required: []string{"image"},
+			},
+			"status": {
+				Type: "object",
+				Properties: map[string]extv1.JSONSchemaProps{
+					"url": {Type: "string"},
+				},
+			},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("NewXRD(...): Crossplane fields should be removed from the schema: -want, +got:\n%s", diff)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	gvk := schema.GroupVersionKind{Group: "example.org", Version: "v1", Kind: "App"}
+
+	cases := map[string]struct {
+		reason string
+		s      *extv1.JSONSchemaProps
+		valid  bool
+	}{
+		"Structural": {
+			reason: "A schema where every field has a type is structural.",
+			s: &extv1.JSONSchemaProps{
+				Type: "object",
+				Properties: map[string]extv1.JSONSchemaProps{
+					"spec": {Type: "object", Properties: map[string]extv1.JSONSchemaProps{"image": {Type: "string"}}},
+				},
+			},
+			valid: true,
+		},
+		"NotStructural": {
+			reason: "A schema where a field has no type isn't structural.",
+			s: &extv1.JSONSchemaProps{
+				Type: "object",
+				Properties: map[string]extv1.JSONSchemaProps{
+					"spec": {Type: "object", Properties: map[string]extv1.JSONSchemaProps{"image": {}}},
+				},
+			},
+			valid: false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			xrd, err := NewXRD(gvk, "", v2.CompositeResourceScopeNamespaced, tc.s)
+			if err != nil {
+				t.Fatalf("NewXRD(...): %v", err)
+			}
+
+			err = Validate(xrd)
+			if diff := cmp.Diff(tc.valid, err == nil); diff != "" {
+				t.Errorf("\n%s\nValidate(...): -want valid, +got valid:\n%s\nerror: %v", tc.reason, diff, err)
+			}
+		})
+	}
+}
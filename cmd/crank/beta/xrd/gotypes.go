@@ -0,0 +1,431 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package xrd
+
+import (
+	"encoding/json"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/ptr"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+)
+
+const (
+	errFmtParseGo         = "cannot parse Go source %s"
+	errFmtUnknownType     = "cannot find Go type %q"
+	errFmtUnsupportedType = "%s: unsupported Go type %s"
+	errFmtRecursiveType   = "%s: recursive type %s can't be represented by a structural schema"
+	errFmtInvalidMarker   = "%s: invalid marker +%s"
+)
+
+// Markers that control whether a field is required.
+const (
+	markerOptional   = "optional"
+	markerRequired   = "required"
+	markerValidation = "kubebuilder:validation:"
+)
+
+// GoTypes are the Go types declared by a package. They're used to generate
+// OpenAPI v3 schemas the way controller-gen does, honoring json struct tags
+// and a subset of kubebuilder validation markers.
+type GoTypes struct {
+	types map[string]*ast.TypeSpec
+	docs  map[string]*ast.CommentGroup
+
+	// visiting is used to detect recursive types.
+	visiting sets.Set[string]
+}
+
+// LoadGoTypes loads the Go types declared by the supplied file, or by the
+// non-test Go files in the supplied directory.
+func LoadGoTypes(path string) (*GoTypes, error) {
+	files := []string{path}
+
+	if fi, err := os.Stat(path); err == nil && fi.IsDir() {
+		files, err = filepath.Glob(filepath.Join(path, "*.go"))
+		if err != nil {
+			return nil, errors.Wrapf(err, errFmtParseGo, path)
+		}
+	}
+
+	t := &GoTypes{types: map[string]*ast.TypeSpec{}, docs: map[string]*ast.CommentGroup{}, visiting: sets.New[string]()}
+	fset := token.NewFileSet()
+
+	for _, name := range files {
+		if strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+
+		f, err := parser.ParseFile(fset, name, nil, parser.ParseComments)
+		if err != nil {
+			return nil, errors.Wrapf(err, errFmtParseGo, name)
+		}
+
+		for _, d := range f.Decls {
+			gd, ok := d.(*ast.GenDecl)
+			if !ok || gd.Tok != token.TYPE {
+				continue
+			}
+
+			for _, s := range gd.Specs {
+				ts := s.(*ast.TypeSpec) //nolint:forcetypeassert // Type declarations only contain type specs.
+				t.types[ts.Name.Name] = ts
+
+				// A lone type declaration's doc comment is attached to the
+				// declaration, not the spec.
+				doc := ts.Doc
+				if doc == nil && len(gd.Specs) == 1 {
+					doc = gd.Doc
+				}
+
+				t.docs[ts.Name.Name] = doc
+			}
+		}
+	}
+
+	return t, nil
+}
+
+// Schema returns the OpenAPI v3 schema of the named type.
+func (t *GoTypes) Schema(name string) (*extv1.JSONSchemaProps, error) {
+	if _, ok := t.types[name]; !ok {
+		return nil, errors.Errorf(errFmtUnknownType, name)
+	}
+
+	s, err := t.schema(ast.NewIdent(name), name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &s, nil
+}
+
+func (t *GoTypes) schema(expr ast.Expr, path string) (extv1.JSONSchemaProps, error) { //nolint:gocognit // Only slightly over.
+	switch e := expr.(type) {
+	case *ast.Ident:
+		if s, ok := basicSchema(e.Name); ok {
+			return s, nil
+		}
+
+		ts, ok := t.types[e.Name]
+		if !ok {
+			return extv1.JSONSchemaProps{}, errors.Errorf(errFmtUnsupportedType, path, e.Name)
+		}
+
+		if t.visiting.Has(e.Name) {
+			return extv1.JSONSchemaProps{}, errors.Errorf(errFmtRecursiveType, path, e.Name)
+		}
+
+		t.visiting.Insert(e.Name)
+		defer t.visiting.Delete(e.Name)
+
+		s, err := t.schema(ts.Type, path)
+		if err != nil {
+			return s, err
+		}
+
+		return s, applyComments(&s, t.docs[e.Name], path)
+	case *ast.StarExpr:
+		return t.schema(e.X, path)
+	case *ast.ArrayType:
+		if id, ok := e.Elt.(*ast.Ident); ok && id.Name == "byte" {
+			return extv1.JSONSchemaProps{Type: typeString, Format: "byte"}, nil
+		}
+
+		is, err := t.schema(e.Elt, path+"[*]")
+		if err != nil {
+			return is, err
+		}
+
+		return extv1.JSONSchemaProps{Type: typeArray, Items: &extv1.JSONSchemaPropsOrArray{Schema: &is}}, nil
+	case *ast.MapType:
+		vs, err := t.schema(e.Value, path+"[*]")
+		if err != nil {
+			return vs, err
+		}
+
+		return extv1.JSONSchemaProps{Type: typeObject, AdditionalProperties: &extv1.JSONSchemaPropsOrBool{Allows: true, Schema: &vs}}, nil
+	case *ast.InterfaceType:
+		return extv1.JSONSchemaProps{XPreserveUnknownFields: ptr.To(true)}, nil
+	case *ast.SelectorExpr:
+		if s, ok := wellKnownSchema(e.Sel.Name); ok {
+			return s, nil
+		}
+
+		return extv1.JSONSchemaProps{}, errors.Errorf(errFmtUnsupportedType, path, exprString(e))
+	case *ast.StructType:
+		return t.structSchema(e, path)
+	}
+
+	return extv1.JSONSchemaProps{}, errors.Errorf(errFmtUnsupportedType, path, exprString(expr))
+}
+
+func (t *GoTypes) structSchema(st *ast.StructType, path string) (extv1.JSONSchemaProps, error) { //nolint:gocognit // Mostly a loop over fields.
+	s := extv1.JSONSchemaProps{Type: typeObject, Properties: map[string]extv1.JSONSchemaProps{}}
+
+	for _, f := range st.Fields.List {
+		// Type and object metadata are part of every XR's schema.
+		if sel, ok := f.Type.(*ast.SelectorExpr); ok && (sel.Sel.Name == "TypeMeta" || sel.Sel.Name == "ObjectMeta") {
+			continue
+		}
+
+		tag := ""
+		if f.Tag != nil {
+			tag = reflect.StructTag(strings.Trim(f.Tag.Value, "`")).Get("json")
+		}
+
+		jsonName, opts, _ := strings.Cut(tag, ",")
+		if jsonName == "-" {
+			continue
+		}
+
+		omitempty := strings.Contains(opts, "omitempty")
+
+		// Embedded structs without a JSON name are inlined.
+		if len(f.Names) == 0 && jsonName == "" {
+			es, err := t.schema(f.Type, path)
+			if err != nil {
+				return s, err
+			}
+
+			for k, v := range es.Properties {
+				s.Properties[k] = v
+			}
+
+			s.Required = append(s.Required, es.Required...)
+
+			continue
+		}
+
+		names := make([]string, 0, len(f.Names))
+		for _, n := range f.Names {
+			if n.IsExported() {
+				names = append(names, n.Name)
+			}
+		}
+
+		if len(f.Names) == 0 {
+			names = append(names, jsonName)
+		}
+
+		for _, n := range names {
+			name := n
+			if jsonName != "" {
+				name = jsonName
+			}
+
+			fs, err := t.schema(f.Type, path+"."+name)
+			if err != nil {
+				return s, err
+			}
+
+			if err := applyComments(&fs, f.Doc, path+"."+name); err != nil {
+				return s, err
+			}
+
+			if required(f.Doc, omitempty) {
+				s.Required = append(s.Required, name)
+			}
+
+			s.Properties[name] = fs
+		}
+	}
+
+	sort.Strings(s.Required)
+
+	return s, nil
+}
+
+// required returns true if a field is required. Like controller-gen, fields
+// are required unless they're omitempty or marked optional.
+func required(doc *ast.CommentGroup, omitempty bool) bool {
+	for _, l := range commentLines(doc) {
+		switch strings.TrimPrefix(l, "+") {
+		case markerOptional, markerValidation + "Optional":
+			return false
+		case markerRequired, markerValidation + "Required":
+			return true
+		}
+	}
+
+	return !omitempty
+}
+
+// applyComments sets the supplied schema's description, and applies any
+// kubebuilder validation markers.
+func applyComments(s *extv1.JSONSchemaProps, doc *ast.CommentGroup, path string) error { //nolint:gocyclo // A switch over supported markers.
+	desc := make([]string, 0)
+
+	for _, l := range commentLines(doc) {
+		if !strings.HasPrefix(l, "+") {
+			desc = append(desc, l)
+			continue
+		}
+
+		m := strings.TrimPrefix(l, "+")
+		key, value, _ := strings.Cut(m, "=")
+
+		var err error
+
+		switch key {
+		case "kubebuilder:default":
+			s.Default = &extv1.JSON{Raw: jsonValue(value)}
+		case "kubebuilder:pruning:PreserveUnknownFields", "kubebuilder:validation:XPreserveUnknownFields":
+			s.XPreserveUnknownFields = ptr.To(true)
+		case markerValidation + "Enum":
+			s.Enum = nil
+			for _, v := range strings.Split(value, ";") {
+				s.Enum = append(s.Enum, extv1.JSON{Raw: jsonValue(v)})
+			}
+		case markerValidation + "Format":
+			s.Format = value
+		case markerValidation + "Pattern":
+			s.Pattern = value
+		case markerValidation + "Minimum":
+			s.Minimum, err = parseFloat(value)
+		case markerValidation + "Maximum":
+			s.Maximum, err = parseFloat(value)
+		case markerValidation + "MinLength":
+			s.MinLength, err = parseInt(value)
+		case markerValidation + "MaxLength":
+			s.MaxLength, err = parseInt(value)
+		case markerValidation + "MinItems":
+			s.MinItems, err = parseInt(value)
+		case markerValidation + "MaxItems":
+			s.MaxItems, err = parseInt(value)
+		case markerValidation + "MinProperties":
+			s.MinProperties, err = parseInt(value)
+		case markerValidation + "MaxProperties":
+			s.MaxProperties, err = parseInt(value)
+		}
+
+		if err != nil {
+			return errors.Wrapf(err, errFmtInvalidMarker, path, m)
+		}
+	}
+
+	if len(desc) > 0 {
+		s.Description = strings.Join(desc, " ")
+	}
+
+	return nil
+}
+
+// commentLines returns the non-empty, trimmed lines of a comment.
+func commentLines(doc *ast.CommentGroup) []string {
+	if doc == nil {
+		return nil
+	}
+
+	var out []string
+
+	for _, c := range doc.List {
+		l := strings.TrimSpace(strings.TrimPrefix(c.Text, "//"))
+		if l != "" {
+			out = append(out, l)
+		}
+	}
+
+	return out
+}
+
+// jsonValue returns the supplied marker value as JSON. Values that aren't
+// valid JSON are treated as strings.
+func jsonValue(v string) []byte {
+	if json.Valid([]byte(v)) {
+		return []byte(v)
+	}
+
+	b, _ := json.Marshal(v)
+
+	return b
+}
+
+func parseFloat(v string) (*float64, error) {
+	f, err := strconv.ParseFloat(v, 64)
+	return &f, err
+}
+
+func parseInt(v string) (*int64, error) {
+	i, err := strconv.ParseInt(v, 10, 64)
+	return &i, err
+}
+
+// basicSchema returns the schema of a Go builtin type.
+func basicSchema(name string) (extv1.JSONSchemaProps, bool) {
+	switch name {
+	case "string":
+		return extv1.JSONSchemaProps{Type: typeString}, true
+	case "bool":
+		return extv1.JSONSchemaProps{Type: typeBoolean}, true
+	case "int32", "uint32", "int16", "uint16", "int8", "uint8":
+		return extv1.JSONSchemaProps{Type: typeInteger, Format: "int32"}, true
+	case "int", "int64", "uint", "uint64":
+		return extv1.JSONSchemaProps{Type: typeInteger, Format: "int64"}, true
+	case "float32", "float64":
+		return extv1.JSONSchemaProps{Type: typeNumber}, true
+	case "any":
+		return extv1.JSONSchemaProps{XPreserveUnknownFields: ptr.To(true)}, true
+	}
+
+	return extv1.JSONSchemaProps{}, false
+}
+
+// wellKnownSchema returns the schema of a well-known Kubernetes type imported
+// from another package, like metav1.Time.
+func wellKnownSchema(name string) (extv1.JSONSchemaProps, bool) {
+	switch name {
+	case "Time", "MicroTime":
+		return extv1.JSONSchemaProps{Type: typeString, Format: "date-time"}, true
+	case "Duration":
+		return extv1.JSONSchemaProps{Type: typeString}, true
+	case "Quantity", "IntOrString":
+		return extv1.JSONSchemaProps{XIntOrString: true}, true
+	case "RawExtension", "JSON":
+		return extv1.JSONSchemaProps{XPreserveUnknownFields: ptr.To(true)}, true
+	}
+
+	return extv1.JSONSchemaProps{}, false
+}
+
+// exprString returns a printable representation of the supplied type
+// expression.
+func exprString(expr ast.Expr) string {
+	switch e := expr.(type) {
+	case *ast.SelectorExpr:
+		return exprString(e.X) + "." + e.Sel.Name
+	case *ast.Ident:
+		return e.Name
+	case *ast.ChanType:
+		return "chan"
+	case *ast.FuncType:
+		return "func"
+	}
+
+	return reflect.TypeOf(expr).String()
+}
@@ -0,0 +1,234 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package xrd
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	un "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/ptr"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+)
+
+const (
+	// maxEnumValues is the most distinct values a string field can have for
+	// it to be considered an enum.
+	maxEnumValues = 5
+
+	errNoExamples          = "no example composite resources"
+	errFmtConflictingTypes = "%s: conflicting types %v"
+)
+
+// JSON schema types.
+const (
+	typeObject  = "object"
+	typeArray   = "array"
+	typeString  = "string"
+	typeInteger = "integer"
+	typeNumber  = "number"
+	typeBoolean = "boolean"
+)
+
+// A node accumulates observations of a field in example composite resources.
+type node struct {
+	// observed is the number of times the field was observed with a non-null
+	// value.
+	observed int
+
+	types   sets.Set[string]
+	strings sets.Set[string]
+
+	properties map[string]*node
+	items      *node
+}
+
+func newNode() *node {
+	return &node{types: sets.New[string](), strings: sets.New[string]()}
+}
+
+func (n *node) observe(v any) {
+	if v == nil {
+		return
+	}
+
+	n.observed++
+
+	switch v := v.(type) {
+	case map[string]any:
+		n.types.Insert(typeObject)
+
+		if n.properties == nil {
+			n.properties = map[string]*node{}
+		}
+
+		for k, pv := range v {
+			if _, ok := n.properties[k]; !ok {
+				n.properties[k] = newNode()
+			}
+
+			n.properties[k].observe(pv)
+		}
+	case []any:
+		n.types.Insert(typeArray)
+
+		if n.items == nil {
+			n.items = newNode()
+		}
+
+		for _, iv := range v {
+			n.items.observe(iv)
+		}
+	case string:
+		n.types.Insert(typeString)
+		n.strings.Insert(v)
+	case bool:
+		n.types.Insert(typeBoolean)
+	case int64, int32, int:
+		n.types.Insert(typeInteger)
+	case float64, float32, json.Number:
+		n.types.Insert(typeNumber)
+	}
+}
+
+// schema returns the structural schema of the observed field. A field is
+// required if it was observed every time its parent object was observed, and
+// its parent object was observed more than once. A string field is an enum if
+// it has few distinct values, and at least one value was observed more than
+// once.
+func (n *node) schema(path string) (extv1.JSONSchemaProps, error) {
+	s := extv1.JSONSchemaProps{}
+
+	types := n.types.Clone()
+
+	// An integer is a valid number.
+	if types.Has(typeNumber) {
+		types.Delete(typeInteger)
+	}
+
+	switch {
+	case types.Len() == 0:
+		// We only observed null values, or empty arrays.
+		s.XPreserveUnknownFields = ptr.To(true)
+		return s, nil
+	case types.Equal(sets.New(typeInteger, typeString)):
+		s.XIntOrString = true
+		return s, nil
+	case types.Len() > 1:
+		return s, errors.Errorf(errFmtConflictingTypes, path, sets.List(types))
+	}
+
+	s.Type = types.UnsortedList()[0]
+
+	switch s.Type {
+	case typeObject:
+		if len(n.properties) == 0 {
+			s.XPreserveUnknownFields = ptr.To(true)
+			return s, nil
+		}
+
+		s.Properties = make(map[string]extv1.JSONSchemaProps, len(n.properties))
+
+		for k, pn := range n.properties {
+			ps, err := pn.schema(path + "." + k)
+			if err != nil {
+				return s, err
+			}
+
+			s.Properties[k] = ps
+
+			if n.observed > 1 && pn.observed == n.observed {
+				s.Required = append(s.Required, k)
+			}
+		}
+
+		sort.Strings(s.Required)
+	case typeArray:
+		if n.items == nil {
+			n.items = newNode()
+		}
+
+		is, err := n.items.schema(path + "[*]")
+		if err != nil {
+			return s, err
+		}
+
+		s.Items = &extv1.JSONSchemaPropsOrArray{Schema: &is}
+	case typeString:
+		if n.strings.Len() <= maxEnumValues && n.strings.Len() < n.observed {
+			for _, v := range sets.List(n.strings) {
+				s.Enum = append(s.Enum, extv1.JSON{Raw: []byte(fmt.Sprintf("%q", v))})
+			}
+		}
+	}
+
+	return s, nil
+}
+
+// InferSchema infers an XRD version's OpenAPI v3 schema from the spec and
+// status of the supplied example composite resources. Fields Crossplane adds
+// to every composite resource are omitted.
+func InferSchema(examples []*un.Unstructured) (*extv1.JSONSchemaProps, error) {
+	if len(examples) == 0 {
+		return nil, errors.New(errNoExamples)
+	}
+
+	spec, status := newNode(), newNode()
+
+	for _, xr := range examples {
+		spec.observe(orEmpty(xr.Object["spec"]))
+
+		if v, ok := xr.Object["status"]; ok {
+			status.observe(v)
+		}
+	}
+
+	ss, err := spec.schema("spec")
+	if err != nil {
+		return nil, err
+	}
+
+	s := &extv1.JSONSchemaProps{
+		Type:       typeObject,
+		Properties: map[string]extv1.JSONSchemaProps{"spec": ss},
+	}
+
+	if status.observed > 0 {
+		st, err := status.schema("status")
+		if err != nil {
+			return nil, err
+		}
+
+		s.Properties["status"] = st
+	}
+
+	return s, nil
+}
+
+// orEmpty returns an empty object if v is nil, so that an XR without a spec
+// still produces an object schema.
+func orEmpty(v any) any {
+	if v == nil {
+		return map[string]any{}
+	}
+
+	return v
+}
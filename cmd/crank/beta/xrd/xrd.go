@@ -0,0 +1,40 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package xrd contains Crossplane CLI subcommands for working with
+// CompositeResourceDefinitions.
+package xrd
+
+// Cmd contains commands for working with CompositeResourceDefinitions.
+type Cmd struct {
+	Check    checkCmd    `cmd:"" help:"Check XRDs for breaking schema changes."`
+	Generate generateCmd `cmd:"" help:"Generate an XRD from example XRs or Go types."`
+}
+
+// Help returns help message for the xrd command.
+func (c *Cmd) Help() string {
+	return `
+This command contains subcommands for working with CompositeResourceDefinitions
+(XRDs).
+
+Examples:
+  # Check whether a new revision of an XRD makes breaking schema changes.
+  crossplane beta xrd check main/xrd.yaml xrd.yaml
+
+  # Generate an XRD from example XRs.
+  crossplane beta xrd generate examples/
+`
+}
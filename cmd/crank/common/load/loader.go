@@ -19,7 +19,7 @@ package load
 
 import (
 	"bufio"
-	"encoding/json"
+	"bytes"
 	"fmt"
 	"io"
 	"os"
@@ -27,17 +27,67 @@ import (
 	"strings"
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
-	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/yaml"
 
 	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
 
 	v1 "github.com/crossplane/crossplane/v2/apis/apiextensions/v1"
+	opsv1alpha1 "github.com/crossplane/crossplane/v2/apis/ops/v1alpha1"
 )
 
 // Loader interface defines the contract for different input sources.
 type Loader interface {
 	Load() ([]*unstructured.Unstructured, error)
+
+	// LoadManifests is like Load, but also returns where each resource was
+	// loaded from.
+	LoadManifests() ([]Manifest, error)
+}
+
+// A Manifest is a resource, and where it was loaded from.
+type Manifest struct {
+	// Resource loaded from the source.
+	Resource *unstructured.Unstructured
+
+	// File the resource was loaded from. Empty if it was loaded from stdin.
+	File string
+
+	// Line of the file the resource's YAML document starts on. Function
+	// inputs extracted from a pipeline have the line of the resource they
+	// were extracted from.
+	Line int
+
+	// Owner is the resource this resource was extracted from, if any. For
+	// example the Composition or Operation a function input was extracted
+	// from.
+	Owner *unstructured.Unstructured
+
+	// FieldPath of this resource within its owner, if it has one.
+	FieldPath string
+}
+
+// A Document is a YAML document read from a stream.
+type Document struct {
+	// Bytes of the document.
+	Bytes []byte
+
+	// Line of the stream the document's content starts on, ignoring any
+	// leading blank or comment lines. The first line is 1.
+	Line int
+}
+
+func toUnstructured(ms []Manifest) []*unstructured.Unstructured {
+	if ms == nil {
+		return nil
+	}
+
+	out := make([]*unstructured.Unstructured, len(ms))
+	for i := range ms {
+		out[i] = ms[i].Resource
+	}
+
+	return out
 }
 
 // NewLoader returns a Loader based on the input source.
@@ -86,10 +136,16 @@ type MultiLoader struct {
 
 // Load reads and merges the content from the loaders.
 func (m *MultiLoader) Load() ([]*unstructured.Unstructured, error) {
-	var manifests []*unstructured.Unstructured
+	ms, err := m.LoadManifests()
+	return toUnstructured(ms), err
+}
+
+// LoadManifests reads and merges the content from the loaders.
+func (m *MultiLoader) LoadManifests() ([]Manifest, error) {
+	var manifests []Manifest
 
 	for i, loader := range m.loaders {
-		output, err := loader.Load()
+		output, err := loader.LoadManifests()
 		if err != nil {
 			return nil, errors.Wrap(err, fmt.Sprintf("cannot load source at position %d", i))
 		}
@@ -105,12 +161,18 @@ type StdinLoader struct{}
 
 // Load reads the contents from stdin.
 func (s *StdinLoader) Load() ([]*unstructured.Unstructured, error) {
-	stream, err := YamlStream(os.Stdin)
+	ms, err := s.LoadManifests()
+	return toUnstructured(ms), err
+}
+
+// LoadManifests reads the contents from stdin.
+func (s *StdinLoader) LoadManifests() ([]Manifest, error) {
+	docs, err := YamlDocuments(os.Stdin)
 	if err != nil {
 		return nil, errors.Wrap(err, "cannot load YAML stream from stdin")
 	}
 
-	return streamToUnstructured(stream)
+	return documentsToManifests("", docs)
 }
 
 // FileLoader implements the Loader interface for reading from a file and converting input to unstructured objects.
@@ -120,12 +182,18 @@ type FileLoader struct {
 
 // Load reads the contents from a file.
 func (f *FileLoader) Load() ([]*unstructured.Unstructured, error) {
-	stream, err := readFile(f.path)
+	ms, err := f.LoadManifests()
+	return toUnstructured(ms), err
+}
+
+// LoadManifests reads the contents from a file.
+func (f *FileLoader) LoadManifests() ([]Manifest, error) {
+	docs, err := readFile(f.path)
 	if err != nil {
 		return nil, errors.Wrap(err, "cannot read file")
 	}
 
-	return streamToUnstructured(stream)
+	return documentsToManifests(f.path, docs)
 }
 
 // FolderLoader implements the Loader interface for reading from a folder.
@@ -135,7 +203,13 @@ type FolderLoader struct {
 
 // Load reads the contents from all files in a folder.
 func (f *FolderLoader) Load() ([]*unstructured.Unstructured, error) {
-	var stream [][]byte
+	ms, err := f.LoadManifests()
+	return toUnstructured(ms), err
+}
+
+// LoadManifests reads the contents from all files in a folder.
+func (f *FolderLoader) LoadManifests() ([]Manifest, error) {
+	var manifests []Manifest
 
 	err := filepath.Walk(f.path, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
@@ -143,12 +217,17 @@ func (f *FolderLoader) Load() ([]*unstructured.Unstructured, error) {
 		}
 
 		if isYamlFile(info) {
-			s, err := readFile(path)
+			docs, err := readFile(path)
 			if err != nil {
 				return err
 			}
 
-			stream = append(stream, s...)
+			ms, err := documentsToManifests(path, docs)
+			if err != nil {
+				return errors.Wrapf(err, "cannot parse %q", path)
+			}
+
+			manifests = append(manifests, ms...)
 		}
 
 		return nil
@@ -157,21 +236,21 @@ func (f *FolderLoader) Load() ([]*unstructured.Unstructured, error) {
 		return nil, errors.Wrap(err, "cannot read folder")
 	}
 
-	return streamToUnstructured(stream)
+	return manifests, nil
 }
 
 func isYamlFile(info os.FileInfo) bool {
 	return !info.IsDir() && (filepath.Ext(info.Name()) == ".yaml" || filepath.Ext(info.Name()) == ".yml")
 }
 
-func readFile(path string) ([][]byte, error) {
+func readFile(path string) ([]Document, error) {
 	f, err := os.Open(filepath.Clean(path))
 	if err != nil {
 		return nil, errors.Wrap(err, "cannot open file")
 	}
 	defer f.Close() //nolint:errcheck // Only open for reading.
 
-	return YamlStream(f)
+	return YamlDocuments(f)
 }
 
 // YamlStream loads a yaml stream from a reader into a 2d byte slice.
@@ -200,49 +279,130 @@ func YamlStream(r io.Reader) ([][]byte, error) {
 	return stream, nil
 }
 
+// YamlDocuments loads a yaml stream from a reader into documents, recording the
+// line each document starts on. It splits the stream exactly as YamlStream
+// does.
+func YamlDocuments(r io.Reader) ([]Document, error) {
+	stream, err := YamlStream(r)
+	if err != nil {
+		return nil, err
+	}
+
+	docs := make([]Document, 0, len(stream))
+
+	// The YAML reader returns one line of the stream per line of each
+	// document, and consumes the separator that ends each document.
+	line := 1
+
+	for _, b := range stream {
+		docs = append(docs, Document{Bytes: b, Line: line + leadingLines(b)})
+		line += bytes.Count(b, []byte("\n")) + 1
+	}
+
+	return docs, nil
+}
+
+// leadingLines returns the number of leading blank, comment, and separator
+// lines in the supplied document. The YAML reader includes separators that
+// precede a document's content.
+func leadingLines(doc []byte) int {
+	n := 0
+
+	for l := range bytes.Lines(doc) {
+		if t := bytes.TrimSpace(l); len(t) > 0 && t[0] != '#' && !bytes.HasPrefix(l, []byte("---")) {
+			break
+		}
+
+		n++
+	}
+
+	return n
+}
+
 func streamToUnstructured(stream [][]byte) ([]*unstructured.Unstructured, error) {
-	manifests := make([]*unstructured.Unstructured, 0, len(stream))
+	docs := make([]Document, len(stream))
+	for i := range stream {
+		docs[i] = Document{Bytes: stream[i]}
+	}
+
+	ms, err := documentsToManifests("", docs)
+	if err != nil {
+		return nil, err
+	}
+
+	return toUnstructured(ms), nil
+}
 
-	for _, y := range stream {
+func documentsToManifests(file string, docs []Document) ([]Manifest, error) {
+	manifests := make([]Manifest, 0, len(docs))
+
+	for _, d := range docs {
 		u := &unstructured.Unstructured{}
-		if err := yaml.Unmarshal(y, u); err != nil {
+		if err := yaml.Unmarshal(d.Bytes, u); err != nil {
 			return nil, errors.Wrap(err, "cannot parse YAML manifest")
 		}
-		// extract pipeline input resources
-		if u.GetObjectKind().GroupVersionKind() == v1.CompositionGroupVersionKind {
-			// Convert the unstructured resource to a Composition
-			var comp v1.Composition
+		// Extract function pipeline inputs as manifests we can validate.
+		inputs, err := pipelineInputs(u)
+		if err != nil {
+			return nil, err
+		}
 
-			err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, &comp)
-			if err != nil {
-				return nil, errors.Wrap(err, "failed to convert unstructured to Composition")
-			}
-			// Iterate over each step in the pipeline
-			for _, step := range comp.Spec.Pipeline {
-				// Create a new resource based on the input (we can use it for validation)
-				if step.Input != nil && step.Input.Raw != nil {
-					var inputMap map[string]interface{}
-
-					err := json.Unmarshal(step.Input.Raw, &inputMap)
-					if err != nil {
-						return nil, errors.Wrap(err, "failed to unmarshal raw input")
-					}
-
-					newInputResource := &unstructured.Unstructured{
-						Object: inputMap,
-					}
-					// Add the input as new manifest to the manifests slice that we can validate
-					manifests = append(manifests, newInputResource)
-				}
-			}
+		for _, in := range inputs {
+			in.File, in.Line = file, d.Line
+			manifests = append(manifests, in)
 		}
 
-		manifests = append(manifests, u)
+		manifests = append(manifests, Manifest{Resource: u, File: file, Line: d.Line})
 	}
 
 	return manifests, nil
 }
 
+// pipelinePaths are the field paths of the function pipelines of each kind of
+// resource that has one.
+var pipelinePaths = map[schema.GroupKind][]string{ //nolint:gochecknoglobals // We treat this as a constant.
+	{Group: v1.Group, Kind: v1.CompositionKind}:                      {"spec", "pipeline"},
+	{Group: opsv1alpha1.Group, Kind: opsv1alpha1.OperationKind}:      {"spec", "pipeline"},
+	{Group: opsv1alpha1.Group, Kind: opsv1alpha1.CronOperationKind}:  {"spec", "operationTemplate", "spec", "pipeline"},
+	{Group: opsv1alpha1.Group, Kind: opsv1alpha1.WatchOperationKind}: {"spec", "operationTemplate", "spec", "pipeline"},
+}
+
+// pipelineInputs returns the function inputs of the supplied resource's
+// pipeline steps, if it has a pipeline.
+func pipelineInputs(u *unstructured.Unstructured) ([]Manifest, error) {
+	path, ok := pipelinePaths[u.GroupVersionKind().GroupKind()]
+	if !ok {
+		return nil, nil
+	}
+
+	steps, _, err := unstructured.NestedSlice(u.Object, path...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot get %s %q pipeline", u.GetKind(), u.GetName())
+	}
+
+	var inputs []Manifest
+
+	for i, s := range steps {
+		step, ok := s.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		input, ok := step["input"].(map[string]any)
+		if !ok {
+			continue
+		}
+
+		inputs = append(inputs, Manifest{
+			Resource:  &unstructured.Unstructured{Object: input},
+			Owner:     u,
+			FieldPath: fmt.Sprintf("%s[%d].input", strings.Join(path, "."), i),
+		})
+	}
+
+	return inputs, nil
+}
+
 // CompositeLoader acts as a composition of multiple loaders
 // to handle loading resources from various sources at once.
 type CompositeLoader struct {
@@ -287,15 +447,22 @@ func NewCompositeLoader(sources []string) (Loader, error) {
 // Load implements the Loader interface by loading from all contained loaders
 // and combining the results.
 func (c *CompositeLoader) Load() ([]*unstructured.Unstructured, error) {
+	ms, err := c.LoadManifests()
+	return toUnstructured(ms), err
+}
+
+// LoadManifests implements the Loader interface by loading from all contained
+// loaders and combining the results.
+func (c *CompositeLoader) LoadManifests() ([]Manifest, error) {
 	if len(c.loaders) == 0 {
 		return nil, errors.New("no loaders configured")
 	}
 
 	// Combine results from all loaders
-	var allResources []*unstructured.Unstructured
+	var allResources []Manifest
 
 	for _, loader := range c.loaders {
-		resources, err := loader.Load()
+		resources, err := loader.LoadManifests()
 		if err != nil {
 			return nil, errors.Wrap(err, "cannot load resources from loader")
 		}
@@ -17,11 +17,17 @@ limitations under the License.
 package load
 
 import (
+	"bufio"
+	"io"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/yaml"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
 )
 
 var (
@@ -501,3 +507,224 @@ spec:
 		})
 	}
 }
+
+func TestYamlDocuments(t *testing.T) {
+	type args struct {
+		stream string
+	}
+
+	type want struct {
+		docs []Document
+		err  error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"SingleDocument": {
+			reason: "A stream without separators should be read as one document starting on the first line.",
+			args: args{
+				stream: "apiVersion: v1\nkind: Pod\n",
+			},
+			want: want{
+				docs: []Document{{Bytes: []byte("apiVersion: v1\nkind: Pod\n"), Line: 1}},
+			},
+		},
+		"MultipleDocuments": {
+			reason: "Each document should record the line its content starts on, skipping separators, blank lines, and comments.",
+			args: args{
+				stream: "---\n# A pod.\napiVersion: v1\nkind: Pod\n--- # Another pod.\n\napiVersion: v1\nkind: Pod",
+			},
+			want: want{
+				docs: []Document{
+					{Bytes: []byte("---\n# A pod.\napiVersion: v1\nkind: Pod\n"), Line: 3},
+					{Bytes: []byte("\napiVersion: v1\nkind: Pod\n"), Line: 7},
+				},
+			},
+		},
+		"EmptyDocuments": {
+			reason: "Documents after empty documents should record the line their content starts on.",
+			args: args{
+				stream: "---\n---\napiVersion: v1\n---\n",
+			},
+			want: want{
+				docs: []Document{
+					{Bytes: []byte("---\n"), Line: 2},
+					{Bytes: []byte("apiVersion: v1\n"), Line: 3},
+				},
+			},
+		},
+		"InvalidSeparator": {
+			reason: "A separator followed by anything but a comment should return an error.",
+			args: args{
+				stream: "apiVersion: v1\n--- nope\n",
+			},
+			want: want{
+				err: cmpopts.AnyError,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := YamlDocuments(strings.NewReader(tc.args.stream))
+			if diff := cmp.Diff(tc.want.docs, got); diff != "" {
+				t.Errorf("%s\nYamlDocuments(...): -want, +got:\n%s", tc.reason, diff)
+			}
+
+			if diff := cmp.Diff(tc.want.err, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("%s\nYamlDocuments(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestYamlDocumentsMatchesYAMLReader(t *testing.T) {
+	cases := map[string]string{
+		"Documents":     "apiVersion: v1\nkind: Pod\n---\napiVersion: v1\nkind: Pod\n",
+		"Comments":      "# A pod.\n--- # Another pod.\napiVersion: v1\n",
+		"EmptyDocument": "---\n---\napiVersion: v1\n---\n",
+		"CRLF":          "apiVersion: v1\r\n---\r\nkind: Pod\r\n",
+		"NoNewline":     "apiVersion: v1\n---\nkind: Pod",
+		"Tag":           "--- !tag\napiVersion: v1\n",
+		"BlockScalar":   "--- |\n  text\n",
+		"FlowMapping":   "---{apiVersion: v1}\n",
+	}
+
+	for name, stream := range cases {
+		t.Run(name, func(t *testing.T) {
+			var want [][]byte
+
+			yr := yaml.NewYAMLReader(bufio.NewReader(strings.NewReader(stream)))
+
+			var wantErr error
+
+			for {
+				b, err := yr.Read()
+				if errors.Is(err, io.EOF) {
+					break
+				}
+
+				if err != nil {
+					wantErr = err
+					break
+				}
+
+				if len(b) > 0 {
+					want = append(want, b)
+				}
+			}
+
+			docs, err := YamlDocuments(strings.NewReader(stream))
+			if (err != nil) != (wantErr != nil) {
+				t.Fatalf("YamlDocuments(...): want error %v, got error %v", wantErr, err)
+			}
+
+			var got [][]byte
+			for _, d := range docs {
+				got = append(got, d.Bytes)
+			}
+
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("YamlDocuments(...): -want documents, +got documents:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestFileLoaderLoadManifests(t *testing.T) {
+	f := &FileLoader{path: "testdata/resources.yaml"}
+
+	want := []Manifest{
+		{Resource: &unstructured.Unstructured{Object: coolResource}, File: "testdata/resources.yaml", Line: 2},
+		{Resource: &unstructured.Unstructured{Object: coolerResource}, File: "testdata/resources.yaml", Line: 11},
+	}
+
+	got, err := f.LoadManifests()
+	if err != nil {
+		t.Fatalf("LoadManifests(...): unexpected error: %v", err)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("LoadManifests(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestPipelineInputs(t *testing.T) {
+	input := map[string]interface{}{
+		"apiVersion": "pt.fn.crossplane.io/v1beta1",
+		"kind":       "Resources",
+	}
+
+	pipeline := []interface{}{
+		map[string]interface{}{"step": "no-input"},
+		map[string]interface{}{"step": "input", "input": input},
+	}
+
+	type want struct {
+		inputs []Manifest
+		err    error
+	}
+
+	cases := map[string]struct {
+		reason string
+		u      *unstructured.Unstructured
+		want   want
+	}{
+		"NotAPipeline": {
+			reason: "Resources without a function pipeline have no inputs.",
+			u: &unstructured.Unstructured{Object: map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "ConfigMap",
+			}},
+			want: want{},
+		},
+		"Operation": {
+			reason: "We should extract the inputs of an Operation's pipeline steps.",
+			u: &unstructured.Unstructured{Object: map[string]interface{}{
+				"apiVersion": "ops.crossplane.io/v1alpha1",
+				"kind":       "Operation",
+				"spec":       map[string]interface{}{"pipeline": pipeline},
+			}},
+			want: want{inputs: []Manifest{{
+				Resource:  &unstructured.Unstructured{Object: input},
+				FieldPath: "spec.pipeline[1].input",
+			}}},
+		},
+		"CronOperation": {
+			reason: "We should extract the inputs of a CronOperation's template's pipeline steps.",
+			u: &unstructured.Unstructured{Object: map[string]interface{}{
+				"apiVersion": "ops.crossplane.io/v1alpha1",
+				"kind":       "CronOperation",
+				"spec": map[string]interface{}{
+					"operationTemplate": map[string]interface{}{
+						"spec": map[string]interface{}{"pipeline": pipeline},
+					},
+				},
+			}},
+			want: want{inputs: []Manifest{{
+				Resource:  &unstructured.Unstructured{Object: input},
+				FieldPath: "spec.operationTemplate.spec.pipeline[1].input",
+			}}},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := pipelineInputs(tc.u)
+			if diff := cmp.Diff(tc.want.inputs, got, cmpopts.IgnoreFields(Manifest{}, "Owner")); diff != "" {
+				t.Errorf("%s\npipelineInputs(...): -want, +got:\n%s", tc.reason, diff)
+			}
+
+			for _, in := range got {
+				if in.Owner != tc.u {
+					t.Errorf("%s\npipelineInputs(...): want input to be owned by the supplied resource", tc.reason)
+				}
+			}
+
+			if diff := cmp.Diff(tc.want.err, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("%s\npipelineInputs(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
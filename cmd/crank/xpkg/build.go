@@ -18,11 +18,18 @@ package xpkg
 
 import (
 	"context"
+	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/google/go-containerregistry/pkg/name"
 	v1 "github.com/google/go-containerregistry/pkg/v1"
 	"github.com/google/go-containerregistry/pkg/v1/daemon"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/layout"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
 	"github.com/google/go-containerregistry/pkg/v1/tarball"
 	"github.com/spf13/afero"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -42,10 +49,16 @@ const (
 	errBuildPackage            = "failed to build package"
 	errImageDigest             = "failed to get package digest"
 	errCreatePackage           = "failed to create package file"
+	errRuntimeConfigFile       = "failed to get config file of runtime image"
 	errParseRuntimeImageRef    = "failed to parse runtime image reference"
 	errPullRuntimeImage        = "failed to pull runtime image"
 	errLoadRuntimeTarball      = "failed to load runtime tarball"
 	errGetRuntimeBaseImageOpts = "failed to get runtime base image options"
+	errSourceDateEpoch         = "failed to parse SOURCE_DATE_EPOCH"
+	errWriteIndex              = "failed to write multi-platform package"
+
+	errFmtRuntimeImage         = "failed to get runtime image for platform %s"
+	errFmtRuntimeImagePlatform = "runtime image for platform %s is for platform %s_%s"
 )
 
 // AfterApply constructs and binds context to any subcommands
@@ -95,12 +108,13 @@ func (c *buildCmd) AfterApply() error {
 // buildCmd builds a crossplane package.
 type buildCmd struct {
 	// Flags. Keep sorted alphabetically.
-	EmbedRuntimeImage        string   `help:"An OCI image to embed in the package as its runtime."                                                                                                    placeholder:"NAME"                                                     xor:"runtime-image"`
-	EmbedRuntimeImageTarball string   `help:"An OCI image tarball to embed in the package as its runtime."                                                                                            placeholder:"PATH"                                                     predictor:"file"      type:"existingfile" xor:"runtime-image"`
-	ExamplesRoot             string   `default:"./examples"                                                                                                                                           help:"A directory of example YAML files to include in the package."    predictor:"directory" short:"e"           type:"path"`
-	Ignore                   []string `help:"Comma-separated file paths, specified relative to --package-root, to exclude from the package. Wildcards are supported. Directories cannot be excluded." placeholder:"PATH"`
-	PackageFile              string   `help:"The file to write the package to. Defaults to a generated filename in --package-root."                                                                   placeholder:"PATH"                                                     predictor:"xpkg_file" short:"o"           type:"path"`
-	PackageRoot              string   `default:"."                                                                                                                                                    help:"The directory that contains the package's crossplane.yaml file." predictor:"directory" short:"f"           type:"existingdir"`
+	EmbedRuntimeImage        string            `help:"An OCI image to embed in the package as its runtime."                                                                                                                                                            placeholder:"NAME"                                                     xor:"runtime-image"`
+	EmbedRuntimeImageTarball string            `help:"An OCI image tarball to embed in the package as its runtime."                                                                                                                                                    placeholder:"PATH"                                                     predictor:"file"      type:"existingfile" xor:"runtime-image"`
+	EmbedRuntimeImages       map[string]string `help:"A runtime image to embed for each platform of a multi-platform package, as <OS>_<arch>=<image>. Images are read from the OCI image tarball at the supplied path if it exists, otherwise from the Docker daemon." placeholder:"PLATFORM=IMAGE"                                           xor:"runtime-image"`
+	ExamplesRoot             string            `default:"./examples"                                                                                                                                                                                                   help:"A directory of example YAML files to include in the package."    predictor:"directory" short:"e"           type:"path"`
+	Ignore                   []string          `help:"Comma-separated file paths, specified relative to --package-root, to exclude from the package. Wildcards are supported. Directories cannot be excluded."                                                         placeholder:"PATH"`
+	PackageFile              string            `help:"The file to write the package to. Defaults to a generated filename in --package-root."                                                                                                                           placeholder:"PATH"                                                     predictor:"xpkg_file" short:"o"           type:"path"`
+	PackageRoot              string            `default:"."                                                                                                                                                                                                            help:"The directory that contains the package's crossplane.yaml file." predictor:"directory" short:"f"           type:"existingdir"`
 
 	// Internal state. These aren't part of the user-exposed CLI structure.
 	fs      afero.Fs
@@ -121,6 +135,17 @@ Examples:
   # 'docker build' so that the package can also be used to run the provider.
   # Provider and Function packages support embedding runtime images.
   crossplane xpkg build --embed-runtime-image=cc873e13cdc1
+
+  # Build a multi-platform package that embeds a runtime image per platform.
+  # The package is written as an OCI image layout tarball containing an image
+  # index. Push it using 'crossplane xpkg push'.
+  crossplane xpkg build \
+    --embed-runtime-images=linux_amd64=runtime-amd64 \
+    --embed-runtime-images=linux_arm64=runtime-arm64
+
+Builds are reproducible. Building identical sources produces packages with
+identical digests. Packages are created at the time set by the
+SOURCE_DATE_EPOCH environment variable, or at the Unix epoch if it's unset.
 `
 }
 
@@ -155,6 +180,118 @@ func (c *buildCmd) GetRuntimeBaseImageOpts() ([]xpkg.BuildOpt, error) {
 	return nil, nil
 }
 
+// runtimeImage returns the runtime image at the supplied OCI image tarball
+// path, or the supplied image in the Docker daemon if no such path exists.
+func runtimeImage(ctx context.Context, image string) (v1.Image, error) {
+	if fi, err := os.Stat(image); err == nil && fi.Mode().IsRegular() {
+		img, err := tarball.ImageFromPath(filepath.Clean(image), nil)
+		return img, errors.Wrap(err, errLoadRuntimeTarball)
+	}
+
+	// See GetRuntimeBaseImageOpts for why we don't use strict validation.
+	ref, err := name.ParseReference(image)
+	if err != nil {
+		return nil, errors.Wrap(err, errParseRuntimeImageRef)
+	}
+
+	img, err := daemon.Image(ref, daemon.WithContext(ctx))
+
+	return img, errors.Wrap(err, errPullRuntimeImage)
+}
+
+// platformImage returns the supplied runtime image's platform, which must
+// match the supplied <OS>_<arch> platform. Images that don't specify a
+// platform are set to the supplied platform.
+func platformImage(img v1.Image, platform string) (v1.Image, *v1.Platform, error) {
+	tokens := strings.Split(platform, "_")
+	if len(tokens) != 2 {
+		return nil, nil, errors.Errorf(errInvalidPlatformFmt, platform)
+	}
+
+	p := &v1.Platform{OS: tokens[0], Architecture: tokens[1]}
+
+	cfg, err := img.ConfigFile()
+	if err != nil {
+		return nil, nil, errors.Wrap(err, errRuntimeConfigFile)
+	}
+
+	if cfg.OS == "" && cfg.Architecture == "" {
+		cfg = cfg.DeepCopy()
+		cfg.OS = p.OS
+		cfg.Architecture = p.Architecture
+
+		img, err = mutate.ConfigFile(img, cfg)
+
+		return img, p, errors.Wrap(err, errRuntimeConfigFile)
+	}
+
+	if cfg.OS != p.OS || cfg.Architecture != p.Architecture {
+		return nil, nil, errors.Errorf(errFmtRuntimeImagePlatform, platform, cfg.OS, cfg.Architecture)
+	}
+
+	p.OSVersion = cfg.OSVersion
+	p.Variant = cfg.Variant
+
+	return img, p, nil
+}
+
+// BuildIndex builds a package for each platform of EmbedRuntimeImages, and
+// returns an OCI image index of them.
+func (c *buildCmd) BuildIndex(ctx context.Context, opts ...xpkg.BuildOpt) (v1.ImageIndex, runtime.Object, error) {
+	adds := make([]mutate.IndexAddendum, 0, len(c.EmbedRuntimeImages))
+
+	var meta runtime.Object
+
+	// Sort platforms so the index is the same every time.
+	for _, platform := range sortedKeys(c.EmbedRuntimeImages) {
+		rt, err := runtimeImage(ctx, c.EmbedRuntimeImages[platform])
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, errFmtRuntimeImage, platform)
+		}
+
+		rt, p, err := platformImage(rt, platform)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, errFmtRuntimeImage, platform)
+		}
+
+		img, m, err := c.builder.Build(ctx, append(opts, xpkg.WithBase(rt))...)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, errBuildPackage)
+		}
+
+		mt, err := img.MediaType()
+		if err != nil {
+			return nil, nil, errors.Wrap(err, errBuildPackage)
+		}
+
+		meta = m
+
+		adds = append(adds, mutate.IndexAddendum{
+			Add:        img,
+			Descriptor: v1.Descriptor{MediaType: mt, Platform: p},
+		})
+	}
+
+	return mutate.AppendManifests(empty.Index, adds...), meta, nil
+}
+
+// sourceDateEpoch returns the time set by the SOURCE_DATE_EPOCH environment
+// variable. It returns the zero time if the variable is unset. See
+// https://reproducible-builds.org/specs/source-date-epoch/.
+func sourceDateEpoch() (time.Time, error) {
+	v, ok := os.LookupEnv("SOURCE_DATE_EPOCH")
+	if !ok || v == "" {
+		return time.Time{}, nil
+	}
+
+	sec, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return time.Time{}, errors.Wrap(err, errSourceDateEpoch)
+	}
+
+	return time.Unix(sec, 0).UTC(), nil
+}
+
 // GetOutputFileName prepares output file name.
 func (c *buildCmd) GetOutputFileName(meta runtime.Object, hash v1.Hash) (string, error) {
 	output := filepath.Clean(c.PackageFile)
@@ -182,6 +319,30 @@ func (c *buildCmd) Run(logger logging.Logger) error {
 
 	buildOpts = append(buildOpts, rtBuildOpts...)
 
+	l, err := xpkg.ReadLockfile(c.fs, filepath.Join(c.root, xpkg.LockfileName))
+	if err != nil {
+		return errors.Wrap(err, errBuildPackage)
+	}
+
+	if l != nil {
+		logger.Debug("Embedding dependency lock", "dependencies", len(l.Lock))
+		buildOpts = append(buildOpts, xpkg.WithLock(l.Lock))
+	}
+
+	created, err := sourceDateEpoch()
+	if err != nil {
+		return err
+	}
+
+	if !created.IsZero() {
+		logger.Debug("Using SOURCE_DATE_EPOCH as package creation time", "created", created)
+		buildOpts = append(buildOpts, xpkg.WithCreated(created))
+	}
+
+	if len(c.EmbedRuntimeImages) > 0 {
+		return c.runIndex(logger, buildOpts...)
+	}
+
 	img, meta, err := c.builder.Build(context.Background(), buildOpts...)
 	if err != nil {
 		return errors.Wrap(err, errBuildPackage)
@@ -213,6 +374,43 @@ func (c *buildCmd) Run(logger logging.Logger) error {
 	return nil
 }
 
+// runIndex builds a multi-platform package, and writes it as an OCI image
+// layout tarball.
+func (c *buildCmd) runIndex(logger logging.Logger, opts ...xpkg.BuildOpt) error {
+	idx, meta, err := c.BuildIndex(context.Background(), opts...)
+	if err != nil {
+		return err
+	}
+
+	hash, err := idx.Digest()
+	if err != nil {
+		return errors.Wrap(err, errImageDigest)
+	}
+
+	output, err := c.GetOutputFileName(meta, hash)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.MkdirTemp("", "xpkg-build-")
+	if err != nil {
+		return errors.Wrap(err, errWriteIndex)
+	}
+	defer os.RemoveAll(tmp) //nolint:errcheck // Best effort cleanup.
+
+	if _, err := layout.Write(tmp, idx); err != nil {
+		return errors.Wrap(err, errWriteIndex)
+	}
+
+	if err := writeTarball(tmp, output); err != nil {
+		return errors.Wrap(err, errCreatePackage)
+	}
+
+	logger.Info("xpkg saved", "output", output, "platforms", len(c.EmbedRuntimeImages))
+
+	return nil
+}
+
 // default build filters skip directories, empty files, and files without YAML
 // extension in addition to any paths specified.
 func buildFilters(root string, skips []string) []parser.FilterFn {
@@ -0,0 +1,120 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package xpkg
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/test"
+)
+
+func TestPlatformImage(t *testing.T) {
+	arm64, _ := mutate.ConfigFile(empty.Image, &v1.ConfigFile{OS: "linux", Architecture: "arm64", Variant: "v8"})
+
+	type args struct {
+		img      v1.Image
+		platform string
+	}
+
+	type want struct {
+		platform *v1.Platform
+		cfg      *v1.Platform
+		err      error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"InvalidPlatform": {
+			reason: "We should return an error if the platform doesn't use the <OS>_<arch> syntax.",
+			args: args{
+				img:      empty.Image,
+				platform: "linux-amd64",
+			},
+			want: want{
+				err: errors.Errorf(errInvalidPlatformFmt, "linux-amd64"),
+			},
+		},
+		"NoPlatform": {
+			reason: "We should set the platform of a runtime image that doesn't specify one.",
+			args: args{
+				img:      empty.Image,
+				platform: "linux_amd64",
+			},
+			want: want{
+				platform: &v1.Platform{OS: "linux", Architecture: "amd64"},
+				cfg:      &v1.Platform{OS: "linux", Architecture: "amd64"},
+			},
+		},
+		"MatchingPlatform": {
+			reason: "We should return the platform of a runtime image that matches the supplied platform.",
+			args: args{
+				img:      arm64,
+				platform: "linux_arm64",
+			},
+			want: want{
+				platform: &v1.Platform{OS: "linux", Architecture: "arm64", Variant: "v8"},
+				cfg:      &v1.Platform{OS: "linux", Architecture: "arm64", Variant: "v8"},
+			},
+		},
+		"MismatchedPlatform": {
+			reason: "We should return an error if the runtime image is for a different platform.",
+			args: args{
+				img:      arm64,
+				platform: "linux_amd64",
+			},
+			want: want{
+				err: errors.Errorf(errFmtRuntimeImagePlatform, "linux_amd64", "linux", "arm64"),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			img, p, err := platformImage(tc.args.img, tc.args.platform)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Fatalf("\n%s\nplatformImage(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+
+			if diff := cmp.Diff(tc.want.platform, p); diff != "" {
+				t.Errorf("\n%s\nplatformImage(...): -want platform, +got platform:\n%s", tc.reason, diff)
+			}
+
+			if err != nil {
+				return
+			}
+
+			cfg, err := img.ConfigFile()
+			if err != nil {
+				t.Fatalf("ConfigFile(): %v", err)
+			}
+
+			got := &v1.Platform{OS: cfg.OS, Architecture: cfg.Architecture, Variant: cfg.Variant}
+			if diff := cmp.Diff(tc.want.cfg, got); diff != "" {
+				t.Errorf("\n%s\nplatformImage(...): -want config platform, +got config platform:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,610 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package xpkg
+
+import (
+	"archive/tar"
+	"context"
+	"crypto/tls"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/semver"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/layout"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"k8s.io/utils/ptr"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/logging"
+
+	pkgmetav1 "github.com/crossplane/crossplane/v2/apis/pkg/meta/v1"
+	"github.com/crossplane/crossplane/v2/apis/pkg/v1beta1"
+	"github.com/crossplane/crossplane/v2/internal/controller/pkg/resolver"
+	"github.com/crossplane/crossplane/v2/internal/dag"
+	"github.com/crossplane/crossplane/v2/internal/xpkg"
+)
+
+const (
+	errFmtFetchPackage      = "cannot fetch package %s"
+	errFmtParsePackage      = "cannot parse package %s"
+	errFmtResolveDependency = "cannot resolve a version of dependency %s"
+	errFmtListTags          = "cannot list tags of %s"
+	errFmtNoVersion         = "no version of %s satisfies constraints %s"
+	errFmtCopyPackage       = "cannot copy package %s into bundle"
+	errFmtNotConfiguration  = "package %s is a %s, not a Configuration"
+	errFmtUnsettled         = "cannot settle on dependency versions after %d attempts"
+	errMissingDependencies  = "bundle is missing dependencies"
+	errSortDependencies     = "cannot sort dependencies"
+	errInitDAG              = "cannot build dependency graph"
+	errWriteBundle          = "cannot write bundle"
+	errReadBundle           = "cannot read bundle"
+
+	// annotationRefName is the OCI image layout annotation that records the
+	// full reference of each package in a bundle.
+	annotationRefName = "org.opencontainers.image.ref.name"
+
+	// maxResolveAttempts is how many times we'll re-resolve dependency
+	// versions while they're still changing. Versions change when a newly
+	// resolved package adds constraints to a dependency we already resolved.
+	maxResolveAttempts = 10
+)
+
+// bundleCmd bundles a Configuration and its dependencies.
+type bundleCmd struct {
+	Create bundleCreateCmd `cmd:"" default:"withargs" help:"Bundle a Configuration and all of its dependencies."`
+	Push   bundlePushCmd   `cmd:""                   help:"Push a bundle to a registry."`
+}
+
+// Help prints out the help for the xpkg bundle command.
+func (c *bundleCmd) Help() string {
+	return `
+A bundle contains a Configuration package and every package it depends on. Use
+bundles to install packages in a control plane that can't reach the registries
+the packages are published to.
+
+Dependencies are resolved the same way Crossplane resolves them. Each
+dependency's version is the highest semantic version tag that satisfies the
+constraints of every package that depends on it. Dependencies pinned to a
+digest are bundled at that digest. Every platform of a multi-platform package
+is bundled, including any embedded runtime image.
+
+A bundle is an OCI image layout. It's written to a directory, or to a tarball
+if the output path ends in .tar.
+
+Examples:
+
+  # Bundle a Configuration and its dependencies into bundle.tar.
+  crossplane xpkg bundle xpkg.crossplane.io/crossplane/configuration-example:v1.0.0
+
+  # Push the bundle to an internal registry and print the ImageConfigs that
+  # rewrite package paths to the internal registry.
+  crossplane xpkg bundle push bundle.tar registry.acme.io/crossplane
+`
+}
+
+// bundleCreateCmd bundles a Configuration and its dependencies.
+type bundleCreateCmd struct {
+	// Arguments.
+	Package string `arg:"" help:"The Configuration to bundle. Must be a fully qualified OCI tag or digest." placeholder:"REGISTRY/REPOSITORY:TAG"`
+
+	// Flags. Keep sorted alphabetically.
+	InsecureSkipTLSVerify bool          `help:"[INSECURE] Skip verifying TLS certificates."`
+	Output                string        `default:"bundle.tar"                               help:"Where to write the bundle. Written as an OCI image layout directory unless the path ends in .tar." short:"o" type:"path"`
+	Timeout               time.Duration `default:"5m"                                       help:"How long to wait for the bundle to be written."`
+}
+
+// Run runs the xpkg bundle cmd.
+func (c *bundleCreateCmd) Run(logger logging.Logger) error {
+	ctx, cancel := context.WithTimeout(context.Background(), c.Timeout)
+	defer cancel()
+
+	ref, err := name.ParseReference(c.Package, name.StrictValidation)
+	if err != nil {
+		return errors.Wrapf(err, errFmtNewTag, c.Package)
+	}
+
+	opts := remoteOptions(c.InsecureSkipTLSVerify)
+	r := &dependencyResolver{opts: opts}
+
+	pkgs, err := r.Resolve(ctx, ref)
+	if err != nil {
+		return err
+	}
+
+	for _, p := range pkgs {
+		logger.Debug("Resolved package", "source", p.Source, "version", p.Version)
+	}
+
+	return errors.Wrap(writeBundle(ctx, pkgs, filepath.Clean(c.Output), opts...), errWriteBundle)
+}
+
+func remoteOptions(insecure bool) []remote.Option {
+	t := &http.Transport{
+		TLSClientConfig: &tls.Config{
+			InsecureSkipVerify: insecure, //nolint:gosec // we need to support insecure connections if requested
+		},
+	}
+
+	return []remote.Option{
+		remote.WithAuthFromKeychain(authn.DefaultKeychain),
+		remote.WithTransport(t),
+	}
+}
+
+// A dependencyResolver resolves package dependencies against their
+// registries, the same way Crossplane does.
+type dependencyResolver struct {
+	opts []remote.Option
+}
+
+// Resolve the supplied Configuration and its dependencies. Packages are
+// returned in the order they should be installed, i.e. each package follows
+// its dependencies.
+func (r *dependencyResolver) Resolve(ctx context.Context, ref name.Reference) ([]v1beta1.LockPackage, error) {
+	root, err := r.load(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	if ptr.Deref(root.Type, "") != v1beta1.ConfigurationPackageType {
+		return nil, errors.Errorf(errFmtNotConfiguration, ref, ptr.Deref(root.Type, ""))
+	}
+
+	return r.ResolveDependencies(ctx, root)
+}
+
+// ResolveDependencies resolves the dependencies of the supplied root package.
+// The root and its dependencies are returned in the order they should be
+// installed, i.e. each package follows its dependencies.
+func (r *dependencyResolver) ResolveDependencies(ctx context.Context, root *v1beta1.LockPackage) ([]v1beta1.LockPackage, error) {
+	pkgs := map[string]*v1beta1.LockPackage{root.Source: root}
+
+	// Resolve until no dependency's version changes. A version changes when
+	// a newly resolved package constrains an already resolved dependency.
+	for attempt := 0; ; attempt++ {
+		if attempt == maxResolveAttempts {
+			return nil, errors.Errorf(errFmtUnsettled, maxResolveAttempts)
+		}
+
+		constraints := dependencyConstraints(root.Source, pkgs)
+
+		changed := false
+
+		for _, src := range sortedKeys(constraints) {
+			if src == root.Source {
+				// The root's version is fixed. Depending on it is a cycle,
+				// which we'll catch when we sort the graph.
+				continue
+			}
+
+			version, err := r.resolveVersion(ctx, src, constraints[src])
+			if err != nil {
+				return nil, errors.Wrapf(err, errFmtResolveDependency, src)
+			}
+
+			if p, ok := pkgs[src]; ok && p.Version == version {
+				continue
+			}
+
+			dref, err := dependencyReference(src, version)
+			if err != nil {
+				return nil, errors.Wrapf(err, errFmtResolveDependency, src)
+			}
+
+			p, err := r.load(ctx, dref)
+			if err != nil {
+				return nil, err
+			}
+
+			// Use the dependency's identifier as written by its parents.
+			p.Source = src
+			pkgs[src] = p
+			changed = true
+		}
+
+		if !changed {
+			// Drop packages only older versions of our dependencies needed.
+			for src := range pkgs {
+				if _, ok := constraints[src]; !ok && src != root.Source {
+					delete(pkgs, src)
+				}
+			}
+
+			break
+		}
+	}
+
+	lps := make([]v1beta1.LockPackage, 0, len(pkgs))
+	for _, src := range sortedKeys(pkgs) {
+		lps = append(lps, *pkgs[src])
+	}
+
+	d := dag.NewMapDag()
+
+	implied, err := d.Init(v1beta1.ToNodes(lps...))
+	if err != nil {
+		return nil, errors.Wrap(err, errInitDAG)
+	}
+
+	if len(implied) > 0 {
+		return nil, errors.Errorf("%s: %s", errMissingDependencies, implied[0].Identifier())
+	}
+
+	order, err := d.Sort()
+	if err != nil {
+		return nil, errors.Wrap(err, errSortDependencies)
+	}
+
+	sorted := make([]v1beta1.LockPackage, len(order))
+	for i, src := range order {
+		sorted[i] = *pkgs[src]
+	}
+
+	return sorted, nil
+}
+
+// dependencyConstraints returns the version constraints of every package
+// reachable from the supplied root, keyed by package source.
+func dependencyConstraints(root string, pkgs map[string]*v1beta1.LockPackage) map[string][]string {
+	constraints := map[string][]string{}
+	visited := map[string]bool{root: true}
+	queue := []string{root}
+
+	for len(queue) > 0 {
+		p := pkgs[queue[0]]
+		queue = queue[1:]
+
+		for _, dep := range p.Dependencies {
+			constraints[dep.Package] = append(constraints[dep.Package], dep.Constraints)
+
+			if _, ok := pkgs[dep.Package]; !ok || visited[dep.Package] {
+				continue
+			}
+
+			visited[dep.Package] = true
+			queue = append(queue, dep.Package)
+		}
+	}
+
+	return constraints
+}
+
+// resolveVersion returns the version of the supplied package that satisfies
+// all of the supplied constraints. We use the same semantics as Crossplane's
+// dependency resolver: a package that's pinned to a digest uses that digest,
+// and a package can't be constrained by both a digest and a semantic version.
+// Otherwise we use the highest version that satisfies the constraints.
+func (r *dependencyResolver) resolveVersion(ctx context.Context, src string, constraints []string) (string, error) {
+	digest, err := resolver.FindDigestToUpdate(&v1beta1.LockPackage{Source: src, ParentConstraints: constraints})
+	if err != nil {
+		return "", err
+	}
+
+	if digest != "" {
+		return digest, nil
+	}
+
+	semvers := make([]*semver.Constraints, 0, len(constraints))
+
+	for _, c := range constraints {
+		sc, err := semver.NewConstraint(c)
+		if err != nil {
+			return "", errors.Wrapf(err, "invalid version constraint %q", c)
+		}
+
+		semvers = append(semvers, sc)
+	}
+
+	repo, err := name.NewRepository(src)
+	if err != nil {
+		return "", err
+	}
+
+	tags, err := remote.List(repo, append(r.opts, remote.WithContext(ctx))...)
+	if err != nil {
+		return "", errors.Wrapf(err, errFmtListTags, src)
+	}
+
+	// Crossplane installs the highest version that satisfies a dependency's
+	// constraint. We need one that satisfies every constraint, so we only
+	// consider the tags that satisfy all of them.
+	valid := make([]string, 0, len(tags))
+
+	for _, t := range tags {
+		if satisfiesAll(t, semvers) {
+			valid = append(valid, t)
+		}
+	}
+
+	if v := resolver.FindVersionToInstall(semvers[0], valid); v != "" {
+		return v, nil
+	}
+
+	return "", errors.Errorf(errFmtNoVersion, src, strings.Join(constraints, ", "))
+}
+
+// satisfiesAll returns true if the supplied tag is a version that satisfies all
+// of the supplied constraints.
+func satisfiesAll(tag string, cs []*semver.Constraints) bool {
+	for _, c := range cs {
+		if resolver.FindVersionToInstall(c, []string{tag}) != tag {
+			return false
+		}
+	}
+
+	return true
+}
+
+// dependencyReference returns a reference to the supplied version of a
+// dependency. The version is either a tag or a digest.
+func dependencyReference(src, version string) (name.Reference, error) {
+	// Dependency identifiers may omit the registry, so we can't enforce
+	// strict validation.
+	repo, err := name.NewRepository(src)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := v1.NewHash(version); err == nil {
+		return repo.Digest(version), nil
+	}
+
+	return repo.Tag(version), nil
+}
+
+// load fetches the supplied package and returns it as a lock package.
+func (r *dependencyResolver) load(ctx context.Context, ref name.Reference) (*v1beta1.LockPackage, error) {
+	img, err := remote.Image(ref, append(r.opts, remote.WithContext(ctx))...)
+	if err != nil {
+		return nil, errors.Wrapf(err, errFmtFetchPackage, ref)
+	}
+
+	meta, err := xpkg.PackageMeta(ctx, img)
+	if err != nil {
+		return nil, errors.Wrapf(err, errFmtParsePackage, ref)
+	}
+
+	deps, err := xpkg.ToDependencies(meta.GetDependencies())
+	if err != nil {
+		return nil, errors.Wrapf(err, errFmtParsePackage, ref)
+	}
+
+	lp := &v1beta1.LockPackage{
+		Source:       xpkg.ParsePackageSourceFromReference(ref),
+		Version:      ref.Identifier(),
+		Dependencies: deps,
+	}
+
+	switch meta.(type) {
+	case *pkgmetav1.Configuration:
+		lp.Type = ptr.To(v1beta1.ConfigurationPackageType)
+	case *pkgmetav1.Provider:
+		lp.Type = ptr.To(v1beta1.ProviderPackageType)
+	case *pkgmetav1.Function:
+		lp.Type = ptr.To(v1beta1.FunctionPackageType)
+	}
+
+	return lp, nil
+}
+
+// writeBundle writes the supplied packages to a bundle at the supplied path. Each package
+// is annotated with its full reference.
+func writeBundle(ctx context.Context, pkgs []v1beta1.LockPackage, path string, opts ...remote.Option) error {
+	dir := path
+
+	if isTarball(path) {
+		tmp, err := os.MkdirTemp("", "xpkg-bundle-")
+		if err != nil {
+			return err
+		}
+		defer os.RemoveAll(tmp) //nolint:errcheck // Best effort cleanup.
+
+		dir = tmp
+	}
+
+	p, err := layout.Write(dir, empty.Index)
+	if err != nil {
+		return err
+	}
+
+	for _, pkg := range pkgs {
+		ref, err := dependencyReference(pkg.Source, pkg.Version)
+		if err != nil {
+			return errors.Wrapf(err, errFmtCopyPackage, pkg.Source)
+		}
+
+		if err := appendPackage(ctx, p, ref, opts...); err != nil {
+			return errors.Wrapf(err, errFmtCopyPackage, ref)
+		}
+	}
+
+	if !isTarball(path) {
+		return nil
+	}
+
+	return writeTarball(dir, path)
+}
+
+// appendPackage appends the supplied package to an OCI image layout. All of
+// a multi-platform package's images are appended.
+func appendPackage(ctx context.Context, p layout.Path, ref name.Reference, opts ...remote.Option) error {
+	desc, err := remote.Get(ref, append(opts, remote.WithContext(ctx))...)
+	if err != nil {
+		return err
+	}
+
+	anno := layout.WithAnnotations(map[string]string{annotationRefName: ref.String()})
+
+	if desc.MediaType.IsIndex() {
+		idx, err := desc.ImageIndex()
+		if err != nil {
+			return err
+		}
+
+		return p.AppendIndex(idx, anno)
+	}
+
+	img, err := desc.Image()
+	if err != nil {
+		return err
+	}
+
+	return p.AppendImage(img, anno)
+}
+
+func isTarball(path string) bool {
+	return filepath.Ext(path) == ".tar"
+}
+
+// writeTarball writes the supplied directory to a tarball.
+func writeTarball(dir, path string) error {
+	f, err := os.Create(filepath.Clean(path))
+	if err != nil {
+		return err
+	}
+	defer f.Close() //nolint:errcheck // We check the error of the explicit Close below.
+
+	tw := tar.NewWriter(f)
+
+	err = filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || p == dir {
+			return err
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+
+		h, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+
+		h.Name = filepath.ToSlash(rel)
+
+		if err := tw.WriteHeader(h); err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			return nil
+		}
+
+		src, err := os.Open(filepath.Clean(p))
+		if err != nil {
+			return err
+		}
+		defer src.Close() //nolint:errcheck // Only open for reading.
+
+		_, err = io.Copy(tw, src)
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+
+	return f.Close()
+}
+
+// readTarball extracts the supplied tarball to a directory.
+func readTarball(path, dir string) error {
+	f, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return err
+	}
+	defer f.Close() //nolint:errcheck // Only open for reading.
+
+	tr := tar.NewReader(f)
+
+	for {
+		h, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if !filepath.IsLocal(h.Name) {
+			return errors.Errorf("invalid path %q in bundle", h.Name)
+		}
+
+		dst := filepath.Join(dir, filepath.FromSlash(h.Name))
+
+		switch h.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(dst, 0o750); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(dst), 0o750); err != nil {
+				return err
+			}
+
+			if err := extractFile(tr, dst); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func extractFile(r io.Reader, dst string) error {
+	f, err := os.OpenFile(filepath.Clean(dst), os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	defer f.Close() //nolint:errcheck // We check the error of the explicit Close below.
+
+	if _, err := io.Copy(f, r); err != nil { //nolint:gosec // Bundles are written by xpkg bundle and are only as large as their packages.
+		return err
+	}
+
+	return f.Close()
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
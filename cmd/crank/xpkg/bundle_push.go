@@ -0,0 +1,238 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package xpkg
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/alecthomas/kong"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/layout"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/logging"
+
+	"github.com/crossplane/crossplane/v2/apis/pkg/v1beta1"
+)
+
+const (
+	errFmtMissingRefName = "bundled package %s has no %s annotation"
+	errFmtPushBundled    = "cannot push bundled package %s to %s"
+	errFmtTargetConflict = "packages %s and %s would both be pushed to %s"
+	errInvalidRegistry   = "invalid target registry"
+	errWriteImageConfigs = "cannot write ImageConfigs"
+)
+
+// bundlePushCmd pushes a bundle to a registry.
+type bundlePushCmd struct {
+	// Arguments.
+	Bundle   string `arg:"" help:"The bundle to push. Either an OCI image layout directory or a .tar file."                  type:"path"`
+	Registry string `arg:"" help:"The registry, and optionally repository prefix, to push the bundle's packages to." placeholder:"REGISTRY[/PREFIX]"`
+
+	// Flags. Keep sorted alphabetically.
+	InsecureSkipTLSVerify bool          `help:"[INSECURE] Skip verifying TLS certificates."`
+	Timeout               time.Duration `default:"5m"                                       help:"How long to wait for the bundle to be pushed."`
+}
+
+// Help prints out the help for the xpkg bundle push command.
+func (c *bundlePushCmd) Help() string {
+	return `
+Push every package in a bundle to a registry. Each package keeps its repository
+path and tag or digest, under the supplied registry and optional prefix.
+
+When the push succeeds the ImageConfigs needed to pull the packages from the
+target registry are printed. Apply them to a control plane before installing
+the bundled Configuration.
+
+Examples:
+
+  # Push the packages in bundle.tar to registry.acme.io/crossplane. The package
+  # xpkg.crossplane.io/crossplane-contrib/provider-nop:v0.4.0 is pushed to
+  # registry.acme.io/crossplane/crossplane-contrib/provider-nop:v0.4.0.
+  crossplane xpkg bundle push bundle.tar registry.acme.io/crossplane
+
+  # Push the bundle and apply the ImageConfigs it needs.
+  crossplane xpkg bundle push bundle.tar registry.acme.io/crossplane | kubectl apply -f -
+`
+}
+
+// Run runs the xpkg bundle push cmd.
+func (c *bundlePushCmd) Run(k *kong.Context, logger logging.Logger) error {
+	ctx, cancel := context.WithTimeout(context.Background(), c.Timeout)
+	defer cancel()
+
+	dir := filepath.Clean(c.Bundle)
+
+	if isTarball(dir) {
+		tmp, err := os.MkdirTemp("", "xpkg-bundle-")
+		if err != nil {
+			return errors.Wrap(err, errReadBundle)
+		}
+		defer os.RemoveAll(tmp) //nolint:errcheck // Best effort cleanup.
+
+		if err := readTarball(dir, tmp); err != nil {
+			return errors.Wrap(err, errReadBundle)
+		}
+
+		dir = tmp
+	}
+
+	pushed, err := pushBundle(ctx, logger, layout.Path(dir), c.Registry, remoteOptions(c.InsecureSkipTLSVerify)...)
+	if err != nil {
+		return err
+	}
+
+	return errors.Wrap(writeImageConfigs(k.Stdout, c.Registry, pushed), errWriteImageConfigs)
+}
+
+// pushBundle pushes the packages in the supplied bundle to the supplied
+// registry. It returns the source references of the pushed packages.
+func pushBundle(ctx context.Context, logger logging.Logger, p layout.Path, registry string, opts ...remote.Option) ([]name.Reference, error) {
+	idx, err := p.ImageIndex()
+	if err != nil {
+		return nil, errors.Wrap(err, errReadBundle)
+	}
+
+	m, err := idx.IndexManifest()
+	if err != nil {
+		return nil, errors.Wrap(err, errReadBundle)
+	}
+
+	opts = append(opts, remote.WithContext(ctx))
+	pushed := make([]name.Reference, 0, len(m.Manifests))
+	targets := map[string]name.Reference{}
+
+	for _, desc := range m.Manifests {
+		src, ok := desc.Annotations[annotationRefName]
+		if !ok {
+			return nil, errors.Errorf(errFmtMissingRefName, desc.Digest, annotationRefName)
+		}
+
+		ref, err := name.ParseReference(src)
+		if err != nil {
+			return nil, errors.Wrap(err, errReadBundle)
+		}
+
+		dst, err := bundleTarget(registry, ref)
+		if err != nil {
+			return nil, err
+		}
+
+		// Two source registries may host the same repository. We'd push them
+		// to the same target repository, where they'd clobber each other.
+		if other, ok := targets[dst.Context().Name()]; ok && other.Context().Name() != ref.Context().Name() {
+			return nil, errors.Errorf(errFmtTargetConflict, other.Context(), ref.Context(), dst.Context())
+		}
+
+		targets[dst.Context().Name()] = ref
+
+		if desc.MediaType.IsIndex() {
+			ii, err := idx.ImageIndex(desc.Digest)
+			if err != nil {
+				return nil, errors.Wrapf(err, errFmtPushBundled, ref, dst)
+			}
+
+			if err := remote.WriteIndex(dst, ii, opts...); err != nil {
+				return nil, errors.Wrapf(err, errFmtPushBundled, ref, dst)
+			}
+		} else {
+			img, err := idx.Image(desc.Digest)
+			if err != nil {
+				return nil, errors.Wrapf(err, errFmtPushBundled, ref, dst)
+			}
+
+			if err := remote.Write(dst, img, opts...); err != nil {
+				return nil, errors.Wrapf(err, errFmtPushBundled, ref, dst)
+			}
+		}
+
+		logger.Debug("Pushed bundled package", "source", ref.String(), "target", dst.String())
+
+		pushed = append(pushed, ref)
+	}
+
+	return pushed, nil
+}
+
+// bundleTarget returns where the supplied package should be pushed in the
+// supplied registry.
+func bundleTarget(registry string, ref name.Reference) (name.Reference, error) {
+	t, err := dependencyReference(strings.TrimSuffix(registry, "/")+"/"+ref.Context().RepositoryStr(), ref.Identifier())
+	return t, errors.Wrap(err, errInvalidRegistry)
+}
+
+var notDNSLabel = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// writeImageConfigs writes an ImageConfig for each source registry of the
+// supplied packages. Each ImageConfig rewrites the registry's packages to the
+// supplied target registry.
+func writeImageConfigs(w io.Writer, registry string, pushed []name.Reference) error {
+	seen := map[string]bool{}
+
+	for _, ref := range pushed {
+		src := ref.Context().RegistryStr()
+		if seen[src] {
+			continue
+		}
+
+		seen[src] = true
+
+		ic := &v1beta1.ImageConfig{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: v1beta1.SchemeGroupVersion.String(),
+				Kind:       v1beta1.ImageConfigKind,
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "bundle-" + strings.Trim(notDNSLabel.ReplaceAllString(strings.ToLower(src), "-"), "-"),
+			},
+			Spec: v1beta1.ImageConfigSpec{
+				MatchImages:  []v1beta1.ImageMatch{{Type: v1beta1.Prefix, Prefix: src + "/"}},
+				RewriteImage: &v1beta1.ImageRewrite{Prefix: strings.TrimSuffix(registry, "/") + "/"},
+			},
+		}
+
+		u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(ic)
+		if err != nil {
+			return err
+		}
+
+		unstructured.RemoveNestedField(u, "metadata", "creationTimestamp")
+
+		b, err := yaml.Marshal(u)
+		if err != nil {
+			return err
+		}
+
+		if _, err := fmt.Fprintf(w, "---\n%s", b); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
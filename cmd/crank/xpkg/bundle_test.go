@@ -0,0 +1,343 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package xpkg
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/layout"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/logging"
+
+	"github.com/crossplane/crossplane/v2/internal/xpkg"
+)
+
+func newTestRegistry(t *testing.T) string {
+	t.Helper()
+
+	s := httptest.NewServer(registry.New())
+	t.Cleanup(s.Close)
+
+	return strings.TrimPrefix(s.URL, "http://")
+}
+
+func testPackageImage(t *testing.T, meta string) v1.Image {
+	t.Helper()
+
+	buf := &bytes.Buffer{}
+	tw := tar.NewWriter(buf)
+
+	if err := tw.WriteHeader(&tar.Header{Name: xpkg.StreamFile, Mode: 0o644, Size: int64(len(meta))}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := tw.Write([]byte(meta)); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	img, err := mutate.Append(empty.Image, mutate.Addendum{
+		Layer:       static.NewLayer(buf.Bytes(), types.DockerLayer),
+		Annotations: map[string]string{xpkg.AnnotationKey: xpkg.PackageAnnotation},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return img
+}
+
+func pushPackage(t *testing.T, ref string, meta string) {
+	t.Helper()
+
+	if err := remote.Write(mustParse(t, ref), testPackageImage(t, meta)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func pushMultiPlatformPackage(t *testing.T, ref string, meta string) {
+	t.Helper()
+
+	idx := mutate.AppendManifests(empty.Index,
+		mutate.IndexAddendum{Add: testPackageImage(t, meta), Descriptor: v1.Descriptor{Platform: &v1.Platform{OS: "linux", Architecture: "amd64"}}},
+		mutate.IndexAddendum{Add: testPackageImage(t, meta+"\n# arm64\n"), Descriptor: v1.Descriptor{Platform: &v1.Platform{OS: "linux", Architecture: "arm64"}}},
+	)
+
+	if err := remote.WriteIndex(mustParse(t, ref), idx); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestBundle(t *testing.T) {
+	src := newTestRegistry(t)
+	dst := newTestRegistry(t)
+
+	provider := src + "/acme/provider-a"
+	platform := src + "/acme/configuration-platform"
+	app := src + "/acme/configuration-app"
+
+	providerMeta := `
+apiVersion: meta.pkg.crossplane.io/v1
+kind: Provider
+metadata:
+  name: provider-a
+`
+	for _, tag := range []string{"v1.0.0", "v1.2.0", "v2"} {
+		pushPackage(t, provider+":"+tag, providerMeta)
+	}
+
+	pushMultiPlatformPackage(t, provider+":v1.1.0", providerMeta)
+
+	pushPackage(t, platform+":v1.0.0", fmt.Sprintf(`
+apiVersion: meta.pkg.crossplane.io/v1
+kind: Configuration
+metadata:
+  name: configuration-platform
+spec:
+  dependsOn:
+  - provider: %s
+    version: "<v1.2.0"
+`, provider))
+
+	pushPackage(t, app+":v1.0.0", fmt.Sprintf(`
+apiVersion: meta.pkg.crossplane.io/v1
+kind: Configuration
+metadata:
+  name: configuration-app
+spec:
+  dependsOn:
+  - provider: %s
+    version: ">=v1.0.0"
+  - configuration: %s
+    version: ">=v1.0.0"
+`, provider, platform))
+
+	r := &dependencyResolver{}
+
+	pkgs, err := r.Resolve(context.Background(), mustParse(t, app+":v1.0.0"))
+	if err != nil {
+		t.Fatalf("Resolve(...): %v", err)
+	}
+
+	got := make([]string, len(pkgs))
+	for i, p := range pkgs {
+		got[i] = p.Source + ":" + p.Version
+	}
+
+	// The provider must satisfy both configurations' constraints, so v1.1.0 is
+	// the highest version we can use. The incomplete v2 tag is ignored. Each
+	// package must follow its dependencies.
+	want := []string{provider + ":v1.1.0", platform + ":v1.0.0", app + ":v1.0.0"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Resolve(...): -want, +got:\n%s", diff)
+	}
+
+	out := filepath.Join(t.TempDir(), "bundle.tar")
+	if err := writeBundle(context.Background(), pkgs, out); err != nil {
+		t.Fatalf("writeBundle(...): %v", err)
+	}
+
+	dir := t.TempDir()
+	if err := readTarball(out, dir); err != nil {
+		t.Fatalf("readTarball(...): %v", err)
+	}
+
+	pushed, err := pushBundle(context.Background(), logging.NewNopLogger(), layout.Path(dir), dst+"/mirror")
+	if err != nil {
+		t.Fatalf("pushBundle(...): %v", err)
+	}
+
+	if diff := cmp.Diff(len(want), len(pushed)); diff != "" {
+		t.Errorf("pushBundle(...): -want pushed, +got pushed:\n%s", diff)
+	}
+
+	// The multi-platform provider should be pushed as an index.
+	desc, err := remote.Get(mustParse(t, dst+"/mirror/acme/provider-a:v1.1.0"))
+	if err != nil {
+		t.Fatalf("remote.Get(...): %v", err)
+	}
+
+	if !desc.MediaType.IsIndex() {
+		t.Errorf("remote.Get(...): want pushed provider to be an index, got %s", desc.MediaType)
+	}
+
+	for _, ref := range []string{platform, app} {
+		target := strings.Replace(ref, src, dst+"/mirror", 1) + ":v1.0.0"
+		if _, err := remote.Head(mustParse(t, target)); err != nil {
+			t.Errorf("remote.Head(%s): %v", target, err)
+		}
+	}
+
+	buf := &bytes.Buffer{}
+	if err := writeImageConfigs(buf, dst+"/mirror", pushed); err != nil {
+		t.Fatalf("writeImageConfigs(...): %v", err)
+	}
+
+	wantConfigs := fmt.Sprintf(`---
+apiVersion: pkg.crossplane.io/v1beta1
+kind: ImageConfig
+metadata:
+  name: bundle-%s
+spec:
+  matchImages:
+  - prefix: %s/
+    type: Prefix
+  rewriteImage:
+    prefix: %s/mirror/
+`, strings.NewReplacer(".", "-", ":", "-").Replace(src), src, dst)
+	if diff := cmp.Diff(wantConfigs, buf.String()); diff != "" {
+		t.Errorf("writeImageConfigs(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestBundleResolveErrors(t *testing.T) {
+	src := newTestRegistry(t)
+
+	provider := src + "/acme/provider-a"
+	pushPackage(t, provider+":v1.0.0", `
+apiVersion: meta.pkg.crossplane.io/v1
+kind: Provider
+metadata:
+  name: provider-a
+`)
+
+	cyclic := src + "/acme/configuration-cyclic"
+	pushPackage(t, cyclic+":v1.0.0", fmt.Sprintf(`
+apiVersion: meta.pkg.crossplane.io/v1
+kind: Configuration
+metadata:
+  name: configuration-cyclic
+spec:
+  dependsOn:
+  - configuration: %s
+    version: ">=v1.0.0"
+`, src+"/acme/configuration-other"))
+	pushPackage(t, src+"/acme/configuration-other:v1.0.0", fmt.Sprintf(`
+apiVersion: meta.pkg.crossplane.io/v1
+kind: Configuration
+metadata:
+  name: configuration-other
+spec:
+  dependsOn:
+  - configuration: %s
+    version: ">=v1.0.0"
+`, cyclic))
+
+	unsatisfiable := src + "/acme/configuration-unsatisfiable"
+	pushPackage(t, unsatisfiable+":v1.0.0", fmt.Sprintf(`
+apiVersion: meta.pkg.crossplane.io/v1
+kind: Configuration
+metadata:
+  name: configuration-unsatisfiable
+spec:
+  dependsOn:
+  - provider: %s
+    version: ">=v2.0.0"
+`, provider))
+
+	d, err := remote.Head(mustParse(t, provider+":v1.0.0"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pinned := src + "/acme/configuration-pinned"
+	pushPackage(t, pinned+":v1.0.0", fmt.Sprintf(`
+apiVersion: meta.pkg.crossplane.io/v1
+kind: Configuration
+metadata:
+  name: configuration-pinned
+spec:
+  dependsOn:
+  - provider: %s
+    version: %s
+`, provider, d.Digest))
+
+	mixed := src + "/acme/configuration-mixed"
+	pushPackage(t, mixed+":v1.0.0", fmt.Sprintf(`
+apiVersion: meta.pkg.crossplane.io/v1
+kind: Configuration
+metadata:
+  name: configuration-mixed
+spec:
+  dependsOn:
+  - provider: %s
+    version: ">=v1.0.0"
+  - configuration: %s
+    version: ">=v1.0.0"
+`, provider, pinned))
+
+	cases := map[string]struct {
+		reason string
+		ref    string
+	}{
+		"NotConfiguration": {
+			reason: "Only Configurations can be bundled.",
+			ref:    provider + ":v1.0.0",
+		},
+		"Cycle": {
+			reason: "Dependency cycles should be rejected.",
+			ref:    cyclic + ":v1.0.0",
+		},
+		"Unsatisfiable": {
+			reason: "A dependency with no version that satisfies its constraints should be rejected.",
+			ref:    unsatisfiable + ":v1.0.0",
+		},
+		"MixedConstraintTypes": {
+			reason: "A dependency constrained by both a digest and a semantic version should be rejected.",
+			ref:    mixed + ":v1.0.0",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := &dependencyResolver{}
+			if _, err := r.Resolve(context.Background(), mustParse(t, tc.ref)); err == nil {
+				t.Errorf("\n%s\nResolve(...): want error, got nil", tc.reason)
+			}
+		})
+	}
+}
+
+func mustParse(t *testing.T, ref string) name.Reference {
+	t.Helper()
+
+	r, err := name.ParseReference(ref)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return r
+}
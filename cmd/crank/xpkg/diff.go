@@ -0,0 +1,612 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package xpkg
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/alecthomas/kong"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+	admv1 "k8s.io/api/admissionregistration/v1"
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/logging"
+
+	xpv1 "github.com/crossplane/crossplane/v2/apis/apiextensions/v1"
+	"github.com/crossplane/crossplane/v2/apis/apiextensions/v1alpha1"
+	xpv2 "github.com/crossplane/crossplane/v2/apis/apiextensions/v2"
+	pkgmetav1 "github.com/crossplane/crossplane/v2/apis/pkg/meta/v1"
+	"github.com/crossplane/crossplane/v2/internal/xcrd"
+	"github.com/crossplane/crossplane/v2/internal/xpkg"
+	"github.com/crossplane/crossplane/v2/internal/xpkg/parser/yaml"
+)
+
+const (
+	errFmtFetchDiffPackage = "cannot fetch package %s"
+	errFmtParseSchema      = "cannot parse schema of %s version %s"
+	errWriteDiff           = "cannot write diff"
+	errNoImages            = "package image index has no images"
+)
+
+// A ChangeType is the type of a change between two packages.
+type ChangeType string
+
+// Types of change.
+const (
+	ChangeAdded   ChangeType = "Added"
+	ChangeRemoved ChangeType = "Removed"
+	ChangeChanged ChangeType = "Changed"
+)
+
+// What changed between two packages.
+const (
+	SubjectKind        = "Kind"
+	SubjectVersion     = "Version"
+	SubjectSchema      = "Schema"
+	SubjectComposition = "Composition"
+	SubjectWebhook     = "Webhook"
+	SubjectDependency  = "Dependency"
+	SubjectCrossplane  = "Crossplane"
+)
+
+// A Change between two packages.
+type Change struct {
+	// Type of change.
+	Type ChangeType `json:"type"`
+
+	// Subject of the change, for example Kind or Schema.
+	Subject string `json:"subject"`
+
+	// Name of the changed thing, for example a kind's group kind, or a
+	// dependency's package.
+	Name string `json:"name"`
+
+	// Version of the kind whose schema changed.
+	Version string `json:"version,omitempty"`
+
+	// Path of the schema field that changed.
+	Path string `json:"path,omitempty"`
+
+	// Message describing the change.
+	Message string `json:"message"`
+
+	// Breaking is true if the change may break existing users of the package.
+	Breaking bool `json:"breaking"`
+}
+
+// diffCmd compares two versions of a package.
+type diffCmd struct {
+	// Arguments.
+	Old string `arg:"" help:"The old version of the package. Either an xpkg file, or an OCI image reference." placeholder:"PATH or REGISTRY/REPOSITORY:TAG"`
+	New string `arg:"" help:"The new version of the package. Either an xpkg file, or an OCI image reference." placeholder:"PATH or REGISTRY/REPOSITORY:TAG"`
+
+	// Flags. Keep sorted alphabetically.
+	FromDaemon bool          `help:"Fetch packages that aren't xpkg files from the Docker daemon, rather than a registry."`
+	Output     string        `default:"text"                                                                               enum:"text,json" help:"Output format. One of: text, json." short:"o"`
+	Timeout    time.Duration `default:"1m"                                                                                 help:"How long to wait to fetch the packages."`
+}
+
+// Help prints out the help for the xpkg diff command.
+func (c *diffCmd) Help() string {
+	return `
+Compare two versions of a package before upgrading it. Each version is read
+from an xpkg file if one exists at the supplied path, otherwise it's fetched
+from a registry, or from the Docker daemon if --from-daemon is set.
+
+The diff reports:
+
+  * Kinds the package's CRDs, MRDs, and XRDs add or remove.
+  * Versions of those kinds that are added or removed.
+  * Schema changes to each version of those kinds, classified as breaking or
+    non-breaking. Removing a field, changing its type, making it required,
+    narrowing its enum, and tightening its validation are breaking changes.
+  * Compositions and webhook configurations that are added, removed, or
+    changed.
+  * Changes to the package's dependencies, and to its Crossplane version
+    constraints.
+
+Examples:
+
+  # Compare two versions of a provider in a registry.
+  crossplane xpkg diff xpkg.crossplane.io/crossplane-contrib/provider-nop:v0.3.0 \
+    xpkg.crossplane.io/crossplane-contrib/provider-nop:v0.4.0
+
+  # Compare a locally built package with the published version, as JSON.
+  crossplane xpkg diff xpkg.crossplane.io/crossplane-contrib/provider-nop:v0.4.0 \
+    provider-nop.xpkg -o json
+`
+}
+
+// Run runs the xpkg diff cmd.
+func (c *diffCmd) Run(k *kong.Context, _ logging.Logger) error {
+	ctx, cancel := context.WithTimeout(context.Background(), c.Timeout)
+	defer cancel()
+
+	// Multi-platform packages are extracted here, and read lazily.
+	tmp, err := os.MkdirTemp("", "xpkg-diff-")
+	if err != nil {
+		return errors.Wrap(err, errTempDir)
+	}
+	defer os.RemoveAll(tmp) //nolint:errcheck // Best effort cleanup.
+
+	o, err := c.load(ctx, c.Old, filepath.Join(tmp, "old"))
+	if err != nil {
+		return err
+	}
+
+	n, err := c.load(ctx, c.New, filepath.Join(tmp, "new"))
+	if err != nil {
+		return err
+	}
+
+	return errors.Wrap(writeChanges(k.Stdout, c.Output, Diff(o, n)), errWriteDiff)
+}
+
+// load fetches and parses the supplied package. Multi-platform packages are
+// extracted to the supplied directory.
+func (c *diffCmd) load(ctx context.Context, pkg, dir string) (*PackageContents, error) {
+	img, err := c.fetch(ctx, pkg, dir)
+	if err != nil {
+		return nil, errors.Wrapf(err, errFmtFetchDiffPackage, pkg)
+	}
+
+	stream, err := packageStream(img)
+	if err != nil {
+		return nil, errors.Wrapf(err, errFmtParsePackage, pkg)
+	}
+
+	p, err := yaml.New()
+	if err != nil {
+		return nil, errors.Wrapf(err, errFmtParsePackage, pkg)
+	}
+
+	parsed, err := p.Parse(ctx, io.NopCloser(stream))
+	if err != nil {
+		return nil, errors.Wrapf(err, errFmtParsePackage, pkg)
+	}
+
+	pc, err := NewPackageContents(parsed.GetMeta(), parsed.GetObjects())
+
+	return pc, errors.Wrapf(err, errFmtParsePackage, pkg)
+}
+
+// fetch returns the image of the supplied package, reading it from an xpkg
+// file if one exists.
+func (c *diffCmd) fetch(ctx context.Context, pkg, dir string) (v1.Image, error) {
+	if fi, err := os.Stat(pkg); err == nil && fi.Mode().IsRegular() {
+		if !isImageLayout(pkg) {
+			return tarball.ImageFromPath(filepath.Clean(pkg), nil)
+		}
+
+		// A multi-platform package built by xpkg build. Every platform's
+		// image has the same package contents, so we just read the first.
+		imgs, err := readImageLayout(pkg, dir)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(imgs) == 0 {
+			return nil, errors.New(errNoImages)
+		}
+
+		return imgs[0].Image, nil
+	}
+
+	ref, err := name.ParseReference(pkg, name.StrictValidation)
+	if err != nil {
+		return nil, errors.Wrap(err, errInvalidTag)
+	}
+
+	if c.FromDaemon {
+		return daemonFetch(ctx, ref)
+	}
+
+	return registryFetch(ctx, ref)
+}
+
+// A Definition is a kind defined by a CRD, MRD, or XRD.
+type Definition struct {
+	// Kind of the defining resource, e.g. CustomResourceDefinition.
+	DefinedBy string
+
+	// Schemas of the kind's served versions.
+	Versions map[string]*extv1.JSONSchemaProps
+}
+
+// PackageContents are the parts of a package that are compared by Diff.
+type PackageContents struct {
+	Meta pkgmetav1.Pkg
+
+	// Definitions keyed by group kind, e.g. Bucket.s3.aws.upbound.io.
+	Definitions map[string]Definition
+
+	// Compositions keyed by name.
+	Compositions map[string]*xpv1.Composition
+
+	// Webhooks keyed by kind and name, e.g.
+	// ValidatingWebhookConfiguration/cool-webhook.
+	Webhooks map[string]runtime.Object
+}
+
+// NewPackageContents returns the contents of a package from its parsed meta
+// and objects.
+func NewPackageContents(metas, objects []runtime.Object) (*PackageContents, error) {
+	pc := &PackageContents{
+		Definitions:  map[string]Definition{},
+		Compositions: map[string]*xpv1.Composition{},
+		Webhooks:     map[string]runtime.Object{},
+	}
+
+	if len(metas) == 1 {
+		if m, ok := xpkg.TryConvertToPkg(metas[0], &pkgmetav1.Provider{}, &pkgmetav1.Configuration{}, &pkgmetav1.Function{}); ok {
+			pc.Meta = m
+		}
+	}
+
+	for _, o := range objects {
+		var (
+			gk  schema.GroupKind
+			d   Definition
+			err error
+		)
+
+		switch obj := o.(type) {
+		case *extv1.CustomResourceDefinition:
+			gk = schema.GroupKind{Group: obj.Spec.Group, Kind: obj.Spec.Names.Kind}
+			d = Definition{DefinedBy: "CustomResourceDefinition", Versions: map[string]*extv1.JSONSchemaProps{}}
+
+			for _, v := range obj.Spec.Versions {
+				if !v.Served {
+					continue
+				}
+
+				d.Versions[v.Name] = &extv1.JSONSchemaProps{}
+				if v.Schema != nil && v.Schema.OpenAPIV3Schema != nil {
+					d.Versions[v.Name] = v.Schema.OpenAPIV3Schema
+				}
+			}
+		case *v1alpha1.ManagedResourceDefinition:
+			gk = schema.GroupKind{Group: obj.Spec.Group, Kind: obj.Spec.Names.Kind}
+			vs := make([]rawVersion, 0, len(obj.Spec.Versions))
+
+			for _, v := range obj.Spec.Versions {
+				rv := rawVersion{name: v.Name, served: v.Served}
+				if v.Schema != nil {
+					rv.schema = v.Schema.OpenAPIV3Schema.Raw
+				}
+
+				vs = append(vs, rv)
+			}
+
+			d, err = newDefinition("ManagedResourceDefinition", obj.GetName(), vs)
+		case *xpv1.CompositeResourceDefinition:
+			gk = schema.GroupKind{Group: obj.Spec.Group, Kind: obj.Spec.Names.Kind}
+			vs := make([]rawVersion, 0, len(obj.Spec.Versions))
+
+			for _, v := range obj.Spec.Versions {
+				rv := rawVersion{name: v.Name, served: v.Served}
+				if v.Schema != nil {
+					rv.schema = v.Schema.OpenAPIV3Schema.Raw
+				}
+
+				vs = append(vs, rv)
+			}
+
+			d, err = newDefinition("CompositeResourceDefinition", obj.GetName(), vs)
+		case *xpv2.CompositeResourceDefinition:
+			gk = schema.GroupKind{Group: obj.Spec.Group, Kind: obj.Spec.Names.Kind}
+			vs := make([]rawVersion, 0, len(obj.Spec.Versions))
+
+			for _, v := range obj.Spec.Versions {
+				rv := rawVersion{name: v.Name, served: v.Served}
+				if v.Schema != nil {
+					rv.schema = v.Schema.OpenAPIV3Schema.Raw
+				}
+
+				vs = append(vs, rv)
+			}
+
+			d, err = newDefinition("CompositeResourceDefinition", obj.GetName(), vs)
+		case *xpv1.Composition:
+			pc.Compositions[obj.GetName()] = obj
+			continue
+		case *admv1.ValidatingWebhookConfiguration:
+			pc.Webhooks["ValidatingWebhookConfiguration/"+obj.GetName()] = obj
+			continue
+		case *admv1.MutatingWebhookConfiguration:
+			pc.Webhooks["MutatingWebhookConfiguration/"+obj.GetName()] = obj
+			continue
+		default:
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		pc.Definitions[gk.String()] = d
+	}
+
+	return pc, nil
+}
+
+// A rawVersion is a version of an MRD or XRD, whose schema is raw JSON.
+type rawVersion struct {
+	name   string
+	served bool
+	schema []byte
+}
+
+func newDefinition(definedBy, name string, vs []rawVersion) (Definition, error) {
+	d := Definition{DefinedBy: definedBy, Versions: map[string]*extv1.JSONSchemaProps{}}
+
+	for _, v := range vs {
+		if !v.served {
+			continue
+		}
+
+		s := &extv1.JSONSchemaProps{}
+		if len(v.schema) > 0 {
+			if err := json.Unmarshal(v.schema, s); err != nil {
+				return Definition{}, errors.Wrapf(err, errFmtParseSchema, name, v.name)
+			}
+		}
+
+		d.Versions[v.name] = s
+	}
+
+	return d, nil
+}
+
+// Diff returns the changes between the supplied old and new package contents.
+func Diff(o, n *PackageContents) []Change {
+	changes := diffDefinitions(o.Definitions, n.Definitions)
+	changes = append(changes, diffObjects(SubjectComposition, o.Compositions, n.Compositions)...)
+	changes = append(changes, diffObjects(SubjectWebhook, o.Webhooks, n.Webhooks)...)
+	changes = append(changes, diffDependencies(o.Meta, n.Meta)...)
+	changes = append(changes, diffCrossplane(o.Meta, n.Meta)...)
+
+	return changes
+}
+
+func diffDefinitions(o, n map[string]Definition) []Change {
+	var changes []Change
+
+	for _, gk := range sortedKeys(o) {
+		nd, ok := n[gk]
+		if !ok {
+			changes = append(changes, Change{Type: ChangeRemoved, Subject: SubjectKind, Name: gk, Message: "kind removed", Breaking: true})
+			continue
+		}
+
+		od := o[gk]
+
+		for _, v := range sortedKeys(od.Versions) {
+			ns, ok := nd.Versions[v]
+			if !ok {
+				changes = append(changes, Change{Type: ChangeRemoved, Subject: SubjectVersion, Name: gk, Version: v, Message: "version removed", Breaking: true})
+				continue
+			}
+
+			for _, sc := range xcrd.CompareSchemas(od.Versions[v], ns) {
+				changes = append(changes, Change{Type: ChangeChanged, Subject: SubjectSchema, Name: gk, Version: v, Path: sc.Path, Message: sc.Message, Breaking: sc.Breaking})
+			}
+		}
+
+		for _, v := range sortedKeys(nd.Versions) {
+			if _, ok := od.Versions[v]; !ok {
+				changes = append(changes, Change{Type: ChangeAdded, Subject: SubjectVersion, Name: gk, Version: v, Message: "version added"})
+			}
+		}
+	}
+
+	for _, gk := range sortedKeys(n) {
+		if _, ok := o[gk]; !ok {
+			changes = append(changes, Change{Type: ChangeAdded, Subject: SubjectKind, Name: gk, Message: fmt.Sprintf("kind added, defined by a %s", n[gk].DefinedBy)})
+		}
+	}
+
+	return changes
+}
+
+// diffObjects returns the objects that were added, removed, or changed.
+// Removing an object is breaking, because users may depend on it.
+func diffObjects[T runtime.Object](subject string, o, n map[string]T) []Change {
+	var changes []Change
+
+	for _, name := range sortedKeys(o) {
+		no, ok := n[name]
+		if !ok {
+			changes = append(changes, Change{Type: ChangeRemoved, Subject: subject, Name: name, Message: strings.ToLower(subject) + " removed", Breaking: true})
+			continue
+		}
+
+		if !equality.Semantic.DeepEqual(o[name], no) {
+			changes = append(changes, Change{Type: ChangeChanged, Subject: subject, Name: name, Message: strings.ToLower(subject) + " changed"})
+		}
+	}
+
+	for _, name := range sortedKeys(n) {
+		if _, ok := o[name]; !ok {
+			changes = append(changes, Change{Type: ChangeAdded, Subject: subject, Name: name, Message: strings.ToLower(subject) + " added"})
+		}
+	}
+
+	return changes
+}
+
+func diffDependencies(o, n pkgmetav1.Pkg) []Change {
+	od := dependencyVersions(o)
+	nd := dependencyVersions(n)
+
+	var changes []Change
+
+	for _, pkg := range sortedKeys(od) {
+		nc, ok := nd[pkg]
+		if !ok {
+			changes = append(changes, Change{Type: ChangeRemoved, Subject: SubjectDependency, Name: pkg, Message: fmt.Sprintf("dependency on %s removed", od[pkg])})
+			continue
+		}
+
+		if nc != od[pkg] {
+			changes = append(changes, Change{Type: ChangeChanged, Subject: SubjectDependency, Name: pkg, Message: fmt.Sprintf("version constraint changed from %q to %q", od[pkg], nc)})
+		}
+	}
+
+	for _, pkg := range sortedKeys(nd) {
+		if _, ok := od[pkg]; !ok {
+			changes = append(changes, Change{Type: ChangeAdded, Subject: SubjectDependency, Name: pkg, Message: fmt.Sprintf("dependency on %s added", nd[pkg])})
+		}
+	}
+
+	return changes
+}
+
+// dependencyVersions returns the version constraints of the supplied
+// package's dependencies, keyed by package.
+func dependencyVersions(p pkgmetav1.Pkg) map[string]string {
+	out := map[string]string{}
+	if p == nil {
+		return out
+	}
+
+	for _, d := range p.GetDependencies() {
+		var pkg string
+
+		switch {
+		case d.Package != nil:
+			pkg = *d.Package
+		case d.Provider != nil:
+			pkg = *d.Provider
+		case d.Configuration != nil:
+			pkg = *d.Configuration
+		case d.Function != nil:
+			pkg = *d.Function
+		default:
+			continue
+		}
+
+		out[pkg] = d.Version
+	}
+
+	return out
+}
+
+func diffCrossplane(o, n pkgmetav1.Pkg) []Change {
+	oc := crossplaneConstraint(o)
+	nc := crossplaneConstraint(n)
+
+	if oc == nc {
+		return nil
+	}
+
+	c := Change{Type: ChangeChanged, Subject: SubjectCrossplane, Name: "crossplane", Message: fmt.Sprintf("version constraint changed from %q to %q", oc, nc)}
+
+	switch {
+	case oc == "":
+		c.Type = ChangeAdded
+		c.Message = fmt.Sprintf("version constraint %q added", nc)
+	case nc == "":
+		c.Type = ChangeRemoved
+		c.Message = fmt.Sprintf("version constraint %q removed", oc)
+	}
+
+	return []Change{c}
+}
+
+func crossplaneConstraint(p pkgmetav1.Pkg) string {
+	if p == nil || p.GetCrossplaneConstraints() == nil {
+		return ""
+	}
+
+	return p.GetCrossplaneConstraints().Version
+}
+
+// changeMarker returns a marker for the supplied change type, similar to a
+// unified diff.
+func changeMarker(t ChangeType) string {
+	switch t {
+	case ChangeAdded:
+		return "+"
+	case ChangeRemoved:
+		return "-"
+	case ChangeChanged:
+		return "~"
+	}
+
+	return " "
+}
+
+func writeChanges(w io.Writer, format string, changes []Change) error {
+	if format == "json" {
+		if changes == nil {
+			changes = []Change{}
+		}
+
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+
+		return enc.Encode(struct {
+			Changes []Change `json:"changes"`
+		}{Changes: changes})
+	}
+
+	breaking := 0
+
+	for _, c := range changes {
+		var b strings.Builder
+
+		fmt.Fprintf(&b, "%s %s %s", changeMarker(c.Type), c.Subject, c.Name)
+
+		if c.Version != "" {
+			fmt.Fprintf(&b, " %s", c.Version)
+		}
+
+		if c.Path != "" {
+			fmt.Fprintf(&b, " %s", c.Path)
+		}
+
+		fmt.Fprintf(&b, ": %s", c.Message)
+
+		if c.Breaking {
+			breaking++
+
+			b.WriteString(" (breaking)")
+		}
+
+		if _, err := fmt.Fprintln(w, b.String()); err != nil {
+			return err
+		}
+	}
+
+	_, err := fmt.Fprintf(w, "%d changes, %d breaking\n", len(changes), breaking)
+
+	return err
+}
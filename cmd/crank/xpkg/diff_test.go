@@ -0,0 +1,333 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package xpkg
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/test"
+
+	xpv1 "github.com/crossplane/crossplane/v2/apis/apiextensions/v1"
+	xpv2 "github.com/crossplane/crossplane/v2/apis/apiextensions/v2"
+	pkgmetav1 "github.com/crossplane/crossplane/v2/apis/pkg/meta/v1"
+)
+
+func TestNewPackageContents(t *testing.T) {
+	type args struct {
+		metas   []runtime.Object
+		objects []runtime.Object
+	}
+
+	type want struct {
+		pc  *PackageContents
+		err error
+	}
+
+	meta := &pkgmetav1.Configuration{
+		ObjectMeta: metav1.ObjectMeta{Name: "cool-configuration"},
+	}
+
+	comp := &xpv1.Composition{ObjectMeta: metav1.ObjectMeta{Name: "cool-composition"}}
+
+	invalid := []byte(`{"type":`)
+	errInvalid := json.Unmarshal(invalid, &extv1.JSONSchemaProps{})
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"Contents": {
+			reason: "We should return the served versions of defined kinds, Compositions, and the package's meta.",
+			args: args{
+				metas: []runtime.Object{meta},
+				objects: []runtime.Object{
+					&xpv2.CompositeResourceDefinition{
+						ObjectMeta: metav1.ObjectMeta{Name: "xdatabases.example.org"},
+						Spec: xpv2.CompositeResourceDefinitionSpec{
+							Group: "example.org",
+							Names: extv1.CustomResourceDefinitionNames{Kind: "XDatabase"},
+							Versions: []xpv2.CompositeResourceDefinitionVersion{
+								{
+									Name:   "v1",
+									Served: true,
+									Schema: &xpv2.CompositeResourceValidation{
+										OpenAPIV3Schema: runtime.RawExtension{Raw: []byte(`{"type":"object"}`)},
+									},
+								},
+								{
+									Name:   "v1alpha1",
+									Served: false,
+								},
+							},
+						},
+					},
+					&extv1.CustomResourceDefinition{
+						Spec: extv1.CustomResourceDefinitionSpec{
+							Group: "example.org",
+							Names: extv1.CustomResourceDefinitionNames{Kind: "Bucket"},
+							Versions: []extv1.CustomResourceDefinitionVersion{
+								{Name: "v1", Served: true},
+							},
+						},
+					},
+					comp,
+				},
+			},
+			want: want{
+				pc: &PackageContents{
+					Meta: meta,
+					Definitions: map[string]Definition{
+						"XDatabase.example.org": {
+							DefinedBy: "CompositeResourceDefinition",
+							Versions:  map[string]*extv1.JSONSchemaProps{"v1": {Type: "object"}},
+						},
+						"Bucket.example.org": {
+							DefinedBy: "CustomResourceDefinition",
+							Versions:  map[string]*extv1.JSONSchemaProps{"v1": {}},
+						},
+					},
+					Compositions: map[string]*xpv1.Composition{"cool-composition": comp},
+					Webhooks:     map[string]runtime.Object{},
+				},
+			},
+		},
+		"InvalidSchema": {
+			reason: "We should return an error if an XRD's schema can't be parsed.",
+			args: args{
+				objects: []runtime.Object{
+					&xpv1.CompositeResourceDefinition{
+						ObjectMeta: metav1.ObjectMeta{Name: "xdatabases.example.org"},
+						Spec: xpv1.CompositeResourceDefinitionSpec{
+							Versions: []xpv1.CompositeResourceDefinitionVersion{
+								{
+									Name:   "v1",
+									Served: true,
+									Schema: &xpv1.CompositeResourceValidation{
+										OpenAPIV3Schema: runtime.RawExtension{Raw: invalid},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				err: errors.Wrapf(errInvalid, errFmtParseSchema, "xdatabases.example.org", "v1"),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := NewPackageContents(tc.args.metas, tc.args.objects)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nNewPackageContents(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+
+			if diff := cmp.Diff(tc.want.pc, got); diff != "" {
+				t.Errorf("\n%s\nNewPackageContents(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDiff(t *testing.T) {
+	type args struct {
+		o *PackageContents
+		n *PackageContents
+	}
+
+	schema := func(props ...string) *extv1.JSONSchemaProps {
+		s := &extv1.JSONSchemaProps{Type: "object", Properties: map[string]extv1.JSONSchemaProps{}}
+		for _, p := range props {
+			s.Properties[p] = extv1.JSONSchemaProps{Type: "string"}
+		}
+
+		return s
+	}
+
+	configuration := func(crossplane string, deps ...pkgmetav1.Dependency) *pkgmetav1.Configuration {
+		c := &pkgmetav1.Configuration{}
+		c.Spec.DependsOn = deps
+
+		if crossplane != "" {
+			c.Spec.Crossplane = &pkgmetav1.CrossplaneConstraints{Version: crossplane}
+		}
+
+		return c
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   []Change
+	}{
+		"Unchanged": {
+			reason: "Identical packages should have no changes.",
+			args: args{
+				o: &PackageContents{
+					Meta:        configuration(">=v2.0.0"),
+					Definitions: map[string]Definition{"XDatabase.example.org": {Versions: map[string]*extv1.JSONSchemaProps{"v1": schema("size")}}},
+				},
+				n: &PackageContents{
+					Meta:        configuration(">=v2.0.0"),
+					Definitions: map[string]Definition{"XDatabase.example.org": {Versions: map[string]*extv1.JSONSchemaProps{"v1": schema("size")}}},
+				},
+			},
+			want: nil,
+		},
+		"Definitions": {
+			reason: "We should report added and removed kinds and versions, and schema changes.",
+			args: args{
+				o: &PackageContents{
+					Definitions: map[string]Definition{
+						"XDatabase.example.org": {Versions: map[string]*extv1.JSONSchemaProps{
+							"v1alpha1": schema("size"),
+							"v1":       schema("size"),
+						}},
+						"XCache.example.org": {Versions: map[string]*extv1.JSONSchemaProps{"v1": schema()}},
+					},
+				},
+				n: &PackageContents{
+					Definitions: map[string]Definition{
+						"XDatabase.example.org": {Versions: map[string]*extv1.JSONSchemaProps{
+							"v1":      schema("size", "engine"),
+							"v1beta1": schema("size"),
+						}},
+						"XQueue.example.org": {DefinedBy: "CompositeResourceDefinition", Versions: map[string]*extv1.JSONSchemaProps{"v1": schema()}},
+					},
+				},
+			},
+			want: []Change{
+				{Type: ChangeRemoved, Subject: SubjectKind, Name: "XCache.example.org", Message: "kind removed", Breaking: true},
+				{Type: ChangeChanged, Subject: SubjectSchema, Name: "XDatabase.example.org", Version: "v1", Path: "engine", Message: "field added"},
+				{Type: ChangeRemoved, Subject: SubjectVersion, Name: "XDatabase.example.org", Version: "v1alpha1", Message: "version removed", Breaking: true},
+				{Type: ChangeAdded, Subject: SubjectVersion, Name: "XDatabase.example.org", Version: "v1beta1", Message: "version added"},
+				{Type: ChangeAdded, Subject: SubjectKind, Name: "XQueue.example.org", Message: "kind added, defined by a CompositeResourceDefinition"},
+			},
+		},
+		"Compositions": {
+			reason: "We should report added, removed, and changed Compositions.",
+			args: args{
+				o: &PackageContents{
+					Compositions: map[string]*xpv1.Composition{
+						"a": {ObjectMeta: metav1.ObjectMeta{Name: "a"}},
+						"b": {ObjectMeta: metav1.ObjectMeta{Name: "b"}},
+					},
+				},
+				n: &PackageContents{
+					Compositions: map[string]*xpv1.Composition{
+						"b": {ObjectMeta: metav1.ObjectMeta{Name: "b"}, Spec: xpv1.CompositionSpec{Mode: xpv1.CompositionModePipeline}},
+						"c": {ObjectMeta: metav1.ObjectMeta{Name: "c"}},
+					},
+				},
+			},
+			want: []Change{
+				{Type: ChangeRemoved, Subject: SubjectComposition, Name: "a", Message: "composition removed", Breaking: true},
+				{Type: ChangeChanged, Subject: SubjectComposition, Name: "b", Message: "composition changed"},
+				{Type: ChangeAdded, Subject: SubjectComposition, Name: "c", Message: "composition added"},
+			},
+		},
+		"Constraints": {
+			reason: "We should report changed dependencies and Crossplane version constraints.",
+			args: args{
+				o: &PackageContents{
+					Meta: configuration(">=v1.20.0",
+						pkgmetav1.Dependency{Provider: ptr.To("xpkg.crossplane.io/crossplane-contrib/provider-nop"), Version: ">=v0.3.0"},
+						pkgmetav1.Dependency{Function: ptr.To("xpkg.crossplane.io/crossplane-contrib/function-auto-ready"), Version: ">=v0.1.0"},
+					),
+				},
+				n: &PackageContents{
+					Meta: configuration(">=v2.0.0",
+						pkgmetav1.Dependency{Package: ptr.To("xpkg.crossplane.io/crossplane-contrib/provider-nop"), Version: ">=v0.4.0"},
+						pkgmetav1.Dependency{Function: ptr.To("xpkg.crossplane.io/crossplane-contrib/function-go-templating"), Version: ">=v0.9.0"},
+					),
+				},
+			},
+			want: []Change{
+				{Type: ChangeRemoved, Subject: SubjectDependency, Name: "xpkg.crossplane.io/crossplane-contrib/function-auto-ready", Message: "dependency on >=v0.1.0 removed"},
+				{Type: ChangeChanged, Subject: SubjectDependency, Name: "xpkg.crossplane.io/crossplane-contrib/provider-nop", Message: "version constraint changed from \">=v0.3.0\" to \">=v0.4.0\""},
+				{Type: ChangeAdded, Subject: SubjectDependency, Name: "xpkg.crossplane.io/crossplane-contrib/function-go-templating", Message: "dependency on >=v0.9.0 added"},
+				{Type: ChangeChanged, Subject: SubjectCrossplane, Name: "crossplane", Message: "version constraint changed from \">=v1.20.0\" to \">=v2.0.0\""},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := Diff(tc.args.o, tc.args.n)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nDiff(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestWriteChanges(t *testing.T) {
+	changes := []Change{
+		{Type: ChangeChanged, Subject: SubjectSchema, Name: "XDatabase.example.org", Version: "v1", Path: "spec.size", Message: "field removed", Breaking: true},
+		{Type: ChangeAdded, Subject: SubjectComposition, Name: "cool-composition", Message: "composition added"},
+	}
+
+	cases := map[string]struct {
+		reason  string
+		format  string
+		changes []Change
+		want    string
+	}{
+		"Text": {
+			reason:  "We should write one line per change, and a summary.",
+			format:  "text",
+			changes: changes,
+			want: `~ Schema XDatabase.example.org v1 spec.size: field removed (breaking)
++ Composition cool-composition: composition added
+2 changes, 1 breaking
+`,
+		},
+		"EmptyJSON": {
+			reason: "We should write an empty array, not null, when there are no changes.",
+			format: "json",
+			want: `{
+  "changes": []
+}
+`,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			b := &bytes.Buffer{}
+			if err := writeChanges(b, tc.format, tc.changes); err != nil {
+				t.Fatalf("writeChanges(...): %v", err)
+			}
+
+			if diff := cmp.Diff(tc.want, b.String()); diff != "" {
+				t.Errorf("\n%s\nwriteChanges(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
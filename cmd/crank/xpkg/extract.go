@@ -136,7 +136,7 @@ type extractCmd struct {
 }
 
 // Run runs the xpkg extract cmd.
-func (c *extractCmd) Run(logger logging.Logger) error { //nolint:gocyclo // xpkg extract for cli
+func (c *extractCmd) Run(logger logging.Logger) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
@@ -146,10 +146,43 @@ func (c *extractCmd) Run(logger logging.Logger) error { //nolint:gocyclo // xpkg
 		return errors.Wrap(err, errFetchPackage)
 	}
 
+	stream, err := packageStream(img)
+	if err != nil {
+		return err
+	}
+
+	out := xpkg.ReplaceExt(filepath.Clean(c.Output), cacheContentExt)
+
+	cf, err := c.fs.Create(out)
+	if err != nil {
+		return errors.Wrap(err, errCreateOutputFile)
+	}
+	defer cf.Close() //nolint:errcheck // defer close
+
+	w, err := gzip.NewWriterLevel(cf, gzip.BestSpeed)
+	if err != nil {
+		return errors.Wrap(err, errCreateGzipWriter)
+	}
+
+	if _, err = io.Copy(w, stream); err != nil {
+		return errors.Wrap(err, errExtractPackageContents)
+	}
+
+	if err := w.Close(); err != nil {
+		return errors.Wrap(err, errExtractPackageContents)
+	}
+
+	logger.Debug("xpkg contents extracted to %s", out)
+
+	return nil
+}
+
+// packageStream returns a reader of the supplied package image's YAML stream.
+func packageStream(img v1.Image) (io.Reader, error) {
 	// Get image manifest.
 	manifest, err := img.Manifest()
 	if err != nil {
-		return errors.Wrap(err, errGetManifest)
+		return nil, errors.Wrap(err, errGetManifest)
 	}
 
 	// Determine if the image is using annotated layers.
@@ -163,19 +196,19 @@ func (c *extractCmd) Run(logger logging.Logger) error { //nolint:gocyclo // xpkg
 		}
 
 		if foundAnnotated {
-			return errors.New(errMultipleAnnotatedLayers)
+			return nil, errors.New(errMultipleAnnotatedLayers)
 		}
 
 		foundAnnotated = true
 
 		layer, err := img.LayerByDigest(l.Digest)
 		if err != nil {
-			return errors.Wrap(err, errFetchLayer)
+			return nil, errors.Wrap(err, errFetchLayer)
 		}
 
 		tarc, err = layer.Uncompressed()
 		if err != nil {
-			return errors.Wrap(err, errGetUncompressed)
+			return nil, errors.Wrap(err, errGetUncompressed)
 		}
 	}
 
@@ -189,42 +222,14 @@ func (c *extractCmd) Run(logger logging.Logger) error { //nolint:gocyclo // xpkg
 	// the package YAML stream.
 	t := tar.NewReader(tarc)
 
-	var size int64
-
 	for {
 		h, err := t.Next()
 		if err != nil {
-			return errors.Wrap(err, errOpenPackageStream)
+			return nil, errors.Wrap(err, errOpenPackageStream)
 		}
 
 		if h.Name == xpkg.StreamFile {
-			size = h.Size
-			break
+			return io.LimitReader(t, h.Size), nil
 		}
 	}
-
-	out := xpkg.ReplaceExt(filepath.Clean(c.Output), cacheContentExt)
-
-	cf, err := c.fs.Create(out)
-	if err != nil {
-		return errors.Wrap(err, errCreateOutputFile)
-	}
-	defer cf.Close() //nolint:errcheck // defer close
-
-	w, err := gzip.NewWriterLevel(cf, gzip.BestSpeed)
-	if err != nil {
-		return errors.Wrap(err, errCreateGzipWriter)
-	}
-
-	if _, err = io.CopyN(w, t, size); err != nil {
-		return errors.Wrap(err, errExtractPackageContents)
-	}
-
-	if err := w.Close(); err != nil {
-		return errors.Wrap(err, errExtractPackageContents)
-	}
-
-	logger.Debug("xpkg contents extracted to %s", out)
-
-	return nil
 }
@@ -59,6 +59,15 @@ type initCmd struct {
 	Directory     string `default:"."                                                     help:"The directory to initialize. It must be empty. It will be created if it doesn't exist." predictor:"directory" short:"d" type:"path"`
 	RunInitScript bool   `help:"Runs the init.sh script if it exists without prompting"   name:"run-init-script"                                                                        short:"r"`
 	RefName       string `help:"The branch or tag to clone from the template repository." name:"ref-name"                                                                               short:"b"`
+
+	// Flags for built-in templates.
+	DependsOn  []string `help:"A package the Configuration depends on, as KIND=REGISTRY/REPOSITORY:VERSION. Can be repeated."   placeholder:"KIND=REGISTRY/REPOSITORY:VERSION"`
+	GoModule   string   `help:"The Go module path of the Function. Defaults to github.com/example/<name>."`
+	Group      string   `default:"example.crossplane.io"                                                                         help:"The API group of the composite resource."`
+	Kind       string   `help:"The kind of the composite resource. Required by the xrd template."`
+	Plural     string   `help:"The plural name of the composite resource. Derived from --kind by default."`
+	Scope      string   `default:"Namespaced"                                                                                    enum:"Namespaced,Cluster"                                        help:"The scope of the composite resource. One of Namespaced or Cluster."`
+	XRDVersion string   `default:"v1alpha1"                                                                                      help:"The API version of the composite resource."                name:"xrd-version"`
 }
 
 func (c *initCmd) Help() string {
@@ -70,6 +79,11 @@ template.
 You can specify either a full Git URL or a well-known name as a template. The
 following well-known template names are supported:
 
+%s
+The following built-in templates don't need network access. They're rendered
+using the --kind, --group, --xrd-version, --scope, --plural, --go-module, and
+--depends-on flags:
+
 %s
 
 If the template contains NOTES.txt in its root directory, it will be
@@ -92,6 +106,14 @@ Examples:
   # Initialize a new Go Composition Function named function-example and run
   # its init.sh script (if it exists) without prompting the user or displaying its contents.
   crossplane xpkg init function-example function-template-go --run-init-script
+
+  # Initialize an XRD and Composition for an App composite resource.
+  crossplane xpkg init app xrd --kind=App --group=platform.example.org
+
+  # Initialize a Configuration that packages an XRD and Composition for an
+  # App composite resource, and depends on a Provider.
+  crossplane xpkg init configuration-app configuration --kind=App \
+    --depends-on=provider=xpkg.crossplane.io/crossplane-contrib/provider-kubernetes:>=v0.18.0
 `
 
 	b := strings.Builder{}
@@ -99,7 +121,12 @@ Examples:
 		b.WriteString(fmt.Sprintf(" - %s (%s)\n", name, url))
 	}
 
-	return fmt.Sprintf(tpl, b.String())
+	bi := strings.Builder{}
+	for name, desc := range BuiltInTemplates() {
+		bi.WriteString(fmt.Sprintf(" - %s (%s)\n", name, desc))
+	}
+
+	return fmt.Sprintf(tpl, b.String(), bi.String())
 }
 
 func (c *initCmd) Run(k *kong.Context, logger logging.Logger) error {
@@ -122,6 +149,16 @@ func (c *initCmd) Run(k *kong.Context, logger logging.Logger) error {
 		return err
 	}
 
+	if _, ok := BuiltInTemplates()[c.Template]; ok {
+		if err := c.generate(c.Template); err != nil {
+			return errors.Wrapf(err, "failed to render built-in template %q", c.Template)
+		}
+
+		_, err := fmt.Fprintf(k.Stdout, "Initialized package %q in directory %q from built-in template %s\n", c.Name, c.Directory, c.Template)
+
+		return errors.Wrap(err, "failed to write to stdout")
+	}
+
 	repoURL, ok := WellKnownTemplates()[c.Template]
 	if !ok {
 		// If the template isn't one of the well-known ones, assume its a URL.
@@ -0,0 +1,224 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package xpkg
+
+import (
+	"context"
+	"path/filepath"
+	"sort"
+	"time"
+
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/spf13/afero"
+	"k8s.io/utils/ptr"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/parser"
+
+	pkgmetav1 "github.com/crossplane/crossplane/v2/apis/pkg/meta/v1"
+	pkgv1 "github.com/crossplane/crossplane/v2/apis/pkg/v1"
+	"github.com/crossplane/crossplane/v2/apis/pkg/v1beta1"
+	"github.com/crossplane/crossplane/v2/internal/xpkg"
+)
+
+const (
+	errReadMeta        = "cannot read package meta"
+	errFmtHeadPackage  = "cannot get digest of package %s"
+	errFmtUnknownType  = "cannot determine the kind of package %s"
+	errResolveLockDeps = "cannot resolve dependencies"
+	errNoMeta          = "package must contain exactly one meta file"
+	errNotMeta         = "package meta is not a Configuration, Provider, or Function"
+)
+
+// lockCmd pins a package's dependencies to exact digests.
+type lockCmd struct {
+	// Flags. Keep sorted alphabetically.
+	InsecureSkipTLSVerify bool          `help:"[INSECURE] Skip verifying TLS certificates."`
+	PackageRoot           string        `default:"."                                        help:"The directory that contains the package's crossplane.yaml file." predictor:"directory" short:"f" type:"existingdir"`
+	Timeout               time.Duration `default:"5m"                                       help:"How long to wait for dependencies to be resolved."`
+
+	// Internal state. These aren't part of the user-exposed CLI structure.
+	fs afero.Fs
+}
+
+// Help prints out the help for the xpkg lock command.
+func (c *lockCmd) Help() string {
+	return `
+Pin a package's dependencies, and their dependencies, to exact digests.
+
+Dependencies are resolved the same way Crossplane resolves them. Each
+dependency's version is the highest semantic version tag that satisfies the
+constraints of every package that depends on it. The digest each version
+resolves to is written to a crossplane.lock file alongside the package's
+crossplane.yaml.
+
+When the package is built the lock is embedded in the package. Crossplane
+installs each locked dependency at its pinned digest, so every control plane
+that installs the package installs an identical dependency tree.
+
+Run the command again to update the lock.
+
+Examples:
+
+  # Lock the dependencies of the package in the current directory.
+  crossplane xpkg lock
+
+  # Lock the dependencies of the package in the configuration directory.
+  crossplane xpkg lock -f configuration
+`
+}
+
+// AfterApply constructs and binds context to any subcommands
+// that have Run() methods that receive it.
+func (c *lockCmd) AfterApply() error {
+	c.fs = afero.NewOsFs()
+	return nil
+}
+
+// Run runs the xpkg lock cmd.
+func (c *lockCmd) Run(logger logging.Logger) error {
+	ctx, cancel := context.WithTimeout(context.Background(), c.Timeout)
+	defer cancel()
+
+	meta, err := readMeta(ctx, c.fs, filepath.Join(c.PackageRoot, xpkg.MetaFile))
+	if err != nil {
+		return errors.Wrap(err, errReadMeta)
+	}
+
+	r := &dependencyResolver{opts: remoteOptions(c.InsecureSkipTLSVerify)}
+
+	l, err := r.Lock(ctx, meta)
+	if err != nil {
+		return err
+	}
+
+	for _, d := range l.Lock {
+		logger.Debug("Locked dependency", "package", d.Package, "version", d.Version, "digest", d.Digest)
+	}
+
+	return xpkg.WriteLockfile(c.fs, filepath.Join(c.PackageRoot, xpkg.LockfileName), l)
+}
+
+// readMeta reads the package meta file at the supplied path.
+func readMeta(ctx context.Context, fs afero.Fs, path string) (pkgmetav1.Pkg, error) {
+	f, err := fs.Open(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+
+	metaScheme, err := xpkg.BuildMetaScheme()
+	if err != nil {
+		return nil, err
+	}
+
+	objScheme, err := xpkg.BuildObjectScheme()
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse closes the file.
+	pkg, err := parser.New(metaScheme, objScheme).Parse(ctx, f)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(pkg.GetMeta()) != 1 {
+		return nil, errors.New(errNoMeta)
+	}
+
+	meta, ok := xpkg.TryConvertToPkg(pkg.GetMeta()[0], &pkgmetav1.Provider{}, &pkgmetav1.Configuration{}, &pkgmetav1.Function{})
+	if !ok {
+		return nil, errors.New(errNotMeta)
+	}
+
+	return meta, nil
+}
+
+// Lock resolves the dependencies of the supplied package and pins each of them
+// to the digest of the version it resolved to.
+func (r *dependencyResolver) Lock(ctx context.Context, meta pkgmetav1.Pkg) (*xpkg.Lockfile, error) {
+	deps, err := xpkg.ToDependencies(meta.GetDependencies())
+	if err != nil {
+		return nil, errors.Wrap(err, errResolveLockDeps)
+	}
+
+	// The root isn't a real package source. It's only used to identify the
+	// package we're locking in the dependency graph.
+	root := &v1beta1.LockPackage{Source: meta.GetName(), Dependencies: deps}
+
+	pkgs, err := r.ResolveDependencies(ctx, root)
+	if err != nil {
+		return nil, errors.Wrap(err, errResolveLockDeps)
+	}
+
+	l := &xpkg.Lockfile{Lock: make([]pkgmetav1.LockedDependency, 0, len(pkgs))}
+
+	for _, p := range pkgs {
+		if p.Source == root.Source {
+			continue
+		}
+
+		ld, err := r.pin(ctx, p)
+		if err != nil {
+			return nil, err
+		}
+
+		l.Lock = append(l.Lock, ld)
+	}
+
+	// Keep the lockfile stable across runs.
+	sort.Slice(l.Lock, func(i, j int) bool { return l.Lock[i].Package < l.Lock[j].Package })
+
+	return l, nil
+}
+
+// pin returns the supplied package pinned to the digest of its version.
+func (r *dependencyResolver) pin(ctx context.Context, p v1beta1.LockPackage) (pkgmetav1.LockedDependency, error) {
+	ld := pkgmetav1.LockedDependency{Package: p.Source, Version: p.Version, Digest: p.Version}
+
+	switch ptr.Deref(p.Type, "") {
+	case v1beta1.ConfigurationPackageType:
+		ld.APIVersion, ld.Kind = pkgv1.ConfigurationGroupVersionKind.GroupVersion().String(), pkgv1.ConfigurationKind
+	case v1beta1.ProviderPackageType:
+		ld.APIVersion, ld.Kind = pkgv1.ProviderGroupVersionKind.GroupVersion().String(), pkgv1.ProviderKind
+	case v1beta1.FunctionPackageType:
+		ld.APIVersion, ld.Kind = pkgv1.FunctionGroupVersionKind.GroupVersion().String(), pkgv1.FunctionKind
+	default:
+		return ld, errors.Errorf(errFmtUnknownType, p.Source)
+	}
+
+	// Dependencies pinned to a digest are already locked.
+	if _, err := v1.NewHash(p.Version); err == nil {
+		return ld, nil
+	}
+
+	ref, err := dependencyReference(p.Source, p.Version)
+	if err != nil {
+		return ld, errors.Wrapf(err, errFmtHeadPackage, p.Source)
+	}
+
+	desc, err := remote.Head(ref, append(r.opts, remote.WithContext(ctx))...)
+	if err != nil {
+		return ld, errors.Wrapf(err, errFmtHeadPackage, ref)
+	}
+
+	ld.Digest = desc.Digest.String()
+
+	return ld, nil
+}
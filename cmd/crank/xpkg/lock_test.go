@@ -0,0 +1,114 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package xpkg
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/spf13/afero"
+
+	pkgmetav1 "github.com/crossplane/crossplane/v2/apis/pkg/meta/v1"
+	"github.com/crossplane/crossplane/v2/internal/xpkg"
+)
+
+func TestLock(t *testing.T) {
+	src := newTestRegistry(t)
+
+	provider := src + "/acme/provider-a"
+	function := src + "/acme/function-a"
+
+	for _, tag := range []string{"v1.0.0", "v1.1.0", "v2.0.0"} {
+		pushPackage(t, provider+":"+tag, fmt.Sprintf(`
+apiVersion: meta.pkg.crossplane.io/v1
+kind: Provider
+metadata:
+  name: provider-a
+spec:
+  dependsOn:
+  - function: %s
+    version: ">=v0.1.0"
+`, function))
+	}
+
+	pushPackage(t, function+":v0.1.0", `
+apiVersion: meta.pkg.crossplane.io/v1beta1
+kind: Function
+metadata:
+  name: function-a
+`)
+
+	fs := afero.NewMemMapFs()
+	_ = afero.WriteFile(fs, "crossplane.yaml", []byte(fmt.Sprintf(`
+apiVersion: meta.pkg.crossplane.io/v1alpha1
+kind: Configuration
+metadata:
+  name: configuration-a
+spec:
+  dependsOn:
+  - provider: %s
+    version: "<v2.0.0"
+`, provider)), 0o644)
+
+	meta, err := readMeta(context.Background(), fs, "crossplane.yaml")
+	if err != nil {
+		t.Fatalf("readMeta(...): %v", err)
+	}
+
+	r := &dependencyResolver{}
+
+	got, err := r.Lock(context.Background(), meta)
+	if err != nil {
+		t.Fatalf("Lock(...): %v", err)
+	}
+
+	digest := func(ref string) string {
+		t.Helper()
+
+		desc, err := remote.Head(mustParse(t, ref))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return desc.Digest.String()
+	}
+
+	// Dependencies should be pinned to the digest of the highest version that
+	// satisfies their constraints, including transitive dependencies.
+	want := &xpkg.Lockfile{Lock: []pkgmetav1.LockedDependency{
+		{
+			APIVersion: "pkg.crossplane.io/v1",
+			Kind:       "Function",
+			Package:    function,
+			Version:    "v0.1.0",
+			Digest:     digest(function + ":v0.1.0"),
+		},
+		{
+			APIVersion: "pkg.crossplane.io/v1",
+			Kind:       "Provider",
+			Package:    provider,
+			Version:    "v1.1.0",
+			Digest:     digest(provider + ":v1.1.0"),
+		},
+	}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Lock(...): -want, +got:\n%s", diff)
+	}
+}
@@ -17,20 +17,24 @@ limitations under the License.
 package xpkg
 
 import (
+	"archive/tar"
 	"context"
 	"crypto/tls"
 	"fmt"
 	"net/http"
 	"os"
 	"path/filepath"
+	"strconv"
 
 	"github.com/google/go-containerregistry/pkg/authn"
 	"github.com/google/go-containerregistry/pkg/name"
 	v1 "github.com/google/go-containerregistry/pkg/v1"
 	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/layout"
 	"github.com/google/go-containerregistry/pkg/v1/mutate"
 	"github.com/google/go-containerregistry/pkg/v1/remote"
 	"github.com/google/go-containerregistry/pkg/v1/tarball"
+	"github.com/sigstore/sigstore/pkg/signature"
 	"github.com/spf13/afero"
 	"golang.org/x/sync/errgroup"
 
@@ -44,6 +48,7 @@ const (
 	errGetwd           = "failed to get working directory while searching for package"
 	errFindPackageinWd = "failed to find a package in current working directory"
 	errAnnotateLayers  = "failed to propagate xpkg annotations from OCI image config file to image layers"
+	errTempDir         = "failed to create temporary directory"
 
 	errFmtNewTag        = "failed to parse package tag %q"
 	errFmtReadPackage   = "failed to read package file %s"
@@ -53,6 +58,10 @@ const (
 	errFmtGetMediaType  = "failed to get media type of package file %s"
 	errFmtGetConfigFile = "failed to get OCI config file of package file %s"
 	errFmtWriteIndex    = "failed to push an OCI image index of %d packages"
+	errFmtReadIndex     = "failed to read multi-platform package file %s"
+
+	// ociLayoutFile is the file that marks a directory as an OCI image layout.
+	ociLayoutFile = "oci-layout"
 )
 
 // pushCmd pushes a package.
@@ -61,8 +70,11 @@ type pushCmd struct {
 	Package string `arg:"" help:"Where to push the package. Must be a fully qualified OCI tag, including the registry, repository, and tag." placeholder:"REGISTRY/REPOSITORY:TAG"`
 
 	// Flags. Keep sorted alphabetically.
+	Attestation           string   `help:"An in-toto predicate file to attest the pushed package with. Requires --sign-key."                                                    placeholder:"PATH"                                                                                                                                                                  predictor:"file"      type:"existingfile"`
+	AttestationType       string   `default:"custom"                                                                                                                            help:"The type of the --attestation predicate. Either a URI, or one of: custom, cyclonedx, link, openvex, slsaprovenance, slsaprovenance02, slsaprovenance1, spdx, spdxjson, vuln."`
 	InsecureSkipTLSVerify bool     `help:"[INSECURE] Skip verifying TLS certificates."`
-	PackageFiles          []string `help:"A comma-separated list of xpkg files to push." placeholder:"PATH" predictor:"xpkg_file" short:"f" type:"existingfile"`
+	PackageFiles          []string `help:"A comma-separated list of xpkg files to push."                                                                                        placeholder:"PATH"                                                                                                                                                                  predictor:"xpkg_file" short:"f"           type:"existingfile"`
+	SignKey               string   `help:"A cosign private key file to sign the pushed package with. The key's password is read from the COSIGN_PASSWORD environment variable." placeholder:"PATH"                                                                                                                                                                  predictor:"file"      type:"existingfile"`
 
 	// Internal state. These aren't part of the user-exposed CLI structure.
 	fs afero.Fs
@@ -81,8 +93,21 @@ Examples:
   # Push a multi-platform package.
   crossplane xpkg push -f function-amd64.xpkg,function-arm64.xpkg xpkg.crossplane.io/crossplane/function-example:v1.0.0
 
+  # Push a multi-platform package built with 'xpkg build --embed-runtime-images'.
+  crossplane xpkg push -f function-example.xpkg xpkg.crossplane.io/crossplane/function-example:v1.0.0
+
   # Push the xpkg file in the current directory to a different registry.
   crossplane xpkg push index.docker.io/crossplane/function-example:v1.0.0
+
+  # Push a package, sign it with a cosign key, and attest its provenance.
+  crossplane xpkg push --sign-key=cosign.key \
+    --attestation=provenance.json --attestation-type=slsaprovenance1 \
+    xpkg.crossplane.io/crossplane/function-example:v1.0.0
+
+Packages pushed with --sign-key are signed by digest. Their signatures and
+attestations are pushed alongside the package, and aren't uploaded to a
+transparency log. Use an ImageConfig with a cosign key authority that sets
+insecureIgnoreTlog to verify them when the package is installed.
 `
 }
 
@@ -94,6 +119,23 @@ func (c *pushCmd) AfterApply() error {
 
 // Run runs the push cmd.
 func (c *pushCmd) Run(logger logging.Logger) error {
+	if c.Attestation != "" && c.SignKey == "" {
+		return errors.New(errAttestationNoKey)
+	}
+
+	// Load the signing key before pushing, so a bad key or password doesn't
+	// leave an unsigned package behind.
+	var sv signature.SignerVerifier
+
+	if c.SignKey != "" {
+		k, err := loadSigningKey(c.SignKey)
+		if err != nil {
+			return err
+		}
+
+		sv = k
+	}
+
 	// If package is not defined, attempt to find single package in current
 	// directory.
 	if len(c.PackageFiles) == 0 {
@@ -113,9 +155,28 @@ func (c *pushCmd) Run(logger logging.Logger) error {
 
 	// load images from all the provided package files
 	images := make([]packageImage, 0, len(c.PackageFiles))
-	for _, p := range c.PackageFiles {
+	tmp, err := os.MkdirTemp("", "xpkg-push-")
+	if err != nil {
+		return errors.Wrap(err, errTempDir)
+	}
+	defer os.RemoveAll(tmp) //nolint:errcheck // Best effort cleanup.
+
+	for i, p := range c.PackageFiles {
 		cleanPath := filepath.Clean(p)
 
+		// Multi-platform packages built by xpkg build are OCI image
+		// layouts. Push each of their images.
+		if isImageLayout(cleanPath) {
+			imgs, err := readImageLayout(cleanPath, filepath.Join(tmp, strconv.Itoa(i)))
+			if err != nil {
+				return errors.Wrapf(err, errFmtReadIndex, cleanPath)
+			}
+
+			images = append(images, imgs...)
+
+			continue
+		}
+
 		img, err := tarball.ImageFromPath(cleanPath, nil)
 		if err != nil {
 			return err
@@ -135,7 +196,43 @@ func (c *pushCmd) Run(logger logging.Logger) error {
 		remote.WithTransport(t),
 	}
 
-	return pushImages(logger, images, c.Package, options...)
+	ref, err := pushImages(logger, images, c.Package, options...)
+	if err != nil {
+		return err
+	}
+
+	if sv == nil {
+		return nil
+	}
+
+	return c.sign(context.Background(), logger, ref, sv, options...)
+}
+
+// sign signs, and optionally attests, the pushed package.
+func (c *pushCmd) sign(ctx context.Context, logger logging.Logger, ref name.Digest, sv signature.SignerVerifier, options ...remote.Option) error {
+	if err := signPackage(ctx, ref, sv, options...); err != nil {
+		return err
+	}
+
+	logger.Debug("Signed package", "ref", ref.String())
+
+	if c.Attestation == "" {
+		return nil
+	}
+
+	f, err := os.Open(filepath.Clean(c.Attestation))
+	if err != nil {
+		return errors.Wrap(err, errReadPredicate)
+	}
+	defer f.Close() //nolint:errcheck // Only open for reading.
+
+	if err := attestPackage(ctx, ref, sv, c.AttestationType, f, options...); err != nil {
+		return err
+	}
+
+	logger.Debug("Attested package", "ref", ref.String(), "type", c.AttestationType)
+
+	return nil
 }
 
 // packageImage describes a package image that will be pushed.
@@ -148,8 +245,63 @@ type packageImage struct {
 	Path string
 }
 
+// isImageLayout returns true if the supplied file is a tarball of an OCI image
+// layout, rather than an image tarball.
+func isImageLayout(path string) bool {
+	f, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return false
+	}
+	defer f.Close() //nolint:errcheck // Only open for reading.
+
+	tr := tar.NewReader(f)
+
+	for {
+		h, err := tr.Next()
+		if err != nil {
+			return false
+		}
+
+		if h.Name == ociLayoutFile {
+			return true
+		}
+	}
+}
+
+// readImageLayout extracts the supplied OCI image layout tarball to the
+// supplied directory, and returns the images of its index.
+func readImageLayout(path, dir string) ([]packageImage, error) {
+	if err := readTarball(path, dir); err != nil {
+		return nil, err
+	}
+
+	idx, err := layout.ImageIndexFromPath(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := idx.IndexManifest()
+	if err != nil {
+		return nil, err
+	}
+
+	images := make([]packageImage, 0, len(m.Manifests))
+
+	for _, desc := range m.Manifests {
+		img, err := idx.Image(desc.Digest)
+		if err != nil {
+			return nil, err
+		}
+
+		images = append(images, packageImage{Image: img, Path: fmt.Sprintf("%s@%s", path, desc.Digest)})
+	}
+
+	return images, nil
+}
+
 // pushImages pushes package images to the given URL using the provided options.
-func pushImages(logger logging.Logger, images []packageImage, url string, options ...remote.Option) error {
+// It returns the digest of the pushed image, or image index.
+func pushImages(logger logging.Logger, images []packageImage, url string, options ...remote.Option) (name.Digest, error) {
 	if len(options) == 0 {
 		options = []remote.Option{
 			remote.WithAuthFromKeychain(authn.DefaultKeychain),
@@ -158,7 +310,7 @@ func pushImages(logger logging.Logger, images []packageImage, url string, option
 
 	tag, err := name.NewTag(url, name.StrictValidation)
 	if err != nil {
-		return errors.Wrapf(err, errFmtNewTag, url)
+		return name.Digest{}, errors.Wrapf(err, errFmtNewTag, url)
 	}
 
 	// If there's only one package file, handle the simple path.
@@ -167,16 +319,21 @@ func pushImages(logger logging.Logger, images []packageImage, url string, option
 
 		img, err := xpkg.AnnotateLayers(pi.Image)
 		if err != nil {
-			return errors.Wrapf(err, errAnnotateLayers)
+			return name.Digest{}, errors.Wrapf(err, errAnnotateLayers)
+		}
+
+		d, err := img.Digest()
+		if err != nil {
+			return name.Digest{}, errors.Wrapf(err, errFmtGetDigest, pi.Path)
 		}
 
 		if err := remote.Write(tag, img, options...); err != nil {
-			return errors.Wrapf(err, errFmtPushPackage, pi.Path)
+			return name.Digest{}, errors.Wrapf(err, errFmtPushPackage, pi.Path)
 		}
 
 		logger.Debug("Pushed package", "path", pi.Path, "ref", tag.String())
 
-		return nil
+		return tag.Digest(d.String()), nil
 	}
 
 	// If there's more than one package file we'll write (push) them all by
@@ -236,14 +393,21 @@ func pushImages(logger logging.Logger, images []packageImage, url string, option
 	}
 
 	if err := g.Wait(); err != nil {
-		return err
+		return name.Digest{}, err
 	}
 
-	if err := remote.WriteIndex(tag, mutate.AppendManifests(empty.Index, adds...), options...); err != nil {
-		return errors.Wrapf(err, errFmtWriteIndex, len(adds))
+	idx := mutate.AppendManifests(empty.Index, adds...)
+
+	d, err := idx.Digest()
+	if err != nil {
+		return name.Digest{}, errors.Wrapf(err, errFmtWriteIndex, len(adds))
+	}
+
+	if err := remote.WriteIndex(tag, idx, options...); err != nil {
+		return name.Digest{}, errors.Wrapf(err, errFmtWriteIndex, len(adds))
 	}
 
 	logger.Debug("Wrote OCI index", "ref", tag.String(), "manifests", len(adds))
 
-	return nil
+	return tag.Digest(d.String()), nil
 }
@@ -0,0 +1,246 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package xpkg
+
+import (
+	"embed"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/gobuffalo/flect"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+
+	pkgv1 "github.com/crossplane/crossplane/v2/apis/pkg/v1"
+)
+
+// Built-in templates.
+const (
+	templateXRD           = "xrd"
+	templateFunction      = "function"
+	templateConfiguration = "configuration"
+)
+
+const (
+	// templateSuffix is stripped from the name of each rendered template.
+	templateSuffix = ".tmpl"
+
+	// patchAndTransform is the function the scaffolded Composition uses.
+	patchAndTransform        = "xpkg.crossplane.io/crossplane-contrib/function-patch-and-transform"
+	patchAndTransformVersion = ">=v0.8.0"
+
+	errFmtRequiresKind   = "the %s template requires --kind"
+	errFmtInvalidDep     = "invalid dependency %q: must be KIND=REGISTRY/REPOSITORY:VERSION"
+	errFmtInvalidDepKind = "invalid dependency %q: kind must be one of provider, configuration, or function"
+	errFmtParseTemplate  = "cannot parse template %s"
+	errFmtRenderTemplate = "cannot render template %s"
+	errFmtWriteFile      = "cannot write file %s"
+)
+
+//go:embed templates
+var templates embed.FS
+
+// BuiltInTemplates are templates that are rendered without cloning a template
+// repository.
+func BuiltInTemplates() map[string]string {
+	return map[string]string{
+		templateXRD:           "A CompositeResourceDefinition and a Composition",
+		templateFunction:      "A Composition Function written in Go",
+		templateConfiguration: "A Configuration, optionally with a CompositeResourceDefinition and a Composition",
+	}
+}
+
+// scaffold is the data built-in templates are rendered with.
+type scaffold struct {
+	Name         string
+	Group        string
+	Kind         string
+	Plural       string
+	Version      string
+	Scope        string
+	GoModule     string
+	Dependencies []dependency
+}
+
+// A dependency of a scaffolded Configuration.
+type dependency struct {
+	APIVersion string
+	Kind       string
+	Package    string
+	Version    string
+}
+
+// parseDependency parses a dependency of the form
+// KIND=REGISTRY/REPOSITORY:VERSION, for example
+// function=xpkg.crossplane.io/crossplane-contrib/function-auto-ready:>=v0.5.0.
+func parseDependency(s string) (dependency, error) {
+	kind, ref, ok := strings.Cut(s, "=")
+	if !ok {
+		return dependency{}, errors.Errorf(errFmtInvalidDep, s)
+	}
+
+	// The version is everything after the last colon, as long as the colon
+	// isn't part of the registry's host:port.
+	i := strings.LastIndex(ref, ":")
+	if i < 0 || i < strings.LastIndex(ref, "/") || i == len(ref)-1 {
+		return dependency{}, errors.Errorf(errFmtInvalidDep, s)
+	}
+
+	d := dependency{
+		APIVersion: pkgv1.SchemeGroupVersion.String(),
+		Package:    ref[:i],
+		Version:    ref[i+1:],
+	}
+
+	switch strings.ToLower(kind) {
+	case "provider":
+		d.Kind = pkgv1.ProviderKind
+	case "configuration":
+		d.Kind = pkgv1.ConfigurationKind
+	case "function":
+		d.Kind = pkgv1.FunctionKind
+	default:
+		return dependency{}, errors.Errorf(errFmtInvalidDepKind, s)
+	}
+
+	return d, nil
+}
+
+// newScaffold returns the data the supplied built-in template should be
+// rendered with.
+func (c *initCmd) newScaffold(tmpl string) (*scaffold, error) {
+	if c.Kind == "" && tmpl == templateXRD {
+		return nil, errors.Errorf(errFmtRequiresKind, tmpl)
+	}
+
+	s := &scaffold{
+		Name:     c.Name,
+		Group:    c.Group,
+		Kind:     c.Kind,
+		Plural:   c.Plural,
+		Version:  c.XRDVersion,
+		Scope:    c.Scope,
+		GoModule: c.GoModule,
+	}
+
+	if s.Plural == "" {
+		s.Plural = flect.Pluralize(strings.ToLower(s.Kind))
+	}
+
+	if s.GoModule == "" {
+		s.GoModule = path.Join("github.com/example", c.Name)
+	}
+
+	pinned := map[string]bool{}
+
+	for _, raw := range c.DependsOn {
+		d, err := parseDependency(raw)
+		if err != nil {
+			return nil, err
+		}
+
+		pinned[d.Package] = true
+		s.Dependencies = append(s.Dependencies, d)
+	}
+
+	// The scaffolded Composition uses function-patch-and-transform, so
+	// the Configuration depends on it unless the caller already supplied a
+	// constraint for it.
+	if s.Kind != "" && !pinned[patchAndTransform] {
+		s.Dependencies = append([]dependency{{
+			APIVersion: pkgv1.SchemeGroupVersion.String(),
+			Kind:       pkgv1.FunctionKind,
+			Package:    patchAndTransform,
+			Version:    patchAndTransformVersion,
+		}}, s.Dependencies...)
+	}
+
+	return s, nil
+}
+
+// generate renders the supplied built-in template to the init directory.
+func (c *initCmd) generate(tmpl string) error {
+	s, err := c.newScaffold(tmpl)
+	if err != nil {
+		return err
+	}
+
+	switch tmpl {
+	case templateXRD:
+		return render(templates, path.Join("templates", templateXRD), c.Directory, s)
+	case templateFunction:
+		return render(templates, path.Join("templates", templateFunction), c.Directory, s)
+	case templateConfiguration:
+		if err := render(templates, path.Join("templates", templateConfiguration), c.Directory, s); err != nil {
+			return err
+		}
+
+		if s.Kind == "" {
+			return nil
+		}
+
+		return render(templates, path.Join("templates", templateXRD), filepath.Join(c.Directory, "apis", s.Plural), s)
+	}
+
+	return errors.Errorf("unknown built-in template %q", tmpl)
+}
+
+// render each template under root in the supplied filesystem to the same
+// relative path under dir, without its .tmpl suffix.
+func render(fsys fs.FS, root, dir string, data any) error {
+	return fs.WalkDir(fsys, root, func(p string, e fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if e.IsDir() {
+			return nil
+		}
+
+		b, err := fs.ReadFile(fsys, p)
+		if err != nil {
+			return errors.Wrapf(err, errFmtParseTemplate, p)
+		}
+
+		t, err := template.New(p).Option("missingkey=error").Parse(string(b))
+		if err != nil {
+			return errors.Wrapf(err, errFmtParseTemplate, p)
+		}
+
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return errors.Wrapf(err, errFmtRenderTemplate, p)
+		}
+
+		dst := filepath.Join(dir, strings.TrimSuffix(rel, templateSuffix))
+		if err := os.MkdirAll(filepath.Dir(dst), 0o750); err != nil {
+			return errors.Wrapf(err, errFmtWriteFile, dst)
+		}
+
+		f, err := os.OpenFile(filepath.Clean(dst), os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+		if err != nil {
+			return errors.Wrapf(err, errFmtWriteFile, dst)
+		}
+		defer f.Close() //nolint:errcheck // We return the error from Execute.
+
+		return errors.Wrapf(t.Execute(f, data), errFmtRenderTemplate, p)
+	})
+}
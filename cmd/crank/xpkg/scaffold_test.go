@@ -0,0 +1,225 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package xpkg
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/yaml"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/test"
+
+	pkgmetav1 "github.com/crossplane/crossplane/v2/apis/pkg/meta/v1"
+)
+
+func TestParseDependency(t *testing.T) {
+	type want struct {
+		d   dependency
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		s      string
+		want   want
+	}{
+		"Function": {
+			reason: "We should parse a function dependency with a version constraint.",
+			s:      "function=xpkg.crossplane.io/crossplane-contrib/function-auto-ready:>=v0.5.0",
+			want: want{
+				d: dependency{
+					APIVersion: "pkg.crossplane.io/v1",
+					Kind:       "Function",
+					Package:    "xpkg.crossplane.io/crossplane-contrib/function-auto-ready",
+					Version:    ">=v0.5.0",
+				},
+			},
+		},
+		"RegistryPort": {
+			reason: "We shouldn't mistake a registry's port for a version.",
+			s:      "Provider=localhost:5000/provider-example:v1.0.0",
+			want: want{
+				d: dependency{
+					APIVersion: "pkg.crossplane.io/v1",
+					Kind:       "Provider",
+					Package:    "localhost:5000/provider-example",
+					Version:    "v1.0.0",
+				},
+			},
+		},
+		"NoKind": {
+			reason: "We should return an error if the dependency has no kind.",
+			s:      "xpkg.crossplane.io/crossplane-contrib/function-auto-ready:v0.5.0",
+			want: want{
+				err: errors.Errorf(errFmtInvalidDep, "xpkg.crossplane.io/crossplane-contrib/function-auto-ready:v0.5.0"),
+			},
+		},
+		"NoVersion": {
+			reason: "We should return an error if the dependency has no version.",
+			s:      "provider=localhost:5000/provider-example",
+			want: want{
+				err: errors.Errorf(errFmtInvalidDep, "provider=localhost:5000/provider-example"),
+			},
+		},
+		"UnknownKind": {
+			reason: "We should return an error if the dependency isn't a known kind of package.",
+			s:      "widget=xpkg.crossplane.io/example/widget:v1.0.0",
+			want: want{
+				err: errors.Errorf(errFmtInvalidDepKind, "widget=xpkg.crossplane.io/example/widget:v1.0.0"),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			d, err := parseDependency(tc.s)
+			if diff := cmp.Diff(tc.want.d, d); diff != "" {
+				t.Errorf("\n%s\nparseDependency(...): -want, +got:\n%s", tc.reason, diff)
+			}
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nparseDependency(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	type want struct {
+		files []string
+		deps  []pkgmetav1.Dependency
+		err   error
+	}
+
+	cases := map[string]struct {
+		reason string
+		tmpl   string
+		c      initCmd
+		want   want
+	}{
+		"XRDRequiresKind": {
+			reason: "The xrd template should require a kind.",
+			tmpl:   templateXRD,
+			want: want{
+				err: errors.Errorf(errFmtRequiresKind, templateXRD),
+			},
+		},
+		"XRD": {
+			reason: "The xrd template should render an XRD and a Composition.",
+			tmpl:   templateXRD,
+			c:      initCmd{Kind: "App"},
+			want: want{
+				files: []string{"composition.yaml", "definition.yaml"},
+			},
+		},
+		"Function": {
+			reason: "The function template should render a Go function skeleton.",
+			tmpl:   templateFunction,
+			want: want{
+				files: []string{"Dockerfile", "README.md", "fn.go", "go.mod", "main.go", "package/crossplane.yaml"},
+			},
+		},
+		"Configuration": {
+			reason: "The configuration template should render a Configuration that depends on the supplied packages.",
+			tmpl:   templateConfiguration,
+			c: initCmd{
+				DependsOn: []string{"provider=xpkg.crossplane.io/crossplane-contrib/provider-kubernetes:>=v0.18.0"},
+			},
+			want: want{
+				files: []string{"crossplane.yaml"},
+				deps: []pkgmetav1.Dependency{
+					{
+						APIVersion: ptr.To("pkg.crossplane.io/v1"),
+						Kind:       ptr.To("Provider"),
+						Package:    ptr.To("xpkg.crossplane.io/crossplane-contrib/provider-kubernetes"),
+						Version:    ">=v0.18.0",
+					},
+				},
+			},
+		},
+		"ConfigurationWithXRD": {
+			reason: "The configuration template should render an XRD and Composition, and depend on the function the Composition uses.",
+			tmpl:   templateConfiguration,
+			c:      initCmd{Kind: "App"},
+			want: want{
+				files: []string{"apis/apps/composition.yaml", "apis/apps/definition.yaml", "crossplane.yaml"},
+				deps: []pkgmetav1.Dependency{
+					{
+						APIVersion: ptr.To("pkg.crossplane.io/v1"),
+						Kind:       ptr.To("Function"),
+						Package:    ptr.To(patchAndTransform),
+						Version:    patchAndTransformVersion,
+					},
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			tc.c.Name = "example"
+			tc.c.Directory = t.TempDir()
+			tc.c.Group = "example.org"
+			tc.c.Scope = "Namespaced"
+			tc.c.XRDVersion = "v1alpha1"
+
+			err := tc.c.generate(tc.tmpl)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ngenerate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+
+			var files []string
+			_ = filepath.WalkDir(tc.c.Directory, func(p string, e fs.DirEntry, _ error) error {
+				if !e.IsDir() {
+					rel, _ := filepath.Rel(tc.c.Directory, p)
+					files = append(files, filepath.ToSlash(rel))
+				}
+				return nil
+			})
+			sort.Strings(files)
+
+			if diff := cmp.Diff(tc.want.files, files); diff != "" {
+				t.Errorf("\n%s\ngenerate(...): -want files, +got files:\n%s", tc.reason, diff)
+			}
+
+			if tc.tmpl != templateConfiguration {
+				return
+			}
+
+			b, err := os.ReadFile(filepath.Join(tc.c.Directory, "crossplane.yaml"))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			cfg := &pkgmetav1.Configuration{}
+			if err := yaml.Unmarshal(b, cfg); err != nil {
+				t.Fatalf("\n%s\ngenerate(...): cannot unmarshal crossplane.yaml: %v", tc.reason, err)
+			}
+
+			if diff := cmp.Diff(tc.want.deps, cfg.Spec.DependsOn); diff != "" {
+				t.Errorf("\n%s\ngenerate(...): -want dependencies, +got dependencies:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,160 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package xpkg
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/sigstore/cosign/v2/pkg/cosign"
+	"github.com/sigstore/cosign/v2/pkg/cosign/attestation"
+	"github.com/sigstore/cosign/v2/pkg/oci/mutate"
+	ociremote "github.com/sigstore/cosign/v2/pkg/oci/remote"
+	"github.com/sigstore/cosign/v2/pkg/oci/static"
+	"github.com/sigstore/cosign/v2/pkg/types"
+	"github.com/sigstore/sigstore/pkg/signature"
+	"github.com/sigstore/sigstore/pkg/signature/dsse"
+	"github.com/sigstore/sigstore/pkg/signature/payload"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+)
+
+const (
+	// envCosignPassword is the environment variable cosign reads private
+	// key passwords from.
+	envCosignPassword = "COSIGN_PASSWORD" //nolint:gosec // This isn't a credential.
+
+	errLoadSigningKey     = "cannot load signing key"
+	errSignPackage        = "cannot sign package"
+	errAttestPackage      = "cannot attest package"
+	errReadPredicate      = "cannot read attestation predicate"
+	errGenerateStatement  = "cannot generate attestation statement"
+	errGetSignedEntity    = "cannot get signed entity"
+	errWriteSignatures    = "cannot write signatures"
+	errWriteAttestations  = "cannot write attestations"
+	errCreateSignature    = "cannot create signature"
+	errAttachSignature    = "cannot attach signature"
+	errAttestationNoKey   = "--attestation requires --sign-key"
+	errMarshalSigPayload  = "cannot marshal signature payload"
+	errMarshalAttestation = "cannot marshal attestation statement"
+)
+
+// loadSigningKey loads the cosign private key at the supplied path. Its
+// password is read from the COSIGN_PASSWORD environment variable.
+func loadSigningKey(path string) (signature.SignerVerifier, error) {
+	b, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, errors.Wrap(err, errLoadSigningKey)
+	}
+
+	sv, err := cosign.LoadPrivateKey(b, []byte(os.Getenv(envCosignPassword)))
+
+	return sv, errors.Wrap(err, errLoadSigningKey)
+}
+
+// signPackage signs the supplied package digest, and pushes the signature to
+// the package's repository. The signature isn't uploaded to a transparency
+// log.
+func signPackage(ctx context.Context, ref name.Digest, sv signature.Signer, opts ...remote.Option) error {
+	p, err := payload.Cosign{Image: ref}.MarshalJSON()
+	if err != nil {
+		return errors.Wrap(err, errMarshalSigPayload)
+	}
+
+	sig, err := sv.SignMessage(bytes.NewReader(p))
+	if err != nil {
+		return errors.Wrap(err, errSignPackage)
+	}
+
+	s, err := static.NewSignature(p, base64.StdEncoding.EncodeToString(sig))
+	if err != nil {
+		return errors.Wrap(err, errCreateSignature)
+	}
+
+	ro := ociremote.WithRemoteOptions(append(opts, remote.WithContext(ctx))...)
+
+	se, err := ociremote.SignedEntity(ref, ro)
+	if err != nil {
+		return errors.Wrap(err, errGetSignedEntity)
+	}
+
+	se, err = mutate.AttachSignatureToEntity(se, s)
+	if err != nil {
+		return errors.Wrap(err, errAttachSignature)
+	}
+
+	return errors.Wrap(ociremote.WriteSignatures(ref.Repository, se, ro), errWriteSignatures)
+}
+
+// attestPackage creates an in-toto attestation of the supplied package digest
+// from the supplied predicate, and pushes it to the package's repository. The
+// attestation isn't uploaded to a transparency log.
+func attestPackage(ctx context.Context, ref name.Digest, sv signature.Signer, predicateType string, predicate io.Reader, opts ...remote.Option) error {
+	h, err := v1.NewHash(ref.DigestStr())
+	if err != nil {
+		return errors.Wrap(err, errAttestPackage)
+	}
+
+	st, err := attestation.GenerateStatement(attestation.GenerateOpts{
+		Predicate: predicate,
+		Type:      predicateType,
+		Digest:    h.Hex,
+		Repo:      ref.Repository.String(),
+		Time:      time.Now,
+	})
+	if err != nil {
+		return errors.Wrap(err, errGenerateStatement)
+	}
+
+	p, err := json.Marshal(st)
+	if err != nil {
+		return errors.Wrap(err, errMarshalAttestation)
+	}
+
+	env, err := dsse.WrapSigner(sv, types.IntotoPayloadType).SignMessage(bytes.NewReader(p))
+	if err != nil {
+		return errors.Wrap(err, errAttestPackage)
+	}
+
+	att, err := static.NewAttestation(env, static.WithLayerMediaType(types.DssePayloadType))
+	if err != nil {
+		return errors.Wrap(err, errCreateSignature)
+	}
+
+	ro := ociremote.WithRemoteOptions(append(opts, remote.WithContext(ctx))...)
+
+	se, err := ociremote.SignedEntity(ref, ro)
+	if err != nil {
+		return errors.Wrap(err, errGetSignedEntity)
+	}
+
+	se, err = mutate.AttachAttestationToEntity(se, att)
+	if err != nil {
+		return errors.Wrap(err, errAttachSignature)
+	}
+
+	return errors.Wrap(ociremote.WriteAttestations(ref.Repository, se, ro), errWriteAttestations)
+}
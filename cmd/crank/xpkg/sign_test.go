@@ -0,0 +1,160 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package xpkg
+
+import (
+	"context"
+	"io"
+	"log"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/sigstore/cosign/v2/pkg/cosign"
+	ociremote "github.com/sigstore/cosign/v2/pkg/oci/remote"
+	"github.com/sigstore/sigstore/pkg/signature"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/logging"
+)
+
+func TestPushSign(t *testing.T) {
+	type want struct {
+		signatures   int
+		attestations int
+	}
+
+	cases := map[string]struct {
+		reason    string
+		predicate string
+		want      want
+	}{
+		"Sign": {
+			reason: "We should sign the pushed package's digest.",
+			want: want{
+				signatures: 1,
+			},
+		},
+		"SignAndAttest": {
+			reason:    "We should sign and attest the pushed package's digest.",
+			predicate: `{"builder":{"id":"https://example.org/builder"}}`,
+			want: want{
+				signatures:   1,
+				attestations: 1,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			srv := httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+			defer srv.Close()
+
+			dir := t.TempDir()
+
+			// Generate a password protected cosign key pair.
+			t.Setenv(envCosignPassword, "hunter2")
+
+			keys, err := cosign.GenerateKeyPair(func(bool) ([]byte, error) { return []byte("hunter2"), nil })
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			key := filepath.Join(dir, "cosign.key")
+			if err := os.WriteFile(key, keys.PrivateBytes, 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			c := &pushCmd{
+				Package:         strings.TrimPrefix(srv.URL, "http://") + "/crossplane/function-example:v1.0.0",
+				AttestationType: "custom",
+				SignKey:         key,
+			}
+
+			if tc.predicate != "" {
+				c.Attestation = filepath.Join(dir, "predicate.json")
+				if err := os.WriteFile(c.Attestation, []byte(tc.predicate), 0o600); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			img, _ := random.Image(100, 1)
+
+			ref, err := pushImages(logging.NewNopLogger(), []packageImage{{Image: img}}, c.Package)
+			if err != nil {
+				t.Fatalf("pushImages(...): %v", err)
+			}
+
+			sv, err := loadSigningKey(key)
+			if err != nil {
+				t.Fatalf("loadSigningKey(...): %v", err)
+			}
+
+			if err := c.sign(context.Background(), logging.NewNopLogger(), ref, sv, remote.WithAuth(authn.Anonymous)); err != nil {
+				t.Fatalf("\n%s\nsign(...): %v", tc.reason, err)
+			}
+
+			sigs, atts := verify(t, ref, sv, tc.want.attestations > 0)
+
+			if diff := cmp.Diff(tc.want.signatures, sigs); diff != "" {
+				t.Errorf("\n%s\nsign(...): -want signatures, +got signatures:\n%s", tc.reason, diff)
+			}
+
+			if diff := cmp.Diff(tc.want.attestations, atts); diff != "" {
+				t.Errorf("\n%s\nsign(...): -want attestations, +got attestations:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+// verify the signatures and attestations of the supplied package the same
+// way a cosign key authority does, without a transparency log.
+func verify(t *testing.T, ref name.Digest, v signature.Verifier, attested bool) (sigs, atts int) {
+	t.Helper()
+
+	co := &cosign.CheckOpts{
+		SigVerifier:        v,
+		IgnoreTlog:         true,
+		ClaimVerifier:      cosign.SimpleClaimVerifier,
+		RegistryClientOpts: []ociremote.Option{ociremote.WithRemoteOptions(remote.WithAuth(authn.Anonymous))},
+	}
+
+	s, _, err := cosign.VerifyImageSignatures(context.Background(), ref, co)
+	if err != nil {
+		t.Fatalf("VerifyImageSignatures(...): %v", err)
+	}
+
+	if !attested {
+		return len(s), 0
+	}
+
+	co.ClaimVerifier = cosign.IntotoSubjectClaimVerifier
+
+	a, _, err := cosign.VerifyImageAttestations(context.Background(), ref, co)
+	if err != nil {
+		t.Fatalf("VerifyImageAttestations(...): %v", err)
+	}
+
+	return len(s), len(a)
+}
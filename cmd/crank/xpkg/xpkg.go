@@ -24,8 +24,11 @@ type Cmd struct {
 	// Keep subcommands sorted alphabetically.
 	Batch   batchCmd   `cmd:"" help:"Batch build and push a family of provider packages."`
 	Build   buildCmd   `cmd:"" help:"Build a new package."`
+	Bundle  bundleCmd  `cmd:"" help:"Bundle a Configuration and its dependencies for air-gapped installation."`
+	Diff    diffCmd    `cmd:"" help:"Compare two versions of a package."`
 	Init    initCmd    `cmd:"" help:"Initialize a new package from a template."`
 	Install installCmd `cmd:"" help:"Install a package in a control plane."`
+	Lock    lockCmd    `cmd:"" help:"Pin a package's dependencies to exact digests."`
 	Push    pushCmd    `cmd:"" help:"Push a package to a registry."`
 	Update  updateCmd  `cmd:"" help:"Update a package in a control plane."`
 	Extract extractCmd `cmd:"" help:"Extract package contents into a Crossplane cache compatible format. Fetches from a remote registry by default."`
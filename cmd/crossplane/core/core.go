@@ -29,12 +29,14 @@ import (
 	"github.com/alecthomas/kong"
 	"github.com/spf13/afero"
 	corev1 "k8s.io/api/core/v1"
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	kmeta "k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/rest"
 	kcache "k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/leaderelection/resourcelock"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -63,8 +65,11 @@ import (
 	"github.com/crossplane/crossplane/v2/internal/features"
 	"github.com/crossplane/crossplane/v2/internal/initializer"
 	"github.com/crossplane/crossplane/v2/internal/metrics"
+	"github.com/crossplane/crossplane/v2/internal/ops/audit"
 	"github.com/crossplane/crossplane/v2/internal/protection/usage"
 	"github.com/crossplane/crossplane/v2/internal/transport"
+	"github.com/crossplane/crossplane/v2/internal/webhook/conversion"
+	imageconfighook "github.com/crossplane/crossplane/v2/internal/webhook/pkg/imageconfig"
 	usagehook "github.com/crossplane/crossplane/v2/internal/webhook/protection/usage"
 	"github.com/crossplane/crossplane/v2/internal/xfn"
 	"github.com/crossplane/crossplane/v2/internal/xfn/cached"
@@ -118,6 +123,10 @@ type startCommand struct {
 	MetricsPort     int `default:"8080" env:"METRICS_PORT"      help:"The port the metrics server listens on."`
 	HealthProbePort int `default:"8081" env:"HEALTH_PROBE_PORT" help:"The port the health probe endpoint listens on."`
 
+	WebhookServiceName      string `env:"WEBHOOK_SERVICE_NAME"      help:"The name of the Service object that the webhook service will be run."`
+	WebhookServiceNamespace string `env:"WEBHOOK_SERVICE_NAMESPACE" help:"The namespace of the Service object that the webhook service will be run."`
+	WebhookServicePort      int32  `env:"WEBHOOK_SERVICE_PORT"      help:"The port of the Service that the webhook service will be run."`
+
 	TLSServerSecretName string `env:"TLS_SERVER_SECRET_NAME" help:"The name of the TLS Secret that will store Crossplane's server certificate."`
 	TLSServerCertsDir   string `env:"TLS_SERVER_CERTS_DIR"   help:"The path of the folder which will store TLS server certificate of Crossplane."`
 	TLSClientSecretName string `env:"TLS_CLIENT_SECRET_NAME" help:"The name of the TLS Secret that will be store Crossplane's client certificate."`
@@ -128,6 +137,14 @@ type startCommand struct {
 	EnableSignatureVerification       bool `group:"Alpha Features:" help:"Enable support for package signature verification via ImageConfig API."`
 	EnableFunctionResponseCache       bool `group:"Alpha Features:" help:"Enable support for caching composition function responses."`
 	EnableOperations                  bool `group:"Alpha Features:" help:"Enable support for Operations."`
+	EnableFunctionCanaryActivation    bool `group:"Alpha Features:" help:"Enable support for rolling out new Function revisions as canaries."`
+	EnablePackageAutoUpdates          bool `group:"Alpha Features:" help:"Enable support for automatically updating packages with an update policy."`
+	EnablePackageHealthGates          bool `group:"Alpha Features:" help:"Enable support for rolling back package revisions that breach their health gate."`
+	EnableBreakingChangeRejection     bool `group:"Alpha Features:" help:"Enable support for refusing XRD schema changes that would break existing composite resources."`
+	EnableConversionFunctions         bool `group:"Alpha Features:" help:"Enable support for converting composite resources between versions by calling a function. Requires webhooks."`
+
+	OperationsAuditFile       string `env:"OPERATIONS_AUDIT_FILE"        group:"Alpha Features:" help:"Append a JSON record of each completed Operation to this file before it's garbage collected. Requires --enable-operations."`
+	OperationsAuditWebhookURL string `env:"OPERATIONS_AUDIT_WEBHOOK_URL" group:"Alpha Features:" help:"POST a JSON record of each completed Operation to this URL before it's garbage collected. Requires --enable-operations."`
 
 	XfnCacheDir    string        `default:"/cache/xfn" env:"XFN_CACHE_DIR"     group:"Alpha Features:" help:"Directory used for caching function responses. Requires --enable-function-response-cache."`
 	XfnCacheMaxTTL time.Duration `default:"24h"        env:"XFN_CACHE_MAX_TTL" group:"Alpha Features:" help:"Maximum TTL for cached function responses. Set to 0 to disable. Requires --enable-function-response-cache."`
@@ -265,12 +282,21 @@ func (c *startCommand) Run(s *runtime.Scheme, log logging.Logger) error { //noli
 	pfrm := xfn.NewPrometheusMetrics()
 	metrics.Registry.MustRegister(pfrm)
 
-	// We want all XR controllers to share the same gRPC clients.
-	pfr := xfn.NewPackagedFunctionRunner(mgr.GetClient(),
+	pfro := []xfn.PackagedFunctionRunnerOption{
 		xfn.WithLogger(log),
 		xfn.WithTLSConfig(clienttls),
 		xfn.WithInterceptorCreators(pfrm),
-	)
+	}
+
+	if c.EnableFunctionCanaryActivation {
+		o.Features.Enable(features.EnableAlphaFunctionCanaryActivation)
+		log.Info("Alpha feature enabled", "flag", features.EnableAlphaFunctionCanaryActivation)
+
+		pfro = append(pfro, xfn.WithCanaryRouting())
+	}
+
+	// We want all XR controllers to share the same gRPC clients.
+	pfr := xfn.NewPackagedFunctionRunner(mgr.GetClient(), pfro...)
 
 	// Periodically remove clients for Functions that no longer exist.
 	go pfr.GarbageCollectConnections(ctx, 10*time.Minute)
@@ -337,11 +363,31 @@ func (c *startCommand) Run(s *runtime.Scheme, log logging.Logger) error { //noli
 		log.Info("Alpha feature enabled", "flag", features.EnableAlphaSignatureVerification)
 	}
 
+	if c.EnablePackageAutoUpdates {
+		o.Features.Enable(features.EnableAlphaPackageAutoUpdates)
+		log.Info("Alpha feature enabled", "flag", features.EnableAlphaPackageAutoUpdates)
+	}
+
+	if c.EnablePackageHealthGates {
+		o.Features.Enable(features.EnableAlphaPackageHealthGates)
+		log.Info("Alpha feature enabled", "flag", features.EnableAlphaPackageHealthGates)
+	}
+
 	if c.EnableOperations {
 		o.Features.Enable(features.EnableAlphaOperations)
 		log.Info("Alpha feature enabled", "flag", features.EnableAlphaOperations)
 	}
 
+	if c.EnableBreakingChangeRejection {
+		o.Features.Enable(features.EnableAlphaBreakingChangeRejection)
+		log.Info("Alpha feature enabled", "flag", features.EnableAlphaBreakingChangeRejection)
+	}
+
+	if c.EnableConversionFunctions {
+		o.Features.Enable(features.EnableAlphaConversionFunctions)
+		log.Info("Alpha feature enabled", "flag", features.EnableAlphaConversionFunctions)
+	}
+
 	// Claim and XR controllers are started and stopped dynamically by the
 	// ControllerEngine below. When realtime compositions are enabled, they also
 	// start and stop their watches (e.g. of composed resources) dynamically. To
@@ -455,6 +501,28 @@ func (c *startCommand) Run(s *runtime.Scheme, log logging.Logger) error { //noli
 		CircuitBreakerCooldown:   c.CircuitBreakerCooldown,
 	}
 
+	// Crossplane serves the conversion webhook of composite resources whose
+	// XRD has a conversion function. Registering the webhook with the manager
+	// is what actually starts the webhook server.
+	if c.EnableWebhooks && o.Features.Enabled(features.EnableAlphaConversionFunctions) {
+		caBundle, err := os.ReadFile(filepath.Join(c.TLSServerCertsDir, initializer.SecretKeyCACert))
+		if err != nil {
+			return errors.Wrap(err, "cannot read webhook server CA certificate")
+		}
+
+		ao.ConversionWebhook = &extv1.WebhookClientConfig{
+			Service: &extv1.ServiceReference{
+				Name:      c.WebhookServiceName,
+				Namespace: c.WebhookServiceNamespace,
+				Port:      &c.WebhookServicePort,
+				Path:      ptr.To(conversion.Path),
+			},
+			CABundle: caBundle,
+		}
+
+		conversion.SetupWebhookWithManager(mgr, runner, o)
+	}
+
 	if err := apiextensions.Setup(mgr, ao); err != nil {
 		return errors.Wrap(err, "cannot setup API extension controllers")
 	}
@@ -465,6 +533,20 @@ func (c *startCommand) Run(s *runtime.Scheme, log logging.Logger) error { //noli
 			FunctionRunner:   runner,
 			ControllerEngine: ce,
 		}
+
+		sinks := audit.MultiSink{}
+		if c.OperationsAuditFile != "" {
+			sinks = append(sinks, audit.NewFileSink(c.OperationsAuditFile))
+			log.Info("Recording completed Operations to file before garbage collection", "path", c.OperationsAuditFile)
+		}
+		if c.OperationsAuditWebhookURL != "" {
+			sinks = append(sinks, audit.NewWebhookSink(c.OperationsAuditWebhookURL))
+			log.Info("Recording completed Operations to webhook before garbage collection", "url", audit.RedactURL(c.OperationsAuditWebhookURL))
+		}
+		if len(sinks) > 0 {
+			oo.AuditSink = sinks
+		}
+
 		if err := ops.Setup(mgr, oo); err != nil {
 			return errors.Wrap(err, "cannot setup ops controllers")
 		}
@@ -491,16 +573,26 @@ func (c *startCommand) Run(s *runtime.Scheme, log logging.Logger) error { //noli
 	log.Info("Package Runtime for Provider: " + string(pr.For(pkgv1.ProviderKind)))
 	log.Info("Package Runtime for Function: " + string(pr.For(pkgv1.FunctionKind)))
 
+	// All package fetchers share registry health, so that a registry one
+	// controller observes to be down is avoided by all of them.
+	rh := xpkg.NewRegistryHealth()
+
 	po := pkgcontroller.Options{
 		Options:                          o,
 		Cache:                            xpkg.NewFsPackageCache(c.XpkgCacheDir, afero.NewOsFs()),
 		Namespace:                        c.Namespace,
 		ServiceAccount:                   c.ServiceAccount,
-		FetcherOptions:                   []xpkg.FetcherOpt{xpkg.WithUserAgent(c.UserAgent)},
+		FetcherOptions:                   []xpkg.FetcherOpt{xpkg.WithUserAgent(c.UserAgent), xpkg.WithRegistryHealth(rh)},
 		PackageRuntime:                   pr,
 		MaxConcurrentPackageEstablishers: c.MaxConcurrentPackageEstablishers,
 	}
 
+	// The Function package controllers analyze canary revisions and health
+	// gates using the responses the function runner observes.
+	if o.Features.Enabled(features.EnableAlphaFunctionCanaryActivation) || o.Features.Enabled(features.EnableAlphaPackageHealthGates) {
+		po.FunctionResponses = pfrm
+	}
+
 	// We need to set the TUF_ROOT environment variable so that the TUF client
 	// knows where to store its data. A directory under CacheDir is a good place
 	// for this because it's a place that Crossplane has write access to, and
@@ -528,6 +620,10 @@ func (c *startCommand) Run(s *runtime.Scheme, log logging.Logger) error { //noli
 
 	// Registering webhooks with the manager is what actually starts the webhook
 	// server.
+	if c.EnableWebhooks {
+		imageconfighook.SetupWebhookWithManager(mgr, o)
+	}
+
 	if c.EnableWebhooks && o.Features.Enabled(features.EnableBetaUsages) {
 		f, err := usage.NewFinder(mgr.GetClient(), mgr.GetFieldIndexer())
 		if err != nil {
@@ -20,6 +20,8 @@ package controller
 import (
 	"time"
 
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+
 	"github.com/crossplane/crossplane-runtime/v2/pkg/controller"
 
 	"github.com/crossplane/crossplane/v2/internal/circuit"
@@ -37,6 +39,11 @@ type Options struct {
 	// FunctionRunner used to run Composition Functions.
 	FunctionRunner xfn.FunctionRunner
 
+	// ConversionWebhook is how the API server calls Crossplane's composite
+	// resource conversion webhook. Composite resources can't be converted by
+	// a function if it's nil.
+	ConversionWebhook *extv1.WebhookClientConfig
+
 	// CircuitBreakerMetrics records XR circuit breaker activity.
 	CircuitBreakerMetrics *circuit.PrometheusMetrics
 
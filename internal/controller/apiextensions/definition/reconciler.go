@@ -49,6 +49,7 @@ import (
 	ucomposite "github.com/crossplane/crossplane-runtime/v2/pkg/resource/unstructured/composite"
 
 	v1 "github.com/crossplane/crossplane/v2/apis/apiextensions/v1"
+	pkgmetav1 "github.com/crossplane/crossplane/v2/apis/pkg/meta/v1"
 	"github.com/crossplane/crossplane/v2/internal/circuit"
 	"github.com/crossplane/crossplane/v2/internal/controller/apiextensions/composite"
 	"github.com/crossplane/crossplane/v2/internal/controller/apiextensions/composite/watch"
@@ -56,6 +57,7 @@ import (
 	"github.com/crossplane/crossplane/v2/internal/engine"
 	"github.com/crossplane/crossplane/v2/internal/features"
 	"github.com/crossplane/crossplane/v2/internal/xcrd"
+	"github.com/crossplane/crossplane/v2/internal/xfn"
 )
 
 const (
@@ -78,6 +80,8 @@ const (
 	errDeleteCRs                      = "cannot delete defined composite resources"
 	errListCRDs                       = "cannot list CustomResourceDefinitions"
 	errCannotAddInformerLoopToManager = "cannot add resources informer loop to manager"
+	errConversionWebhookDisabled      = "cannot use a conversion function: the composite resource conversion webhook is disabled"
+	errConversionCapability           = "cannot use conversion function"
 )
 
 // Wait strings.
@@ -180,6 +184,7 @@ func Setup(mgr ctrl.Manager, o apiextensionscontroller.Options) error {
 		WithLogger(o.Logger.WithValues("controller", name)),
 		WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name), o.EventFilterFunctions...)),
 		WithControllerEngine(o.ControllerEngine),
+		WithCapabilityChecker(xfn.NewRevisionCapabilityChecker(mgr.GetClient())),
 		WithOptions(o))
 
 	return ctrl.NewControllerManagedBy(mgr).
@@ -239,6 +244,14 @@ func WithCRDRenderer(c CRDRenderer) ReconcilerOption {
 	}
 }
 
+// WithCapabilityChecker specifies how the Reconciler should check that an
+// XRD's conversion function has the conversion capability.
+func WithCapabilityChecker(c xfn.CapabilityChecker) ReconcilerOption {
+	return func(r *Reconciler) {
+		r.functions = c
+	}
+}
+
 type definition struct {
 	CRDRenderer
 	resource.Finalizer
@@ -263,6 +276,8 @@ func NewReconciler(ca resource.ClientApplicator, opts ...ReconcilerOption) *Reco
 
 		engine: &NopEngine{},
 
+		functions: xfn.CapabilityCheckerFn(func(_ context.Context, _ []string, _ ...string) error { return nil }),
+
 		log:        logging.NewNopLogger(),
 		record:     event.NewNopRecorder(),
 		conditions: conditions.ObservedGenerationPropagationManager{},
@@ -291,6 +306,8 @@ type Reconciler struct {
 
 	engine ControllerEngine
 
+	functions xfn.CapabilityChecker
+
 	log        logging.Logger
 	record     event.Recorder
 	conditions conditions.Manager
@@ -470,8 +487,43 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 		return reconcile.Result{}, err
 	}
 
+	if err := r.configureConversion(ctx, d, crd); err != nil {
+		log.Debug("Cannot configure conversion function", "error", err)
+		r.record.Event(d, event.Warning(reasonRenderCRD, err))
+		status.MarkConditions(v1.InvalidConversion(err.Error()))
+
+		if err := r.client.Status().Update(ctx, d); err != nil {
+			return reconcile.Result{}, errors.Wrap(err, errUpdateStatus)
+		}
+
+		// Return the error so we retry, for example once the function
+		// is installed.
+		return reconcile.Result{}, err
+	}
+
+	// Only XRDs that use, or used, a conversion function have this
+	// condition.
+	if d.Spec.ConversionFunction != nil || d.GetCondition(v1.TypeValidConversion).Status != corev1.ConditionUnknown {
+		status.MarkConditions(v1.ValidConversion())
+	}
+
+	// Breaking schema changes may invalidate existing composite resources.
+	// We refuse to make them if breaking change rejection is enabled, unless
+	// the XRD explicitly allows them. Otherwise we only warn about them.
+	var breaking error
+
 	origRV := ""
-	if err := r.client.Applicator.Apply(ctx, crd, resource.MustBeControllableBy(d.GetUID()), resource.StoreCurrentRV(&origRV)); err != nil {
+	ao := []resource.ApplyOption{
+		resource.MustBeControllableBy(d.GetUID()),
+		resource.StoreCurrentRV(&origRV),
+		xcrd.WarnBreakingChanges(func(err error) { breaking = err }),
+	}
+
+	if r.options.Features.Enabled(features.EnableAlphaBreakingChangeRejection) && !xcrd.AllowsBreakingChanges(d) {
+		ao = append(ao, xcrd.DenyBreakingChanges())
+	}
+
+	if err := r.client.Applicator.Apply(ctx, crd, ao...); err != nil {
 		log.Debug(errApplyCRD, "error", err)
 
 		if kerrors.IsConflict(err) {
@@ -481,7 +533,25 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 		err = errors.Wrap(err, errApplyCRD)
 		r.record.Event(d, event.Warning(reasonEstablishXR, err))
 
-		return reconcile.Result{}, err
+		if breaking == nil {
+			return reconcile.Result{}, err
+		}
+
+		// There's no point retrying until the XRD changes.
+		status.MarkConditions(v1.BreakingSchemaChanges(err.Error()))
+
+		return reconcile.Result{}, errors.Wrap(r.client.Status().Update(ctx, d), errUpdateStatus)
+	}
+
+	switch {
+	case breaking != nil:
+		r.record.Event(d, event.Warning(reasonEstablishXR, breaking))
+		status.MarkConditions(v1.BreakingSchemaChanges(breaking.Error()))
+	case d.GetCondition(v1.TypeCompatibleSchema).ObservedGeneration != d.GetGeneration():
+		// We keep reporting breaking changes we made until the XRD
+		// changes again. The CRD we just applied is identical, so
+		// comparing against it finds no breaking changes.
+		status.MarkConditions(v1.CompatibleSchema())
 	}
 
 	if crd.GetResourceVersion() != origRV {
@@ -663,3 +733,31 @@ func ControllerNeedsRestart(d *v1.CompositeResourceDefinition) bool {
 	// Restart needed if the XRD has changed since the controller was started
 	return c.ObservedGeneration != d.GetGeneration()
 }
+
+// configureConversion configures the supplied CRD to call Crossplane's
+// composite resource conversion webhook if the supplied XRD has a conversion
+// function.
+func (r *Reconciler) configureConversion(ctx context.Context, d *v1.CompositeResourceDefinition, crd *extv1.CustomResourceDefinition) error {
+	cf := d.Spec.ConversionFunction
+	if cf == nil {
+		return nil
+	}
+
+	if r.options.ConversionWebhook == nil {
+		return errors.New(errConversionWebhookDisabled)
+	}
+
+	if err := r.functions.CheckCapabilities(ctx, []string{pkgmetav1.FunctionCapabilityConversion}, cf.FunctionRef.Name); err != nil {
+		return errors.Wrap(err, errConversionCapability)
+	}
+
+	crd.Spec.Conversion = &extv1.CustomResourceConversion{
+		Strategy: extv1.WebhookConverter,
+		Webhook: &extv1.WebhookConversion{
+			ClientConfig:             r.options.ConversionWebhook.DeepCopy(),
+			ConversionReviewVersions: []string{"v1"},
+		},
+	}
+
+	return nil
+}
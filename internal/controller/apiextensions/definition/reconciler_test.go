@@ -27,15 +27,22 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	"github.com/crossplane/crossplane-runtime/v2/pkg/controller"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/feature"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/test"
 
 	v1 "github.com/crossplane/crossplane/v2/apis/apiextensions/v1"
+	apiextensionscontroller "github.com/crossplane/crossplane/v2/internal/controller/apiextensions/controller"
 	"github.com/crossplane/crossplane/v2/internal/engine"
+	"github.com/crossplane/crossplane/v2/internal/features"
+	"github.com/crossplane/crossplane/v2/internal/xcrd"
+	"github.com/crossplane/crossplane/v2/internal/xfn"
 )
 
 var (
@@ -580,6 +587,218 @@ func TestReconcile(t *testing.T) {
 				err: errors.Wrap(errBoom, errApplyCRD),
 			},
 		},
+		"BreakingSchemaChangeWarning": {
+			reason: "We should apply a CRD that would make breaking changes to a served version's schema if breaking change rejection is disabled.",
+			args: args{
+				ca: resource.ClientApplicator{
+					Client: &test.MockClient{
+						MockGet: test.NewMockGetFn(nil),
+					},
+					Applicator: resource.ApplyFn(func(ctx context.Context, o client.Object, ao ...resource.ApplyOption) error {
+						for _, fn := range ao {
+							if err := fn(ctx, unservedCRD(), o); err != nil {
+								return err
+							}
+						}
+						return nil
+					}),
+				},
+				opts: []ReconcilerOption{
+					WithCRDRenderer(CRDRenderFn(func(_ *v1.CompositeResourceDefinition) (*extv1.CustomResourceDefinition, error) {
+						return &extv1.CustomResourceDefinition{}, nil
+					})),
+					WithFinalizer(resource.FinalizerFns{AddFinalizerFn: func(_ context.Context, _ resource.Object) error {
+						return nil
+					}}),
+				},
+			},
+			want: want{
+				// We requeue because the applied CRD isn't established yet.
+				r: reconcile.Result{Requeue: true},
+			},
+		},
+		"BreakingSchemaChangeRejected": {
+			reason: "We should refuse to apply a CRD that would make breaking changes to a served version's schema if breaking change rejection is enabled, and say why in the XRD's status.",
+			args: args{
+				ca: resource.ClientApplicator{
+					Client: &test.MockClient{
+						MockGet: test.NewMockGetFn(nil),
+						MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil, func(got client.Object) error {
+							breaking := xcrd.BreakingChangesError{Changes: []xcrd.VersionChange{
+								{Version: "v1", SchemaChange: xcrd.SchemaChange{Message: "version is no longer served", Breaking: true}},
+							}}
+							err := errors.Wrap(errors.Wrapf(breaking, "refusing to apply CustomResourceDefinition (set the %s annotation to \"true\" to allow breaking changes)", xcrd.AnnotationKeyAllowBreakingChanges), errApplyCRD)
+
+							want := &v1.CompositeResourceDefinition{}
+							want.Status.SetConditions(v1.BreakingSchemaChanges(err.Error()))
+
+							if diff := cmp.Diff(want, got); diff != "" {
+								t.Errorf("MockStatusUpdate: -want, +got:\n%s\n", diff)
+							}
+
+							return nil
+						}),
+					},
+					Applicator: resource.ApplyFn(func(ctx context.Context, o client.Object, ao ...resource.ApplyOption) error {
+						for _, fn := range ao {
+							if err := fn(ctx, unservedCRD(), o); err != nil {
+								return err
+							}
+						}
+						return nil
+					}),
+				},
+				opts: []ReconcilerOption{
+					WithCRDRenderer(CRDRenderFn(func(_ *v1.CompositeResourceDefinition) (*extv1.CustomResourceDefinition, error) {
+						return &extv1.CustomResourceDefinition{}, nil
+					})),
+					WithFinalizer(resource.FinalizerFns{AddFinalizerFn: func(_ context.Context, _ resource.Object) error {
+						return nil
+					}}),
+					WithOptions(withFeatures(features.EnableAlphaBreakingChangeRejection)),
+				},
+			},
+			want: want{
+				r: reconcile.Result{},
+			},
+		},
+		"ConversionWebhookDisabled": {
+			reason: "We should return an error, and say why in the XRD's status, if the XRD has a conversion function but the conversion webhook is disabled.",
+			args: args{
+				ca: resource.ClientApplicator{
+					Client: &test.MockClient{
+						MockGet: test.NewMockGetFn(nil, withConversionFunction),
+						MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil, func(got client.Object) error {
+							want := &v1.CompositeResourceDefinition{}
+							_ = withConversionFunction(want)
+							want.Status.SetConditions(v1.InvalidConversion(errConversionWebhookDisabled))
+
+							if diff := cmp.Diff(want, got); diff != "" {
+								t.Errorf("MockStatusUpdate: -want, +got:\n%s\n", diff)
+							}
+
+							return nil
+						}),
+					},
+				},
+				opts: []ReconcilerOption{
+					WithCRDRenderer(CRDRenderFn(func(_ *v1.CompositeResourceDefinition) (*extv1.CustomResourceDefinition, error) {
+						return &extv1.CustomResourceDefinition{}, nil
+					})),
+					WithFinalizer(resource.FinalizerFns{AddFinalizerFn: func(_ context.Context, _ resource.Object) error {
+						return nil
+					}}),
+				},
+			},
+			want: want{
+				err: errors.New(errConversionWebhookDisabled),
+			},
+		},
+		"ConversionFunctionMissingCapability": {
+			reason: "We should return an error, and say why in the XRD's status, if the XRD's conversion function doesn't have the conversion capability.",
+			args: args{
+				ca: resource.ClientApplicator{
+					Client: &test.MockClient{
+						MockGet: test.NewMockGetFn(nil, withConversionFunction),
+						MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil, func(got client.Object) error {
+							want := &v1.CompositeResourceDefinition{}
+							_ = withConversionFunction(want)
+							want.Status.SetConditions(v1.InvalidConversion(errors.Wrap(errBoom, errConversionCapability).Error()))
+
+							if diff := cmp.Diff(want, got); diff != "" {
+								t.Errorf("MockStatusUpdate: -want, +got:\n%s\n", diff)
+							}
+
+							return nil
+						}),
+					},
+				},
+				opts: []ReconcilerOption{
+					WithCRDRenderer(CRDRenderFn(func(_ *v1.CompositeResourceDefinition) (*extv1.CustomResourceDefinition, error) {
+						return &extv1.CustomResourceDefinition{}, nil
+					})),
+					WithFinalizer(resource.FinalizerFns{AddFinalizerFn: func(_ context.Context, _ resource.Object) error {
+						return nil
+					}}),
+					WithOptions(withConversionWebhook()),
+					WithCapabilityChecker(xfn.CapabilityCheckerFn(func(_ context.Context, _ []string, _ ...string) error {
+						return errBoom
+					})),
+				},
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errConversionCapability),
+			},
+		},
+		"ConversionFunctionConfigured": {
+			reason: "We should configure the CRD to call the conversion webhook if the XRD has a conversion function with the conversion capability.",
+			args: args{
+				ca: resource.ClientApplicator{
+					Client: &test.MockClient{
+						MockGet: test.NewMockGetFn(nil, withConversionFunction),
+					},
+					Applicator: resource.ApplyFn(func(_ context.Context, o client.Object, _ ...resource.ApplyOption) error {
+						want := &extv1.CustomResourceConversion{
+							Strategy: extv1.WebhookConverter,
+							Webhook: &extv1.WebhookConversion{
+								ClientConfig:             withConversionWebhook().ConversionWebhook,
+								ConversionReviewVersions: []string{"v1"},
+							},
+						}
+
+						if diff := cmp.Diff(want, o.(*extv1.CustomResourceDefinition).Spec.Conversion); diff != "" {
+							t.Errorf("Apply(...): -want conversion, +got conversion:\n%s\n", diff)
+						}
+
+						return nil
+					}),
+				},
+				opts: []ReconcilerOption{
+					WithCRDRenderer(CRDRenderFn(func(_ *v1.CompositeResourceDefinition) (*extv1.CustomResourceDefinition, error) {
+						return &extv1.CustomResourceDefinition{}, nil
+					})),
+					WithFinalizer(resource.FinalizerFns{AddFinalizerFn: func(_ context.Context, _ resource.Object) error {
+						return nil
+					}}),
+					WithOptions(withConversionWebhook()),
+				},
+			},
+			want: want{
+				// We requeue because the applied CRD isn't established yet.
+				r: reconcile.Result{Requeue: true},
+			},
+		},
+		"ConversionFunctionDeleted": {
+			reason: "We should clean up a deleted XRD even if its conversion function can't be used.",
+			args: args{
+				ca: resource.ClientApplicator{
+					Client: &test.MockClient{
+						MockGet: test.NewMockGetFn(nil, func(o client.Object) error {
+							if v, ok := o.(*v1.CompositeResourceDefinition); ok {
+								_ = withConversionFunction(v)
+								v.SetDeletionTimestamp(&now)
+							}
+							return nil
+						}),
+						MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+					},
+				},
+				opts: []ReconcilerOption{
+					WithCRDRenderer(CRDRenderFn(func(_ *v1.CompositeResourceDefinition) (*extv1.CustomResourceDefinition, error) {
+						return &extv1.CustomResourceDefinition{}, nil
+					})),
+					WithFinalizer(resource.FinalizerFns{RemoveFinalizerFn: func(_ context.Context, _ resource.Object) error {
+						return nil
+					}}),
+					WithCapabilityChecker(xfn.CapabilityCheckerFn(func(_ context.Context, _ []string, _ ...string) error {
+						return errBoom
+					})),
+				},
+			},
+			want: want{
+				r: reconcile.Result{Requeue: false},
+			},
+		},
 		"CustomResourceDefinitionIsNotEstablished": {
 			reason: "We should requeue if we're waiting for a newly created CRD to become established.",
 			args: args{
@@ -937,6 +1156,40 @@ func TestReconcile(t *testing.T) {
 	}
 }
 
+// unservedCRD returns a CRD that serves a version the CRDs rendered by
+// TestReconcile don't.
+func unservedCRD() *extv1.CustomResourceDefinition {
+	return &extv1.CustomResourceDefinition{
+		Spec: extv1.CustomResourceDefinitionSpec{
+			Versions: []extv1.CustomResourceDefinitionVersion{{Name: "v1", Served: true}},
+		},
+	}
+}
+
+func withConversionFunction(o client.Object) error {
+	if d, ok := o.(*v1.CompositeResourceDefinition); ok {
+		d.Spec.ConversionFunction = &v1.ConversionFunction{FunctionRef: v1.FunctionReference{Name: "function-convert"}}
+	}
+
+	return nil
+}
+
+func withConversionWebhook() apiextensionscontroller.Options {
+	o := apiextensionscontroller.Options{Options: controller.DefaultOptions()}
+	o.ConversionWebhook = &extv1.WebhookClientConfig{URL: ptr.To("https://crossplane-webhooks.crossplane-system.svc:9443/convert-composite")}
+
+	return o
+}
+
+func withFeatures(ff ...feature.Flag) apiextensionscontroller.Options {
+	o := apiextensionscontroller.Options{Options: controller.DefaultOptions()}
+	for _, f := range ff {
+		o.Features.Enable(f)
+	}
+
+	return o
+}
+
 func TestControllerNeedsRestart(t *testing.T) {
 	type args struct {
 		d *v1.CompositeResourceDefinition
@@ -436,7 +436,19 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 	}
 
 	origRV := ""
-	if err := r.client.Applicator.Apply(ctx, crd, resource.MustBeControllableBy(d.GetUID()), resource.StoreCurrentRV(&origRV)); err != nil {
+	ao := []resource.ApplyOption{resource.MustBeControllableBy(d.GetUID()), resource.StoreCurrentRV(&origRV)}
+
+	// Breaking schema changes may invalidate existing claims. We refuse to
+	// make them if breaking change rejection is enabled, unless the XRD
+	// explicitly allows them. Otherwise we only warn about them. The
+	// definition reconciler reports them as a condition of the XRD.
+	if r.options.Features.Enabled(features.EnableAlphaBreakingChangeRejection) && !xcrd.AllowsBreakingChanges(d) {
+		ao = append(ao, xcrd.DenyBreakingChanges())
+	} else {
+		ao = append(ao, xcrd.WarnBreakingChanges(func(err error) { r.record.Event(d, event.Warning(reasonOfferXRC, err)) }))
+	}
+
+	if err := r.client.Applicator.Apply(ctx, crd, ao...); err != nil {
 		if kerrors.IsConflict(err) {
 			return reconcile.Result{Requeue: true}, nil
 		}
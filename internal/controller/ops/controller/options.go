@@ -21,6 +21,7 @@ import (
 	"github.com/crossplane/crossplane-runtime/v2/pkg/controller"
 
 	"github.com/crossplane/crossplane/v2/internal/engine"
+	"github.com/crossplane/crossplane/v2/internal/ops/audit"
 	"github.com/crossplane/crossplane/v2/internal/xfn"
 )
 
@@ -33,4 +34,8 @@ type Options struct {
 
 	// ControllerEngine used to dynamically manage watches.
 	ControllerEngine *engine.ControllerEngine
+
+	// AuditSink stores a record of each completed Operation before it's
+	// garbage collected. Optional.
+	AuditSink audit.Sink
 }
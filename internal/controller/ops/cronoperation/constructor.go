@@ -34,6 +34,7 @@ import (
 
 	"github.com/crossplane/crossplane/v2/apis/ops/v1alpha1"
 	opscontroller "github.com/crossplane/crossplane/v2/internal/controller/ops/controller"
+	"github.com/crossplane/crossplane/v2/internal/ops/audit"
 )
 
 // Setup adds a controller that reconciles CronOperations by
@@ -41,9 +42,15 @@ import (
 func Setup(mgr ctrl.Manager, o opscontroller.Options) error {
 	name := "ops/" + strings.ToLower(v1alpha1.CronOperationGroupKind)
 
-	r := NewReconciler(mgr,
+	ro := []ReconcilerOption{
 		WithLogger(o.Logger.WithValues("controller", name)),
-		WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name), o.EventFilterFunctions...)))
+		WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name), o.EventFilterFunctions...)),
+	}
+	if o.AuditSink != nil {
+		ro = append(ro, WithAuditSink(o.AuditSink))
+	}
+
+	r := NewReconciler(mgr, ro...)
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
@@ -70,6 +77,14 @@ func WithRecorder(er event.Recorder) ReconcilerOption {
 	}
 }
 
+// WithAuditSink specifies where the Reconciler should store records of
+// Operations before garbage collecting them.
+func WithAuditSink(s audit.Sink) ReconcilerOption {
+	return func(r *Reconciler) {
+		r.audit = s
+	}
+}
+
 // WithScheduler specifies how the Reconciler should schedule operations.
 func WithScheduler(s Scheduler) ReconcilerOption {
 	return func(r *Reconciler) {
@@ -84,6 +99,7 @@ func NewReconciler(mgr manager.Manager, opts ...ReconcilerOption) *Reconciler {
 		log:        logging.NewNopLogger(),
 		record:     event.NewNopRecorder(),
 		conditions: conditions.ObservedGenerationPropagationManager{},
+		audit:      audit.NopSink{},
 		schedule: SchedulerFn(func(schedule string, last time.Time) (time.Time, error) {
 			cs, err := cron.ParseStandard(schedule)
 			if err != nil {
@@ -37,6 +37,7 @@ import (
 	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
 
 	"github.com/crossplane/crossplane/v2/apis/ops/v1alpha1"
+	"github.com/crossplane/crossplane/v2/internal/ops/audit"
 	"github.com/crossplane/crossplane/v2/internal/ops/lifecycle"
 )
 
@@ -45,6 +46,7 @@ const (
 	reasonInvalidSchedule          = "InvalidSchedule"
 	reasonListOperations           = "ListOperations"
 	reasonGarbageCollectOperations = "GarbageCollectOperations"
+	reasonAuditOperation           = "AuditOperation"
 	reasonReplaceRunningOperation  = "ReplaceRunningOperation"
 	reasonCreateOperation          = "CreateOperation"
 )
@@ -69,6 +71,7 @@ type Reconciler struct {
 	record     event.Recorder
 	conditions conditions.Manager
 	schedule   Scheduler
+	audit      audit.Sink
 }
 
 // Reconcile a CronOperation.
@@ -135,6 +138,18 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 
 	// Garbage collect Operations older than the history limits.
 	for _, op := range lifecycle.MarkGarbage(ptr.Deref(co.Spec.SuccessfulHistoryLimit, 3), ptr.Deref(co.Spec.FailedHistoryLimit, 1), ol.Items...) {
+		// Don't delete an Operation until we've durably recorded it. If
+		// the delete fails we'll record it again next time, so sinks
+		// may see duplicate records.
+		if err := r.audit.Store(ctx, audit.NewRecord(&op)); err != nil {
+			log.Debug("Cannot store audit record of Operation", "error", err, "operation", op.GetName())
+			err = errors.Wrapf(err, "cannot store audit record of Operation %q", op.GetName())
+			r.record.Event(co, event.Warning(reasonAuditOperation, err))
+			status.MarkConditions(xpv1.ReconcileError(err))
+			_ = r.client.Status().Update(ctx, co)
+			return reconcile.Result{}, err
+		}
+
 		if err := r.client.Delete(ctx, &op); resource.IgnoreNotFound(err) != nil {
 			log.Debug("Cannot garbage collect Operation", "error", err, "operation", op.GetName())
 			err = errors.Wrapf(err, "cannot garbage collect Operation %q", op.GetName())
@@ -38,6 +38,7 @@ import (
 	"github.com/crossplane/crossplane-runtime/v2/pkg/test"
 
 	"github.com/crossplane/crossplane/v2/apis/ops/v1alpha1"
+	"github.com/crossplane/crossplane/v2/internal/ops/audit"
 )
 
 // EquateApproxDuration returns a cmp.Option that considers two time.Duration values
@@ -583,6 +584,88 @@ func TestReconcile(t *testing.T) {
 				err: cmpopts.AnyError,
 			},
 		},
+		"AuditError": {
+			reason: "We should return an error, and not garbage collect old operations, if we can't store an audit record of them",
+			params: params{
+				mgr: &fake.Manager{
+					Client: &test.MockClient{
+						MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+							co := &v1alpha1.CronOperation{
+								ObjectMeta: metav1.ObjectMeta{
+									Name:              "test-cron",
+									CreationTimestamp: metav1.Time{Time: past},
+								},
+								Spec: v1alpha1.CronOperationSpec{
+									Schedule:               "0 * * * *",
+									SuccessfulHistoryLimit: ptr.To(int32(1)),
+									FailedHistoryLimit:     ptr.To(int32(1)),
+								},
+							}
+							co.DeepCopyInto(obj.(*v1alpha1.CronOperation))
+							return nil
+						}),
+						MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
+							list := obj.(*v1alpha1.OperationList)
+							// Add old operations that should be garbage collected
+							list.Items = []v1alpha1.Operation{
+								{
+									ObjectMeta: metav1.ObjectMeta{
+										Name:              "old-op",
+										CreationTimestamp: metav1.Time{Time: past.Add(-2 * time.Hour)},
+									},
+									Status: v1alpha1.OperationStatus{
+										ConditionedStatus: xpv1.ConditionedStatus{
+											Conditions: []xpv1.Condition{
+												{
+													Type:   v1alpha1.TypeSucceeded,
+													Status: "True",
+													Reason: v1alpha1.ReasonPipelineSuccess,
+												},
+											},
+										},
+									},
+								},
+								{
+									ObjectMeta: metav1.ObjectMeta{
+										Name:              "newer-op",
+										CreationTimestamp: metav1.Time{Time: past.Add(-1 * time.Hour)},
+									},
+									Status: v1alpha1.OperationStatus{
+										ConditionedStatus: xpv1.ConditionedStatus{
+											Conditions: []xpv1.Condition{
+												{
+													Type:   v1alpha1.TypeSucceeded,
+													Status: "True",
+													Reason: v1alpha1.ReasonPipelineSuccess,
+												},
+											},
+										},
+									},
+								},
+							}
+							return nil
+						}),
+						MockDelete: test.NewMockDeleteFn(nil, func(_ client.Object) error {
+							t.Errorf("Delete(...): unexpected call to delete an Operation that wasn't audited")
+							return nil
+						}),
+						MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+					},
+				},
+				opts: []ReconcilerOption{
+					WithAuditSink(audit.SinkFn(func(_ context.Context, _ *audit.Record) error {
+						return errors.New("boom")
+					})),
+					WithScheduler(SchedulerFn(func(_ string, _ time.Time) (time.Time, error) {
+						return future, nil
+					})),
+				},
+			},
+			want: want{
+				r:   reconcile.Result{},
+				err: cmpopts.AnyError,
+			},
+		},
 	}
 
 	for name, tc := range cases {
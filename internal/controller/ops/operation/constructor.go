@@ -90,6 +90,14 @@ func WithRequiredResourcesFetcher(f xfn.RequiredResourcesFetcher) ReconcilerOpti
 	}
 }
 
+// WithWaitChecker specifies how the Reconciler should check whether pipeline
+// steps are done waiting.
+func WithWaitChecker(wc WaitChecker) ReconcilerOption {
+	return func(r *Reconciler) {
+		r.waits = wc
+	}
+}
+
 // NewReconciler returns a Reconciler of Usages.
 func NewReconciler(mgr manager.Manager, opts ...ReconcilerOption) *Reconciler {
 	r := &Reconciler{
@@ -99,6 +107,7 @@ func NewReconciler(mgr manager.Manager, opts ...ReconcilerOption) *Reconciler {
 		conditions: conditions.ObservedGenerationPropagationManager{},
 		functions:  xfn.NewRevisionCapabilityChecker(mgr.GetClient()),
 		resources:  xfn.NewExistingRequiredResourcesFetcher(mgr.GetClient()),
+		waits:      NewAPIWaitChecker(mgr.GetClient()),
 	}
 
 	for _, f := range opts {
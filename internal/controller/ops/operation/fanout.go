@@ -0,0 +1,385 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operation
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/v2/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/logging"
+
+	"github.com/crossplane/crossplane/v2/apis/ops/v1alpha1"
+)
+
+// DefaultMaxConcurrency is the number of fan-out targets an Operation runs its
+// pipeline against at once, unless otherwise specified.
+const DefaultMaxConcurrency = 1
+
+// DefaultMaxUnavailable is the number of fan-out targets an Operation may fail
+// against, unless otherwise specified.
+const DefaultMaxUnavailable = 0
+
+// Event reasons.
+const (
+	reasonListTargets    = "ListTargets"
+	reasonTargetFailed   = "TargetFailed"
+	reasonMaxUnavailable = "MaxUnavailable"
+)
+
+// reconcileFanOut runs a FanOut Operation's pipeline once per target.
+//
+// Each target's result is persisted to the Operation's status as soon as it's
+// known. Targets that already have a result are skipped, so a FanOut Operation
+// that is interrupted or requeued picks up where it left off. A target that
+// was running alongside others when the Operation was interrupted may run
+// again.
+func (r *Reconciler) reconcileFanOut(ctx context.Context, log logging.Logger, op *v1alpha1.Operation) (reconcile.Result, error) { //nolint:gocognit // Only slightly over.
+	status := r.conditions.For(op)
+
+	if op.Spec.FanOut == nil {
+		// This should be prevented by CEL validation, but just in case.
+		status.MarkConditions(xpv1.ReconcileSuccess(), v1alpha1.Failed("fanOut must be specified when mode is FanOut"))
+		return reconcile.Result{}, errors.Wrap(r.client.Status().Update(ctx, op), "cannot update Operation status")
+	}
+
+	targets, err := r.listTargets(ctx, op.Spec.FanOut.Targets)
+	if err != nil {
+		op.Status.Failures++
+
+		log.Debug("Cannot list fan-out targets", "error", err, "failures", op.Status.Failures)
+		err = errors.Wrap(err, "cannot list fan-out targets")
+		r.record.Event(op, event.Warning(reasonListTargets, err))
+		status.MarkConditions(xpv1.ReconcileError(err))
+		_ = r.client.Status().Update(ctx, op)
+
+		return reconcile.Result{}, err
+	}
+
+	maxUnavailable := ptr.Deref(op.Spec.FanOut.MaxUnavailable, DefaultMaxUnavailable)
+	maxConcurrency := ptr.Deref(op.Spec.FanOut.MaxConcurrency, DefaultMaxConcurrency)
+	if maxConcurrency < 1 {
+		maxConcurrency = DefaultMaxConcurrency
+	}
+
+	// Start running against targets as fast as concurrency allows, unless
+	// we're configured to start no more than a number of targets per minute.
+	// We persist when we last started running against a target, so the
+	// limit holds across reconciles.
+	var interval time.Duration
+	if n := ptr.Deref(op.Spec.FanOut.TargetsPerMinute, 0); n > 0 {
+		interval = time.Minute / time.Duration(n)
+	}
+
+	// Targets are sorted by key. Every target up to and including the last
+	// completed target has a result, as do any targets we failed against.
+	pending := make([]*kunstructured.Unstructured, 0, len(targets))
+	for _, t := range targets {
+		if targetKey(t.GetNamespace(), t.GetName()) <= op.Status.LastCompletedTarget {
+			continue
+		}
+		if GetTargetStatus(op.Status.Targets, t) != nil {
+			continue
+		}
+		pending = append(pending, t)
+	}
+
+	log = log.WithValues("targets", len(targets), "pending", len(pending))
+	log.Debug("Running operation pipeline against fan-out targets")
+
+	// Pipelines read the Operation's spec concurrently while we write its
+	// status. Give them their own copy to read.
+	snapshot := op.DeepCopy()
+
+	mu := &sync.Mutex{}
+	g := &errgroup.Group{}
+	sem := make(chan struct{}, maxConcurrency)
+
+	// Targets complete out of order when we run more than one at once. We
+	// only advance the last completed target once every target before it
+	// is done, so we never skip a target when we resume.
+	done := make([]bool, len(pending))
+	next := 0
+
+	var werr error
+	var requeue time.Duration
+	for i, t := range pending {
+		// Wait until there's capacity to run another pipeline.
+		sem <- struct{}{}
+
+		mu.Lock()
+		exceeded := op.Status.FailedTargets > maxUnavailable
+		mu.Unlock()
+
+		// Stop processing new targets once we've exceeded our failure
+		// budget. Targets that are already running will finish.
+		if exceeded {
+			<-sem
+			break
+		}
+
+		if interval > 0 {
+			mu.Lock()
+			requeue = untilNextTarget(op.Status.LastTargetStartTime, interval)
+			if requeue == 0 {
+				op.Status.LastTargetStartTime = ptr.To(metav1.Now())
+				werr = errors.Wrap(r.client.Status().Update(ctx, op), "cannot update Operation status")
+			}
+			mu.Unlock()
+
+			// Come back when it's time to start the next target.
+			if requeue > 0 || werr != nil {
+				<-sem
+				break
+			}
+		}
+
+		g.Go(func() error {
+			defer func() { <-sem }()
+
+			log := log.WithValues("target-kind", t.GetKind(), "target-namespace", t.GetNamespace(), "target-name", t.GetName())
+
+			res, err := r.runPipeline(ctx, log, snapshot, InjectTarget(snapshot.Spec.Pipeline, t))
+
+			// Don't blame the target if we ran out of time. We'll
+			// try it again the next time we reconcile.
+			if err != nil && ctx.Err() != nil {
+				return err
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			if err != nil {
+				log.Debug("Cannot run operation pipeline against fan-out target", "error", err)
+				r.record.Event(op, event.Warning(reasonTargetFailed, errors.Wrapf(err, "cannot run pipeline against %s %q", t.GetKind(), targetName(t))))
+
+				op.Status.FailedTargets++
+
+				// We only record the targets we failed against, and
+				// only up to a point. The counts summarise the rest.
+				if len(op.Status.Targets) < v1alpha1.MaxTargetStatuses {
+					ts := NewTargetStatus(t)
+					ts.Result = v1alpha1.TargetResultFailed
+					ts.Message = err.Error()
+					ts.Pipeline = res.pipeline
+					op.Status.Targets = SetTargetStatus(op.Status.Targets, ts)
+				}
+			} else {
+				op.Status.SucceededTargets++
+			}
+
+			// Like failed targets, we only record applied resources up
+			// to a point so large fan-outs don't bloat the Operation.
+			for _, u := range res.applied {
+				if len(op.Status.AppliedResourceRefs) >= v1alpha1.MaxFanOutAppliedResourceRefs {
+					break
+				}
+				op.Status.AppliedResourceRefs = AddResourceRef(op.Status.AppliedResourceRefs, u)
+			}
+
+			done[i] = true
+			for next < len(done) && done[next] {
+				op.Status.LastCompletedTarget = targetKey(pending[next].GetNamespace(), pending[next].GetName())
+				next++
+			}
+
+			return errors.Wrap(r.client.Status().Update(ctx, op), "cannot update Operation status")
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if werr != nil {
+		return reconcile.Result{}, werr
+	}
+
+	failed := op.Status.FailedTargets
+
+	if requeue > 0 && failed <= maxUnavailable {
+		log.Debug("Waiting to start running against the next fan-out target", "requeue-after", requeue)
+		status.MarkConditions(xpv1.ReconcileSuccess())
+
+		return reconcile.Result{RequeueAfter: requeue}, errors.Wrap(r.client.Status().Update(ctx, op), "cannot update Operation status")
+	}
+
+	if failed > maxUnavailable {
+		err := errors.Errorf("pipeline failed against %d of %d targets, exceeding maxUnavailable of %d", failed, len(targets), maxUnavailable)
+		r.record.Event(op, event.Warning(reasonMaxUnavailable, err))
+		status.MarkConditions(xpv1.ReconcileSuccess(), v1alpha1.Failed(err.Error()))
+
+		return reconcile.Result{}, errors.Wrap(r.client.Status().Update(ctx, op), "cannot update Operation status")
+	}
+
+	if failed > 0 {
+		r.record.Event(op, event.Normal(reasonRunPipelineStep, fmt.Sprintf("Pipeline failed against %d of %d targets, within maxUnavailable of %d", failed, len(targets), maxUnavailable)))
+	}
+
+	status.MarkConditions(xpv1.ReconcileSuccess(), v1alpha1.Complete())
+
+	return reconcile.Result{}, errors.Wrap(r.client.Status().Update(ctx, op), "cannot update Operation status")
+}
+
+// untilNextTarget returns how long to wait before starting to run against
+// another target, given when we last started running against one.
+func untilNextTarget(last *metav1.Time, interval time.Duration) time.Duration {
+	if last == nil {
+		return 0
+	}
+
+	return max(time.Until(last.Add(interval)), 0)
+}
+
+// listTargets returns the resources selected by the supplied targets, sorted
+// by key.
+func (r *Reconciler) listTargets(ctx context.Context, ft v1alpha1.FanOutTargets) ([]*kunstructured.Unstructured, error) {
+	gv, err := schema.ParseGroupVersion(ft.APIVersion)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot parse apiVersion %q", ft.APIVersion)
+	}
+
+	l := &kunstructured.UnstructuredList{}
+	l.SetGroupVersionKind(gv.WithKind(ft.Kind + "List"))
+
+	opts := []client.ListOption{}
+	if ft.Namespace != "" {
+		opts = append(opts, client.InNamespace(ft.Namespace))
+	}
+	if len(ft.MatchLabels) > 0 {
+		opts = append(opts, client.MatchingLabels(ft.MatchLabels))
+	}
+
+	if err := r.client.List(ctx, l, opts...); err != nil {
+		return nil, err
+	}
+
+	out := make([]*kunstructured.Unstructured, len(l.Items))
+	for i := range l.Items {
+		out[i] = &l.Items[i]
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		return targetKey(out[i].GetNamespace(), out[i].GetName()) < targetKey(out[j].GetNamespace(), out[j].GetName())
+	})
+
+	return out, nil
+}
+
+// InjectTarget returns a copy of the supplied pipeline with the supplied
+// target injected into each step as a required resource.
+func InjectTarget(pipeline []v1alpha1.PipelineStep, t *kunstructured.Unstructured) []v1alpha1.PipelineStep {
+	sel := v1alpha1.RequiredResourceSelector{
+		RequirementName: v1alpha1.RequirementNameFanOutTarget,
+		APIVersion:      t.GetAPIVersion(),
+		Kind:            t.GetKind(),
+		Name:            ptr.To(t.GetName()),
+	}
+
+	// Add namespace if the resource is namespaced
+	if t.GetNamespace() != "" {
+		sel.Namespace = ptr.To(t.GetNamespace())
+	}
+
+	out := make([]v1alpha1.PipelineStep, len(pipeline))
+	for i := range pipeline {
+		step := pipeline[i].DeepCopy()
+
+		if step.Requirements == nil {
+			step.Requirements = &v1alpha1.FunctionRequirements{}
+		}
+
+		step.Requirements.RequiredResources = append(step.Requirements.RequiredResources, sel)
+		out[i] = *step
+	}
+
+	return out
+}
+
+// NewTargetStatus returns a TargetStatus for the supplied target, with no
+// result.
+func NewTargetStatus(t *kunstructured.Unstructured) v1alpha1.TargetStatus {
+	ts := v1alpha1.TargetStatus{
+		APIVersion: t.GetAPIVersion(),
+		Kind:       t.GetKind(),
+		Name:       t.GetName(),
+	}
+	if t.GetNamespace() != "" {
+		ts.Namespace = ptr.To(t.GetNamespace())
+	}
+
+	return ts
+}
+
+// GetTargetStatus returns the status of the supplied target, or nil if the
+// target has no status.
+func GetTargetStatus(targets []v1alpha1.TargetStatus, t *kunstructured.Unstructured) *v1alpha1.TargetStatus {
+	for i := range targets {
+		if targets[i].Matches(t.GetAPIVersion(), t.GetKind(), t.GetNamespace(), t.GetName()) {
+			return &targets[i]
+		}
+	}
+
+	return nil
+}
+
+// SetTargetStatus updates the supplied target status in place if a status for
+// the same target exists. Otherwise it appends it. Targets are kept sorted by
+// key.
+func SetTargetStatus(targets []v1alpha1.TargetStatus, ts v1alpha1.TargetStatus) []v1alpha1.TargetStatus {
+	for i := range targets {
+		if targets[i].Matches(ts.APIVersion, ts.Kind, ptr.Deref(ts.Namespace, ""), ts.Name) {
+			targets[i] = ts
+			return targets
+		}
+	}
+
+	targets = append(targets, ts)
+
+	sort.SliceStable(targets, func(i, j int) bool {
+		return targetKey(ptr.Deref(targets[i].Namespace, ""), targets[i].Name) < targetKey(ptr.Deref(targets[j].Namespace, ""), targets[j].Name)
+	})
+
+	return targets
+}
+
+// targetKey returns the key targets are sorted by. Cluster scoped targets have
+// an empty namespace, so they sort before namespaced targets.
+func targetKey(namespace, name string) string {
+	return namespace + "/" + name
+}
+
+func targetName(t *kunstructured.Unstructured) string {
+	if t.GetNamespace() == "" {
+		return t.GetName()
+	}
+	return t.GetNamespace() + "/" + t.GetName()
+}
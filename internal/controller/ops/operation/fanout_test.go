@@ -0,0 +1,351 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/test"
+
+	"github.com/crossplane/crossplane/v2/apis/ops/v1alpha1"
+	"github.com/crossplane/crossplane/v2/internal/xfn"
+	fnv1 "github.com/crossplane/crossplane/v2/proto/fn/v1"
+)
+
+func TestReconcileFanOut(t *testing.T) {
+	fanOutOp := func(maxUnavailable int64, st v1alpha1.OperationStatus) func(obj client.Object) error {
+		return func(obj client.Object) error {
+			op := &v1alpha1.Operation{
+				Spec: v1alpha1.OperationSpec{
+					Mode: v1alpha1.OperationModeFanOut,
+					Pipeline: []v1alpha1.PipelineStep{
+						{
+							Step:        "do-it",
+							FunctionRef: v1alpha1.FunctionReference{Name: "function-cool"},
+						},
+					},
+					FanOut: &v1alpha1.FanOut{
+						Targets: v1alpha1.FanOutTargets{
+							APIVersion: "example.org/v1",
+							Kind:       "XR",
+						},
+						MaxConcurrency: ptr.To[int64](1),
+						MaxUnavailable: ptr.To(maxUnavailable),
+					},
+				},
+				Status: st,
+			}
+			op.DeepCopyInto(obj.(*v1alpha1.Operation))
+
+			return nil
+		}
+	}
+
+	// The same Operation, limited to starting one target per minute.
+	rateLimitedOp := func(st v1alpha1.OperationStatus) func(obj client.Object) error {
+		return func(obj client.Object) error {
+			_ = fanOutOp(1, st)(obj)
+			obj.(*v1alpha1.Operation).Spec.FanOut.TargetsPerMinute = ptr.To[int64](1)
+
+			return nil
+		}
+	}
+
+	listTargets := test.NewMockListFn(nil, func(obj client.ObjectList) error {
+		l := obj.(*kunstructured.UnstructuredList)
+		for _, name := range []string{"c", "b", "a"} {
+			u := kunstructured.Unstructured{}
+			u.SetAPIVersion("example.org/v1")
+			u.SetKind("XR")
+			u.SetName(name)
+			l.Items = append(l.Items, u)
+		}
+
+		return nil
+	})
+
+	// The function fails against target "b".
+	runner := xfn.FunctionRunnerFn(func(_ context.Context, _ string, req *fnv1.RunFunctionRequest) (*fnv1.RunFunctionResponse, error) {
+		sel := req.GetRequiredResources()[v1alpha1.RequirementNameFanOutTarget]
+		if sel == nil {
+			return nil, errors.New("target not injected")
+		}
+		if sel.GetItems()[0].GetResource().GetFields()["metadata"].GetStructValue().GetFields()["name"].GetStringValue() == "b" {
+			return nil, errors.New("boom")
+		}
+
+		return &fnv1.RunFunctionResponse{}, nil
+	})
+
+	fetcher := xfn.RequiredResourcesFetcherFn(func(_ context.Context, rs *fnv1.ResourceSelector) (*fnv1.Resources, error) {
+		return &fnv1.Resources{Items: []*fnv1.Resource{{Resource: MustStructJSON(`{"metadata":{"name":"` + rs.GetMatchName() + `"}}`)}}}, nil
+	})
+
+	caps := xfn.CapabilityCheckerFn(func(_ context.Context, _ []string, _ ...string) error { return nil })
+
+	type want struct {
+		r         reconcile.Result
+		err       error
+		targets   []v1alpha1.TargetStatus
+		counts    [2]int64
+		last      string
+		succeeded corev1.ConditionStatus
+	}
+
+	cases := map[string]struct {
+		reason string
+		get    func(obj client.Object) error
+		list   test.MockListFn
+		want   want
+	}{
+		"ListTargetsError": {
+			reason: "We should return an error if we can't list fan-out targets.",
+			get:    fanOutOp(0, v1alpha1.OperationStatus{}),
+			list:   test.NewMockListFn(errors.New("boom")),
+			want: want{
+				err:       cmpopts.AnyError,
+				succeeded: corev1.ConditionUnknown,
+			},
+		},
+		"WithinMaxUnavailable": {
+			reason: "We should complete the Operation if fewer targets than maxUnavailable failed, recording only the failed target.",
+			get:    fanOutOp(1, v1alpha1.OperationStatus{}),
+			list:   listTargets,
+			want: want{
+				targets: []v1alpha1.TargetStatus{
+					{APIVersion: "example.org/v1", Kind: "XR", Name: "b", Result: v1alpha1.TargetResultFailed, Message: `failed to invoke pipeline step "do-it": boom`},
+				},
+				counts:    [2]int64{2, 1},
+				last:      "/c",
+				succeeded: corev1.ConditionTrue,
+			},
+		},
+		"ExceedsMaxUnavailable": {
+			reason: "We should fail the Operation if more targets than maxUnavailable failed.",
+			get:    fanOutOp(0, v1alpha1.OperationStatus{SucceededTargets: 1, LastCompletedTarget: "/a"}),
+			list:   listTargets,
+			want: want{
+				targets: []v1alpha1.TargetStatus{
+					{APIVersion: "example.org/v1", Kind: "XR", Name: "b", Result: v1alpha1.TargetResultFailed, Message: `failed to invoke pipeline step "do-it": boom`},
+				},
+				counts:    [2]int64{1, 1},
+				last:      "/b",
+				succeeded: corev1.ConditionFalse,
+			},
+		},
+		"ResumeAfterLastCompletedTarget": {
+			reason: "We should skip targets up to and including the last completed target, and targets we already failed against.",
+			get: fanOutOp(1, v1alpha1.OperationStatus{
+				SucceededTargets:    1,
+				FailedTargets:       1,
+				LastCompletedTarget: "/a",
+				Targets: []v1alpha1.TargetStatus{
+					{APIVersion: "example.org/v1", Kind: "XR", Name: "b", Result: v1alpha1.TargetResultFailed, Message: "boom"},
+				},
+			}),
+			list: listTargets,
+			want: want{
+				targets: []v1alpha1.TargetStatus{
+					{APIVersion: "example.org/v1", Kind: "XR", Name: "b", Result: v1alpha1.TargetResultFailed, Message: "boom"},
+				},
+				counts:    [2]int64{2, 1},
+				last:      "/c",
+				succeeded: corev1.ConditionTrue,
+			},
+		},
+		"RateLimitedStartsOneTarget": {
+			reason: "We should start one target then requeue until the next slot if we're limited to one target per minute.",
+			get:    rateLimitedOp(v1alpha1.OperationStatus{}),
+			list:   listTargets,
+			want: want{
+				r:         reconcile.Result{RequeueAfter: time.Minute},
+				counts:    [2]int64{1, 0},
+				last:      "/a",
+				succeeded: corev1.ConditionUnknown,
+			},
+		},
+		"RateLimitedAcrossReconciles": {
+			reason: "We should not start a target until the next slot if we recently started one in a previous reconcile.",
+			get: rateLimitedOp(v1alpha1.OperationStatus{
+				SucceededTargets:    1,
+				LastCompletedTarget: "/a",
+				LastTargetStartTime: ptr.To(metav1.Now()),
+			}),
+			list: listTargets,
+			want: want{
+				r:         reconcile.Result{RequeueAfter: time.Minute},
+				counts:    [2]int64{1, 0},
+				last:      "/a",
+				succeeded: corev1.ConditionUnknown,
+			},
+		},
+	}
+
+	// Requeues are relative to when the test runs.
+	approx := cmp.Comparer(func(a, b time.Duration) bool {
+		return (a - b).Abs() < 5*time.Second
+	})
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := &v1alpha1.Operation{}
+			mgr := &fake.Manager{
+				Client: &test.MockClient{
+					MockGet:  test.NewMockGetFn(nil, tc.get),
+					MockList: tc.list,
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil, func(obj client.Object) error {
+						obj.(*v1alpha1.Operation).DeepCopyInto(got)
+						return nil
+					}),
+				},
+			}
+
+			r := NewReconciler(mgr,
+				WithCapabilityChecker(caps),
+				WithFunctionRunner(runner),
+				WithRequiredResourcesFetcher(fetcher))
+
+			res, err := r.Reconcile(context.Background(), reconcile.Request{})
+			if diff := cmp.Diff(tc.want.err, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+
+			if diff := cmp.Diff(tc.want.r, res, approx); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want result, +got:\n%s", tc.reason, diff)
+			}
+
+			if diff := cmp.Diff(tc.want.targets, got.Status.Targets, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want targets, +got targets:\n%s", tc.reason, diff)
+			}
+
+			if diff := cmp.Diff(tc.want.counts, [2]int64{got.Status.SucceededTargets, got.Status.FailedTargets}); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want [succeeded, failed] targets, +got:\n%s", tc.reason, diff)
+			}
+
+			if diff := cmp.Diff(tc.want.last, got.Status.LastCompletedTarget); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want last completed target, +got:\n%s", tc.reason, diff)
+			}
+
+			if diff := cmp.Diff(tc.want.succeeded, got.GetCondition(v1alpha1.TypeSucceeded).Status); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want Succeeded status, +got Succeeded status:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestInjectTarget(t *testing.T) {
+	target := &kunstructured.Unstructured{}
+	target.SetAPIVersion("example.org/v1")
+	target.SetKind("XR")
+	target.SetNamespace("default")
+	target.SetName("cool")
+
+	existing := v1alpha1.RequiredResourceSelector{
+		RequirementName: "configs",
+		APIVersion:      "v1",
+		Kind:            "ConfigMap",
+		MatchLabels:     map[string]string{"cool": "true"},
+	}
+
+	injected := v1alpha1.RequiredResourceSelector{
+		RequirementName: v1alpha1.RequirementNameFanOutTarget,
+		APIVersion:      "example.org/v1",
+		Kind:            "XR",
+		Namespace:       ptr.To("default"),
+		Name:            ptr.To("cool"),
+	}
+
+	pipeline := []v1alpha1.PipelineStep{
+		{Step: "one"},
+		{Step: "two", Requirements: &v1alpha1.FunctionRequirements{RequiredResources: []v1alpha1.RequiredResourceSelector{existing}}},
+	}
+
+	want := []v1alpha1.PipelineStep{
+		{Step: "one", Requirements: &v1alpha1.FunctionRequirements{RequiredResources: []v1alpha1.RequiredResourceSelector{injected}}},
+		{Step: "two", Requirements: &v1alpha1.FunctionRequirements{RequiredResources: []v1alpha1.RequiredResourceSelector{existing, injected}}},
+	}
+
+	got := InjectTarget(pipeline, target)
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("InjectTarget(...): -want, +got:\n%s", diff)
+	}
+
+	// The supplied pipeline must not be mutated.
+	if pipeline[0].Requirements != nil || len(pipeline[1].Requirements.RequiredResources) != 1 {
+		t.Errorf("InjectTarget(...): mutated the supplied pipeline")
+	}
+}
+
+func TestSetTargetStatus(t *testing.T) {
+	a := v1alpha1.TargetStatus{APIVersion: "example.org/v1", Kind: "XR", Name: "a", Result: v1alpha1.TargetResultFailed}
+	b := v1alpha1.TargetStatus{APIVersion: "example.org/v1", Kind: "XR", Name: "b", Result: v1alpha1.TargetResultFailed}
+	nsA := v1alpha1.TargetStatus{APIVersion: "example.org/v1", Kind: "XR", Namespace: ptr.To("default"), Name: "a", Result: v1alpha1.TargetResultFailed}
+	bFailed := v1alpha1.TargetStatus{APIVersion: "example.org/v1", Kind: "XR", Name: "b", Result: v1alpha1.TargetResultFailed, Message: "boom"}
+
+	cases := map[string]struct {
+		reason  string
+		targets []v1alpha1.TargetStatus
+		ts      v1alpha1.TargetStatus
+		want    []v1alpha1.TargetStatus
+	}{
+		"AddToEmpty": {
+			reason: "Should add a status to an empty slice.",
+			ts:     a,
+			want:   []v1alpha1.TargetStatus{a},
+		},
+		"AddSorted": {
+			reason:  "Should add a new status and keep the slice sorted.",
+			targets: []v1alpha1.TargetStatus{b},
+			ts:      a,
+			want:    []v1alpha1.TargetStatus{a, b},
+		},
+		"SortClusterScopedFirst": {
+			reason:  "Should sort cluster scoped targets before namespaced targets, the same way targets are listed.",
+			targets: []v1alpha1.TargetStatus{nsA},
+			ts:      b,
+			want:    []v1alpha1.TargetStatus{b, nsA},
+		},
+		"UpdateExisting": {
+			reason:  "Should update an existing status in place.",
+			targets: []v1alpha1.TargetStatus{a, b},
+			ts:      bFailed,
+			want:    []v1alpha1.TargetStatus{a, bFailed},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := SetTargetStatus(tc.targets, tc.ts)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nSetTargetStatus(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -77,6 +77,7 @@ type Reconciler struct {
 	pipeline  xfn.FunctionRunner
 	functions xfn.CapabilityChecker
 	resources xfn.RequiredResourcesFetcher
+	waits     WaitChecker
 }
 
 // Reconcile an Operation by running its function pipeline.
@@ -164,6 +165,118 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 	// All functions have the required operation capability
 	status.MarkConditions(v1alpha1.ValidPipeline())
 
+	if op.Spec.Mode == v1alpha1.OperationModeFanOut {
+		return r.reconcileFanOut(ctx, log, op)
+	}
+
+	// Steps that wait split the pipeline into phases. Each phase runs its
+	// steps, applies the desired resources they produced, then waits for
+	// them before the next phase runs. A pipeline without steps that wait
+	// has only one phase. We record the progress of each phase that waits in
+	// our status, so we can pick up where we left off.
+	for _, phase := range Phases(op.Spec.Pipeline) {
+		last := phase[len(phase)-1]
+
+		ps := GetPipelineStepStatus(op.Status.Pipeline, last.Step)
+		if last.WaitFor != nil && ps != nil && ps.Phase == v1alpha1.PipelineStepPhaseComplete {
+			continue
+		}
+
+		if last.WaitFor == nil || ps == nil || ps.Phase != v1alpha1.PipelineStepPhaseWaiting {
+			res, err := r.runPipeline(ctx, log, op, phase)
+
+			// Record whatever the pipeline managed to do, even if it
+			// failed part way through.
+			for _, ps := range res.pipeline {
+				op.Status.Pipeline = AddPipelineStepOutput(op.Status.Pipeline, ps.Step, ps.Output)
+			}
+			for _, u := range res.applied {
+				op.Status.AppliedResourceRefs = AddResourceRef(op.Status.AppliedResourceRefs, u)
+			}
+
+			if err != nil {
+				return r.pipelineError(ctx, log, op, err)
+			}
+
+			// This is the last phase. There's nothing to wait for.
+			if last.WaitFor == nil {
+				continue
+			}
+
+			refs := make([]v1alpha1.AppliedResourceRef, 0, len(res.applied))
+			for _, u := range res.applied {
+				refs = AddResourceRef(refs, u)
+			}
+			op.Status.Pipeline = SetPipelineStepWaiting(op.Status.Pipeline, last.Step, refs)
+
+			// Persist our progress before we start waiting, so we don't
+			// run this phase again if we're restarted.
+			if err := r.client.Status().Update(ctx, op); err != nil {
+				return reconcile.Result{}, errors.Wrap(err, "cannot update Operation status")
+			}
+		}
+
+		if res, done, err := r.waitFor(ctx, log, op, last); !done || err != nil {
+			return res, err
+		}
+	}
+
+	status.MarkConditions(xpv1.ReconcileSuccess(), v1alpha1.Complete())
+
+	return reconcile.Result{}, errors.Wrap(r.client.Status().Update(ctx, op), "cannot update Operation status")
+}
+
+// pipelineError records that the supplied Operation's pipeline returned the
+// supplied error.
+func (r *Reconciler) pipelineError(ctx context.Context, log logging.Logger, op *v1alpha1.Operation, err error) (reconcile.Result, error) {
+	status := r.conditions.For(op)
+
+	// A terminal error requires human intervention to fix, so we
+	// immediately fail this operation without retrying.
+	te := &terminalError{}
+	if errors.As(err, &te) {
+		status.MarkConditions(xpv1.ReconcileSuccess(), v1alpha1.Failed(te.message))
+		_ = r.client.Status().Update(ctx, op)
+
+		return reconcile.Result{}, err
+	}
+
+	op.Status.Failures++
+
+	log.Debug("Cannot run operation pipeline", "error", err, "failures", op.Status.Failures)
+	status.MarkConditions(xpv1.ReconcileError(err))
+	_ = r.client.Status().Update(ctx, op)
+
+	return reconcile.Result{}, err
+}
+
+// A terminalError is a pipeline error that retrying won't fix.
+type terminalError struct {
+	error
+
+	// message is used as the Operation's Succeeded condition message.
+	message string
+}
+
+func (e *terminalError) Unwrap() error { return e.error }
+
+// pipelineResult is what a pipeline produced. It may be partially populated
+// if the pipeline returned an error.
+type pipelineResult struct {
+	// pipeline contains the output of each step that produced output.
+	pipeline []v1alpha1.PipelineStepStatus
+
+	// applied contains each desired resource that was applied.
+	applied []*kunstructured.Unstructured
+}
+
+// runPipeline runs the supplied pipeline of functions, then applies any
+// desired resources it produced. Pipeline step results are emitted as events
+// against the supplied Operation. The supplied Operation is only read, so it's
+// safe to run several pipelines for the same Operation concurrently.
+func (r *Reconciler) runPipeline(ctx context.Context, log logging.Logger, op *v1alpha1.Operation, pipeline []v1alpha1.PipelineStep) (*pipelineResult, error) { //nolint:gocognit // Only slightly over.
+	res := &pipelineResult{}
+
 	// The function pipeline starts with empty desired state.
 	d := &fnv1.State{}
 
@@ -173,8 +286,8 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 	// Run any operation functions in the pipeline. Each function may mutate
 	// the desired state returned by the last, and each function may produce
 	// results that will be emitted as events.
-	for _, fn := range op.Spec.Pipeline {
-		log = log.WithValues("step", fn.Step)
+	for _, fn := range pipeline {
+		log := log.WithValues("step", fn.Step)
 
 		req := &fnv1.RunFunctionRequest{Desired: d, Context: fctx}
 
@@ -182,13 +295,8 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 			in := &structpb.Struct{}
 			if err := in.UnmarshalJSON(fn.Input.Raw); err != nil {
 				log.Debug("Cannot unmarshal input for operation pipeline step", "error", err)
-
-				// An unmarshalable input requires human intervention to fix, so
-				// we immediately fail this operation without retrying.
-				status.MarkConditions(xpv1.ReconcileSuccess(), v1alpha1.Failed(fmt.Sprintf("cannot unmarshal input for operation pipeline step %q", fn.Step)))
-				_ = r.client.Status().Update(ctx, op)
-
-				return reconcile.Result{}, errors.Wrapf(err, "cannot unmarshal input for operation pipeline step %q", fn.Step)
+				msg := fmt.Sprintf("cannot unmarshal input for operation pipeline step %q", fn.Step)
+				return res, &terminalError{error: errors.Wrap(err, msg), message: msg}
 			}
 
 			req.Input = in
@@ -203,15 +311,11 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 
 			s := &corev1.Secret{}
 			if err := r.client.Get(ctx, client.ObjectKey{Namespace: cs.SecretRef.Namespace, Name: cs.SecretRef.Name}, s); err != nil {
-				op.Status.Failures++
-
-				log.Debug("Cannot get Operation pipeline step credential", "error", err, "failures", op.Status.Failures, "credential", cs.Name)
+				log.Debug("Cannot get Operation pipeline step credential", "error", err, "credential", cs.Name)
 				err = errors.Wrapf(err, "cannot get operation pipeline step %q credential %q from Secret", fn.Step, cs.Name)
 				r.record.Event(op, event.Warning(reasonFunctionInvocation, err))
-				status.MarkConditions(xpv1.ReconcileError(err))
-				_ = r.client.Status().Update(ctx, op)
 
-				return reconcile.Result{}, err
+				return res, err
 			}
 
 			req.Credentials[cs.Name] = &fnv1.Credentials{
@@ -231,15 +335,11 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 			for _, sel := range fn.Requirements.RequiredResources {
 				resources, err := r.resources.Fetch(ctx, xfn.ToProtobufResourceSelector(&sel))
 				if err != nil {
-					op.Status.Failures++
-
-					log.Debug("Cannot fetch bootstrap required resources", "error", err, "failures", op.Status.Failures, "requirement", sel.RequirementName)
+					log.Debug("Cannot fetch bootstrap required resources", "error", err, "requirement", sel.RequirementName)
 					err = errors.Wrapf(err, "cannot fetch bootstrap required resources for requirement %q", sel.RequirementName)
 					r.record.Event(op, event.Warning(reasonBootstrapRequirements, err))
-					status.MarkConditions(xpv1.ReconcileError(err))
-					_ = r.client.Status().Update(ctx, op)
 
-					return reconcile.Result{}, err
+					return res, err
 				}
 
 				// Add to request (resources could be nil if not found)
@@ -251,15 +351,11 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 
 		rsp, err := r.pipeline.RunFunction(ctx, fn.FunctionRef.Name, req)
 		if err != nil {
-			op.Status.Failures++
-
-			log.Debug("Cannot run operation pipeline step", "error", err, "failures", op.Status.Failures)
+			log.Debug("Cannot run operation pipeline step", "error", err)
 			err = errors.Wrapf(err, "failed to invoke pipeline step %q", fn.Step)
 			r.record.Event(op, event.Warning(reasonFunctionInvocation, err))
-			status.MarkConditions(xpv1.ReconcileError(err))
-			_ = r.client.Status().Update(ctx, op)
 
-			return reconcile.Result{}, err
+			return res, err
 		}
 
 		// Pass the desired state returned by this Function to the next one.
@@ -274,15 +370,11 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 		for _, rs := range rsp.GetResults() {
 			switch rs.GetSeverity() {
 			case fnv1.Severity_SEVERITY_FATAL:
-				op.Status.Failures++
-
-				log.Debug("Pipeline step returned a fatal result", "error", rs.GetMessage(), "failures", op.Status.Failures)
+				log.Debug("Pipeline step returned a fatal result", "error", rs.GetMessage())
 				err = errors.New(rs.GetMessage())
 				r.record.Event(op, event.Warning(reasonFunctionInvocation, err))
-				status.MarkConditions(xpv1.ReconcileError(err))
-				_ = r.client.Status().Update(ctx, op)
 
-				return reconcile.Result{}, err
+				return res, err
 			case fnv1.Severity_SEVERITY_WARNING:
 				r.record.Event(op, event.Warning(reasonRunPipelineStep, errors.Errorf("Pipeline step %q: %s", fn.Step, rs.GetMessage())))
 			case fnv1.Severity_SEVERITY_NORMAL:
@@ -298,18 +390,14 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 		if o := rsp.GetOutput(); o != nil {
 			j, err := protojson.Marshal(o)
 			if err != nil {
-				op.Status.Failures++
-
-				log.Debug("Cannot marshal pipeline step output to JSON", "error", err, "failures", op.Status.Failures)
+				log.Debug("Cannot marshal pipeline step output to JSON", "error", err)
 				err = errors.Wrapf(err, "cannot marshal pipeline step %q output to JSON", fn.Step)
 				r.record.Event(op, event.Warning(reasonInvalidOutput, err))
-				status.MarkConditions(xpv1.ReconcileError(err))
-				_ = r.client.Status().Update(ctx, op)
 
-				return reconcile.Result{}, err
+				return res, err
 			}
 
-			op.Status.Pipeline = AddPipelineStepOutput(op.Status.Pipeline, fn.Step, &runtime.RawExtension{Raw: j})
+			res.pipeline = AddPipelineStepOutput(res.pipeline, fn.Step, &runtime.RawExtension{Raw: j})
 		}
 	}
 
@@ -318,15 +406,11 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 	for name, dr := range d.GetResources() {
 		u := &kunstructured.Unstructured{}
 		if err := xfn.FromStruct(u, dr.GetResource()); err != nil {
-			op.Status.Failures++
-
-			log.Debug("Cannot load desired resource from protobuf struct", "error", err, "failures", op.Status.Failures, "resource-name", name)
+			log.Debug("Cannot load desired resource from protobuf struct", "error", err, "resource-name", name)
 			err = errors.Wrapf(err, "cannot load desired resource %q from protobuf struct", name)
 			r.record.Event(op, event.Warning(reasonInvalidResource, err))
-			status.MarkConditions(xpv1.ReconcileError(err))
-			_ = r.client.Status().Update(ctx, op)
 
-			return reconcile.Result{}, err
+			return res, err
 		}
 
 		// TODO(negz): Do we really want to force ownership? We'll
@@ -334,27 +418,21 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 		// TODO(negz): Do we ever want to be an owner reference of these
 		// resources?
 		if err := r.client.Patch(ctx, u, client.Apply, client.ForceOwnership, client.FieldOwner(FieldOwnerPrefix+op.GetUID())); err != nil {
-			op.Status.Failures++
-			log.Debug("Cannot apply desired resource", "error", err, "failures", op.Status.Failures, "resource-name", name)
-
+			log.Debug("Cannot apply desired resource", "error", err, "resource-name", name)
 			err = errors.Wrap(err, "cannot apply desired resource")
 			r.record.Event(op, event.Warning(reasonInvalidResource, err))
-			status.MarkConditions(xpv1.ReconcileError(err))
-			_ = r.client.Status().Update(ctx, op)
 
-			return reconcile.Result{}, err
+			return res, err
 		}
 
 		// TODO(negz): A pipeline could overflow this if it returned
 		// hundreds of desired resources. We could switch to a plain
 		// count, but it's pretty useful to know what resources an
 		// Operation applied...
-		op.Status.AppliedResourceRefs = AddResourceRef(op.Status.AppliedResourceRefs, u)
+		res.applied = append(res.applied, u)
 	}
 
-	status.MarkConditions(xpv1.ReconcileSuccess(), v1alpha1.Complete())
-
-	return reconcile.Result{}, errors.Wrap(r.client.Status().Update(ctx, op), "cannot update Operation status")
+	return res, nil
 }
 
 // AddResourceRef adds a reference to the supplied resource to supplied
@@ -0,0 +1,250 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operation
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/cel-go/cel"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	celconfig "k8s.io/apiserver/pkg/apis/cel"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/v2/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/fieldpath"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/logging"
+
+	"github.com/crossplane/crossplane/v2/apis/ops/v1alpha1"
+)
+
+// DefaultWaitTimeout is how long a pipeline step waits for the resources it
+// applied, unless otherwise specified.
+const DefaultWaitTimeout = 10 * time.Minute
+
+// How often to check whether a pipeline step is done waiting.
+const waitPollInterval = 10 * time.Second
+
+// Event reasons.
+const (
+	reasonWaitTimeout = "WaitTimeout"
+)
+
+// A WaitChecker checks whether the resources a pipeline step applied satisfy
+// its wait condition.
+type WaitChecker interface {
+	// Check returns true if all of the supplied resources satisfy the
+	// supplied wait condition. If it returns false it also returns a
+	// human-readable explanation of what it's waiting for.
+	Check(ctx context.Context, w *v1alpha1.StepWait, refs []v1alpha1.AppliedResourceRef) (bool, string, error)
+}
+
+// A WaitCheckerFn is a function that satisfies WaitChecker.
+type WaitCheckerFn func(ctx context.Context, w *v1alpha1.StepWait, refs []v1alpha1.AppliedResourceRef) (bool, string, error)
+
+// Check whether the supplied resources satisfy the supplied wait condition.
+func (fn WaitCheckerFn) Check(ctx context.Context, w *v1alpha1.StepWait, refs []v1alpha1.AppliedResourceRef) (bool, string, error) {
+	return fn(ctx, w, refs)
+}
+
+// An APIWaitChecker checks wait conditions by reading resources from the API
+// server.
+type APIWaitChecker struct {
+	client client.Reader
+}
+
+// NewAPIWaitChecker returns a WaitChecker that reads resources using the
+// supplied client.
+func NewAPIWaitChecker(c client.Reader) *APIWaitChecker {
+	return &APIWaitChecker{client: c}
+}
+
+// Check whether the supplied resources satisfy the supplied wait condition. A
+// resource that doesn't exist doesn't satisfy any wait condition.
+func (c *APIWaitChecker) Check(ctx context.Context, w *v1alpha1.StepWait, refs []v1alpha1.AppliedResourceRef) (bool, string, error) {
+	var prg cel.Program
+	if w.Expression != nil {
+		p, err := CompileWaitExpression(*w.Expression)
+		if err != nil {
+			msg := fmt.Sprintf("cannot compile wait expression %q: %s", *w.Expression, err)
+			return false, "", &terminalError{error: errors.Wrapf(err, "cannot compile wait expression %q", *w.Expression), message: msg}
+		}
+		prg = p
+	}
+
+	ct := xpv1.ConditionType(ptr.Deref(w.ConditionType, string(xpv1.TypeReady)))
+
+	for _, ref := range refs {
+		u := &kunstructured.Unstructured{}
+		u.SetAPIVersion(ref.APIVersion)
+		u.SetKind(ref.Kind)
+
+		nn := types.NamespacedName{Namespace: ptr.Deref(ref.Namespace, ""), Name: ref.Name}
+		if err := c.client.Get(ctx, nn, u); err != nil {
+			if kerrors.IsNotFound(err) {
+				return false, fmt.Sprintf("%s %q doesn't exist", ref.Kind, nn.String()), nil
+			}
+			return false, "", errors.Wrapf(err, "cannot get %s %q", ref.Kind, nn.String())
+		}
+
+		if prg != nil {
+			out, _, err := prg.ContextEval(ctx, map[string]any{"self": u.Object})
+			if err != nil {
+				return false, "", errors.Wrapf(err, "cannot evaluate wait expression %q against %s %q", *w.Expression, ref.Kind, nn.String())
+			}
+			if ok, _ := out.Value().(bool); !ok {
+				return false, fmt.Sprintf("%s %q doesn't satisfy expression %q", ref.Kind, nn.String(), *w.Expression), nil
+			}
+			continue
+		}
+
+		cs := xpv1.ConditionedStatus{}
+		// An error here means there are no conditions, or they're not
+		// the shape we expect. Either way, the condition isn't True.
+		_ = fieldpath.Pave(u.Object).GetValueInto("status", &cs)
+		if cs.GetCondition(ct).Status != corev1.ConditionTrue {
+			return false, fmt.Sprintf("%s %q doesn't have condition %s=True", ref.Kind, nn.String(), ct), nil
+		}
+	}
+
+	return true, "", nil
+}
+
+// CompileWaitExpression compiles the supplied CEL expression. The expression
+// may refer to the resource being checked as self, and must return a bool. Like
+// CRD validation rules, evaluating the expression against each resource is
+// limited to Kubernetes' per call CEL cost limit.
+func CompileWaitExpression(expr string) (cel.Program, error) {
+	env, err := cel.NewEnv(cel.Variable("self", cel.DynType))
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot create CEL environment")
+	}
+
+	ast, iss := env.Compile(expr)
+	if iss.Err() != nil {
+		return nil, iss.Err()
+	}
+
+	if !ast.OutputType().IsExactType(cel.BoolType) && !ast.OutputType().IsExactType(cel.DynType) {
+		return nil, errors.Errorf("expression must return a bool, not %s", ast.OutputType())
+	}
+
+	return env.Program(ast, cel.CostLimit(celconfig.PerCallLimit))
+}
+
+// waitFor checks whether the resources applied by the supplied step satisfy its
+// wait condition. It returns true if they do. If they don't it updates the
+// Operation's status and returns the result Reconcile should return.
+func (r *Reconciler) waitFor(ctx context.Context, log logging.Logger, op *v1alpha1.Operation, step v1alpha1.PipelineStep) (reconcile.Result, bool, error) {
+	status := r.conditions.For(op)
+	log = log.WithValues("step", step.Step)
+
+	ps := GetPipelineStepStatus(op.Status.Pipeline, step.Step)
+
+	ok, reason, err := r.waits.Check(ctx, step.WaitFor, ps.WaitingFor)
+	if err != nil {
+		res, err := r.pipelineError(ctx, log, op, errors.Wrapf(err, "cannot check whether pipeline step %q is done waiting", step.Step))
+		return res, false, err
+	}
+
+	if !ok {
+		timeout := ptr.Deref(step.WaitFor.Timeout, metav1.Duration{Duration: DefaultWaitTimeout}).Duration
+		since := ptr.Deref(ps.WaitingSince, op.GetCreationTimestamp())
+
+		if time.Since(since.Time) > timeout {
+			msg := fmt.Sprintf("pipeline step %q timed out after %s waiting for applied resources: %s", step.Step, timeout, reason)
+			log.Debug("Timed out waiting for pipeline step's applied resources", "reason", reason, "timeout", timeout)
+			r.record.Event(op, event.Warning(reasonWaitTimeout, errors.New(msg)))
+			status.MarkConditions(xpv1.ReconcileSuccess(), v1alpha1.Failed(msg))
+
+			return reconcile.Result{}, false, errors.Wrap(r.client.Status().Update(ctx, op), "cannot update Operation status")
+		}
+
+		log.Debug("Waiting for pipeline step's applied resources", "reason", reason)
+		status.MarkConditions(xpv1.ReconcileSuccess())
+
+		return reconcile.Result{RequeueAfter: waitPollInterval}, false, errors.Wrap(r.client.Status().Update(ctx, op), "cannot update Operation status")
+	}
+
+	op.Status.Pipeline = SetPipelineStepPhase(op.Status.Pipeline, step.Step, v1alpha1.PipelineStepPhaseComplete)
+
+	return reconcile.Result{}, true, nil
+}
+
+// Phases splits the supplied pipeline into phases. Each phase ends with a step
+// that waits, except for the last phase, which ends with the pipeline's last
+// step. A pipeline without steps that wait has a single phase.
+func Phases(pipeline []v1alpha1.PipelineStep) [][]v1alpha1.PipelineStep {
+	phases := make([][]v1alpha1.PipelineStep, 0)
+
+	start := 0
+	for i, step := range pipeline {
+		if step.WaitFor != nil {
+			phases = append(phases, pipeline[start:i+1])
+			start = i + 1
+		}
+	}
+
+	if start < len(pipeline) {
+		phases = append(phases, pipeline[start:])
+	}
+
+	return phases
+}
+
+// GetPipelineStepStatus returns the status of the supplied step, or nil if the
+// step has no status.
+func GetPipelineStepStatus(pipeline []v1alpha1.PipelineStepStatus, step string) *v1alpha1.PipelineStepStatus {
+	for i := range pipeline {
+		if pipeline[i].Step == step {
+			return &pipeline[i]
+		}
+	}
+	return nil
+}
+
+// SetPipelineStepWaiting records that the supplied step is waiting for the
+// supplied resources, starting now.
+func SetPipelineStepWaiting(pipeline []v1alpha1.PipelineStepStatus, step string, refs []v1alpha1.AppliedResourceRef) []v1alpha1.PipelineStepStatus {
+	pipeline = SetPipelineStepPhase(pipeline, step, v1alpha1.PipelineStepPhaseWaiting)
+
+	ps := GetPipelineStepStatus(pipeline, step)
+	ps.WaitingSince = ptr.To(metav1.Now())
+	ps.WaitingFor = refs
+
+	return pipeline
+}
+
+// SetPipelineStepPhase updates the phase of the supplied step in place if the
+// step exists. If it doesn't exist, it's appended.
+func SetPipelineStepPhase(pipeline []v1alpha1.PipelineStepStatus, step string, phase v1alpha1.PipelineStepPhase) []v1alpha1.PipelineStepStatus {
+	if ps := GetPipelineStepStatus(pipeline, step); ps != nil {
+		ps.Phase = phase
+		return pipeline
+	}
+
+	return append(pipeline, v1alpha1.PipelineStepStatus{Step: step, Phase: phase})
+}
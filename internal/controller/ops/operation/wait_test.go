@@ -0,0 +1,330 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operation
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/test"
+
+	"github.com/crossplane/crossplane/v2/apis/ops/v1alpha1"
+	"github.com/crossplane/crossplane/v2/internal/xfn"
+	fnv1 "github.com/crossplane/crossplane/v2/proto/fn/v1"
+)
+
+func TestReconcileWait(t *testing.T) {
+	applied := v1alpha1.AppliedResourceRef{APIVersion: "example.org/v1", Kind: "Test", Name: "scale-down"}
+
+	pipeline := []v1alpha1.PipelineStep{
+		{
+			Step:        "scale-down",
+			FunctionRef: v1alpha1.FunctionReference{Name: "scale-down"},
+			WaitFor:     &v1alpha1.StepWait{},
+		},
+		{
+			Step:        "scale-up",
+			FunctionRef: v1alpha1.FunctionReference{Name: "scale-up"},
+		},
+	}
+
+	// Each step runs a function named after it. The function returns a
+	// desired resource named after the step too.
+	runner := func(ran *[]string) xfn.FunctionRunnerFn {
+		return func(_ context.Context, step string, _ *fnv1.RunFunctionRequest) (*fnv1.RunFunctionResponse, error) {
+			*ran = append(*ran, step)
+			return &fnv1.RunFunctionResponse{
+				Desired: &fnv1.State{
+					Resources: map[string]*fnv1.Resource{
+						step: {Resource: MustStructJSON(`{"apiVersion":"example.org/v1","kind":"Test","metadata":{"name":"` + step + `"}}`)},
+					},
+				},
+			}, nil
+		}
+	}
+
+	type want struct {
+		r        reconcile.Result
+		err      error
+		ran      []string
+		phase    v1alpha1.PipelineStepPhase
+		complete corev1.ConditionStatus
+	}
+
+	cases := map[string]struct {
+		reason string
+		status v1alpha1.OperationStatus
+		done   bool
+		want   want
+	}{
+		"StartWaiting": {
+			reason: "We should run the first phase, then requeue while its applied resources aren't ready.",
+			done:   false,
+			want: want{
+				r:        reconcile.Result{RequeueAfter: waitPollInterval},
+				ran:      []string{"scale-down"},
+				phase:    v1alpha1.PipelineStepPhaseWaiting,
+				complete: corev1.ConditionUnknown,
+			},
+		},
+		"StillWaiting": {
+			reason: "We shouldn't run the first phase again while we're waiting for its applied resources.",
+			status: v1alpha1.OperationStatus{
+				Pipeline: []v1alpha1.PipelineStepStatus{
+					{Step: "scale-down", Phase: v1alpha1.PipelineStepPhaseWaiting, WaitingSince: ptr.To(metav1.Now()), WaitingFor: []v1alpha1.AppliedResourceRef{applied}},
+				},
+			},
+			done: false,
+			want: want{
+				r:        reconcile.Result{RequeueAfter: waitPollInterval},
+				phase:    v1alpha1.PipelineStepPhaseWaiting,
+				complete: corev1.ConditionUnknown,
+			},
+		},
+		"TimedOut": {
+			reason: "We should fail the Operation if we time out waiting for applied resources.",
+			status: v1alpha1.OperationStatus{
+				Pipeline: []v1alpha1.PipelineStepStatus{
+					{Step: "scale-down", Phase: v1alpha1.PipelineStepPhaseWaiting, WaitingSince: ptr.To(metav1.NewTime(time.Now().Add(-1 * time.Hour))), WaitingFor: []v1alpha1.AppliedResourceRef{applied}},
+				},
+			},
+			done: false,
+			want: want{
+				phase:    v1alpha1.PipelineStepPhaseWaiting,
+				complete: corev1.ConditionFalse,
+			},
+		},
+		"DoneWaiting": {
+			reason: "We should run the next phase once the applied resources are ready.",
+			status: v1alpha1.OperationStatus{
+				Pipeline: []v1alpha1.PipelineStepStatus{
+					{Step: "scale-down", Phase: v1alpha1.PipelineStepPhaseWaiting, WaitingSince: ptr.To(metav1.Now()), WaitingFor: []v1alpha1.AppliedResourceRef{applied}},
+				},
+			},
+			done: true,
+			want: want{
+				ran:      []string{"scale-up"},
+				phase:    v1alpha1.PipelineStepPhaseComplete,
+				complete: corev1.ConditionTrue,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ran := make([]string, 0)
+			got := &v1alpha1.Operation{}
+
+			mgr := &fake.Manager{
+				Client: &test.MockClient{
+					MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+						op := &v1alpha1.Operation{
+							ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.Now()},
+							Spec:       v1alpha1.OperationSpec{Pipeline: pipeline},
+							Status:     tc.status,
+						}
+						op.DeepCopyInto(obj.(*v1alpha1.Operation))
+						return nil
+					}),
+					MockPatch: test.NewMockPatchFn(nil),
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil, func(obj client.Object) error {
+						obj.(*v1alpha1.Operation).DeepCopyInto(got)
+						return nil
+					}),
+				},
+			}
+
+			r := NewReconciler(mgr,
+				WithCapabilityChecker(xfn.CapabilityCheckerFn(func(_ context.Context, _ []string, _ ...string) error { return nil })),
+				WithFunctionRunner(runner(&ran)),
+				WithWaitChecker(WaitCheckerFn(func(_ context.Context, _ *v1alpha1.StepWait, refs []v1alpha1.AppliedResourceRef) (bool, string, error) {
+					if diff := cmp.Diff([]v1alpha1.AppliedResourceRef{applied}, refs); diff != "" {
+						t.Errorf("Check(...): -want refs, +got refs:\n%s", diff)
+					}
+					return tc.done, "not ready", nil
+				})))
+
+			res, err := r.Reconcile(context.Background(), reconcile.Request{})
+			if diff := cmp.Diff(tc.want.err, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.r, res); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want result, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.ran, ran, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want steps run, +got steps run:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.phase, GetPipelineStepStatus(got.Status.Pipeline, "scale-down").Phase); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want phase, +got phase:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.complete, got.GetCondition(v1alpha1.TypeSucceeded).Status); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want Succeeded status, +got Succeeded status:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestAPIWaitChecker(t *testing.T) {
+	ready := `{"apiVersion":"example.org/v1","kind":"Test","metadata":{"name":"cool"},"spec":{"replicas":3},"status":{"readyReplicas":3,"conditions":[{"type":"Ready","status":"True"}]}}`
+	notReady := `{"apiVersion":"example.org/v1","kind":"Test","metadata":{"name":"cool"},"spec":{"replicas":3},"status":{"readyReplicas":1,"conditions":[{"type":"Ready","status":"False"}]}}`
+
+	refs := []v1alpha1.AppliedResourceRef{{APIVersion: "example.org/v1", Kind: "Test", Name: "cool"}}
+
+	// A CEL list literal of 100 integers.
+	ints := make([]string, 100)
+	for i := range ints {
+		ints[i] = strconv.Itoa(i)
+	}
+	hundred := "[" + strings.Join(ints, ",") + "]"
+
+	getFn := func(j string) test.MockGetFn {
+		return test.NewMockGetFn(nil, func(obj client.Object) error {
+			MustUnstructJSON(j).DeepCopyInto(obj.(*kunstructured.Unstructured))
+			return nil
+		})
+	}
+
+	type want struct {
+		ok  bool
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		get    test.MockGetFn
+		wait   *v1alpha1.StepWait
+		want   want
+	}{
+		"NotFound": {
+			reason: "A resource that doesn't exist shouldn't satisfy the wait.",
+			get:    test.NewMockGetFn(kerrors.NewNotFound(schema.GroupResource{}, "cool")),
+			wait:   &v1alpha1.StepWait{},
+			want:   want{ok: false},
+		},
+		"DefaultReady": {
+			reason: "A resource with a Ready=True condition should satisfy the default wait.",
+			get:    getFn(ready),
+			wait:   &v1alpha1.StepWait{},
+			want:   want{ok: true},
+		},
+		"DefaultNotReady": {
+			reason: "A resource without a Ready=True condition shouldn't satisfy the default wait.",
+			get:    getFn(notReady),
+			wait:   &v1alpha1.StepWait{},
+			want:   want{ok: false},
+		},
+		"OtherConditionType": {
+			reason: "A resource without the specified condition shouldn't satisfy the wait.",
+			get:    getFn(ready),
+			wait:   &v1alpha1.StepWait{ConditionType: ptr.To("Synced")},
+			want:   want{ok: false},
+		},
+		"ExpressionTrue": {
+			reason: "A resource should satisfy an expression that returns true.",
+			get:    getFn(ready),
+			wait:   &v1alpha1.StepWait{Expression: ptr.To("self.status.readyReplicas == self.spec.replicas")},
+			want:   want{ok: true},
+		},
+		"ExpressionFalse": {
+			reason: "A resource shouldn't satisfy an expression that returns false.",
+			get:    getFn(notReady),
+			wait:   &v1alpha1.StepWait{Expression: ptr.To("self.status.readyReplicas == self.spec.replicas")},
+			want:   want{ok: false},
+		},
+		"ExpressionTooExpensive": {
+			reason: "We should return an error if the expression exceeds the CEL cost limit.",
+			get:    getFn(ready),
+			wait:   &v1alpha1.StepWait{Expression: ptr.To(fmt.Sprintf("%[1]s.all(a, %[1]s.all(b, %[1]s.all(c, a + b + c >= 0)))", hundred))},
+			want:   want{err: cmpopts.AnyError},
+		},
+		"InvalidExpression": {
+			reason: "We should return an error if the expression doesn't compile.",
+			get:    getFn(ready),
+			wait:   &v1alpha1.StepWait{Expression: ptr.To("self.status.readyReplicas ==")},
+			want:   want{err: cmpopts.AnyError},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c := NewAPIWaitChecker(&test.MockClient{MockGet: tc.get})
+
+			ok, _, err := c.Check(context.Background(), tc.wait, refs)
+			if diff := cmp.Diff(tc.want.err, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nCheck(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.ok, ok); diff != "" {
+				t.Errorf("\n%s\nCheck(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestPhases(t *testing.T) {
+	wait := &v1alpha1.StepWait{}
+
+	cases := map[string]struct {
+		reason   string
+		pipeline []v1alpha1.PipelineStep
+		want     [][]v1alpha1.PipelineStep
+	}{
+		"NoWaits": {
+			reason:   "A pipeline without steps that wait should have one phase.",
+			pipeline: []v1alpha1.PipelineStep{{Step: "a"}, {Step: "b"}},
+			want:     [][]v1alpha1.PipelineStep{{{Step: "a"}, {Step: "b"}}},
+		},
+		"WaitInMiddle": {
+			reason:   "A step that waits should end a phase.",
+			pipeline: []v1alpha1.PipelineStep{{Step: "a", WaitFor: wait}, {Step: "b"}, {Step: "c", WaitFor: wait}, {Step: "d"}},
+			want: [][]v1alpha1.PipelineStep{
+				{{Step: "a", WaitFor: wait}},
+				{{Step: "b"}, {Step: "c", WaitFor: wait}},
+				{{Step: "d"}},
+			},
+		},
+		"WaitAtEnd": {
+			reason:   "A pipeline that ends with a step that waits shouldn't have an empty final phase.",
+			pipeline: []v1alpha1.PipelineStep{{Step: "a"}, {Step: "b", WaitFor: wait}},
+			want:     [][]v1alpha1.PipelineStep{{{Step: "a"}, {Step: "b", WaitFor: wait}}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := Phases(tc.pipeline)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nPhases(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
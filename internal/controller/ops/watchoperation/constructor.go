@@ -34,6 +34,7 @@ import (
 	"github.com/crossplane/crossplane/v2/apis/ops/v1alpha1"
 	opscontroller "github.com/crossplane/crossplane/v2/internal/controller/ops/controller"
 	"github.com/crossplane/crossplane/v2/internal/engine"
+	"github.com/crossplane/crossplane/v2/internal/ops/audit"
 )
 
 // A ControllerEngine can start and stop Kubernetes controllers on demand.
@@ -96,11 +97,17 @@ func (e *NopEngine) GetFieldIndexer() client.FieldIndexer {
 func Setup(mgr ctrl.Manager, o opscontroller.Options) error {
 	name := "watchoperation/" + strings.ToLower(v1alpha1.WatchOperationGroupKind)
 
-	r := NewReconciler(mgr.GetClient(),
+	ro := []ReconcilerOption{
 		WithLogger(o.Logger.WithValues("controller", name)),
 		WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name), o.EventFilterFunctions...)),
 		WithControllerEngine(o.ControllerEngine),
-		WithOptions(o))
+		WithOptions(o),
+	}
+	if o.AuditSink != nil {
+		ro = append(ro, WithAuditSink(o.AuditSink))
+	}
+
+	r := NewReconciler(mgr.GetClient(), ro...)
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
@@ -127,6 +134,14 @@ func WithRecorder(er event.Recorder) ReconcilerOption {
 	}
 }
 
+// WithAuditSink specifies where the Reconciler should store records of
+// Operations before garbage collecting them.
+func WithAuditSink(s audit.Sink) ReconcilerOption {
+	return func(r *Reconciler) {
+		r.audit = s
+	}
+}
+
 // WithControllerEngine specifies how the Reconciler should manage controllers.
 func WithControllerEngine(ce *engine.ControllerEngine) ReconcilerOption {
 	return func(r *Reconciler) {
@@ -148,6 +163,7 @@ func NewReconciler(c client.Client, opts ...ReconcilerOption) *Reconciler {
 		log:        logging.NewNopLogger(),
 		record:     event.NewNopRecorder(),
 		conditions: conditions.ObservedGenerationPropagationManager{},
+		audit:      audit.NopSink{},
 		finalizer:  resource.NewAPIFinalizer(c, finalizer),
 		engine:     &NopEngine{},
 		options: opscontroller.Options{
@@ -40,6 +40,7 @@ import (
 	opscontroller "github.com/crossplane/crossplane/v2/internal/controller/ops/controller"
 	"github.com/crossplane/crossplane/v2/internal/controller/ops/watched"
 	"github.com/crossplane/crossplane/v2/internal/engine"
+	"github.com/crossplane/crossplane/v2/internal/ops/audit"
 	"github.com/crossplane/crossplane/v2/internal/ops/lifecycle"
 )
 
@@ -53,6 +54,7 @@ const (
 	reasonEstablishWatched event.Reason = "EstablishWatched"
 	reasonTerminateWatched event.Reason = "TerminateWatched"
 	reasonGarbageCollect   event.Reason = "GarbageCollectOperations"
+	reasonAuditOperation   event.Reason = "AuditOperation"
 )
 
 // WatchTypeWatchOperation is the watch type used when a WatchOperation
@@ -67,6 +69,7 @@ type Reconciler struct {
 
 	finalizer  resource.Finalizer
 	conditions conditions.Manager
+	audit      audit.Sink
 
 	engine  ControllerEngine
 	options opscontroller.Options
@@ -167,6 +170,18 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 
 	// Garbage collect Operations older than the history limits.
 	for _, op := range lifecycle.MarkGarbage(ptr.Deref(wo.Spec.SuccessfulHistoryLimit, 3), ptr.Deref(wo.Spec.FailedHistoryLimit, 1), ol.Items...) {
+		// Don't delete an Operation until we've durably recorded it. If
+		// the delete fails we'll record it again next time, so sinks
+		// may see duplicate records.
+		if err := r.audit.Store(ctx, audit.NewRecord(&op)); err != nil {
+			log.Debug("Cannot store audit record of Operation", "error", err, "operation", op.GetName())
+			err = errors.Wrapf(err, "cannot store audit record of Operation %q", op.GetName())
+			r.record.Event(wo, event.Warning(reasonAuditOperation, err))
+			status.MarkConditions(xpv1.ReconcileError(err))
+			_ = r.client.Status().Update(ctx, wo)
+			return reconcile.Result{}, err
+		}
+
 		if err := r.client.Delete(ctx, &op); resource.IgnoreNotFound(err) != nil {
 			log.Debug("Cannot garbage collect Operation", "error", err, "operation", op.GetName())
 			err = errors.Wrapf(err, "cannot garbage collect Operation %q", op.GetName())
@@ -19,22 +19,27 @@ package watchoperation
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	xpv1 "github.com/crossplane/crossplane-runtime/v2/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/test"
 
 	"github.com/crossplane/crossplane/v2/apis/ops/v1alpha1"
 	"github.com/crossplane/crossplane/v2/internal/engine"
+	"github.com/crossplane/crossplane/v2/internal/ops/audit"
 )
 
 var errBoom = errors.New("boom")
@@ -91,6 +96,7 @@ func TestReconcile(t *testing.T) {
 	type params struct {
 		client client.Client
 		engine ControllerEngine
+		opts   []ReconcilerOption
 	}
 	type args struct {
 		ctx context.Context
@@ -101,6 +107,19 @@ func TestReconcile(t *testing.T) {
 		err    error
 	}
 
+	// succeeded returns successful Operations, oldest first.
+	succeeded := func(names ...string) []v1alpha1.Operation {
+		ops := make([]v1alpha1.Operation, len(names))
+		for i, name := range names {
+			ops[i].SetName(name)
+			ops[i].SetCreationTimestamp(metav1.NewTime(time.Now().Add(time.Duration(i-len(names)) * time.Hour)))
+			ops[i].SetConditions(xpv1.Condition{Type: v1alpha1.TypeSucceeded, Status: corev1.ConditionTrue, Reason: v1alpha1.ReasonPipelineSuccess})
+		}
+		return ops
+	}
+
+	audited := map[string]bool{}
+
 	cases := map[string]struct {
 		reason string
 		params params
@@ -363,6 +382,125 @@ func TestReconcile(t *testing.T) {
 				err:    cmpopts.AnyError,
 			},
 		},
+		"AuditError": {
+			reason: "Should return an error, and not garbage collect old Operations, if storing an audit record of them fails",
+			params: params{
+				client: &test.MockClient{
+					MockGet: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+						wo := obj.(*v1alpha1.WatchOperation)
+						wo.SetName("test-watch")
+						wo.SetUID("test-uid")
+						wo.SetFinalizers([]string{finalizer})
+						wo.Spec.Watch = v1alpha1.WatchSpec{
+							APIVersion: "v1",
+							Kind:       "Pod",
+						}
+						wo.Spec.SuccessfulHistoryLimit = ptr.To[int32](1)
+						return nil
+					},
+					MockList: func(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+						if ol, ok := list.(*v1alpha1.OperationList); ok {
+							ol.Items = succeeded("old-op", "new-op")
+							return nil
+						}
+						if ul, ok := list.(*unstructured.UnstructuredList); ok {
+							ul.Items = []unstructured.Unstructured{}
+							return nil
+						}
+						return errBoom
+					},
+					MockDelete: func(_ context.Context, _ client.Object, _ ...client.DeleteOption) error {
+						t.Errorf("Delete(...): unexpected call to delete an Operation that wasn't audited")
+						return nil
+					},
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				engine: &MockEngine{},
+				opts: []ReconcilerOption{
+					WithAuditSink(audit.SinkFn(func(_ context.Context, _ *audit.Record) error {
+						return errBoom
+					})),
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				req: reconcile.Request{
+					NamespacedName: types.NamespacedName{
+						Name: "test-watch",
+					},
+				},
+			},
+			want: want{
+				result: reconcile.Result{},
+				err:    cmpopts.AnyError,
+			},
+		},
+		"AuditSuccess": {
+			reason: "Should store an audit record of old Operations before garbage collecting them",
+			params: params{
+				client: &test.MockClient{
+					MockGet: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+						wo := obj.(*v1alpha1.WatchOperation)
+						wo.SetName("test-watch")
+						wo.SetUID("test-uid")
+						wo.SetFinalizers([]string{finalizer})
+						wo.Spec.Watch = v1alpha1.WatchSpec{
+							APIVersion: "v1",
+							Kind:       "Pod",
+						}
+						wo.Spec.SuccessfulHistoryLimit = ptr.To[int32](1)
+						return nil
+					},
+					MockList: func(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+						if ol, ok := list.(*v1alpha1.OperationList); ok {
+							ol.Items = succeeded("old-op", "new-op")
+							return nil
+						}
+						if ul, ok := list.(*unstructured.UnstructuredList); ok {
+							ul.Items = []unstructured.Unstructured{}
+							return nil
+						}
+						return errBoom
+					},
+					MockDelete: func(_ context.Context, obj client.Object, _ ...client.DeleteOption) error {
+						if !audited[obj.GetName()] || obj.GetName() != "old-op" {
+							t.Errorf("Delete(...): deleted Operation %q, which is either unaudited or within the history limit", obj.GetName())
+						}
+						return nil
+					},
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				engine: &MockEngine{
+					MockGetCached: func() client.Client {
+						return &test.MockClient{}
+					},
+					MockStart: func(_ string, _ ...engine.ControllerOption) error {
+						return nil
+					},
+					MockStartWatches: func(_ context.Context, _ string, _ ...engine.Watch) error {
+						return nil
+					},
+				},
+				opts: []ReconcilerOption{
+					WithAuditSink(audit.SinkFn(func(_ context.Context, r *audit.Record) error {
+						audited[r.Name] = true
+						return nil
+					})),
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				req: reconcile.Request{
+					NamespacedName: types.NamespacedName{
+						Name: "test-watch",
+					},
+				},
+			},
+			want: want{
+				result: reconcile.Result{Requeue: false},
+				err:    nil,
+			},
+		},
 		"StartControllerError": {
 			reason: "Should return an error if starting watched controller fails",
 			params: params{
@@ -524,7 +662,7 @@ func TestReconcile(t *testing.T) {
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
 			r := NewReconciler(tc.params.client,
-				WithControllerEngine(&engine.ControllerEngine{}))
+				append([]ReconcilerOption{WithControllerEngine(&engine.ControllerEngine{})}, tc.params.opts...)...)
 			r.engine = tc.params.engine
 			got, err := r.Reconcile(tc.args.ctx, tc.args.req)
 
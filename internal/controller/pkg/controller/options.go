@@ -46,4 +46,20 @@ type Options struct {
 	// MaxConcurrentPackageEstablishers is the maximum number of goroutines to use
 	// for establishing Providers, Configurations and Functions.
 	MaxConcurrentPackageEstablishers int
+
+	// FunctionResponses counts the responses Crossplane receives from
+	// Functions. The Function package controllers use it to analyze canary
+	// revisions and health gates.
+	FunctionResponses FunctionResponseCounter
+}
+
+// A FunctionResponseCounter counts the responses Crossplane receives from
+// Functions.
+type FunctionResponseCounter interface {
+	// Responses returns the number of responses received from the supplied
+	// gRPC target, and how many of them failed.
+	Responses(target string) (total, failed int64)
+
+	// Forget discards the responses received from the supplied gRPC target.
+	Forget(target string)
 }
@@ -0,0 +1,230 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manager
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
+
+	v1 "github.com/crossplane/crossplane/v2/apis/pkg/v1"
+	"github.com/crossplane/crossplane/v2/internal/controller/pkg/controller"
+)
+
+const (
+	defaultCanaryInterval     = 10 * time.Minute
+	defaultCanaryMinRequests  = int64(10)
+	defaultCanaryMaxErrorRate = int32(5)
+)
+
+const reasonCanary event.Reason = "CanaryAnalysis"
+
+// A canaryVerdict is the outcome of analyzing a canary revision.
+type canaryVerdict int
+
+const (
+	// canaryWait means there isn't enough data to analyze the canary yet.
+	canaryWait canaryVerdict = iota
+
+	// canaryPromote means the canary should become the active revision.
+	canaryPromote
+
+	// canaryRollBack means the canary failed analysis.
+	canaryRollBack
+)
+
+// WithFunctionResponseCounter specifies how the Reconciler should count the
+// responses a canary revision returns. Canary activation is disabled unless a
+// counter is supplied.
+func WithFunctionResponseCounter(c controller.FunctionResponseCounter) ReconcilerOption {
+	return func(r *Reconciler) {
+		r.responses = c
+	}
+}
+
+// canaryStable returns the revision that should stay active while the supplied
+// package's current revision is rolled out as a canary. It returns nil if the
+// package doesn't use canary activation, if its current revision is already
+// active, or if there is no active revision to roll out alongside, for example
+// when the package is first installed.
+func (r *Reconciler) canaryStable(p v1.Package, revisions []v1.PackageRevision) v1.PackageRevision {
+	if r.responses == nil {
+		return nil
+	}
+
+	// Only Functions support canary activation.
+	if _, ok := p.(*v1.Function); !ok {
+		return nil
+	}
+
+	if p.GetActivationPolicy() == nil || *p.GetActivationPolicy() != v1.CanaryActivation {
+		return nil
+	}
+
+	var stable v1.PackageRevision
+
+	for _, rev := range revisions {
+		if rev.GetName() == p.GetCurrentRevision() {
+			if rev.GetDesiredState() == v1.PackageRevisionActive {
+				return nil
+			}
+
+			continue
+		}
+
+		if rev.GetDesiredState() != v1.PackageRevisionActive {
+			continue
+		}
+
+		if stable == nil || rev.GetRevision() > stable.GetRevision() {
+			stable = rev
+		}
+	}
+
+	return stable
+}
+
+// reconcileCanary analyzes the supplied canary revision of the supplied
+// Function. It promotes the canary by activating it, or rolls it back by
+// labelling it. The caller is responsible for applying the canary. It returns
+// how long to wait before analyzing the canary again, or zero if the canary
+// doesn't need to be analyzed again.
+func (r *Reconciler) reconcileCanary(p v1.Package, pr v1.PackageRevision, state string, now time.Time) time.Duration {
+	labels := pr.GetLabels()
+
+	// A canary that was rolled back stays rolled back. The package must
+	// produce a new revision to try again.
+	if state == v1.CanaryRolledBack {
+		labels[v1.LabelCanary] = v1.CanaryRolledBack
+		pr.SetLabels(labels)
+
+		return 0
+	}
+
+	labels[v1.LabelCanary] = v1.CanaryProgressing
+	pr.SetLabels(labels)
+
+	var spec *v1.CanaryAnalysis
+	if f, ok := p.(*v1.Function); ok && f.Spec.Canary != nil {
+		spec = f.Spec.Canary.Analysis
+	}
+
+	// A new canary revision doesn't exist yet, so it can't have an endpoint
+	// or serve any requests.
+	total, failed := int64(0), int64(0)
+	if ep := endpoint(pr); ep != "" {
+		total, failed = r.responses.Responses(ep)
+	}
+
+	age := time.Duration(0)
+	if ts := pr.GetCreationTimestamp(); !ts.IsZero() {
+		age = now.Sub(ts.Time)
+	}
+
+	verdict, wait := analyzeCanary(spec, age, total, failed)
+
+	switch verdict {
+	case canaryWait:
+		return wait
+	case canaryPromote:
+		delete(labels, v1.LabelCanary)
+		pr.SetLabels(labels)
+		pr.SetDesiredState(v1.PackageRevisionActive)
+		r.record.Event(p, event.Normal(reasonCanary, fmt.Sprintf("Promoted canary package revision %q: %d of %d responses failed", pr.GetName(), failed, total)))
+	case canaryRollBack:
+		labels[v1.LabelCanary] = v1.CanaryRolledBack
+		pr.SetLabels(labels)
+		r.record.Event(p, event.Warning(reasonCanary, errors.Errorf("rolled back canary package revision %q: %d of %d responses failed", pr.GetName(), failed, total)))
+	}
+
+	// The canary's endpoint won't serve canary traffic again, so its
+	// responses needn't be counted anymore.
+	r.responses.Forget(endpoint(pr))
+
+	return 0
+}
+
+// supersedeCanary stops the supplied revision from running as a canary if it
+// was a canary when its package produced a newer revision. It returns true if
+// the revision was a canary. The caller is responsible for applying it.
+func (r *Reconciler) supersedeCanary(rev v1.PackageRevision) bool {
+	if r.responses == nil || !v1.IsCanary(rev) {
+		return false
+	}
+
+	labels := rev.GetLabels()
+	delete(labels, v1.LabelCanary)
+	rev.SetLabels(labels)
+
+	r.responses.Forget(endpoint(rev))
+
+	return true
+}
+
+// endpoint returns the gRPC endpoint of the supplied revision. It returns an
+// empty string if the revision isn't a FunctionRevision, or doesn't have an
+// endpoint yet.
+func endpoint(pr v1.PackageRevision) string {
+	fr, ok := pr.(*v1.FunctionRevision)
+	if !ok {
+		return ""
+	}
+
+	return fr.Status.Endpoint
+}
+
+// analyzeCanary decides whether a canary revision that has run for the supplied
+// duration and returned the supplied number of responses should be promoted or
+// rolled back. If there isn't enough data to decide it also returns how long to
+// wait before analyzing the canary again.
+func analyzeCanary(spec *v1.CanaryAnalysis, age time.Duration, total, failed int64) (canaryVerdict, time.Duration) {
+	interval := defaultCanaryInterval
+	minRequests := defaultCanaryMinRequests
+	maxErrorRate := defaultCanaryMaxErrorRate
+
+	if spec != nil {
+		if spec.Interval != nil {
+			interval = spec.Interval.Duration
+		}
+
+		if spec.MinRequests != nil {
+			minRequests = *spec.MinRequests
+		}
+
+		if spec.MaxErrorRate != nil {
+			maxErrorRate = *spec.MaxErrorRate
+		}
+	}
+
+	if age < interval {
+		return canaryWait, interval - age
+	}
+
+	// Keep waiting until the canary has served enough requests. It might
+	// not serve any for a while if few composite resources route to it.
+	if total < max(minRequests, 1) {
+		return canaryWait, interval
+	}
+
+	if failed*100 > total*int64(maxErrorRate) {
+		return canaryRollBack, 0
+	}
+
+	return canaryPromote, 0
+}
@@ -0,0 +1,396 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manager
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
+
+	v1 "github.com/crossplane/crossplane/v2/apis/pkg/v1"
+	"github.com/crossplane/crossplane/v2/internal/controller/pkg/controller"
+)
+
+var _ controller.FunctionResponseCounter = &MockResponseCounter{}
+
+type MockResponseCounter struct {
+	Total     int64
+	Failed    int64
+	Forgotten []string
+}
+
+func (m *MockResponseCounter) Responses(_ string) (total, failed int64) {
+	return m.Total, m.Failed
+}
+
+func (m *MockResponseCounter) Forget(target string) {
+	m.Forgotten = append(m.Forgotten, target)
+}
+
+func TestCanaryStable(t *testing.T) {
+	canary := ptr.To(v1.CanaryActivation)
+
+	active := &v1.FunctionRevision{
+		ObjectMeta: metav1.ObjectMeta{Name: "fn-a"},
+		Spec: v1.FunctionRevisionSpec{PackageRevisionSpec: v1.PackageRevisionSpec{
+			DesiredState: v1.PackageRevisionActive,
+			Revision:     1,
+		}},
+	}
+	current := &v1.FunctionRevision{
+		ObjectMeta: metav1.ObjectMeta{Name: "fn-b"},
+		Spec: v1.FunctionRevisionSpec{PackageRevisionSpec: v1.PackageRevisionSpec{
+			DesiredState: v1.PackageRevisionInactive,
+			Revision:     2,
+		}},
+	}
+
+	fn := func(policy *v1.RevisionActivationPolicy) *v1.Function {
+		f := &v1.Function{}
+		f.SetActivationPolicy(policy)
+		f.SetCurrentRevision("fn-b")
+
+		return f
+	}
+
+	type args struct {
+		responses controller.FunctionResponseCounter
+		p         v1.Package
+		revisions []v1.PackageRevision
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   v1.PackageRevision
+	}{
+		"CanaryDisabled": {
+			reason: "We shouldn't roll out canaries unless we can analyze them.",
+			args: args{
+				p:         fn(canary),
+				revisions: []v1.PackageRevision{active, current},
+			},
+			want: nil,
+		},
+		"NotAFunction": {
+			reason: "Only Functions support canary activation.",
+			args: args{
+				responses: &MockResponseCounter{},
+				p:         &v1.Provider{Spec: v1.ProviderSpec{PackageSpec: v1.PackageSpec{RevisionActivationPolicy: canary}}},
+				revisions: []v1.PackageRevision{active, current},
+			},
+			want: nil,
+		},
+		"AutomaticActivation": {
+			reason: "We shouldn't roll out canaries for a Function with automatic activation.",
+			args: args{
+				responses: &MockResponseCounter{},
+				p:         fn(ptr.To(v1.AutomaticActivation)),
+				revisions: []v1.PackageRevision{active, current},
+			},
+			want: nil,
+		},
+		"FirstInstall": {
+			reason: "There's no revision to keep active when a Function is first installed.",
+			args: args{
+				responses: &MockResponseCounter{},
+				p:         fn(canary),
+			},
+			want: nil,
+		},
+		"CurrentRevisionActive": {
+			reason: "There's no canary once the current revision is active.",
+			args: args{
+				responses: &MockResponseCounter{},
+				p:         fn(canary),
+				revisions: []v1.PackageRevision{active, &v1.FunctionRevision{
+					ObjectMeta: metav1.ObjectMeta{Name: "fn-b"},
+					Spec: v1.FunctionRevisionSpec{PackageRevisionSpec: v1.PackageRevisionSpec{
+						DesiredState: v1.PackageRevisionActive,
+					}},
+				}},
+			},
+			want: nil,
+		},
+		"CanaryProgressing": {
+			reason: "We should keep the active revision active while the current revision is a canary.",
+			args: args{
+				responses: &MockResponseCounter{},
+				p:         fn(canary),
+				revisions: []v1.PackageRevision{active, current},
+			},
+			want: active,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := &Reconciler{responses: tc.args.responses}
+
+			got := r.canaryStable(tc.args.p, tc.args.revisions)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nr.canaryStable(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestReconcileCanary(t *testing.T) {
+	now := time.Now()
+	created := metav1.NewTime(now.Add(-1 * time.Hour))
+
+	type args struct {
+		responses *MockResponseCounter
+		state     string
+		pr        *v1.FunctionRevision
+	}
+
+	type want struct {
+		wait      time.Duration
+		state     v1.PackageRevisionDesiredState
+		labels    map[string]string
+		forgotten []string
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"NewCanary": {
+			reason: "A new canary revision should be labelled as progressing, and analyzed after the default interval.",
+			args: args{
+				responses: &MockResponseCounter{},
+				pr:        &v1.FunctionRevision{},
+			},
+			want: want{
+				wait:   defaultCanaryInterval,
+				labels: map[string]string{v1.LabelCanary: v1.CanaryProgressing},
+			},
+		},
+		"Promote": {
+			reason: "A canary that returned few enough failed responses should be activated.",
+			args: args{
+				responses: &MockResponseCounter{Total: 100, Failed: 5},
+				state:     v1.CanaryProgressing,
+				pr: &v1.FunctionRevision{
+					ObjectMeta: metav1.ObjectMeta{CreationTimestamp: created},
+					Status:     v1.FunctionRevisionStatus{Endpoint: "dns:///fn-b.crossplane-system:9443"},
+				},
+			},
+			want: want{
+				state:     v1.PackageRevisionActive,
+				labels:    map[string]string{},
+				forgotten: []string{"dns:///fn-b.crossplane-system:9443"},
+			},
+		},
+		"RollBack": {
+			reason: "A canary that returned too many failed responses should be rolled back.",
+			args: args{
+				responses: &MockResponseCounter{Total: 100, Failed: 6},
+				state:     v1.CanaryProgressing,
+				pr: &v1.FunctionRevision{
+					ObjectMeta: metav1.ObjectMeta{CreationTimestamp: created},
+					Status:     v1.FunctionRevisionStatus{Endpoint: "dns:///fn-b.crossplane-system:9443"},
+				},
+			},
+			want: want{
+				labels:    map[string]string{v1.LabelCanary: v1.CanaryRolledBack},
+				forgotten: []string{"dns:///fn-b.crossplane-system:9443"},
+			},
+		},
+		"RolledBack": {
+			reason: "A canary that was rolled back should stay rolled back.",
+			args: args{
+				responses: &MockResponseCounter{Total: 100},
+				state:     v1.CanaryRolledBack,
+				pr: &v1.FunctionRevision{
+					ObjectMeta: metav1.ObjectMeta{CreationTimestamp: created},
+					Status:     v1.FunctionRevisionStatus{Endpoint: "dns:///fn-b.crossplane-system:9443"},
+				},
+			},
+			want: want{
+				labels: map[string]string{v1.LabelCanary: v1.CanaryRolledBack},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := &Reconciler{responses: tc.args.responses, record: event.NewNopRecorder()}
+			tc.args.pr.SetLabels(map[string]string{})
+
+			wait := r.reconcileCanary(&v1.Function{}, tc.args.pr, tc.args.state, now)
+			if diff := cmp.Diff(tc.want.wait, wait); diff != "" {
+				t.Errorf("\n%s\nr.reconcileCanary(...): -want wait, +got wait:\n%s", tc.reason, diff)
+			}
+
+			if diff := cmp.Diff(tc.want.state, tc.args.pr.GetDesiredState()); diff != "" {
+				t.Errorf("\n%s\nr.reconcileCanary(...): -want desired state, +got desired state:\n%s", tc.reason, diff)
+			}
+
+			if diff := cmp.Diff(tc.want.labels, tc.args.pr.GetLabels()); diff != "" {
+				t.Errorf("\n%s\nr.reconcileCanary(...): -want labels, +got labels:\n%s", tc.reason, diff)
+			}
+
+			if diff := cmp.Diff(tc.want.forgotten, tc.args.responses.Forgotten); diff != "" {
+				t.Errorf("\n%s\nr.reconcileCanary(...): -want forgotten endpoints, +got forgotten endpoints:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestSupersedeCanary(t *testing.T) {
+	cases := map[string]struct {
+		reason     string
+		pr         *v1.FunctionRevision
+		want       bool
+		wantLabels map[string]string
+		forgotten  []string
+	}{
+		"Canary": {
+			reason: "A canary that's no longer the current revision should stop running as a canary, and its responses should be forgotten.",
+			pr: &v1.FunctionRevision{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{v1.LabelCanary: v1.CanaryProgressing}},
+				Spec: v1.FunctionRevisionSpec{PackageRevisionSpec: v1.PackageRevisionSpec{
+					DesiredState: v1.PackageRevisionInactive,
+				}},
+				Status: v1.FunctionRevisionStatus{Endpoint: "dns:///fn-b.crossplane-system:9443"},
+			},
+			want:       true,
+			wantLabels: map[string]string{},
+			forgotten:  []string{"dns:///fn-b.crossplane-system:9443"},
+		},
+		"NotCanary": {
+			reason: "A revision that isn't a canary should be left alone.",
+			pr: &v1.FunctionRevision{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{v1.LabelCanary: v1.CanaryRolledBack}},
+				Spec: v1.FunctionRevisionSpec{PackageRevisionSpec: v1.PackageRevisionSpec{
+					DesiredState: v1.PackageRevisionInactive,
+				}},
+			},
+			want:       false,
+			wantLabels: map[string]string{v1.LabelCanary: v1.CanaryRolledBack},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			rc := &MockResponseCounter{}
+			r := &Reconciler{responses: rc}
+
+			got := r.supersedeCanary(tc.pr)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nr.supersedeCanary(...): -want, +got:\n%s", tc.reason, diff)
+			}
+
+			if diff := cmp.Diff(tc.wantLabels, tc.pr.GetLabels()); diff != "" {
+				t.Errorf("\n%s\nr.supersedeCanary(...): -want labels, +got labels:\n%s", tc.reason, diff)
+			}
+
+			if diff := cmp.Diff(tc.forgotten, rc.Forgotten); diff != "" {
+				t.Errorf("\n%s\nr.supersedeCanary(...): -want forgotten endpoints, +got forgotten endpoints:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestAnalyzeCanary(t *testing.T) {
+	type args struct {
+		spec   *v1.CanaryAnalysis
+		age    time.Duration
+		total  int64
+		failed int64
+	}
+
+	type want struct {
+		verdict canaryVerdict
+		wait    time.Duration
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"TooYoung": {
+			reason: "We should wait until the canary has run for the analysis interval.",
+			args: args{
+				spec:  &v1.CanaryAnalysis{Interval: &metav1.Duration{Duration: 5 * time.Minute}},
+				age:   2 * time.Minute,
+				total: 100,
+			},
+			want: want{
+				verdict: canaryWait,
+				wait:    3 * time.Minute,
+			},
+		},
+		"TooFewRequests": {
+			reason: "We should wait until the canary has returned enough responses.",
+			args: args{
+				age:   time.Hour,
+				total: defaultCanaryMinRequests - 1,
+			},
+			want: want{
+				verdict: canaryWait,
+				wait:    defaultCanaryInterval,
+			},
+		},
+		"Promote": {
+			reason: "We should promote a canary whose error rate is at or below the maximum.",
+			args: args{
+				spec:   &v1.CanaryAnalysis{MaxErrorRate: ptr.To[int32](10)},
+				age:    time.Hour,
+				total:  20,
+				failed: 2,
+			},
+			want: want{
+				verdict: canaryPromote,
+			},
+		},
+		"RollBack": {
+			reason: "We should roll back a canary whose error rate is above the maximum.",
+			args: args{
+				spec:   &v1.CanaryAnalysis{MaxErrorRate: ptr.To[int32](0)},
+				age:    time.Hour,
+				total:  20,
+				failed: 1,
+			},
+			want: want{
+				verdict: canaryRollBack,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			verdict, wait := analyzeCanary(tc.args.spec, tc.args.age, tc.args.total, tc.args.failed)
+			if diff := cmp.Diff(tc.want.verdict, verdict); diff != "" {
+				t.Errorf("\n%s\nanalyzeCanary(...): -want verdict, +got verdict:\n%s", tc.reason, diff)
+			}
+
+			if diff := cmp.Diff(tc.want.wait, wait); diff != "" {
+				t.Errorf("\n%s\nanalyzeCanary(...): -want wait, +got wait:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manager
+
+import (
+	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
+
+	v1 "github.com/crossplane/crossplane/v2/apis/pkg/v1"
+)
+
+const errActivatePreviousRevision = "cannot activate previous package revision"
+
+const reasonHealthGate event.Reason = "HealthGate"
+
+// WithHealthGates specifies that the Reconciler should roll back package
+// revisions that fail their health gate.
+func WithHealthGates() ReconcilerOption {
+	return func(r *Reconciler) {
+		r.healthGates = true
+	}
+}
+
+// healthGateRollback returns the revision that should be active instead of the
+// supplied package's current revision because the current revision failed its
+// health gate. It returns nil if the current revision didn't fail, if the
+// package no longer has a health gate, or if there's no earlier revision to
+// roll back to.
+func (r *Reconciler) healthGateRollback(p v1.Package, revisions []v1.PackageRevision) v1.PackageRevision {
+	if !r.healthGates {
+		return nil
+	}
+
+	// Removing the health gate from the package is how a user overrides a
+	// rollback.
+	pwr, ok := p.(v1.PackageWithRuntime)
+	if !ok || pwr.GetHealthGate() == nil {
+		return nil
+	}
+
+	var current v1.PackageRevision
+
+	for _, rev := range revisions {
+		if rev.GetName() == p.GetCurrentRevision() {
+			current = rev
+			break
+		}
+	}
+
+	if current == nil || !failedHealthGate(current) {
+		return nil
+	}
+
+	var previous v1.PackageRevision
+
+	for _, rev := range revisions {
+		if rev.GetRevision() >= current.GetRevision() || failedHealthGate(rev) {
+			continue
+		}
+
+		if previous == nil || rev.GetRevision() > previous.GetRevision() {
+			previous = rev
+		}
+	}
+
+	return previous
+}
+
+// failedHealthGate returns true if the supplied package revision failed its
+// health gate.
+func failedHealthGate(pr v1.PackageRevision) bool {
+	s := healthGateStatus(pr)
+	return s != nil && s.Result == v1.HealthGateFailed
+}
+
+// healthGateMessage explains why the supplied package revision failed its
+// health gate.
+func healthGateMessage(pr v1.PackageRevision) string {
+	if s := healthGateStatus(pr); s != nil {
+		return s.Message
+	}
+
+	return ""
+}
+
+func healthGateStatus(pr v1.PackageRevision) *v1.HealthGateStatus {
+	prwr, ok := pr.(v1.PackageRevisionWithRuntime)
+	if !ok {
+		return nil
+	}
+
+	return prwr.GetHealthGateStatus()
+}
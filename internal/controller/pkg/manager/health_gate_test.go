@@ -0,0 +1,129 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manager
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/crossplane/crossplane/v2/apis/pkg/v1"
+)
+
+func TestHealthGateRollback(t *testing.T) {
+	rev := func(name string, num int64, result v1.HealthGateResult) *v1.ProviderRevision {
+		pr := &v1.ProviderRevision{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       v1.ProviderRevisionSpec{PackageRevisionSpec: v1.PackageRevisionSpec{Revision: num}},
+		}
+		if result != "" {
+			pr.SetHealthGateStatus(&v1.HealthGateStatus{Result: result})
+		}
+
+		return pr
+	}
+
+	provider := func(gate *v1.HealthGate) *v1.Provider {
+		p := &v1.Provider{}
+		p.Spec.HealthGate = gate
+		p.SetCurrentRevision("provider-c")
+
+		return p
+	}
+
+	type args struct {
+		enabled   bool
+		p         v1.Package
+		revisions []v1.PackageRevision
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   v1.PackageRevision
+	}{
+		"Disabled": {
+			reason: "We shouldn't roll back unless health gates are enabled.",
+			args: args{
+				p:         provider(&v1.HealthGate{}),
+				revisions: []v1.PackageRevision{rev("provider-b", 2, v1.HealthGatePassed), rev("provider-c", 3, v1.HealthGateFailed)},
+			},
+			want: nil,
+		},
+		"NoHealthGate": {
+			reason: "We shouldn't roll back if the package no longer has a health gate.",
+			args: args{
+				enabled:   true,
+				p:         provider(nil),
+				revisions: []v1.PackageRevision{rev("provider-b", 2, v1.HealthGatePassed), rev("provider-c", 3, v1.HealthGateFailed)},
+			},
+			want: nil,
+		},
+		"NotAPackageWithRuntime": {
+			reason: "Configurations don't have health gates.",
+			args: args{
+				enabled:   true,
+				p:         &v1.Configuration{},
+				revisions: []v1.PackageRevision{&v1.ConfigurationRevision{}},
+			},
+			want: nil,
+		},
+		"CurrentRevisionObserving": {
+			reason: "We shouldn't roll back a revision that is still being observed.",
+			args: args{
+				enabled:   true,
+				p:         provider(&v1.HealthGate{}),
+				revisions: []v1.PackageRevision{rev("provider-b", 2, v1.HealthGatePassed), rev("provider-c", 3, v1.HealthGateObserving)},
+			},
+			want: nil,
+		},
+		"FirstRevisionFailed": {
+			reason: "We can't roll back if there is no earlier revision.",
+			args: args{
+				enabled:   true,
+				p:         provider(&v1.HealthGate{}),
+				revisions: []v1.PackageRevision{rev("provider-c", 3, v1.HealthGateFailed)},
+			},
+			want: nil,
+		},
+		"RollBack": {
+			reason: "We should roll back to the newest earlier revision that didn't fail its health gate.",
+			args: args{
+				enabled: true,
+				p:       provider(&v1.HealthGate{}),
+				revisions: []v1.PackageRevision{
+					rev("provider-a", 1, ""),
+					rev("provider-b", 2, v1.HealthGateFailed),
+					rev("provider-c", 3, v1.HealthGateFailed),
+				},
+			},
+			want: rev("provider-a", 1, ""),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := &Reconciler{healthGates: tc.args.enabled}
+
+			got := r.healthGateRollback(tc.args.p, tc.args.revisions)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nr.healthGateRollback(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -44,6 +44,7 @@ import (
 	v1 "github.com/crossplane/crossplane/v2/apis/pkg/v1"
 	"github.com/crossplane/crossplane/v2/apis/pkg/v1beta1"
 	"github.com/crossplane/crossplane/v2/internal/controller/pkg/controller"
+	"github.com/crossplane/crossplane/v2/internal/features"
 	"github.com/crossplane/crossplane/v2/internal/xpkg"
 )
 
@@ -132,6 +133,14 @@ func WithConfigStore(c xpkg.ConfigStore) ReconcilerOption {
 	}
 }
 
+// WithSourceResolver specifies how the Reconciler should resolve the source a
+// package image should be pulled from.
+func WithSourceResolver(sr xpkg.SourceResolver) ReconcilerOption {
+	return func(r *Reconciler) {
+		r.source = sr
+	}
+}
+
 // WithLogger specifies how the Reconciler should log messages.
 func WithLogger(log logging.Logger) ReconcilerOption {
 	return func(r *Reconciler) {
@@ -158,6 +167,7 @@ func WithManagingRevisionRuntimeSpec() ReconcilerOption {
 				prwr.SetRuntimeConfigRef(pwr.GetRuntimeConfigRef())
 				prwr.SetTLSServerSecretName(pwr.GetTLSServerSecretName())
 				prwr.SetTLSClientSecretName(pwr.GetTLSClientSecretName())
+				prwr.SetHealthGate(pwr.GetHealthGate())
 			}
 		}
 	}
@@ -168,12 +178,19 @@ type Reconciler struct {
 	client     resource.ClientApplicator
 	pkg        Revisioner
 	config     xpkg.ConfigStore
+	source     xpkg.SourceResolver
 	log        logging.Logger
 	record     event.Recorder
 	conditions conditions.Manager
 
 	setPackageRuntimeManagedFields func(p v1.Package, pr v1.PackageRevision)
 
+	// responses is used to analyze canary revisions.
+	responses controller.FunctionResponseCounter
+
+	// healthGates enables rolling back revisions that fail their health gate.
+	healthGates bool
+
 	newPackage             func() v1.Package
 	newPackageRevision     func() v1.PackageRevision
 	newPackageRevisionList func() v1.PackageRevisionList
@@ -191,7 +208,7 @@ func SetupProvider(mgr ctrl.Manager, o controller.Options) error {
 		return errors.Wrap(err, errCreateK8sClient)
 	}
 
-	f, err := xpkg.NewK8sFetcher(cs, append(o.FetcherOptions, xpkg.WithNamespace(o.Namespace), xpkg.WithServiceAccount(o.ServiceAccount))...)
+	f, err := xpkg.NewK8sFetcher(cs, append(o.FetcherOptions, xpkg.WithNamespace(o.Namespace), xpkg.WithServiceAccount(o.ServiceAccount), xpkg.WithImageConfigStore(xpkg.NewImageConfigStore(mgr.GetClient(), o.Namespace)))...)
 	if err != nil {
 		return errors.Wrap(err, errBuildFetcher)
 	}
@@ -202,6 +219,7 @@ func SetupProvider(mgr ctrl.Manager, o controller.Options) error {
 		WithNewPackageRevisionFn(nr),
 		WithNewPackageRevisionListFn(nrl),
 		WithRevisioner(NewPackageRevisioner(f)),
+		WithSourceResolver(f),
 		WithConfigStore(xpkg.NewImageConfigStore(mgr.GetClient(), o.Namespace)),
 		WithLogger(log),
 		WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name), o.EventFilterFunctions...)),
@@ -211,6 +229,10 @@ func SetupProvider(mgr ctrl.Manager, o controller.Options) error {
 		opts = append(opts, WithManagingRevisionRuntimeSpec())
 	}
 
+	if o.Features.Enabled(features.EnableAlphaPackageHealthGates) {
+		opts = append(opts, WithHealthGates())
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		For(&v1.Provider{}).
@@ -232,7 +254,7 @@ func SetupConfiguration(mgr ctrl.Manager, o controller.Options) error {
 		return errors.Wrap(err, "failed to initialize clientset")
 	}
 
-	fetcher, err := xpkg.NewK8sFetcher(clientset, append(o.FetcherOptions, xpkg.WithNamespace(o.Namespace), xpkg.WithServiceAccount(o.ServiceAccount))...)
+	fetcher, err := xpkg.NewK8sFetcher(clientset, append(o.FetcherOptions, xpkg.WithNamespace(o.Namespace), xpkg.WithServiceAccount(o.ServiceAccount), xpkg.WithImageConfigStore(xpkg.NewImageConfigStore(mgr.GetClient(), o.Namespace)))...)
 	if err != nil {
 		return errors.Wrap(err, "cannot build fetcher")
 	}
@@ -243,6 +265,7 @@ func SetupConfiguration(mgr ctrl.Manager, o controller.Options) error {
 		WithNewPackageRevisionFn(nr),
 		WithNewPackageRevisionListFn(nrl),
 		WithRevisioner(NewPackageRevisioner(fetcher)),
+		WithSourceResolver(fetcher),
 		WithConfigStore(xpkg.NewImageConfigStore(mgr.GetClient(), o.Namespace)),
 		WithLogger(log),
 		WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name), o.EventFilterFunctions...)),
@@ -269,7 +292,7 @@ func SetupFunction(mgr ctrl.Manager, o controller.Options) error {
 		return errors.Wrap(err, errCreateK8sClient)
 	}
 
-	f, err := xpkg.NewK8sFetcher(cs, append(o.FetcherOptions, xpkg.WithNamespace(o.Namespace), xpkg.WithServiceAccount(o.ServiceAccount))...)
+	f, err := xpkg.NewK8sFetcher(cs, append(o.FetcherOptions, xpkg.WithNamespace(o.Namespace), xpkg.WithServiceAccount(o.ServiceAccount), xpkg.WithImageConfigStore(xpkg.NewImageConfigStore(mgr.GetClient(), o.Namespace)))...)
 	if err != nil {
 		return errors.Wrap(err, errBuildFetcher)
 	}
@@ -280,6 +303,7 @@ func SetupFunction(mgr ctrl.Manager, o controller.Options) error {
 		WithNewPackageRevisionFn(nr),
 		WithNewPackageRevisionListFn(nrl),
 		WithRevisioner(NewPackageRevisioner(f)),
+		WithSourceResolver(f),
 		WithConfigStore(xpkg.NewImageConfigStore(mgr.GetClient(), o.Namespace)),
 		WithLogger(log),
 		WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name), o.EventFilterFunctions...)),
@@ -289,6 +313,14 @@ func SetupFunction(mgr ctrl.Manager, o controller.Options) error {
 		opts = append(opts, WithManagingRevisionRuntimeSpec())
 	}
 
+	if o.Features.Enabled(features.EnableAlphaFunctionCanaryActivation) && o.FunctionResponses != nil {
+		opts = append(opts, WithFunctionResponseCounter(o.FunctionResponses))
+	}
+
+	if o.Features.Enabled(features.EnableAlphaPackageHealthGates) {
+		opts = append(opts, WithHealthGates())
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		For(&v1.Function{}).
@@ -306,6 +338,7 @@ func NewReconciler(mgr ctrl.Manager, opts ...ReconcilerOption) *Reconciler {
 			Applicator: resource.NewAPIPatchingApplicator(mgr.GetClient()),
 		},
 		pkg:        NewNopRevisioner(),
+		source:     xpkg.NopSourceResolver{},
 		log:        logging.NewNopLogger(),
 		record:     event.NewNopRecorder(),
 		conditions: conditions.ObservedGenerationPropagationManager{},
@@ -389,6 +422,19 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 		p.ClearAppliedImageConfigRef(v1.ImageConfigReasonRewrite)
 	}
 
+	// Pull the image from one of its mirrors if it can't be pulled from its
+	// (possibly rewritten) path.
+	if src := r.source.ResolveSource(ctx, imagePath, v1.RefNames(p.GetPackagePullSecrets())...); src.ImageConfig != "" {
+		imagePath = src.Image
+
+		p.SetAppliedImageConfigRefs(v1.ImageConfigRef{
+			Name:   src.ImageConfig,
+			Reason: v1.ImageConfigReasonMirror,
+		})
+	} else {
+		p.ClearAppliedImageConfigRef(v1.ImageConfigReasonMirror)
+	}
+
 	p.SetResolvedSource(imagePath)
 
 	pullSecretConfig, pullSecretFromConfig, err := r.config.PullSecretFor(ctx, p.GetResolvedSource())
@@ -449,6 +495,14 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 	oldestRevisionIndex := -1
 	revisions := prs.GetRevisions()
 
+	// If the package uses canary activation this is the revision that stays
+	// active while the current revision is analyzed.
+	stable := r.canaryStable(p, revisions)
+
+	// If the current revision failed its health gate this is the revision
+	// that's active instead.
+	previous := r.healthGateRollback(p, revisions)
+
 	// Check to see if revision already exists.
 	for index, rev := range revisions {
 		revisionNum := rev.GetRevision()
@@ -473,10 +527,34 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 			continue
 		}
 
+		if stable != nil && rev.GetName() == stable.GetName() {
+			continue
+		}
+
+		if previous != nil && rev.GetName() == previous.GetName() {
+			continue
+		}
+
+		// A canary that's no longer the current revision stops running
+		// alongside the active revision.
+		if r.supersedeCanary(rev) {
+			if err := r.client.Applicator.Apply(ctx, rev, resource.MustBeControllableBy(p.GetUID())); err != nil {
+				if kerrors.IsConflict(err) {
+					return reconcile.Result{Requeue: true}, nil
+				}
+
+				err = errors.Wrap(err, errUpdateInactivePackageRevision)
+				r.record.Event(p, event.Warning(reasonTransitionRevision, err))
+
+				return reconcile.Result{}, err
+			}
+		}
+
 		if rev.GetDesiredState() == v1.PackageRevisionActive {
 			// If revision is not the current revision, set to
 			// inactive. This should always be done, regardless of
-			// the package's revision activation policy.
+			// the package's revision activation policy, unless
+			// the current revision is a canary or was rolled back.
 			rev.SetDesiredState(v1.PackageRevisionInactive)
 
 			if err := r.client.Applicator.Apply(ctx, rev, resource.MustBeControllableBy(p.GetUID())); err != nil {
@@ -512,6 +590,9 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 	}
 
 	health := v1.PackageHealth(pr)
+	if previous != nil {
+		health = v1.PackageHealth(previous)
+	}
 	if health.Status == corev1.ConditionTrue && p.GetCondition(v1.TypeHealthy).Status != corev1.ConditionTrue {
 		// NOTE(phisco): We don't want to spam the user with events if the
 		// package is already healthy.
@@ -528,6 +609,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 	}
 
 	// Create the non-existent package revision.
+	canary := pr.GetLabels()[v1.LabelCanary]
 	pr.SetName(revisionName)
 	pr.SetLabels(map[string]string{v1.LabelParentPackage: p.GetName()})
 	// Use the original source; the revision reconciler will rewrite it if
@@ -546,11 +628,26 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 	}
 
 	// If the current revision is not active, and we have an automatic or
-	// undefined activation policy, always activate.
-	if pr.GetDesiredState() != v1.PackageRevisionActive && (p.GetActivationPolicy() == nil || *p.GetActivationPolicy() == v1.AutomaticActivation) {
+	// undefined activation policy, always activate. A canary activation
+	// policy activates automatically when there is no active revision to
+	// roll out alongside.
+	if pr.GetDesiredState() != v1.PackageRevisionActive && stable == nil && previous == nil && (p.GetActivationPolicy() == nil || *p.GetActivationPolicy() == v1.AutomaticActivation || *p.GetActivationPolicy() == v1.CanaryActivation) {
 		pr.SetDesiredState(v1.PackageRevisionActive)
 	}
 
+	// A current revision that failed its health gate stays inactive until the
+	// package changes, regardless of its activation policy.
+	if previous != nil {
+		pr.SetDesiredState(v1.PackageRevisionInactive)
+	}
+
+	// Analyze the current revision if it's a canary. It stays inactive until
+	// it's promoted.
+	canaryWait := time.Duration(0)
+	if stable != nil {
+		canaryWait = r.reconcileCanary(p, pr, canary, time.Now())
+	}
+
 	controlRef := meta.AsController(meta.TypedReferenceTo(p, p.GetObjectKind().GroupVersionKind()))
 	controlRef.BlockOwnerDeletion = ptr.To(true)
 	meta.AddOwnerReference(pr, controlRef)
@@ -583,16 +680,50 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 		}
 	}
 
+	if previous != nil && previous.GetDesiredState() != v1.PackageRevisionActive {
+		previous.SetDesiredState(v1.PackageRevisionActive)
+
+		if err := r.client.Applicator.Apply(ctx, previous, resource.MustBeControllableBy(p.GetUID())); err != nil {
+			if kerrors.IsConflict(err) {
+				return reconcile.Result{Requeue: true}, nil
+			}
+
+			err = errors.Wrap(err, errActivatePreviousRevision)
+			r.record.Event(p, event.Warning(reasonHealthGate, err))
+
+			return reconcile.Result{}, err
+		}
+
+		r.record.Event(p, event.Warning(reasonHealthGate, errors.Errorf("rolled back to package revision %q because package revision %q failed its health gate: %s", previous.GetName(), pr.GetName(), healthGateMessage(pr))))
+	}
+
 	status.MarkConditions(v1.Active())
 
 	// If current revision is still not active, the package is inactive.
 	if pr.GetDesiredState() != v1.PackageRevisionActive {
-		status.MarkConditions(v1.Inactive().WithMessage("Package is inactive"))
+		msg := "Package is inactive"
+		if previous != nil {
+			msg = fmt.Sprintf("Package revision %q is active because package revision %q failed its health gate: %s", previous.GetName(), pr.GetName(), healthGateMessage(pr))
+		}
+
+		if stable != nil {
+			msg = fmt.Sprintf("Package revision %q is active while canary package revision %q is analyzed", stable.GetName(), pr.GetName())
+			if pr.GetLabels()[v1.LabelCanary] == v1.CanaryRolledBack {
+				msg = fmt.Sprintf("Package revision %q is active because canary package revision %q was rolled back", stable.GetName(), pr.GetName())
+			}
+		}
+
+		status.MarkConditions(v1.Inactive().WithMessage(msg))
+	}
+
+	result := pullBasedRequeue(p.GetPackagePullPolicy())
+	if canaryWait > 0 && (result.RequeueAfter == 0 || canaryWait < result.RequeueAfter) {
+		result = reconcile.Result{RequeueAfter: canaryWait}
 	}
 
 	// NOTE(hasheddan): when the first package revision is created for a
 	// package, the health of the package is not set until the revision reports
 	// its health. If updating from an existing revision, the package health
 	// will match the health of the old revision until the next reconcile.
-	return pullBasedRequeue(p.GetPackagePullPolicy()), errors.Wrap(r.client.Status().Update(ctx, p), errUpdateStatus)
+	return result, errors.Wrap(r.client.Status().Update(ctx, p), errUpdateStatus)
 }
@@ -19,7 +19,6 @@ package manager
 import (
 	"context"
 	"fmt"
-	"strings"
 
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -30,6 +29,7 @@ import (
 
 	v1 "github.com/crossplane/crossplane/v2/apis/pkg/v1"
 	"github.com/crossplane/crossplane/v2/apis/pkg/v1beta1"
+	"github.com/crossplane/crossplane/v2/internal/xpkg"
 )
 
 // hasPullSecret returns true if the ImageConfig has authentication with a pull secret.
@@ -48,6 +48,11 @@ func hasRewriteRules(ic *v1beta1.ImageConfig) bool {
 	return ic.Spec.RewriteImage != nil
 }
 
+// hasMirrors returns true if the ImageConfig has image mirrors.
+func hasMirrors(ic *v1beta1.ImageConfig) bool {
+	return len(ic.Spec.Mirrors) > 0
+}
+
 // EnqueuePackagesForImageConfig enqueues a reconcile for all packages
 // an ImageConfig applies to.
 func EnqueuePackagesForImageConfig(kube client.Client, l v1.PackageList, log logging.Logger) handler.EventHandler {
@@ -56,8 +61,9 @@ func EnqueuePackagesForImageConfig(kube client.Client, l v1.PackageList, log log
 		if !ok {
 			return nil
 		}
-		// We only care about ImageConfigs that have a pull secret or rewrite rules.
-		if !hasPullSecret(ic) && !hasRewriteRules(ic) {
+		// We only care about ImageConfigs that have a pull secret, rewrite
+		// rules, or mirrors.
+		if !hasPullSecret(ic) && !hasRewriteRules(ic) && !hasMirrors(ic) {
 			return nil
 		}
 		// Enqueue all packages matching the prefixes in the ImageConfig.
@@ -72,7 +78,7 @@ func EnqueuePackagesForImageConfig(kube client.Client, l v1.PackageList, log log
 
 		for _, pkg := range pl.GetPackages() {
 			for _, m := range ic.Spec.MatchImages {
-				if strings.HasPrefix(pkg.GetSource(), m.Prefix) || strings.HasPrefix(pkg.GetResolvedSource(), m.Prefix) {
+				if xpkg.ImageMatches(pkg.GetSource(), m) || xpkg.ImageMatches(pkg.GetResolvedSource(), m) {
 					log.Debug("Enqueuing package for image config",
 						"package-type", fmt.Sprintf("%T", pkg),
 						"package-name", pkg.GetName(),
@@ -133,6 +133,42 @@ func TestHasRewriteRules(t *testing.T) {
 	}
 }
 
+func TestHasMirrors(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		ic     *v1beta1.ImageConfig
+		want   bool
+	}{
+		"NoMirrors": {
+			reason: "Should return false when Mirrors is empty",
+			ic: &v1beta1.ImageConfig{
+				Spec: v1beta1.ImageConfigSpec{},
+			},
+			want: false,
+		},
+		"HasMirrors": {
+			reason: "Should return true when Mirrors is set",
+			ic: &v1beta1.ImageConfig{
+				Spec: v1beta1.ImageConfigSpec{
+					Mirrors: []v1beta1.ImageRewrite{
+						{Prefix: "mirror.example.org/crossplane-contrib/"},
+					},
+				},
+			},
+			want: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := hasMirrors(tc.ic)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nhasMirrors(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
 // testEnqueuePackagesForImageConfig tests the handler by calling it with mock events and queues.
 func TestEnqueuePackagesForImageConfig(t *testing.T) {
 	errBoom := errors.New("boom")
@@ -288,6 +324,45 @@ func TestEnqueuePackagesForImageConfig(t *testing.T) {
 				},
 			},
 		},
+		"SuccessfulWithMirrorsOnly": {
+			reason: "Should enqueue matching packages when ImageConfig only has mirrors",
+			params: params{
+				kube: &test.MockClient{
+					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
+						list := obj.(*v1.ProviderList)
+						list.Items = []v1.Provider{
+							{
+								ObjectMeta: metav1.ObjectMeta{Name: "provider-aws"},
+								Spec: v1.ProviderSpec{
+									PackageSpec: v1.PackageSpec{
+										Package: "xpkg.upbound.io/upbound/provider-aws:v1.0.0",
+									},
+								},
+							},
+						}
+						return nil
+					}),
+				},
+				l:   &v1.ProviderList{},
+				log: logging.NewNopLogger(),
+				obj: &v1beta1.ImageConfig{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-config"},
+					Spec: v1beta1.ImageConfigSpec{
+						MatchImages: []v1beta1.ImageMatch{
+							{Prefix: "xpkg.upbound.io/upbound/"},
+						},
+						Mirrors: []v1beta1.ImageRewrite{
+							{Prefix: "mirror.example.org/upbound/"},
+						},
+					},
+				},
+			},
+			want: want{
+				reqs: []reconcile.Request{
+					{NamespacedName: types.NamespacedName{Name: "provider-aws"}},
+				},
+			},
+		},
 		"NoMatchingPackages": {
 			reason: "Should not enqueue when no packages match the prefix",
 			params: params{
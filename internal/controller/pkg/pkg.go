@@ -27,6 +27,7 @@ import (
 	"github.com/crossplane/crossplane/v2/internal/controller/pkg/revision"
 	"github.com/crossplane/crossplane/v2/internal/controller/pkg/runtime"
 	"github.com/crossplane/crossplane/v2/internal/controller/pkg/signature"
+	"github.com/crossplane/crossplane/v2/internal/controller/pkg/updater"
 	"github.com/crossplane/crossplane/v2/internal/features"
 )
 
@@ -62,6 +63,14 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 		}...)
 	}
 
+	if o.Features.Enabled(features.EnableAlphaPackageAutoUpdates) {
+		setupFuncs = append(setupFuncs, []func(c ctrl.Manager, options controller.Options) error{
+			updater.SetupProvider,
+			updater.SetupConfiguration,
+			updater.SetupFunction,
+		}...)
+	}
+
 	for _, setup := range setupFuncs {
 		if err := setup(mgr, o); err != nil {
 			return err
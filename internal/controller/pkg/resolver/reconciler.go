@@ -20,7 +20,6 @@ package resolver
 import (
 	"context"
 	"fmt"
-	"sort"
 	"strings"
 	"time"
 
@@ -63,29 +62,30 @@ const (
 	lockName  = "lock"
 	finalizer = "lock.pkg.crossplane.io"
 
-	errGetLock                = "cannot get package lock"
-	errAddFinalizer           = "cannot add lock finalizer"
-	errRemoveFinalizer        = "cannot remove lock finalizer"
-	errBuildDAG               = "cannot build DAG"
-	errSortDAG                = "cannot sort DAG"
-	errFmtMissingDependency   = "missing package (%s) is not a dependency"
-	errInvalidConstraint      = "version constraint on dependency is invalid"
-	errInvalidDependency      = "dependency package is not valid"
-	errFindDependency         = "cannot find dependency version to install"
-	errGetPullConfig          = "cannot get image pull secret from config"
-	errRewriteImage           = "cannot rewrite image path using config"
-	errInvalidRewrite         = "rewritten image path is invalid"
-	errFetchTags              = "cannot fetch dependency package tags"
-	errFindDependencyUpgrade  = "cannot find dependency version to upgrade"
-	errFmtNoValidVersion      = "dependency (%s) does not have a valid version to upgrade that satisfies all constraints. If there is a valid version that requires downgrade, manual intervention is required. Constraints: %v"
-	errGetDependency          = "cannot get dependency package"
-	errConstructDependency    = "cannot construct dependency package"
-	errCreateDependency       = "cannot create dependency package"
-	errUpdateDependency       = "cannot update dependency package"
-	errFmtSplit               = "package should have 2 segments after split but has %d"
-	errFmtDiffConstraintTypes = "a dependency package has different types of parent constraints (%v)"
-	errFmtDiffDigests         = "a dependency package has different digests in parent constraints (%v)"
-	errCannotUpdateStatus     = "cannot update status"
+	errGetLock                 = "cannot get package lock"
+	errAddFinalizer            = "cannot add lock finalizer"
+	errRemoveFinalizer         = "cannot remove lock finalizer"
+	errBuildDAG                = "cannot build DAG"
+	errSortDAG                 = "cannot sort DAG"
+	errFmtMissingDependency    = "missing package (%s) is not a dependency"
+	errInvalidConstraint       = "version constraint on dependency is invalid"
+	errInvalidDependency       = "dependency package is not valid"
+	errFindDependency          = "cannot find dependency version to install"
+	errGetPullConfig           = "cannot get image pull secret from config"
+	errRewriteImage            = "cannot rewrite image path using config"
+	errInvalidRewrite          = "rewritten image path is invalid"
+	errFetchTags               = "cannot fetch dependency package tags"
+	errFindDependencyUpgrade   = "cannot find dependency version to upgrade"
+	errInvalidInstalledVersion = "installed dependency version is not a valid semantic version"
+	errFmtNoValidVersion       = "dependency (%s) does not have a valid version to upgrade that satisfies all constraints. If there is a valid version that requires downgrade, manual intervention is required. Constraints: %v"
+	errGetDependency           = "cannot get dependency package"
+	errConstructDependency     = "cannot construct dependency package"
+	errCreateDependency        = "cannot create dependency package"
+	errUpdateDependency        = "cannot update dependency package"
+	errFmtSplit                = "package should have 2 segments after split but has %d"
+	errFmtDiffConstraintTypes  = "a dependency package has different types of parent constraints (%v)"
+	errFmtDiffDigests          = "a dependency package has different digests in parent constraints (%v)"
+	errCannotUpdateStatus      = "cannot update status"
 )
 
 // ReconcilerOption is used to configure the Reconciler.
@@ -163,7 +163,7 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 		return errors.Wrap(err, "failed to initialize clientset")
 	}
 
-	f, err := xpkg.NewK8sFetcher(cs, append(o.FetcherOptions, xpkg.WithNamespace(o.Namespace), xpkg.WithServiceAccount(o.ServiceAccount))...)
+	f, err := xpkg.NewK8sFetcher(cs, append(o.FetcherOptions, xpkg.WithNamespace(o.Namespace), xpkg.WithServiceAccount(o.ServiceAccount), xpkg.WithImageConfigStore(xpkg.NewImageConfigStore(mgr.GetClient(), o.Namespace)))...)
 	if err != nil {
 		return errors.Wrap(err, "cannot build fetcher")
 	}
@@ -471,8 +471,6 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 }
 
 func (r *Reconciler) findDependencyVersionToInstall(ctx context.Context, dep *v1beta1.Dependency, log logging.Logger, ref name.Reference) (string, error) {
-	var addVer string
-
 	if digest, err := conregv1.NewHash(dep.Constraints); err == nil {
 		log.Debug("package is pinned to a specific digest, skipping resolution")
 		return digest.String(), nil
@@ -523,39 +521,13 @@ func (r *Reconciler) findDependencyVersionToInstall(ctx context.Context, dep *v1
 		return "", errors.Wrap(err, errFetchTags)
 	}
 
-	vs := []*semver.Version{}
-	for _, r := range tags {
-		v, err := semver.NewVersion(r)
-		if err != nil {
-			// We skip any tags that are not valid semantic versions.
-			continue
-		}
-
-		// We also skip any tags that are incomplete semantic versions (e.g.,
-		// "v1" will parse as "v1.0.0"). This prevents a "v1" tag, which may not
-		// point to v1.0.0 of a package, from matching a "v1.0.0" constraint.
-		if v.String() != strings.TrimPrefix(v.Original(), "v") {
-			continue
-		}
-
-		vs = append(vs, v)
-	}
-
-	sort.Sort(semver.Collection(vs))
-
-	for _, v := range vs {
-		if c.Check(v) {
-			addVer = v.Original()
-		}
-	}
-
-	return addVer, nil
+	return FindVersionToInstall(c, tags), nil
 }
 
 // findDependencyVersionToUpdate finds a valid version to update the dependency considering the parent constraints.
 func (r *Reconciler) findDependencyVersionToUpdate(ctx context.Context, ref name.Reference, insVer string, dep internaldag.Node, log logging.Logger) (string, error) {
 	// If there is a digest in the parent constraints, we need to make sure that all other parent constraints are the same.
-	digest, err := findDigestToUpdate(dep)
+	digest, err := FindDigestToUpdate(dep)
 	if err != nil {
 		log.Debug("cannot find digest to update", "error", err)
 		return "", err
@@ -603,91 +575,13 @@ func (r *Reconciler) findDependencyVersionToUpdate(ctx context.Context, ref name
 		return "", errors.Wrap(err, errFetchTags)
 	}
 
-	availableVersions := make([]*semver.Version, 0, len(tags))
-	for _, r := range tags {
-		v, err := semver.NewVersion(r)
-		if err != nil {
-			// We skip any tags that are not valid semantic versions.
-			continue
-		}
-
-		availableVersions = append(availableVersions, v)
-	}
-
-	parentConstraints := make([]*semver.Constraints, 0, len(dep.GetParentConstraints()))
-	for _, c := range dep.GetParentConstraints() {
-		constraint, err := semver.NewConstraint(c)
-		if err != nil {
-			log.Debug(errInvalidConstraint, "error", err)
-			return "", errors.Wrap(err, errInvalidConstraint)
-		}
-
-		parentConstraints = append(parentConstraints, constraint)
-	}
-
-	sort.Sort(semver.Collection(availableVersions))
-	currentVersion := semver.MustParse(insVer)
-
-	var targetVersion *semver.Version
-
-	// We aim to find the lowest version that satisfies all parent constraints and is greater than the current version.
-	for _, v := range availableVersions {
-		valid := true
-
-		for _, c := range parentConstraints {
-			if !c.Check(v) {
-				valid = false
-				break
-			}
-		}
-
-		// If we're upgrading, we target the first valid version that is greater than the current version.
-		if (v.GreaterThan(currentVersion) || v.Equal(currentVersion)) && valid {
-			return v.Original(), nil
-		}
-
-		// If we're downgrading, we target the largest valid version that is less than the current version.
-		if r.downgradesEnabled && valid {
-			targetVersion = v
-		}
-	}
-
-	if targetVersion != nil {
-		return targetVersion.Original(), nil
-	}
-
-	log.Debug(errFindDependencyUpgrade, "error", errors.Errorf(errFmtNoValidVersion, dep.Identifier(), dep.GetParentConstraints()))
-
-	return "", errors.Errorf(errFmtNoValidVersion, dep.Identifier(), dep.GetParentConstraints())
-}
-
-// findDigestToUpdate returns the digest to update if all parent constraints are the same digest.
-// It returns an error, if there is at least one digest which is different from other constraints.
-func findDigestToUpdate(node internaldag.Node) (string, error) {
-	foundDigest := ""
-	foundVersion := false
-
-	for _, c := range node.GetParentConstraints() {
-		if d, err := conregv1.NewHash(c); err == nil {
-			if foundDigest != "" && foundDigest != d.String() {
-				return "", errors.Errorf(errFmtDiffDigests, node.GetParentConstraints())
-			}
-
-			foundDigest = d.String()
-		} else {
-			foundVersion = true
-		}
-
-		if foundVersion && foundDigest != "" {
-			return "", errors.Errorf(errFmtDiffConstraintTypes, node.GetParentConstraints())
-		}
-	}
-
-	if foundDigest != "" {
-		return foundDigest, nil
+	v, err := FindVersionToUpdate(dep, insVer, tags, r.downgradesEnabled)
+	if err != nil {
+		log.Debug(errFindDependencyUpgrade, "error", err)
+		return "", err
 	}
 
-	return "", nil
+	return v, nil
 }
 
 // NewPackage creates a new package from the given dependency and version.
@@ -1249,7 +1249,7 @@ func TestFindDigestToUpdate(t *testing.T) {
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			got, err := findDigestToUpdate(tc.args.node)
+			got, err := FindDigestToUpdate(tc.args.node)
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
 				t.Errorf("\n%s\nr.Reconcile(...): -want error, +got error:\n%s", tc.reason, diff)
 			}
@@ -0,0 +1,158 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/Masterminds/semver"
+	conregv1 "github.com/google/go-containerregistry/pkg/v1"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+
+	internaldag "github.com/crossplane/crossplane/v2/internal/dag"
+)
+
+// FindVersionToInstall returns the highest of the supplied tags that satisfies
+// the supplied constraints. It returns an empty string if no tag satisfies
+// them.
+func FindVersionToInstall(c *semver.Constraints, tags []string) string {
+	vs := []*semver.Version{}
+	for _, r := range tags {
+		v, err := semver.NewVersion(r)
+		if err != nil {
+			// We skip any tags that are not valid semantic versions.
+			continue
+		}
+
+		// We also skip any tags that are incomplete semantic versions (e.g.,
+		// "v1" will parse as "v1.0.0"). This prevents a "v1" tag, which may not
+		// point to v1.0.0 of a package, from matching a "v1.0.0" constraint.
+		if v.String() != strings.TrimPrefix(v.Original(), "v") {
+			continue
+		}
+
+		vs = append(vs, v)
+	}
+
+	sort.Sort(semver.Collection(vs))
+
+	var addVer string
+
+	for _, v := range vs {
+		if c.Check(v) {
+			addVer = v.Original()
+		}
+	}
+
+	return addVer
+}
+
+// FindVersionToUpdate returns the version of the supplied tags that an
+// installed dependency should be updated to in order to satisfy all of its
+// parent constraints. It prefers the lowest valid version that isn't lower
+// than the installed version. If downgrades are enabled and there is no such
+// version it returns the highest valid version lower than the installed one.
+func FindVersionToUpdate(dep internaldag.Node, installed string, tags []string, downgrades bool) (string, error) {
+	availableVersions := make([]*semver.Version, 0, len(tags))
+	for _, r := range tags {
+		v, err := semver.NewVersion(r)
+		if err != nil {
+			// We skip any tags that are not valid semantic versions.
+			continue
+		}
+
+		availableVersions = append(availableVersions, v)
+	}
+
+	parentConstraints := make([]*semver.Constraints, 0, len(dep.GetParentConstraints()))
+	for _, c := range dep.GetParentConstraints() {
+		constraint, err := semver.NewConstraint(c)
+		if err != nil {
+			return "", errors.Wrap(err, errInvalidConstraint)
+		}
+
+		parentConstraints = append(parentConstraints, constraint)
+	}
+
+	sort.Sort(semver.Collection(availableVersions))
+
+	currentVersion, err := semver.NewVersion(installed)
+	if err != nil {
+		return "", errors.Wrap(err, errInvalidInstalledVersion)
+	}
+
+	var targetVersion *semver.Version
+
+	// We aim to find the lowest version that satisfies all parent constraints and is greater than the current version.
+	for _, v := range availableVersions {
+		valid := true
+
+		for _, c := range parentConstraints {
+			if !c.Check(v) {
+				valid = false
+				break
+			}
+		}
+
+		// If we're upgrading, we target the first valid version that is greater than the current version.
+		if (v.GreaterThan(currentVersion) || v.Equal(currentVersion)) && valid {
+			return v.Original(), nil
+		}
+
+		// If we're downgrading, we target the largest valid version that is less than the current version.
+		if downgrades && valid {
+			targetVersion = v
+		}
+	}
+
+	if targetVersion != nil {
+		return targetVersion.Original(), nil
+	}
+
+	return "", errors.Errorf(errFmtNoValidVersion, dep.Identifier(), dep.GetParentConstraints())
+}
+
+// FindDigestToUpdate returns the digest to update if all parent constraints are the same digest.
+// It returns an error, if there is at least one digest which is different from other constraints.
+func FindDigestToUpdate(node internaldag.Node) (string, error) {
+	foundDigest := ""
+	foundVersion := false
+
+	for _, c := range node.GetParentConstraints() {
+		if d, err := conregv1.NewHash(c); err == nil {
+			if foundDigest != "" && foundDigest != d.String() {
+				return "", errors.Errorf(errFmtDiffDigests, node.GetParentConstraints())
+			}
+
+			foundDigest = d.String()
+		} else {
+			foundVersion = true
+		}
+
+		if foundVersion && foundDigest != "" {
+			return "", errors.Errorf(errFmtDiffConstraintTypes, node.GetParentConstraints())
+		}
+	}
+
+	if foundDigest != "" {
+		return foundDigest, nil
+	}
+
+	return "", nil
+}
@@ -112,6 +112,9 @@ func (m *PackageDependencyManager) Resolve(ctx context.Context, meta pkgmetav1.P
 		sources[i] = pdep
 	}
 
+	// Pin dependencies to the digests in the package's lock, if any.
+	sources = xpkg.PinDependencies(sources, meta.GetLock())
+
 	found = len(sources)
 
 	// Get the lock.
@@ -252,12 +255,20 @@ func (m *PackageDependencyManager) Resolve(ctx context.Context, meta pkgmetav1.P
 		// Check if the constraint is a digest, if so, compare it directly.
 		if d, err := conregv1.NewHash(dep.Constraints); err == nil {
 			if lp.Version != d.String() {
-				return found, installed, invalid, errors.Errorf("existing package %s@%s is incompatible with constraint %s", lp.Identifier(), lp.Version, strings.TrimSpace(dep.Constraints))
+				invalidDeps = append(invalidDeps, fmt.Sprintf("existing package %s@%s is incompatible with constraint %s", lp.Identifier(), lp.Version, strings.TrimSpace(dep.Constraints)))
 			}
 
 			continue
 		}
 
+		// A dependency installed at a digest was pinned by another package,
+		// for example by its lock. We can't check a digest against semantic
+		// version constraints.
+		if _, err := conregv1.NewHash(lp.Version); err == nil {
+			invalidDeps = append(invalidDeps, fmt.Sprintf("existing package %s@%s is pinned to a digest that can't be checked against constraint %s", lp.Identifier(), lp.Version, strings.TrimSpace(dep.Constraints)))
+			continue
+		}
+
 		c, err := semver.NewConstraint(dep.Constraints)
 		if err != nil {
 			return found, installed, invalid, err
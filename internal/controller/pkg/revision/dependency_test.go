@@ -548,6 +548,228 @@ func TestResolve(t *testing.T) {
 				invalid:   0,
 			},
 		},
+		"SuccessfulSelfExistLockedDependencies": {
+			reason: "Should not return error if self exists and all dependencies are installed at the digests pinned by the package's lock.",
+			args: args{
+				dep: &PackageDependencyManager{
+					client: &test.MockClient{
+						MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+							l := obj.(*v1beta1.Lock)
+							l.Packages = []v1beta1.LockPackage{
+								{
+									Name:   "config-nop-a-abc123",
+									Source: "xpkg.crossplane.io/hasheddan/config-nop-a",
+								},
+							}
+							return nil
+						}),
+						MockUpdate: test.NewMockUpdateFn(nil),
+					},
+					newDag: func() dag.DAG {
+						return &dagfake.MockDag{
+							MockInit: func(_ []dag.Node) ([]dag.Node, error) {
+								return nil, nil
+							},
+							MockTraceNode: func(_ string) (map[string]dag.Node, error) {
+								return map[string]dag.Node{
+									"not-here-1": &v1beta1.Dependency{},
+									"not-here-2": &v1beta1.Dependency{},
+								}, nil
+							},
+							MockGetNode: func(s string) (dag.Node, error) {
+								return &v1beta1.LockPackage{
+									Source:  s,
+									Version: "sha256:ecc25c121431dfc7058754427f97c034ecde26d4aafa0da16d50bd80c9c84a05",
+								}, nil
+							},
+						}
+					},
+					log: logging.NewNopLogger(),
+				},
+				meta: &pkgmetav1.Configuration{
+					Spec: pkgmetav1.ConfigurationSpec{
+						MetaSpec: pkgmetav1.MetaSpec{
+							DependsOn: []pkgmetav1.Dependency{
+								{
+									Provider: ptr.To("not-here-1"),
+									Version:  ">=v0.1.0",
+								},
+							},
+							Lock: []pkgmetav1.LockedDependency{
+								{
+									APIVersion: "pkg.crossplane.io/v1",
+									Kind:       "Provider",
+									Package:    "not-here-1",
+									Version:    "v0.2.0",
+									Digest:     "sha256:ecc25c121431dfc7058754427f97c034ecde26d4aafa0da16d50bd80c9c84a05",
+								},
+								{
+									APIVersion: "pkg.crossplane.io/v1",
+									Kind:       "Provider",
+									Package:    "not-here-2",
+									Version:    "v0.3.0",
+									Digest:     "sha256:ecc25c121431dfc7058754427f97c034ecde26d4aafa0da16d50bd80c9c84a05",
+								},
+							},
+						},
+					},
+				},
+				pr: &v1.ConfigurationRevision{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "config-nop-a-abc123",
+					},
+					Spec: v1.PackageRevisionSpec{
+						Package:      "xpkg.crossplane.io/hasheddan/config-nop-a:v0.0.1",
+						DesiredState: v1.PackageRevisionActive,
+					},
+				},
+			},
+			want: want{
+				total:     2,
+				installed: 2,
+			},
+		},
+		"ErrorSelfExistLockedDependencyNotPinned": {
+			reason: "Should return error if a dependency is installed at a version other than the digest pinned by the package's lock.",
+			args: args{
+				dep: &PackageDependencyManager{
+					client: &test.MockClient{
+						MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+							l := obj.(*v1beta1.Lock)
+							l.Packages = []v1beta1.LockPackage{
+								{
+									Name:   "config-nop-a-abc123",
+									Source: "xpkg.crossplane.io/hasheddan/config-nop-a",
+								},
+							}
+							return nil
+						}),
+						MockUpdate: test.NewMockUpdateFn(nil),
+					},
+					newDag: func() dag.DAG {
+						return &dagfake.MockDag{
+							MockInit: func(_ []dag.Node) ([]dag.Node, error) {
+								return nil, nil
+							},
+							MockTraceNode: func(_ string) (map[string]dag.Node, error) {
+								return map[string]dag.Node{
+									"not-here-1": &v1beta1.Dependency{},
+								}, nil
+							},
+							MockGetNode: func(s string) (dag.Node, error) {
+								return &v1beta1.LockPackage{
+									Source:  s,
+									Version: "v0.2.0",
+								}, nil
+							},
+						}
+					},
+					log: logging.NewNopLogger(),
+				},
+				meta: &pkgmetav1.Configuration{
+					Spec: pkgmetav1.ConfigurationSpec{
+						MetaSpec: pkgmetav1.MetaSpec{
+							DependsOn: []pkgmetav1.Dependency{
+								{
+									Provider: ptr.To("not-here-1"),
+									Version:  ">=v0.1.0",
+								},
+							},
+							Lock: []pkgmetav1.LockedDependency{
+								{
+									APIVersion: "pkg.crossplane.io/v1",
+									Kind:       "Provider",
+									Package:    "not-here-1",
+									Version:    "v0.2.0",
+									Digest:     "sha256:ecc25c121431dfc7058754427f97c034ecde26d4aafa0da16d50bd80c9c84a05",
+								},
+							},
+						},
+					},
+				},
+				pr: &v1.ConfigurationRevision{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "config-nop-a-abc123",
+					},
+					Spec: v1.PackageRevisionSpec{
+						Package:      "xpkg.crossplane.io/hasheddan/config-nop-a:v0.0.1",
+						DesiredState: v1.PackageRevisionActive,
+					},
+				},
+			},
+			want: want{
+				err:       errors.Errorf(errFmtIncompatibleDependency, "existing package not-here-1@v0.2.0 is incompatible with constraint sha256:ecc25c121431dfc7058754427f97c034ecde26d4aafa0da16d50bd80c9c84a05"),
+				total:     1,
+				installed: 1,
+				invalid:   1,
+			},
+		},
+		"ErrorSelfExistDependencyPinnedByAnotherPackage": {
+			reason: "Should return error if a dependency is installed at a digest but this package constrains it by semantic version.",
+			args: args{
+				dep: &PackageDependencyManager{
+					client: &test.MockClient{
+						MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+							l := obj.(*v1beta1.Lock)
+							l.Packages = []v1beta1.LockPackage{
+								{
+									Name:   "config-nop-a-abc123",
+									Source: "xpkg.crossplane.io/hasheddan/config-nop-a",
+								},
+							}
+							return nil
+						}),
+						MockUpdate: test.NewMockUpdateFn(nil),
+					},
+					newDag: func() dag.DAG {
+						return &dagfake.MockDag{
+							MockInit: func(_ []dag.Node) ([]dag.Node, error) {
+								return nil, nil
+							},
+							MockTraceNode: func(_ string) (map[string]dag.Node, error) {
+								return map[string]dag.Node{
+									"not-here-1": &v1beta1.Dependency{},
+								}, nil
+							},
+							MockGetNode: func(s string) (dag.Node, error) {
+								return &v1beta1.LockPackage{
+									Source:  s,
+									Version: "sha256:ecc25c121431dfc7058754427f97c034ecde26d4aafa0da16d50bd80c9c84a05",
+								}, nil
+							},
+						}
+					},
+					log: logging.NewNopLogger(),
+				},
+				meta: &pkgmetav1.Configuration{
+					Spec: pkgmetav1.ConfigurationSpec{
+						MetaSpec: pkgmetav1.MetaSpec{
+							DependsOn: []pkgmetav1.Dependency{
+								{
+									Provider: ptr.To("not-here-1"),
+									Version:  ">=v0.1.0",
+								},
+							},
+						},
+					},
+				},
+				pr: &v1.ConfigurationRevision{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "config-nop-a-abc123",
+					},
+					Spec: v1.PackageRevisionSpec{
+						Package:      "xpkg.crossplane.io/hasheddan/config-nop-a:v0.0.1",
+						DesiredState: v1.PackageRevisionActive,
+					},
+				},
+			},
+			want: want{
+				err:       errors.Errorf(errFmtIncompatibleDependency, "existing package not-here-1@sha256:ecc25c121431dfc7058754427f97c034ecde26d4aafa0da16d50bd80c9c84a05 is pinned to a digest that can't be checked against constraint >=v0.1.0"),
+				total:     1,
+				installed: 1,
+				invalid:   1,
+			},
+		},
 		"SuccessfulLockPackageSourceMismatch": {
 			reason: "Should not return error if source in packages does not match provider revision package.",
 			args: args{
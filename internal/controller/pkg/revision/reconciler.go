@@ -182,6 +182,14 @@ func WithParser(p parser.Parser) ReconcilerOption {
 	}
 }
 
+// WithSourceResolver specifies how the Reconciler should resolve the source a
+// package image should be pulled from.
+func WithSourceResolver(sr xpkg.SourceResolver) ReconcilerOption {
+	return func(r *Reconciler) {
+		r.source = sr
+	}
+}
+
 // WithParserBackend specifies how the Reconciler should parse a package.
 func WithParserBackend(p parser.Backend) ReconcilerOption {
 	return func(r *Reconciler) {
@@ -246,6 +254,7 @@ type Reconciler struct {
 	versioner      version.Operations
 	backend        parser.Backend
 	config         xpkg.ConfigStore
+	source         xpkg.SourceResolver
 	log            logging.Logger
 	record         event.Recorder
 	conditions     conditions.Manager
@@ -276,7 +285,7 @@ func SetupProviderRevision(mgr ctrl.Manager, o controller.Options) error {
 		return errors.New(errCannotBuildObjectSchema)
 	}
 
-	fetcher, err := xpkg.NewK8sFetcher(clientset, append(o.FetcherOptions, xpkg.WithNamespace(o.Namespace), xpkg.WithServiceAccount(o.ServiceAccount))...)
+	fetcher, err := xpkg.NewK8sFetcher(clientset, append(o.FetcherOptions, xpkg.WithNamespace(o.Namespace), xpkg.WithServiceAccount(o.ServiceAccount), xpkg.WithImageConfigStore(xpkg.NewImageConfigStore(mgr.GetClient(), o.Namespace)))...)
 	if err != nil {
 		return errors.Wrap(err, errCannotBuildFetcher)
 	}
@@ -296,6 +305,7 @@ func SetupProviderRevision(mgr ctrl.Manager, o controller.Options) error {
 		WithNewPackageRevisionFn(nr),
 		WithParser(parser.New(metaScheme, objScheme)),
 		WithParserBackend(NewImageBackend(fetcher)),
+		WithSourceResolver(fetcher),
 		WithConfigStore(xpkg.NewImageConfigStore(mgr.GetClient(), o.Namespace)),
 		WithLinter(xpkg.NewProviderLinter()),
 		WithLogger(log),
@@ -329,7 +339,7 @@ func SetupConfigurationRevision(mgr ctrl.Manager, o controller.Options) error {
 		return errors.New(errCannotBuildObjectSchema)
 	}
 
-	f, err := xpkg.NewK8sFetcher(cs, append(o.FetcherOptions, xpkg.WithNamespace(o.Namespace), xpkg.WithServiceAccount(o.ServiceAccount))...)
+	f, err := xpkg.NewK8sFetcher(cs, append(o.FetcherOptions, xpkg.WithNamespace(o.Namespace), xpkg.WithServiceAccount(o.ServiceAccount), xpkg.WithImageConfigStore(xpkg.NewImageConfigStore(mgr.GetClient(), o.Namespace)))...)
 	if err != nil {
 		return errors.Wrap(err, errCannotBuildFetcher)
 	}
@@ -342,6 +352,7 @@ func SetupConfigurationRevision(mgr ctrl.Manager, o controller.Options) error {
 		WithEstablisher(NewAPIEstablisher(mgr.GetClient(), o.Namespace, o.MaxConcurrentPackageEstablishers)),
 		WithParser(parser.New(metaScheme, objScheme)),
 		WithParserBackend(NewImageBackend(f)),
+		WithSourceResolver(f),
 		WithConfigStore(xpkg.NewImageConfigStore(mgr.GetClient(), o.Namespace)),
 		WithLinter(xpkg.NewConfigurationLinter()),
 		WithLogger(log),
@@ -380,7 +391,7 @@ func SetupFunctionRevision(mgr ctrl.Manager, o controller.Options) error {
 		return errors.New(errCannotBuildObjectSchema)
 	}
 
-	fetcher, err := xpkg.NewK8sFetcher(clientset, append(o.FetcherOptions, xpkg.WithNamespace(o.Namespace), xpkg.WithServiceAccount(o.ServiceAccount))...)
+	fetcher, err := xpkg.NewK8sFetcher(clientset, append(o.FetcherOptions, xpkg.WithNamespace(o.Namespace), xpkg.WithServiceAccount(o.ServiceAccount), xpkg.WithImageConfigStore(xpkg.NewImageConfigStore(mgr.GetClient(), o.Namespace)))...)
 	if err != nil {
 		return errors.Wrap(err, errCannotBuildFetcher)
 	}
@@ -400,6 +411,7 @@ func SetupFunctionRevision(mgr ctrl.Manager, o controller.Options) error {
 		WithNewPackageRevisionFn(nr),
 		WithParser(parser.New(metaScheme, objScheme)),
 		WithParserBackend(NewImageBackend(fetcher)),
+		WithSourceResolver(fetcher),
 		WithConfigStore(xpkg.NewImageConfigStore(mgr.GetClient(), o.Namespace)),
 		WithLinter(xpkg.NewFunctionLinter()),
 		WithLogger(log),
@@ -423,6 +435,7 @@ func NewReconciler(mgr manager.Manager, opts ...ReconcilerOption) *Reconciler {
 		parser:     parser.New(nil, nil),
 		linter:     parser.NewPackageLinter(nil, nil, nil),
 		versioner:  version.New(),
+		source:     xpkg.NopSourceResolver{},
 		log:        logging.NewNopLogger(),
 		record:     event.NewNopRecorder(),
 		conditions: conditions.ObservedGenerationPropagationManager{},
@@ -549,6 +562,19 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 		pr.ClearAppliedImageConfigRef(v1.ImageConfigReasonRewrite)
 	}
 
+	// Pull the image from one of its mirrors if it can't be pulled from its
+	// (possibly rewritten) path.
+	if src := r.source.ResolveSource(ctx, imagePath, v1.RefNames(pr.GetPackagePullSecrets())...); src.ImageConfig != "" {
+		imagePath = src.Image
+
+		pr.SetAppliedImageConfigRefs(v1.ImageConfigRef{
+			Name:   src.ImageConfig,
+			Reason: v1.ImageConfigReasonMirror,
+		})
+	} else {
+		pr.ClearAppliedImageConfigRef(v1.ImageConfigReasonMirror)
+	}
+
 	// Ensure the rewritten image path is persisted before we proceed.
 	if pr.GetResolvedSource() != imagePath {
 		pr.SetResolvedSource(imagePath)
@@ -19,7 +19,6 @@ package revision
 import (
 	"context"
 	"fmt"
-	"strings"
 
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -30,6 +29,7 @@ import (
 
 	v1 "github.com/crossplane/crossplane/v2/apis/pkg/v1"
 	"github.com/crossplane/crossplane/v2/apis/pkg/v1beta1"
+	"github.com/crossplane/crossplane/v2/internal/xpkg"
 )
 
 // hasPullSecret returns true if the ImageConfig has authentication with a pull secret.
@@ -48,6 +48,11 @@ func hasRewriteRules(ic *v1beta1.ImageConfig) bool {
 	return ic.Spec.RewriteImage != nil
 }
 
+// hasMirrors returns true if the ImageConfig has image mirrors.
+func hasMirrors(ic *v1beta1.ImageConfig) bool {
+	return len(ic.Spec.Mirrors) > 0
+}
+
 // EnqueuePackageRevisionsForImageConfig enqueues a reconcile for all package
 // revisions an ImageConfig applies to.
 func EnqueuePackageRevisionsForImageConfig(kube client.Client, l v1.PackageRevisionList, log logging.Logger) handler.EventHandler {
@@ -56,8 +61,9 @@ func EnqueuePackageRevisionsForImageConfig(kube client.Client, l v1.PackageRevis
 		if !ok {
 			return nil
 		}
-		// We only care about ImageConfigs that have a pull secret or rewrite rules.
-		if !hasPullSecret(ic) && !hasRewriteRules(ic) {
+		// We only care about ImageConfigs that have a pull secret, rewrite
+		// rules, or mirrors.
+		if !hasPullSecret(ic) && !hasRewriteRules(ic) && !hasMirrors(ic) {
 			return nil
 		}
 		// Enqueue all package revisions matching the prefixes in the ImageConfig.
@@ -73,7 +79,7 @@ func EnqueuePackageRevisionsForImageConfig(kube client.Client, l v1.PackageRevis
 
 		for _, rev := range rl.GetRevisions() {
 			for _, m := range ic.Spec.MatchImages {
-				if strings.HasPrefix(rev.GetSource(), m.Prefix) || strings.HasPrefix(rev.GetResolvedSource(), m.Prefix) {
+				if xpkg.ImageMatches(rev.GetSource(), m) || xpkg.ImageMatches(rev.GetResolvedSource(), m) {
 					log.Debug("Enqueuing for image config",
 						"revision-type", fmt.Sprintf("%T", rev),
 						"revision-name", rev.GetName(),
@@ -133,6 +133,42 @@ func TestHasRewriteRules(t *testing.T) {
 	}
 }
 
+func TestHasMirrors(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		ic     *v1beta1.ImageConfig
+		want   bool
+	}{
+		"NoMirrors": {
+			reason: "Should return false when Mirrors is empty",
+			ic: &v1beta1.ImageConfig{
+				Spec: v1beta1.ImageConfigSpec{},
+			},
+			want: false,
+		},
+		"HasMirrors": {
+			reason: "Should return true when Mirrors is set",
+			ic: &v1beta1.ImageConfig{
+				Spec: v1beta1.ImageConfigSpec{
+					Mirrors: []v1beta1.ImageRewrite{
+						{Prefix: "mirror.example.org/crossplane-contrib/"},
+					},
+				},
+			},
+			want: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := hasMirrors(tc.ic)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nhasMirrors(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
 func TestEnqueuePackageRevisionsForImageConfig(t *testing.T) {
 	errBoom := errors.New("boom")
 
@@ -287,6 +323,45 @@ func TestEnqueuePackageRevisionsForImageConfig(t *testing.T) {
 				},
 			},
 		},
+		"SuccessfulWithMirrorsOnly": {
+			reason: "Should enqueue matching package revisions when ImageConfig only has mirrors",
+			params: params{
+				kube: &test.MockClient{
+					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
+						list := obj.(*v1.ProviderRevisionList)
+						list.Items = []v1.ProviderRevision{
+							{
+								ObjectMeta: metav1.ObjectMeta{Name: "provider-aws-abc123"},
+								Spec: v1.ProviderRevisionSpec{
+									PackageRevisionSpec: v1.PackageRevisionSpec{
+										Package: "xpkg.upbound.io/upbound/provider-aws:v1.0.0",
+									},
+								},
+							},
+						}
+						return nil
+					}),
+				},
+				l:   &v1.ProviderRevisionList{},
+				log: logging.NewNopLogger(),
+				obj: &v1beta1.ImageConfig{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-config"},
+					Spec: v1beta1.ImageConfigSpec{
+						MatchImages: []v1beta1.ImageMatch{
+							{Prefix: "xpkg.upbound.io/upbound/"},
+						},
+						Mirrors: []v1beta1.ImageRewrite{
+							{Prefix: "mirror.example.org/upbound/"},
+						},
+					},
+				},
+			},
+			want: want{
+				reqs: []reconcile.Request{
+					{NamespacedName: types.NamespacedName{Name: "provider-aws-abc123"}},
+				},
+			},
+		},
 		"NoMatchingPackageRevisions": {
 			reason: "Should not enqueue when no package revisions match the prefix",
 			params: params{
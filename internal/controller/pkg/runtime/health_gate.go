@@ -0,0 +1,220 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
+
+	v1 "github.com/crossplane/crossplane/v2/apis/pkg/v1"
+	"github.com/crossplane/crossplane/v2/internal/controller/pkg/controller"
+	"github.com/crossplane/crossplane/v2/internal/features"
+)
+
+const (
+	defaultHealthGateObservationPeriod = 10 * time.Minute
+	defaultHealthGateMaxRestarts       = int32(3)
+	defaultHealthGateMaxErrorRate      = int32(5)
+	defaultHealthGateMinRequests       = int64(10)
+
+	// healthGateProbeInterval is how often we probe a package revision's
+	// runtime during its observation period.
+	healthGateProbeInterval = 30 * time.Second
+)
+
+const (
+	errListPods       = "cannot list package runtime pods"
+	errHealthGate     = "cannot probe package revision health gate"
+	errFmtFailedProbe = "package revision failed its health gate: %s"
+)
+
+const reasonHealthGate event.Reason = "HealthGate"
+
+// WithPodReader specifies how the Reconciler should read the pods of a package
+// revision's runtime when probing its health gate. It's used to count container
+// restarts.
+func WithPodReader(c client.Reader) ReconcilerOption {
+	return func(r *Reconciler) {
+		r.pods = c
+	}
+}
+
+// WithFunctionResponseCounter specifies how the Reconciler should count the
+// responses a function revision returns when probing its health gate.
+func WithFunctionResponseCounter(c controller.FunctionResponseCounter) ReconcilerOption {
+	return func(r *Reconciler) {
+		r.responses = c
+	}
+}
+
+// probeHealthGate observes the runtime of the supplied active package revision
+// and records the result in the status of its health gate. It returns how long
+// to wait before probing again, or zero if the revision doesn't need to be
+// probed again.
+func (r *Reconciler) probeHealthGate(ctx context.Context, pr v1.PackageRevisionWithRuntime, b ManifestBuilder, now time.Time) (time.Duration, error) {
+	g := pr.GetHealthGate()
+	if g == nil || !r.features.Enabled(features.EnableAlphaPackageHealthGates) {
+		return 0, nil
+	}
+
+	// A revision is only observed once, after it's first activated. This
+	// means a revision that is activated again after a rollback isn't
+	// observed again.
+	s := pr.GetHealthGateStatus()
+	if s != nil && s.Result != v1.HealthGateObserving {
+		return 0, nil
+	}
+
+	total, failed := r.functionResponses(pr)
+
+	if s == nil {
+		s = &v1.HealthGateStatus{
+			StartTime:              metav1.NewTime(now),
+			Result:                 v1.HealthGateObserving,
+			InitialResponses:       total,
+			InitialFailedResponses: failed,
+		}
+	}
+
+	// Responses are counted by endpoint, and a Function's active revisions
+	// all share an endpoint. Only count the responses returned since we
+	// started observing this revision. Crossplane's counts reset when it
+	// restarts.
+	if total < s.InitialResponses || failed < s.InitialFailedResponses {
+		s.InitialResponses, s.InitialFailedResponses = 0, 0
+	}
+
+	restarts, err := r.restarts(ctx, b)
+	if err != nil {
+		return 0, errors.Wrap(err, errHealthGate)
+	}
+
+	s.Restarts = restarts
+	s.Result, s.Message = evaluateHealthGate(g, now.Sub(s.StartTime.Time), restarts, total-s.InitialResponses, failed-s.InitialFailedResponses)
+	pr.SetHealthGateStatus(s)
+
+	switch s.Result {
+	case v1.HealthGateFailed:
+		r.record.Event(pr, event.Warning(reasonHealthGate, errors.Errorf(errFmtFailedProbe, s.Message)))
+		return 0, nil
+	case v1.HealthGatePassed:
+		r.record.Event(pr, event.Normal(reasonHealthGate, "Package revision passed its health gate"))
+		return 0, nil
+	case v1.HealthGateObserving:
+	}
+
+	period := defaultHealthGateObservationPeriod
+	if g.ObservationPeriod != nil {
+		period = g.ObservationPeriod.Duration
+	}
+
+	return min(healthGateProbeInterval, s.StartTime.Add(period).Sub(now)), nil
+}
+
+// restarts returns how many times the containers of the package revision's
+// runtime pods have restarted.
+func (r *Reconciler) restarts(ctx context.Context, b ManifestBuilder) (int32, error) {
+	if r.pods == nil {
+		return 0, nil
+	}
+
+	d := b.Deployment("")
+	if d.Spec.Selector == nil {
+		return 0, nil
+	}
+
+	l := &corev1.PodList{}
+	// Crossplane may only read pods in its own namespace, where it runs
+	// package runtimes.
+	if err := r.pods.List(ctx, l, client.InNamespace(r.namespace), client.MatchingLabels(d.Spec.Selector.MatchLabels)); err != nil {
+		return 0, errors.Wrap(err, errListPods)
+	}
+
+	restarts := int32(0)
+
+	for _, p := range l.Items {
+		for _, cs := range p.Status.InitContainerStatuses {
+			restarts += cs.RestartCount
+		}
+
+		for _, cs := range p.Status.ContainerStatuses {
+			restarts += cs.RestartCount
+		}
+	}
+
+	return restarts, nil
+}
+
+// functionResponses returns how many responses the supplied function revision's
+// endpoint has returned, and how many of them failed. It returns zero for
+// provider revisions.
+func (r *Reconciler) functionResponses(pr v1.PackageRevisionWithRuntime) (total, failed int64) {
+	fr, ok := pr.(*v1.FunctionRevision)
+	if !ok || r.responses == nil || fr.Status.Endpoint == "" {
+		return 0, 0
+	}
+
+	return r.responses.Responses(fr.Status.Endpoint)
+}
+
+// evaluateHealthGate decides whether a package revision that has been observed
+// for the supplied duration breached the supplied health gate's thresholds. It
+// returns the result, and a message explaining it.
+func evaluateHealthGate(g *v1.HealthGate, age time.Duration, restarts int32, total, failed int64) (v1.HealthGateResult, string) {
+	period := defaultHealthGateObservationPeriod
+	maxRestarts := defaultHealthGateMaxRestarts
+	maxErrorRate := defaultHealthGateMaxErrorRate
+	minRequests := defaultHealthGateMinRequests
+
+	if g.ObservationPeriod != nil {
+		period = g.ObservationPeriod.Duration
+	}
+
+	if g.MaxRestarts != nil {
+		maxRestarts = *g.MaxRestarts
+	}
+
+	if g.MaxErrorRate != nil {
+		maxErrorRate = *g.MaxErrorRate
+	}
+
+	if g.MinRequests != nil {
+		minRequests = *g.MinRequests
+	}
+
+	if restarts > maxRestarts {
+		return v1.HealthGateFailed, fmt.Sprintf("runtime containers restarted %d times, more than the maximum of %d", restarts, maxRestarts)
+	}
+
+	if total > 0 && total >= minRequests && failed*100 > total*int64(maxErrorRate) {
+		return v1.HealthGateFailed, fmt.Sprintf("%d of %d RunFunction responses failed, more than the maximum error rate of %d%%", failed, total, maxErrorRate)
+	}
+
+	if age < period {
+		return v1.HealthGateObserving, fmt.Sprintf("observing package revision for %s", period)
+	}
+
+	return v1.HealthGatePassed, fmt.Sprintf("package revision was healthy for %s", period)
+}
@@ -0,0 +1,346 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtime
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/feature"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/test"
+
+	v1 "github.com/crossplane/crossplane/v2/apis/pkg/v1"
+	"github.com/crossplane/crossplane/v2/internal/controller/pkg/controller"
+	"github.com/crossplane/crossplane/v2/internal/features"
+)
+
+var _ controller.FunctionResponseCounter = &MockResponseCounter{}
+
+type MockResponseCounter struct {
+	Total  int64
+	Failed int64
+}
+
+func (m *MockResponseCounter) Responses(_ string) (total, failed int64) {
+	return m.Total, m.Failed
+}
+
+func (m *MockResponseCounter) Forget(_ string) {}
+
+func TestProbeHealthGate(t *testing.T) {
+	errBoom := errors.New("boom")
+	now := time.Now()
+	started := metav1.NewTime(now.Add(-1 * time.Minute))
+
+	enabled := &feature.Flags{}
+	enabled.Enable(features.EnableAlphaPackageHealthGates)
+
+	pods := func(restarts int32) test.MockListFn {
+		return func(_ context.Context, obj client.ObjectList, opts ...client.ListOption) error {
+			lo := &client.ListOptions{}
+			lo.ApplyOptions(opts)
+
+			if lo.Namespace != "crossplane-system" {
+				return errors.Errorf("want pods listed in namespace crossplane-system, got %q", lo.Namespace)
+			}
+
+			l := obj.(*corev1.PodList)
+			l.Items = []corev1.Pod{{Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{{RestartCount: restarts}}}}}
+
+			return nil
+		}
+	}
+
+	type args struct {
+		features  *feature.Flags
+		pods      client.Reader
+		responses controller.FunctionResponseCounter
+		pr        v1.PackageRevisionWithRuntime
+	}
+
+	type want struct {
+		wait   time.Duration
+		status *v1.HealthGateStatus
+		err    error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"FeatureDisabled": {
+			reason: "We shouldn't probe revisions unless health gates are enabled.",
+			args: args{
+				pr: &v1.ProviderRevision{Spec: v1.ProviderRevisionSpec{PackageRevisionRuntimeSpec: v1.PackageRevisionRuntimeSpec{
+					PackageRuntimeSpec: v1.PackageRuntimeSpec{HealthGate: &v1.HealthGate{}},
+				}}},
+			},
+			want: want{},
+		},
+		"NoHealthGate": {
+			reason: "We shouldn't probe revisions that don't have a health gate.",
+			args: args{
+				features: enabled,
+				pr:       &v1.ProviderRevision{},
+			},
+			want: want{},
+		},
+		"AlreadyPassed": {
+			reason: "We shouldn't probe revisions that already passed their health gate.",
+			args: args{
+				features: enabled,
+				pr: &v1.ProviderRevision{
+					Spec: v1.ProviderRevisionSpec{PackageRevisionRuntimeSpec: v1.PackageRevisionRuntimeSpec{
+						PackageRuntimeSpec: v1.PackageRuntimeSpec{HealthGate: &v1.HealthGate{}},
+					}},
+					Status: v1.ProviderRevisionStatus{PackageRevisionRuntimeStatus: v1.PackageRevisionRuntimeStatus{
+						HealthGate: &v1.HealthGateStatus{StartTime: started, Result: v1.HealthGatePassed},
+					}},
+				},
+			},
+			want: want{
+				status: &v1.HealthGateStatus{StartTime: started, Result: v1.HealthGatePassed},
+			},
+		},
+		"ListPodsError": {
+			reason: "We should return an error if we can't list the revision's pods.",
+			args: args{
+				features: enabled,
+				pods:     &test.MockClient{MockList: test.NewMockListFn(errBoom)},
+				pr: &v1.ProviderRevision{
+					Spec: v1.ProviderRevisionSpec{PackageRevisionRuntimeSpec: v1.PackageRevisionRuntimeSpec{
+						PackageRuntimeSpec: v1.PackageRuntimeSpec{HealthGate: &v1.HealthGate{}},
+					}},
+				},
+			},
+			want: want{
+				err: errors.Wrap(errors.Wrap(errBoom, errListPods), errHealthGate),
+			},
+		},
+		"StartObserving": {
+			reason: "We should start observing a newly activated revision.",
+			args: args{
+				features: enabled,
+				pods:     &test.MockClient{MockList: pods(0)},
+				pr: &v1.ProviderRevision{
+					Spec: v1.ProviderRevisionSpec{PackageRevisionRuntimeSpec: v1.PackageRevisionRuntimeSpec{
+						PackageRuntimeSpec: v1.PackageRuntimeSpec{HealthGate: &v1.HealthGate{}},
+					}},
+				},
+			},
+			want: want{
+				wait: healthGateProbeInterval,
+				status: &v1.HealthGateStatus{
+					StartTime: metav1.NewTime(now),
+					Result:    v1.HealthGateObserving,
+					Message:   "observing package revision for 10m0s",
+				},
+			},
+		},
+		"CrashLooping": {
+			reason: "A revision whose containers restart too many times should fail its health gate.",
+			args: args{
+				features: enabled,
+				pods:     &test.MockClient{MockList: pods(4)},
+				pr: &v1.ProviderRevision{
+					Spec: v1.ProviderRevisionSpec{PackageRevisionRuntimeSpec: v1.PackageRevisionRuntimeSpec{
+						PackageRuntimeSpec: v1.PackageRuntimeSpec{HealthGate: &v1.HealthGate{}},
+					}},
+					Status: v1.ProviderRevisionStatus{PackageRevisionRuntimeStatus: v1.PackageRevisionRuntimeStatus{
+						HealthGate: &v1.HealthGateStatus{StartTime: started, Result: v1.HealthGateObserving},
+					}},
+				},
+			},
+			want: want{
+				status: &v1.HealthGateStatus{
+					StartTime: started,
+					Result:    v1.HealthGateFailed,
+					Message:   "runtime containers restarted 4 times, more than the maximum of 3",
+					Restarts:  4,
+				},
+			},
+		},
+		"FunctionErrors": {
+			reason: "A function revision that returns too many failed responses since it was activated should fail its health gate.",
+			args: args{
+				features:  enabled,
+				pods:      &test.MockClient{MockList: pods(0)},
+				responses: &MockResponseCounter{Total: 120, Failed: 12},
+				pr: &v1.FunctionRevision{
+					Spec: v1.FunctionRevisionSpec{PackageRevisionRuntimeSpec: v1.PackageRevisionRuntimeSpec{
+						PackageRuntimeSpec: v1.PackageRuntimeSpec{HealthGate: &v1.HealthGate{}},
+					}},
+					Status: v1.FunctionRevisionStatus{
+						Endpoint: "dns:///fn.crossplane-system:9443",
+						PackageRevisionRuntimeStatus: v1.PackageRevisionRuntimeStatus{
+							HealthGate: &v1.HealthGateStatus{
+								StartTime:              started,
+								Result:                 v1.HealthGateObserving,
+								InitialResponses:       100,
+								InitialFailedResponses: 10,
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				status: &v1.HealthGateStatus{
+					StartTime:              started,
+					Result:                 v1.HealthGateFailed,
+					Message:                "2 of 20 RunFunction responses failed, more than the maximum error rate of 5%",
+					InitialResponses:       100,
+					InitialFailedResponses: 10,
+				},
+			},
+		},
+		"Passed": {
+			reason: "A revision that stays healthy for the observation period should pass its health gate.",
+			args: args{
+				features: enabled,
+				pods:     &test.MockClient{MockList: pods(1)},
+				pr: &v1.ProviderRevision{
+					Spec: v1.ProviderRevisionSpec{PackageRevisionRuntimeSpec: v1.PackageRevisionRuntimeSpec{
+						PackageRuntimeSpec: v1.PackageRuntimeSpec{HealthGate: &v1.HealthGate{ObservationPeriod: &metav1.Duration{Duration: time.Minute}}},
+					}},
+					Status: v1.ProviderRevisionStatus{PackageRevisionRuntimeStatus: v1.PackageRevisionRuntimeStatus{
+						HealthGate: &v1.HealthGateStatus{StartTime: started, Result: v1.HealthGateObserving},
+					}},
+				},
+			},
+			want: want{
+				status: &v1.HealthGateStatus{
+					StartTime: started,
+					Result:    v1.HealthGatePassed,
+					Message:   "package revision was healthy for 1m0s",
+					Restarts:  1,
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := &Reconciler{
+				namespace: "crossplane-system",
+				features:  tc.args.features,
+				pods:      tc.args.pods,
+				responses: tc.args.responses,
+				record:    event.NewNopRecorder(),
+			}
+
+			tc.args.pr.SetLabels(map[string]string{v1.LabelParentPackage: "cool-pkg"})
+			b := NewDeploymentRuntimeBuilder(tc.args.pr, "crossplane-system")
+
+			wait, err := r.probeHealthGate(context.Background(), tc.args.pr, b, now)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nr.probeHealthGate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+
+			if diff := cmp.Diff(tc.want.wait, wait); diff != "" {
+				t.Errorf("\n%s\nr.probeHealthGate(...): -want wait, +got wait:\n%s", tc.reason, diff)
+			}
+
+			if diff := cmp.Diff(tc.want.status, tc.args.pr.GetHealthGateStatus()); diff != "" {
+				t.Errorf("\n%s\nr.probeHealthGate(...): -want status, +got status:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestEvaluateHealthGate(t *testing.T) {
+	type args struct {
+		g        *v1.HealthGate
+		age      time.Duration
+		restarts int32
+		total    int64
+		failed   int64
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   v1.HealthGateResult
+	}{
+		"Observing": {
+			reason: "A revision should be observed until the observation period is over.",
+			args: args{
+				g:   &v1.HealthGate{},
+				age: time.Minute,
+			},
+			want: v1.HealthGateObserving,
+		},
+		"TooManyRestarts": {
+			reason: "A revision whose containers restarted too many times should fail.",
+			args: args{
+				g:        &v1.HealthGate{MaxRestarts: ptr.To[int32](0)},
+				age:      time.Minute,
+				restarts: 1,
+			},
+			want: v1.HealthGateFailed,
+		},
+		"TooFewRequests": {
+			reason: "A function's error rate shouldn't be considered until it has served enough requests.",
+			args: args{
+				g:      &v1.HealthGate{},
+				age:    time.Hour,
+				total:  defaultHealthGateMinRequests - 1,
+				failed: defaultHealthGateMinRequests - 1,
+			},
+			want: v1.HealthGatePassed,
+		},
+		"ErrorRateTooHigh": {
+			reason: "A function whose error rate is above the maximum should fail.",
+			args: args{
+				g:      &v1.HealthGate{MaxErrorRate: ptr.To[int32](10)},
+				age:    time.Minute,
+				total:  100,
+				failed: 11,
+			},
+			want: v1.HealthGateFailed,
+		},
+		"Passed": {
+			reason: "A revision that didn't breach any thresholds during the observation period should pass.",
+			args: args{
+				g:        &v1.HealthGate{ObservationPeriod: &metav1.Duration{Duration: 5 * time.Minute}},
+				age:      5 * time.Minute,
+				restarts: 3,
+				total:    100,
+				failed:   5,
+			},
+			want: v1.HealthGatePassed,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, _ := evaluateHealthGate(tc.args.g, tc.args.age, tc.args.restarts, tc.args.total, tc.args.failed)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nevaluateHealthGate(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -157,6 +157,10 @@ type Reconciler struct {
 	serviceAccount string
 	pkgConfig      xpkg.ConfigStore
 
+	// pods and responses are used to probe health gates.
+	pods      client.Reader
+	responses controller.FunctionResponseCounter
+
 	newPackageRevisionWithRuntime func() v1.PackageRevisionWithRuntime
 }
 
@@ -189,6 +193,9 @@ func SetupProviderRevision(mgr ctrl.Manager, o controller.Options) error {
 		WithFeatureFlags(o.Features),
 		WithDeploymentSelectorMigrator(NewDeletingDeploymentSelectorMigrator(mgr.GetClient(), log)),
 		WithConfigStore(xpkg.NewImageConfigStore(mgr.GetClient(), o.Namespace)),
+		// We don't want to cache every pod in the cluster just to count
+		// the restarts of a few.
+		WithPodReader(mgr.GetAPIReader()),
 	)
 
 	return cb.WithOptions(o.ForControllerRuntime()).
@@ -223,6 +230,10 @@ func SetupFunctionRevision(mgr ctrl.Manager, o controller.Options) error {
 		WithRuntimeHooks(NewFunctionHooks(mgr.GetClient())),
 		WithFeatureFlags(o.Features),
 		WithConfigStore(xpkg.NewImageConfigStore(mgr.GetClient(), o.Namespace)),
+		// We don't want to cache every pod in the cluster just to count
+		// the restarts of a few.
+		WithPodReader(mgr.GetAPIReader()),
+		WithFunctionResponseCounter(o.FunctionResponses),
 	)
 
 	return cb.WithOptions(o.ForControllerRuntime()).
@@ -341,8 +352,9 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 
 	builder := NewDeploymentRuntimeBuilder(pr, r.namespace, opts...)
 
-	// Deactivate revision if it is inactive.
-	if pr.GetDesiredState() == v1.PackageRevisionInactive {
+	// Deactivate revision if it is inactive. A canary is inactive, but runs
+	// alongside the active revision until it's promoted or rolled back.
+	if pr.GetDesiredState() == v1.PackageRevisionInactive && !v1.IsCanary(pr) {
 		if err := r.runtimeHook.Deactivate(ctx, pr, builder); err != nil {
 			err := errors.Wrap(err, "failed to run deactivation hook")
 			r.log.Info("Error", "error", err)
@@ -379,13 +391,37 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 		return reconcile.Result{}, err
 	}
 
+	// Probe the health gate of a newly activated revision. We do this before
+	// we wait for the revision to be healthy, because a revision that is
+	// crash looping may never become healthy. Canaries are analyzed by the
+	// package manager instead.
+	probeAfter := time.Duration(0)
+	if !v1.IsCanary(pr) {
+		probeAfter, err = r.probeHealthGate(ctx, pr, builder, time.Now())
+		if err != nil {
+			status.MarkConditions(v1.RuntimeUnhealthy().WithMessage(err.Error()))
+
+			_ = r.client.Status().Update(ctx, pr)
+			r.record.Event(pr, event.Warning(reasonHealthGate, err))
+
+			return reconcile.Result{}, err
+		}
+	}
+
+	// The package manager will roll back a revision that failed its health
+	// gate.
+	if s := pr.GetHealthGateStatus(); s != nil && s.Result == v1.HealthGateFailed {
+		status.MarkConditions(v1.RuntimeUnhealthy().WithMessage(errors.Errorf(errFmtFailedProbe, s.Message).Error()))
+		return reconcile.Result{}, errors.Wrap(r.client.Status().Update(ctx, pr), errUpdateStatus)
+	}
+
 	// Wait for the package revision to be healthy before running the
 	// post-establish hooks.
 	if pr.GetCondition(v1.TypeRevisionHealthy).Status != corev1.ConditionTrue {
 		log.Debug("Waiting for the package revision to be healthy before running post-establish hooks")
 		status.MarkConditions(v1.RuntimeUnhealthy().WithMessage("Package revision is not healthy yet"))
 
-		return reconcile.Result{}, errors.Wrap(r.client.Status().Update(ctx, pr), errUpdateStatus)
+		return reconcile.Result{RequeueAfter: probeAfter}, errors.Wrap(r.client.Status().Update(ctx, pr), errUpdateStatus)
 	}
 
 	// Run post-establish hooks
@@ -411,7 +447,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 
 	status.MarkConditions(v1.RuntimeHealthy())
 
-	return reconcile.Result{Requeue: false}, errors.Wrap(r.client.Status().Update(ctx, pr), errUpdateStatus)
+	return reconcile.Result{RequeueAfter: probeAfter}, errors.Wrap(r.client.Status().Update(ctx, pr), errUpdateStatus)
 }
 
 func (r *Reconciler) builderOptions(ctx context.Context, pwr v1.PackageRevisionWithRuntime) ([]BuilderOption, error) {
@@ -63,7 +63,8 @@ func NewFunctionHooks(client client.Client) *FunctionHooks {
 
 // Pre performs operations meant to happen before establishing objects.
 func (h *FunctionHooks) Pre(ctx context.Context, pr v1.PackageRevisionWithRuntime, build ManifestBuilder) error {
-	if pr.GetDesiredState() != v1.PackageRevisionActive {
+	canary := v1.IsCanary(pr)
+	if pr.GetDesiredState() != v1.PackageRevisionActive && !canary {
 		return nil
 	}
 
@@ -77,7 +78,7 @@ func (h *FunctionHooks) Pre(ctx context.Context, pr v1.PackageRevisionWithRuntim
 	// generating certificates requires the service to be defined. This is why
 	// we're creating the service here but service account and deployment in the
 	// post-establish.
-	svc := build.Service(
+	so := []ServiceOverride{
 		// We want a headless service so that our gRPC client (i.e. the Crossplane
 		// FunctionComposer) can load balance across the endpoints.
 		// https://kubernetes.io/docs/concepts/services-networking/service/#headless-services
@@ -90,7 +91,18 @@ func (h *FunctionHooks) Pre(ctx context.Context, pr v1.PackageRevisionWithRuntim
 				TargetPort:  intstr.FromString(GRPCPortName),
 				AppProtocol: &AppProtocolTLS,
 			},
-		}))
+		}),
+	}
+
+	// A canary runs alongside the active revision, so it can't share the
+	// Function's Service or its server certificate. It gets its own, named
+	// after the revision.
+	if canary {
+		pr.SetObservedTLSServerSecretName(v1.GetSecretNameWithSuffix(pr.GetName(), v1.TLSServerSecretNameSuffix))
+		so = append(so, ServiceWithName(pr.GetName()))
+	}
+
+	svc := build.Service(so...)
 	if err := h.client.Applicator.Apply(ctx, svc); err != nil {
 		return errors.Wrap(err, errApplyFunctionService)
 	}
@@ -119,7 +131,7 @@ func (h *FunctionHooks) Pre(ctx context.Context, pr v1.PackageRevisionWithRuntim
 
 // Post performs operations meant to happen after establishing objects.
 func (h *FunctionHooks) Post(ctx context.Context, pr v1.PackageRevisionWithRuntime, build ManifestBuilder) error {
-	if pr.GetDesiredState() != v1.PackageRevisionActive {
+	if pr.GetDesiredState() != v1.PackageRevisionActive && !v1.IsCanary(pr) {
 		return nil
 	}
 
@@ -179,7 +191,8 @@ func (h *FunctionHooks) Deactivate(ctx context.Context, _ v1.PackageRevisionWith
 	// deleted if they are not used by any other package revisions.
 
 	// NOTE(ezgidemirel): Service and secret are created per package. Therefore,
-	// we're not deleting them here.
+	// we're not deleting them here. A canary's Service and secret are created
+	// per revision, and are garbage collected with it.
 	return nil
 }
 
@@ -0,0 +1,396 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package signature
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/authn/k8schain"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/notaryproject/notation-go"
+	"github.com/notaryproject/notation-go/registry"
+	"github.com/notaryproject/notation-go/verifier"
+	"github.com/notaryproject/notation-go/verifier/trustpolicy"
+	"github.com/notaryproject/notation-go/verifier/truststore"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+
+	"github.com/crossplane/crossplane/v2/apis/pkg/v1beta1"
+)
+
+// maxNotationSignatures is the maximum number of Notation signatures we'll
+// try to verify for an image.
+const maxNotationSignatures = 50
+
+// maxNotationEnvelopeSize is the maximum size of a Notation signature
+// envelope we'll read.
+const maxNotationEnvelopeSize = 4 * 1024 * 1024
+
+// An UnenforcedError is a signature verification failure that the image's
+// verification policy records, but doesn't enforce. For example a Notation
+// trust policy at the audit level enforces the integrity of signatures, but
+// not their authenticity.
+type UnenforcedError struct {
+	error
+}
+
+// Unwrap returns the verification failure that wasn't enforced.
+func (e *UnenforcedError) Unwrap() error {
+	return e.error
+}
+
+// IsUnenforced returns true if the supplied error is a verification failure
+// that wasn't enforced.
+func IsUnenforced(err error) bool {
+	ue := &UnenforcedError{}
+	return errors.As(err, &ue)
+}
+
+// notationTrustStores are the certificates in each trust store, keyed by
+// type:name. They satisfy Notation's truststore.X509TrustStore.
+type notationTrustStores map[string][]*x509.Certificate
+
+// GetCertificates returns the certificates in the supplied trust store.
+func (s notationTrustStores) GetCertificates(_ context.Context, t truststore.Type, name string) ([]*x509.Certificate, error) {
+	certs, ok := s[string(t)+":"+name]
+	if !ok {
+		return nil, errors.Errorf("trust store %s:%s is not configured", t, name)
+	}
+
+	return certs, nil
+}
+
+// NewNotationValidator returns a new NotationValidator.
+func NewNotationValidator(c client.Reader, k kubernetes.Interface, namespace, serviceAccount string) *NotationValidator {
+	return &NotationValidator{
+		client:         c,
+		clientset:      k,
+		namespace:      namespace,
+		serviceAccount: serviceAccount,
+	}
+}
+
+// NotationValidator validates image signatures produced by Notation (the
+// Notary Project), against trust policies and trust stores read from
+// ConfigMaps and Secrets. Signatures are verified by Notation's own verifier,
+// including certificate revocation and RFC 3161 timestamp checks.
+type NotationValidator struct {
+	client         client.Reader
+	clientset      kubernetes.Interface
+	namespace      string
+	serviceAccount string
+}
+
+// Validate validates the image signature.
+func (n *NotationValidator) Validate(ctx context.Context, ref name.Reference, config *v1beta1.ImageVerification, pullSecrets ...string) error {
+	if config.Provider != v1beta1.ImageVerificationProviderNotation || config.Notation == nil {
+		return errors.New("unsupported image verification provider")
+	}
+
+	doc, err := n.trustPolicy(ctx, config.Notation.TrustPolicyRef)
+	if err != nil {
+		return errors.Wrap(err, "cannot load trust policy")
+	}
+
+	stores, err := n.trustStores(ctx, config.Notation.TrustStores)
+	if err != nil {
+		return errors.Wrap(err, "cannot load trust stores")
+	}
+
+	// The verifier checks certificate revocation using OCSP and CRLs.
+	v, err := verifier.NewWithOptions(EnforceAuthenticTimestamp(doc), stores, nil, verifier.VerifierOptions{})
+	if err != nil {
+		return errors.Wrap(err, "cannot create Notation verifier")
+	}
+
+	auth, err := k8schain.New(ctx, n.clientset, k8schain.Options{
+		Namespace:          n.namespace,
+		ServiceAccountName: n.serviceAccount,
+		ImagePullSecrets:   pullSecrets,
+	})
+	if err != nil {
+		return errors.Wrap(err, "cannot create k8s auth chain")
+	}
+
+	repo := &notationRepository{repo: ref.Context(), opts: []remote.Option{remote.WithAuthFromKeychain(auth), remote.WithContext(ctx)}}
+
+	// VerifyNotation returns an UnenforcedError if the signature failed
+	// checks that the trust policy's verification level only records.
+	return VerifyNotation(ctx, ref, v, repo)
+}
+
+func (n *NotationValidator) trustPolicy(ctx context.Context, ref v1beta1.LocalConfigMapKeySelector) (*trustpolicy.Document, error) {
+	cm := &corev1.ConfigMap{}
+	if err := n.client.Get(ctx, types.NamespacedName{Namespace: n.namespace, Name: ref.Name}, cm); err != nil {
+		return nil, errors.Wrapf(err, "cannot get configmap %q", ref.Name)
+	}
+
+	v, ok := cm.Data[ref.Key]
+	if !ok {
+		return nil, errors.Errorf("no data found for key %q in configmap %q", ref.Key, ref.Name)
+	}
+
+	doc := &trustpolicy.Document{}
+	if err := json.Unmarshal([]byte(v), doc); err != nil {
+		return nil, errors.Wrapf(err, "cannot parse trust policy in configmap %q", ref.Name)
+	}
+
+	if err := doc.Validate(); err != nil {
+		return nil, errors.Wrapf(err, "invalid trust policy in configmap %q", ref.Name)
+	}
+
+	return doc, nil
+}
+
+func (n *NotationValidator) trustStores(ctx context.Context, ts []v1beta1.NotationTrustStore) (notationTrustStores, error) {
+	out := notationTrustStores{}
+
+	for _, s := range ts {
+		var data []byte
+
+		switch {
+		case s.SecretRef != nil:
+			sec := &corev1.Secret{}
+			if err := n.client.Get(ctx, types.NamespacedName{Namespace: n.namespace, Name: s.SecretRef.Name}, sec); err != nil {
+				return nil, errors.Wrapf(err, "cannot get secret %q", s.SecretRef.Name)
+			}
+
+			data = sec.Data[s.SecretRef.Key]
+		case s.ConfigMapRef != nil:
+			cm := &corev1.ConfigMap{}
+			if err := n.client.Get(ctx, types.NamespacedName{Namespace: n.namespace, Name: s.ConfigMapRef.Name}, cm); err != nil {
+				return nil, errors.Wrapf(err, "cannot get configmap %q", s.ConfigMapRef.Name)
+			}
+
+			data = []byte(cm.Data[s.ConfigMapRef.Key])
+		}
+
+		certs, err := parseCertificates(data)
+		if err != nil {
+			return nil, errors.Wrapf(err, "trust store %q", s.Name)
+		}
+
+		if len(certs) == 0 {
+			return nil, errors.Errorf("trust store %q contains no certificates", s.Name)
+		}
+
+		t := s.Type
+		if t == "" {
+			t = v1beta1.NotationTrustStoreTypeCA
+		}
+
+		key := string(t) + ":" + s.Name
+		out[key] = append(out[key], certs...)
+	}
+
+	return out, nil
+}
+
+// EnforceAuthenticTimestamp returns a copy of the supplied trust policy
+// document that enforces authentic timestamps at every verification level
+// except skip.
+//
+// Notation's permissive and audit levels only log a signing certificate chain
+// that isn't valid now. Under the notary.x509 signing scheme the signing time
+// is claimed by the signer, so anyone holding an expired or retired key could
+// backdate a signature. Enforcing authentic timestamps means a signature made
+// by a certificate chain that isn't valid now is only accepted if it has a
+// countersignature from a trusted timestamp authority (TSA) proving it was
+// made while the chain was valid.
+func EnforceAuthenticTimestamp(doc *trustpolicy.Document) *trustpolicy.Document {
+	out := &trustpolicy.Document{Version: doc.Version, TrustPolicies: make([]trustpolicy.TrustPolicy, len(doc.TrustPolicies))}
+
+	for i, p := range doc.TrustPolicies {
+		if p.SignatureVerification.VerificationLevel != trustpolicy.LevelSkip.Name {
+			o := make(map[trustpolicy.ValidationType]trustpolicy.ValidationAction, len(p.SignatureVerification.Override)+1)
+			for k, v := range p.SignatureVerification.Override {
+				o[k] = v
+			}
+			o[trustpolicy.TypeAuthenticTimestamp] = trustpolicy.ActionEnforce
+			p.SignatureVerification.Override = o
+		}
+
+		out.TrustPolicies[i] = p
+	}
+
+	return out
+}
+
+// VerifyNotation verifies that the supplied image has at least one Notation
+// signature that satisfies the verifier's trust policy. It returns an
+// UnenforcedError if the signature satisfies the checks the trust policy
+// enforces, but failed checks the trust policy only logs.
+func VerifyNotation(ctx context.Context, ref name.Reference, v notation.Verifier, repo registry.Repository) error {
+	// Notation only verifies artifacts referenced by digest.
+	desc, err := repo.Resolve(ctx, ref.Identifier())
+	if err != nil {
+		return errors.Wrapf(err, "cannot resolve %s", ref)
+	}
+
+	_, outcomes, err := notation.Verify(ctx, v, repo, notation.VerifyOptions{
+		ArtifactReference:    ref.Context().Name() + "@" + desc.Digest.String(),
+		MaxSignatureAttempts: maxNotationSignatures,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "cannot verify Notation signatures of %s", ref)
+	}
+
+	var errs []error
+
+	for _, o := range outcomes {
+		for _, r := range o.VerificationResults {
+			if r.Error != nil && r.Action == trustpolicy.ActionLog {
+				errs = append(errs, errors.Wrapf(r.Error, "%s check", r.Type))
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return &UnenforcedError{error: errors.Join(errs...)}
+	}
+
+	return nil
+}
+
+// A notationRepository reads Notation signatures from an OCI repository. It
+// satisfies Notation's registry.Repository using go-containerregistry, so
+// signatures are fetched with the same credentials as packages.
+type notationRepository struct {
+	repo name.Repository
+	opts []remote.Option
+}
+
+// Resolve the supplied tag or digest to a manifest descriptor.
+func (r *notationRepository) Resolve(_ context.Context, reference string) (ocispec.Descriptor, error) {
+	var ref name.Reference = r.repo.Tag(reference)
+	if strings.Contains(reference, ":") {
+		ref = r.repo.Digest(reference)
+	}
+
+	d, err := remote.Head(ref, r.opts...)
+	if err != nil {
+		return ocispec.Descriptor{}, errors.Wrap(err, "cannot get image descriptor")
+	}
+
+	return ocispec.Descriptor{MediaType: string(d.MediaType), Digest: digest.Digest(d.Digest.String()), Size: d.Size}, nil
+}
+
+// ListSignatures passes the Notation signatures that refer to the supplied
+// manifest to the supplied function.
+func (r *notationRepository) ListSignatures(_ context.Context, desc ocispec.Descriptor, fn func(signatureManifests []ocispec.Descriptor) error) error {
+	idx, err := remote.Referrers(r.repo.Digest(desc.Digest.String()), r.opts...)
+	if err != nil {
+		return errors.Wrap(err, "cannot list image referrers")
+	}
+
+	im, err := idx.IndexManifest()
+	if err != nil {
+		return errors.Wrap(err, "cannot read image referrers")
+	}
+
+	sigs := make([]ocispec.Descriptor, 0, len(im.Manifests))
+	for _, m := range im.Manifests {
+		if m.ArtifactType != registry.ArtifactTypeNotation {
+			continue
+		}
+		sigs = append(sigs, ocispec.Descriptor{MediaType: string(m.MediaType), ArtifactType: m.ArtifactType, Digest: digest.Digest(m.Digest.String()), Size: m.Size})
+	}
+
+	return fn(sigs)
+}
+
+// FetchSignatureBlob returns the signature envelope of the supplied signature
+// manifest, and the envelope's descriptor.
+func (r *notationRepository) FetchSignatureBlob(_ context.Context, desc ocispec.Descriptor) ([]byte, ocispec.Descriptor, error) {
+	img, err := remote.Image(r.repo.Digest(desc.Digest.String()), r.opts...)
+	if err != nil {
+		return nil, ocispec.Descriptor{}, errors.Wrap(err, "cannot fetch signature")
+	}
+
+	m, err := img.Manifest()
+	if err != nil {
+		return nil, ocispec.Descriptor{}, errors.Wrap(err, "cannot get signature manifest")
+	}
+
+	if len(m.Layers) != 1 {
+		return nil, ocispec.Descriptor{}, errors.Errorf("signature must have exactly one layer, found %d", len(m.Layers))
+	}
+
+	l := m.Layers[0]
+	if l.Size > maxNotationEnvelopeSize {
+		return nil, ocispec.Descriptor{}, errors.Errorf("signature envelope is too large: %d bytes", l.Size)
+	}
+
+	layer, err := img.LayerByDigest(l.Digest)
+	if err != nil {
+		return nil, ocispec.Descriptor{}, errors.Wrap(err, "cannot get signature envelope")
+	}
+
+	rc, err := layer.Compressed()
+	if err != nil {
+		return nil, ocispec.Descriptor{}, errors.Wrap(err, "cannot read signature envelope")
+	}
+	defer rc.Close() //nolint:errcheck // Only reading.
+
+	raw, err := io.ReadAll(io.LimitReader(rc, maxNotationEnvelopeSize))
+	if err != nil {
+		return nil, ocispec.Descriptor{}, errors.Wrap(err, "cannot read signature envelope")
+	}
+
+	return raw, ocispec.Descriptor{MediaType: string(l.MediaType), Digest: digest.Digest(l.Digest.String()), Size: l.Size}, nil
+}
+
+// PushSignature isn't supported. We only verify signatures.
+func (r *notationRepository) PushSignature(_ context.Context, _ string, _ []byte, _ ocispec.Descriptor, _ map[string]string) (ocispec.Descriptor, ocispec.Descriptor, error) {
+	return ocispec.Descriptor{}, ocispec.Descriptor{}, errors.New("pushing Notation signatures is not supported")
+}
+
+func parseCertificates(data []byte) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+
+	for {
+		var b *pem.Block
+
+		b, data = pem.Decode(data)
+		if b == nil {
+			return certs, nil
+		}
+
+		if b.Type != "CERTIFICATE" {
+			continue
+		}
+
+		c, err := x509.ParseCertificate(b.Bytes)
+		if err != nil {
+			return nil, errors.Wrap(err, "cannot parse certificate")
+		}
+
+		certs = append(certs, c)
+	}
+}
@@ -0,0 +1,517 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package signature
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	ociv1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/notaryproject/notation-core-go/signature"
+	"github.com/notaryproject/notation-core-go/signature/jws"
+	notationregistry "github.com/notaryproject/notation-go/registry"
+	"github.com/notaryproject/notation-go/verifier/trustpolicy"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/v2/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/test"
+
+	"github.com/crossplane/crossplane/v2/apis/pkg/v1beta1"
+)
+
+type notationSigner struct {
+	signer signature.LocalSigner
+}
+
+func newCA(t *testing.T, cn string) (*x509.Certificate, *ecdsa.PrivateKey, []byte) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: cn},
+		NotBefore:             time.Now().Add(-24 * time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return c, key, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+// newSigner returns a signer with a certificate issued by the supplied CA,
+// valid between the supplied times.
+func newSigner(t *testing.T, ca *x509.Certificate, caKey crypto.Signer, subject pkix.Name, notBefore, notAfter time.Time) *notationSigner {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      subject,
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca, &key.PublicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ls, err := signature.NewLocalSigner([]*x509.Certificate{c, ca}, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &notationSigner{signer: ls}
+}
+
+// Sign returns a JWS signature envelope for the supplied target. The supplied
+// functions may modify the sign request.
+func (s *notationSigner) Sign(t *testing.T, target ociv1.Descriptor, fns ...func(r *signature.SignRequest)) []byte {
+	t.Helper()
+
+	p, _ := json.Marshal(map[string]any{"targetArtifact": map[string]any{
+		"mediaType": target.MediaType,
+		"digest":    target.Digest.String(),
+		"size":      target.Size,
+	}})
+
+	req := &signature.SignRequest{
+		Payload:       signature.Payload{ContentType: "application/vnd.cncf.notary.payload.v1+json", Content: p},
+		Signer:        s.signer,
+		SigningTime:   time.Now(),
+		SigningScheme: signature.SigningSchemeX509,
+	}
+	for _, fn := range fns {
+		fn(req)
+	}
+
+	env, err := signature.NewEnvelope(jws.MediaTypeEnvelope)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := env.Sign(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return b
+}
+
+// pushSignature pushes the supplied signature envelope as a referrer of the
+// supplied subject.
+func pushSignature(t *testing.T, repo name.Repository, subject ociv1.Descriptor, envelope []byte) {
+	t.Helper()
+
+	img := mutate.MediaType(empty.Image, types.OCIManifestSchema1)
+	img = mutate.ConfigMediaType(img, notationregistry.ArtifactTypeNotation)
+
+	img, err := mutate.AppendLayers(img, static.NewLayer(envelope, jws.MediaTypeEnvelope))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	img = mutate.Subject(img, subject).(ociv1.Image) //nolint:forcetypeassert // Subject returns the type it's passed.
+
+	d, err := img.Digest()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := remote.Write(repo.Digest(d.String()), img); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func trustPolicy(level string, stores []string, identities ...string) string {
+	p := trustpolicy.Document{
+		Version: "1.0",
+		TrustPolicies: []trustpolicy.TrustPolicy{{
+			Name:                  "crossplane",
+			RegistryScopes:        []string{"*"},
+			SignatureVerification: trustpolicy.SignatureVerification{VerificationLevel: level},
+			TrustStores:           stores,
+			TrustedIdentities:     identities,
+		}},
+	}
+
+	b, _ := json.Marshal(p)
+
+	return string(b)
+}
+
+func TestNotationValidator(t *testing.T) {
+	srv := httptest.NewServer(registry.New(registry.WithReferrersSupport(true)))
+	defer srv.Close()
+
+	host := strings.TrimPrefix(srv.URL, "http://")
+
+	ca, caKey, caPEM := newCA(t, "Acme Root CA")
+	_, _, otherPEM := newCA(t, "Other Root CA")
+
+	subject := pkix.Name{Country: []string{"US"}, Province: []string{"WA"}, Organization: []string{"Acme"}, CommonName: "Acme Signer"}
+	signer := newSigner(t, ca, caKey, subject, time.Now().Add(-time.Hour), time.Now().Add(time.Hour))
+
+	// A signer whose certificate expired an hour ago.
+	retired := newSigner(t, ca, caKey, subject, time.Now().Add(-3*time.Hour), time.Now().Add(-time.Hour))
+
+	push := func(t *testing.T, repo string) (name.Reference, ociv1.Descriptor) {
+		t.Helper()
+
+		ref, err := name.ParseReference(host + "/" + repo + ":v1")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		img, err := random.Image(64, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := remote.Write(ref, img); err != nil {
+			t.Fatal(err)
+		}
+
+		desc, err := remote.Head(ref)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return ref, *desc
+	}
+
+	config := &v1beta1.ImageVerification{
+		Provider: v1beta1.ImageVerificationProviderNotation,
+		Notation: &v1beta1.NotationVerificationConfig{
+			TrustPolicyRef: v1beta1.LocalConfigMapKeySelector{Name: "notation", Key: "trustpolicy.json"},
+			TrustStores: []v1beta1.NotationTrustStore{{
+				Type:      v1beta1.NotationTrustStoreTypeCA,
+				Name:      "acme",
+				SecretRef: &v1beta1.LocalSecretKeySelector{LocalSecretReference: xpv1.LocalSecretReference{Name: "acme"}, Key: "ca.crt"},
+			}},
+		},
+	}
+
+	type args struct {
+		policy string
+		store  []byte
+		sign   func(t *testing.T, ref name.Reference, desc ociv1.Descriptor)
+	}
+
+	// What Validate should return.
+	const (
+		valid      = "Valid"
+		unenforced = "Unenforced"
+		invalid    = "Invalid"
+	)
+
+	signedBy := func(s *notationSigner, fns ...func(r *signature.SignRequest)) func(t *testing.T, ref name.Reference, desc ociv1.Descriptor) {
+		return func(t *testing.T, ref name.Reference, desc ociv1.Descriptor) {
+			t.Helper()
+			pushSignature(t, ref.Context(), desc, s.Sign(t, desc, fns...))
+		}
+	}
+
+	signed := func(fns ...func(r *signature.SignRequest)) func(t *testing.T, ref name.Reference, desc ociv1.Descriptor) {
+		return signedBy(signer, fns...)
+	}
+
+	wrongTarget := func(t *testing.T, ref name.Reference, desc ociv1.Descriptor) {
+		t.Helper()
+		other := desc
+		other.Digest = ociv1.Hash{Algorithm: "sha256", Hex: strings.Repeat("0", 64)}
+		pushSignature(t, ref.Context(), desc, signer.Sign(t, other))
+	}
+
+	expired := func(r *signature.SignRequest) {
+		r.SigningTime = time.Now().Add(-10 * time.Minute)
+		r.Expiry = time.Now().Add(-time.Minute)
+	}
+
+	// Claim the signature was made while the retired certificate was valid.
+	backdated := func(r *signature.SignRequest) {
+		r.SigningTime = time.Now().Add(-2 * time.Hour)
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   string
+	}{
+		"Valid": {
+			reason: "An image signed by a trusted identity with a certificate issued by a trusted CA should be valid.",
+			args: args{
+				policy: trustPolicy("strict", []string{"ca:acme"}, "x509.subject: C=US, ST=WA, O=Acme"),
+				store:  caPEM,
+				sign:   signed(),
+			},
+			want: valid,
+		},
+		"NoSignatures": {
+			reason: "An unsigned image should be invalid.",
+			args: args{
+				policy: trustPolicy("strict", []string{"ca:acme"}, "*"),
+				store:  caPEM,
+				sign:   func(_ *testing.T, _ name.Reference, _ ociv1.Descriptor) {},
+			},
+			want: invalid,
+		},
+		"UntrustedCA": {
+			reason: "An image signed with a certificate that wasn't issued by a trusted CA should be invalid.",
+			args: args{
+				policy: trustPolicy("strict", []string{"ca:acme"}, "*"),
+				store:  otherPEM,
+				sign:   signed(),
+			},
+			want: invalid,
+		},
+		"UntrustedIdentity": {
+			reason: "An image signed by an identity the trust policy doesn't trust should be invalid.",
+			args: args{
+				policy: trustPolicy("strict", []string{"ca:acme"}, "x509.subject: C=US, ST=WA, O=Evil"),
+				store:  caPEM,
+				sign:   signed(),
+			},
+			want: invalid,
+		},
+		"WrongTarget": {
+			reason: "A signature of a different artifact should be invalid, even if it's attached to the image.",
+			args: args{
+				policy: trustPolicy("strict", []string{"ca:acme"}, "*"),
+				store:  caPEM,
+				sign:   wrongTarget,
+			},
+			want: invalid,
+		},
+		"AuditUntrustedCA": {
+			reason: "The audit level shouldn't enforce the authenticity of signatures, but should report it.",
+			args: args{
+				policy: trustPolicy("audit", []string{"ca:acme"}, "*"),
+				store:  otherPEM,
+				sign:   signed(),
+			},
+			want: unenforced,
+		},
+		"AuditNoSignatures": {
+			reason: "The audit level should still require a signature.",
+			args: args{
+				policy: trustPolicy("audit", []string{"ca:acme"}, "*"),
+				store:  caPEM,
+				sign:   func(_ *testing.T, _ name.Reference, _ ociv1.Descriptor) {},
+			},
+			want: invalid,
+		},
+		"AuditWrongTarget": {
+			reason: "The audit level should enforce the integrity of signatures.",
+			args: args{
+				policy: trustPolicy("audit", []string{"ca:acme"}, "*"),
+				store:  caPEM,
+				sign:   wrongTarget,
+			},
+			want: invalid,
+		},
+		"StrictExpired": {
+			reason: "The strict level should enforce signature expiry.",
+			args: args{
+				policy: trustPolicy("strict", []string{"ca:acme"}, "*"),
+				store:  caPEM,
+				sign:   signed(expired),
+			},
+			want: invalid,
+		},
+		"PermissiveExpired": {
+			reason: "The permissive level shouldn't enforce signature expiry, but should report it.",
+			args: args{
+				policy: trustPolicy("permissive", []string{"ca:acme"}, "*"),
+				store:  caPEM,
+				sign:   signed(expired),
+			},
+			want: unenforced,
+		},
+		"PermissiveUntrustedIdentity": {
+			reason: "The permissive level should enforce the authenticity of signatures.",
+			args: args{
+				policy: trustPolicy("permissive", []string{"ca:acme"}, "x509.subject: C=US, ST=WA, O=Evil"),
+				store:  caPEM,
+				sign:   signed(),
+			},
+			want: invalid,
+		},
+		"PermissiveBackdatedExpiredCertificate": {
+			reason: "A signature made with an expired certificate should be invalid without an authentic timestamp, even if it claims to be made while the certificate was valid.",
+			args: args{
+				policy: trustPolicy("permissive", []string{"ca:acme"}, "*"),
+				store:  caPEM,
+				sign:   signedBy(retired, backdated),
+			},
+			want: invalid,
+		},
+		"AuditBackdatedExpiredCertificate": {
+			reason: "The audit level should also reject a signature made with an expired certificate without an authentic timestamp.",
+			args: args{
+				policy: trustPolicy("audit", []string{"ca:acme"}, "*"),
+				store:  caPEM,
+				sign:   signedBy(retired, backdated),
+			},
+			want: invalid,
+		},
+		"Skip": {
+			reason: "Verification should be skipped at the skip level.",
+			args: args{
+				policy: trustPolicy("skip", nil),
+				store:  caPEM,
+				sign:   func(_ *testing.T, _ name.Reference, _ ociv1.Descriptor) {},
+			},
+			want: valid,
+		},
+		"UnknownTrustStore": {
+			reason: "A trust policy that references a trust store that isn't configured should be invalid.",
+			args: args{
+				policy: trustPolicy("strict", []string{"ca:unknown"}, "*"),
+				store:  caPEM,
+				sign:   signed(),
+			},
+			want: invalid,
+		},
+		"InvalidTrustPolicy": {
+			reason: "A trust policy document that Notation considers invalid should be invalid.",
+			args: args{
+				policy: `{"version":"1.0","trustPolicies":[{"name":"a","registryScopes":["*"],"signatureVerification":{"level":"strict"},"trustStores":["ca:acme"],"trustedIdentities":["*"]},{"name":"b","registryScopes":["*"],"signatureVerification":{"level":"strict"},"trustStores":["ca:acme"],"trustedIdentities":["*"]}]}`,
+				store:  caPEM,
+				sign:   signed(),
+			},
+			want: invalid,
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			ref, desc := push(t, strings.ToLower(n))
+			tc.args.sign(t, ref, desc)
+
+			c := &test.MockClient{
+				MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+					switch o := obj.(type) {
+					case *corev1.ConfigMap:
+						o.Data = map[string]string{"trustpolicy.json": tc.args.policy}
+					case *corev1.Secret:
+						o.Data = map[string][]byte{"ca.crt": tc.args.store}
+					default:
+						return kerrors.NewNotFound(schema.GroupResource{}, key.Name)
+					}
+					return nil
+				},
+			}
+
+			v := NewNotationValidator(c, fake.NewClientset(), "crossplane-system", "crossplane")
+
+			err := v.Validate(context.Background(), ref, config)
+
+			got := valid
+			switch {
+			case IsUnenforced(err):
+				got = unenforced
+			case err != nil:
+				got = invalid
+			}
+
+			if got != tc.want {
+				t.Errorf("\n%s\nValidate(...): want %s, got %s: %v", tc.reason, tc.want, got, err)
+			}
+		})
+	}
+}
+
+func TestEnforceAuthenticTimestamp(t *testing.T) {
+	policy := func(level string, o map[trustpolicy.ValidationType]trustpolicy.ValidationAction) trustpolicy.TrustPolicy {
+		return trustpolicy.TrustPolicy{Name: level, SignatureVerification: trustpolicy.SignatureVerification{VerificationLevel: level, Override: o}}
+	}
+
+	in := &trustpolicy.Document{Version: "1.0", TrustPolicies: []trustpolicy.TrustPolicy{
+		policy("permissive", map[trustpolicy.ValidationType]trustpolicy.ValidationAction{trustpolicy.TypeRevocation: trustpolicy.ActionEnforce}),
+		policy("skip", nil),
+	}}
+
+	want := &trustpolicy.Document{Version: "1.0", TrustPolicies: []trustpolicy.TrustPolicy{
+		policy("permissive", map[trustpolicy.ValidationType]trustpolicy.ValidationAction{
+			trustpolicy.TypeRevocation:         trustpolicy.ActionEnforce,
+			trustpolicy.TypeAuthenticTimestamp: trustpolicy.ActionEnforce,
+		}),
+		policy("skip", nil),
+	}}
+
+	got := EnforceAuthenticTimestamp(in)
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("EnforceAuthenticTimestamp(...): -want, +got:\n%s", diff)
+	}
+
+	if _, ok := in.TrustPolicies[0].SignatureVerification.Override[trustpolicy.TypeAuthenticTimestamp]; ok {
+		t.Errorf("EnforceAuthenticTimestamp(...): must not modify the supplied document")
+	}
+}
@@ -0,0 +1,165 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package signature
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+
+	"github.com/google/cel-go/cel"
+	"github.com/sigstore/cosign/v2/pkg/oci"
+	celconfig "k8s.io/apiserver/pkg/apis/cel"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+
+	"github.com/crossplane/crossplane/v2/apis/pkg/v1beta1"
+)
+
+// verifyAttestation returns nil if at least one of the supplied verified
+// attestations is of the supplied attestation's predicate type and satisfies
+// all of its policies.
+func verifyAttestation(ctx context.Context, att v1beta1.Attestation, verified []oci.Signature) error {
+	var errs []error
+
+	for _, s := range verified {
+		b, _, err := attestationToPayloadJSON(ctx, att.PredicateType, s)
+		if err != nil {
+			errs = append(errs, errors.Errorf("cannot convert attestation %q to payload JSON: %v", att.Name, err))
+			continue
+		}
+
+		// This attestation isn't of the predicate type we're looking for.
+		if len(b) == 0 {
+			continue
+		}
+
+		if err := evaluateAttestationPolicies(ctx, att.Policies, b); err != nil {
+			errs = append(errs, errors.Wrapf(err, "attestation %q", att.Name))
+			continue
+		}
+
+		return nil
+	}
+
+	if len(errs) == 0 {
+		return errors.Errorf("no attestation of type %q found for %q", att.PredicateType, att.Name)
+	}
+
+	return errors.Join(errs...)
+}
+
+// evaluateAttestationPolicies returns an error naming the first of the supplied
+// policies the supplied attestation payload doesn't satisfy.
+func evaluateAttestationPolicies(ctx context.Context, policies []v1beta1.AttestationPolicy, payload []byte) error {
+	if len(policies) == 0 {
+		return nil
+	}
+
+	statement := map[string]any{}
+	if err := json.Unmarshal(payload, &statement); err != nil {
+		return errors.Wrap(err, "cannot unmarshal attestation payload")
+	}
+
+	for _, p := range policies {
+		prg, err := programs.Program(p.Expression)
+		if err != nil {
+			return errors.Wrapf(err, "cannot compile rule %q", p.Name)
+		}
+
+		out, _, err := prg.ContextEval(ctx, map[string]any{"self": statement})
+		if err != nil {
+			return errors.Wrapf(err, "rule %q failed", p.Name)
+		}
+
+		if ok, _ := out.Value().(bool); !ok {
+			return errors.Errorf("rule %q failed: %s is false", p.Name, p.Expression)
+		}
+	}
+
+	return nil
+}
+
+// maxCachedPrograms is the maximum number of compiled policies we cache.
+// Policies come from ImageConfigs, so we expect far fewer than this. If there
+// are more we start again with an empty cache.
+const maxCachedPrograms = 1024
+
+// A compiledProgram is a compiled attestation policy.
+type compiledProgram struct {
+	prg cel.Program
+	err error
+}
+
+// A programCache caches compiled attestation policies. We verify a package's
+// attestations each time its revision is reconciled, so we avoid compiling
+// the same policies over and over. A policy is only compiled again when its
+// ImageConfig changes its expression.
+type programCache struct {
+	mu       sync.RWMutex
+	compiled map[string]compiledProgram
+}
+
+var programs = &programCache{compiled: map[string]compiledProgram{}}
+
+// Program returns the supplied attestation policy expression, compiled per
+// CompileAttestationPolicy.
+func (c *programCache) Program(expr string) (cel.Program, error) {
+	c.mu.RLock()
+	p, ok := c.compiled[expr]
+	c.mu.RUnlock()
+
+	if ok {
+		return p.prg, p.err
+	}
+
+	prg, err := CompileAttestationPolicy(expr)
+	p = compiledProgram{prg: prg, err: err}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.compiled) >= maxCachedPrograms {
+		c.compiled = map[string]compiledProgram{}
+	}
+
+	c.compiled[expr] = p
+
+	return p.prg, p.err
+}
+
+// CompileAttestationPolicy compiles the supplied CEL expression. The expression
+// may refer to the attestation's in-toto statement as self, and must return a
+// bool. Evaluating it is limited to the same cost as a single Kubernetes CEL
+// validation rule.
+func CompileAttestationPolicy(expr string) (cel.Program, error) {
+	env, err := cel.NewEnv(cel.Variable("self", cel.DynType))
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot create CEL environment")
+	}
+
+	ast, iss := env.Compile(expr)
+	if iss.Err() != nil {
+		return nil, iss.Err()
+	}
+
+	if !ast.OutputType().IsExactType(cel.BoolType) && !ast.OutputType().IsExactType(cel.DynType) {
+		return nil, errors.Errorf("expression must return a bool, not %s", ast.OutputType())
+	}
+
+	return env.Program(ast, cel.CostLimit(celconfig.PerCallLimit))
+}
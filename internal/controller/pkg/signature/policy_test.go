@@ -0,0 +1,228 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package signature
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sigstore/cosign/v2/pkg/oci"
+	"github.com/sigstore/cosign/v2/pkg/oci/static"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/test"
+
+	"github.com/crossplane/crossplane/v2/apis/pkg/v1beta1"
+)
+
+func TestVerifyAttestation(t *testing.T) {
+	attest := func(t *testing.T, predicateType string, predicate map[string]any) oci.Signature {
+		t.Helper()
+
+		statement, err := json.Marshal(map[string]any{
+			"_type":         "https://in-toto.io/Statement/v0.1",
+			"predicateType": predicateType,
+			"subject":       []any{},
+			"predicate":     predicate,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		envelope, err := json.Marshal(map[string]any{
+			"payloadType": "application/vnd.in-toto+json",
+			"payload":     base64.StdEncoding.EncodeToString(statement),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		s, err := static.NewAttestation(envelope)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return s
+	}
+
+	provenance := map[string]any{"builder": map[string]any{"id": "https://github.com/crossplane/crossplane/.github/workflows/ci.yml"}}
+	sbom := map[string]any{"packages": []any{map[string]any{"name": "cool-lib"}, map[string]any{"name": "log4j-core"}}}
+
+	type args struct {
+		att      v1beta1.Attestation
+		verified func(t *testing.T) []oci.Signature
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   error
+	}{
+		"NoMatchingPredicateType": {
+			reason: "We should return an error if no attestation has the requested predicate type.",
+			args: args{
+				att: v1beta1.Attestation{Name: "provenance", PredicateType: "slsaprovenance"},
+				verified: func(t *testing.T) []oci.Signature {
+					t.Helper()
+					return []oci.Signature{attest(t, "https://spdx.dev/Document", sbom)}
+				},
+			},
+			want: errors.New(`no attestation of type "slsaprovenance" found for "provenance"`),
+		},
+		"NoPolicies": {
+			reason: "An attestation with the requested predicate type should be enough if there are no policies.",
+			args: args{
+				att: v1beta1.Attestation{Name: "provenance", PredicateType: "slsaprovenance"},
+				verified: func(t *testing.T) []oci.Signature {
+					t.Helper()
+					return []oci.Signature{attest(t, "https://slsa.dev/provenance/v0.2", provenance)}
+				},
+			},
+			want: nil,
+		},
+		"PoliciesPass": {
+			reason: "We should return nil if an attestation satisfies all policies.",
+			args: args{
+				att: v1beta1.Attestation{
+					Name:          "provenance",
+					PredicateType: "slsaprovenance",
+					Policies: []v1beta1.AttestationPolicy{{
+						Name:       "trusted-builder",
+						Expression: "self.predicate.builder.id.startsWith('https://github.com/crossplane/')",
+					}},
+				},
+				verified: func(t *testing.T) []oci.Signature {
+					t.Helper()
+					return []oci.Signature{attest(t, "https://slsa.dev/provenance/v0.2", provenance)}
+				},
+			},
+			want: nil,
+		},
+		"PolicyFails": {
+			reason: "We should return an error naming the failing rule if no attestation satisfies it.",
+			args: args{
+				att: v1beta1.Attestation{
+					Name:          "sbom",
+					PredicateType: "https://spdx.dev/Document",
+					Policies: []v1beta1.AttestationPolicy{{
+						Name:       "no-log4j",
+						Expression: "!self.predicate.packages.exists(p, p.name == 'log4j-core')",
+					}},
+				},
+				verified: func(t *testing.T) []oci.Signature {
+					t.Helper()
+					return []oci.Signature{attest(t, "https://spdx.dev/Document", sbom)}
+				},
+			},
+			want: errors.Join(errors.Wrap(errors.New(`rule "no-log4j" failed: !self.predicate.packages.exists(p, p.name == 'log4j-core') is false`), `attestation "sbom"`)),
+		},
+		"ExpressionNotBool": {
+			reason: "We should return an error if a rule doesn't return a bool.",
+			args: args{
+				att: v1beta1.Attestation{
+					Name:          "provenance",
+					PredicateType: "slsaprovenance",
+					Policies:      []v1beta1.AttestationPolicy{{Name: "not-bool", Expression: "'nope'"}},
+				},
+				verified: func(t *testing.T) []oci.Signature {
+					t.Helper()
+					return []oci.Signature{attest(t, "https://slsa.dev/provenance/v0.2", provenance)}
+				},
+			},
+			want: errors.Join(errors.Wrap(errors.Wrap(errors.New("expression must return a bool, not string"), `cannot compile rule "not-bool"`), `attestation "provenance"`)),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := verifyAttestation(context.Background(), tc.args.att, tc.args.verified(t))
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nverifyAttestation(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCompileAttestationPolicy(t *testing.T) {
+	many := make([]any, 200)
+	for i := range many {
+		many[i] = i
+	}
+
+	cases := map[string]struct {
+		reason  string
+		expr    string
+		self    any
+		wantErr bool
+	}{
+		"Valid": {
+			reason: "A valid expression should compile and evaluate.",
+			expr:   "self.size() == 200",
+			self:   many,
+		},
+		"Invalid": {
+			reason:  "An expression that isn't valid CEL should not compile.",
+			expr:    "self.size() ==",
+			wantErr: true,
+		},
+		"TooExpensive": {
+			reason:  "Evaluating an expression should fail once it exceeds the cost limit.",
+			expr:    "self.all(x, self.all(y, self.all(z, true)))",
+			self:    many,
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			prg, err := CompileAttestationPolicy(tc.expr)
+			if err == nil {
+				_, _, err = prg.Eval(map[string]any{"self": tc.self})
+			}
+
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("\n%s\nCompileAttestationPolicy(...): want error %t, got %v", tc.reason, tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestProgramCache(t *testing.T) {
+	c := &programCache{compiled: map[string]compiledProgram{}}
+
+	a, err := c.Program("self.size() > 0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, _ := c.Program("self.size() > 0")
+	if a != b {
+		t.Errorf("c.Program(...): want the cached program, got a newly compiled one")
+	}
+
+	for i := range maxCachedPrograms {
+		_, _ = c.Program(fmt.Sprintf("self.size() > %d", i))
+	}
+
+	if got := len(c.compiled); got > maxCachedPrograms {
+		t.Errorf("len(c.compiled): want at most %d cached programs, got %d", maxCachedPrograms, got)
+	}
+}
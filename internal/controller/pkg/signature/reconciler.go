@@ -141,7 +141,10 @@ func SetupProviderRevision(mgr ctrl.Manager, o controller.Options) error {
 		WithNamespace(o.Namespace),
 		WithServiceAccount(o.ServiceAccount),
 		WithConfigStore(xpkg.NewImageConfigStore(mgr.GetClient(), o.Namespace)),
-		WithValidator(cosignValidator),
+		WithValidator(ProviderValidator{
+			v1beta1.ImageVerificationProviderCosign:   cosignValidator,
+			v1beta1.ImageVerificationProviderNotation: NewNotationValidator(mgr.GetClient(), clientset, o.Namespace, o.ServiceAccount),
+		}),
 		WithLogger(log),
 	)
 
@@ -175,7 +178,10 @@ func SetupConfigurationRevision(mgr ctrl.Manager, o controller.Options) error {
 		WithNamespace(o.Namespace),
 		WithServiceAccount(o.ServiceAccount),
 		WithConfigStore(xpkg.NewImageConfigStore(mgr.GetClient(), o.Namespace)),
-		WithValidator(cosignValidator),
+		WithValidator(ProviderValidator{
+			v1beta1.ImageVerificationProviderCosign:   cosignValidator,
+			v1beta1.ImageVerificationProviderNotation: NewNotationValidator(mgr.GetClient(), clientset, o.Namespace, o.ServiceAccount),
+		}),
 		WithLogger(log),
 	)
 
@@ -209,7 +215,10 @@ func SetupFunctionRevision(mgr ctrl.Manager, o controller.Options) error {
 		WithNamespace(o.Namespace),
 		WithServiceAccount(o.ServiceAccount),
 		WithConfigStore(xpkg.NewImageConfigStore(mgr.GetClient(), o.Namespace)),
-		WithValidator(cosignValidator),
+		WithValidator(ProviderValidator{
+			v1beta1.ImageVerificationProviderCosign:   cosignValidator,
+			v1beta1.ImageVerificationProviderNotation: NewNotationValidator(mgr.GetClient(), clientset, o.Namespace, o.ServiceAccount),
+		}),
 		WithLogger(log),
 	)
 
@@ -297,7 +306,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 		return reconcile.Result{}, errors.Wrap(err, errGetVerificationConfig)
 	}
 
-	if vc == nil || vc.Cosign == nil {
+	if vc == nil || (vc.Cosign == nil && vc.Notation == nil) {
 		// No verification config found for this image, so, we will skip
 		// verification.
 		log.Debug("No signature verification config found for image, skipping verification")
@@ -341,7 +350,15 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 		pullSecrets = append(pullSecrets, s)
 	}
 
-	if err = r.validator.Validate(ctx, ref, vc, pullSecrets...); err != nil {
+	err = r.validator.Validate(ctx, ref, vc, pullSecrets...)
+	if IsUnenforced(err) {
+		log.Debug("Signature verification failed checks that aren't enforced", "error", err)
+		status.MarkConditions(v1.VerificationAudited(ic, err))
+
+		return reconcile.Result{}, errors.Wrap(r.client.Status().Update(ctx, pr), "cannot update status with audited verification")
+	}
+
+	if err != nil {
 		log.Debug("Signature verification failed", "error", err)
 		status.MarkConditions(v1.VerificationFailed(ic, err))
 
@@ -363,7 +380,7 @@ func enqueuePackageRevisionsForImageConfig(kube client.Client, log logging.Logge
 		if !ok {
 			return nil
 		}
-		// We only care about ImageConfigs with Cosign verification configured.
+		// We only care about ImageConfigs with verification configured.
 		if ic.Spec.Verification == nil {
 			return nil
 		}
@@ -379,7 +396,7 @@ func enqueuePackageRevisionsForImageConfig(kube client.Client, log logging.Logge
 
 		for _, p := range l.GetRevisions() {
 			for _, m := range ic.Spec.MatchImages {
-				if strings.HasPrefix(p.GetResolvedSource(), m.Prefix) {
+				if xpkg.ImageMatches(p.GetResolvedSource(), m) {
 					log.Debug("Enqueuing provider revisions for image config", "provider-revision", p.GetName(), "imageConfig", ic.Name)
 					matches = append(matches, reconcile.Request{NamespacedName: types.NamespacedName{Name: p.GetName()}})
 				}
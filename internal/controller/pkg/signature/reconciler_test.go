@@ -348,6 +348,43 @@ func TestReconcile(t *testing.T) {
 							withAppliedImageConfigRef(imageConfigName),
 						)
 
+						if diff := cmp.Diff(&want, o); diff != "" {
+							t.Errorf("-want, +got:\n%s", diff)
+						}
+						return nil
+					},
+				},
+			},
+		},
+		"UnenforcedVerification": {
+			reason: "If verification fails checks that aren't enforced, we should mark the revision as verified and explain what failed.",
+			args: args{
+				opts: []ReconcilerOption{
+					WithNewPackageRevisionFn(func() v1.PackageRevision { return &v1.ConfigurationRevision{} }),
+					WithConfigStore(&xpkgfake.MockConfigStore{
+						MockPullSecretFor: xpkgfake.NewMockConfigStorePullSecretForFn(imageConfigName, "", nil),
+						MockImageVerificationConfigFor: xpkgfake.NewMockConfigStoreImageVerificationConfigForFn(imageConfigName, &v1beta1.ImageVerification{
+							Provider: v1beta1.ImageVerificationProviderCosign,
+							Cosign:   &v1beta1.CosignVerificationConfig{},
+						}, nil),
+					}),
+					WithValidator(&MockValidator{
+						ValidateFn: func(_ context.Context, _ name.Reference, _ *v1beta1.ImageVerification, _ ...string) error {
+							return &UnenforcedError{error: errBoom}
+						},
+					}),
+				},
+				client: &test.MockClient{
+					MockGet: test.NewMockGetFn(nil, func(o client.Object) error {
+						*o.(*v1.ConfigurationRevision) = testRevision()
+						return nil
+					}),
+					MockStatusUpdate: func(_ context.Context, o client.Object, _ ...client.SubResourceUpdateOption) error {
+						want := testRevision(
+							withConditions(v1.VerificationAudited(imageConfigName, &UnenforcedError{error: errBoom})),
+							withAppliedImageConfigRef(imageConfigName),
+						)
+
 						if diff := cmp.Diff(&want, o); diff != "" {
 							t.Errorf("-want, +got:\n%s", diff)
 						}
@@ -32,6 +32,20 @@ type Validator interface {
 	Validate(ctx context.Context, ref name.Reference, config *v1beta1.ImageVerification, pullSecrets ...string) error
 }
 
+// A ProviderValidator validates image signatures using the Validator for the
+// configured image verification provider.
+type ProviderValidator map[v1beta1.ImageVerificationProvider]Validator
+
+// Validate validates the image signature using the configured provider.
+func (p ProviderValidator) Validate(ctx context.Context, ref name.Reference, config *v1beta1.ImageVerification, pullSecrets ...string) error {
+	v, ok := p[config.Provider]
+	if !ok {
+		return errors.Errorf("unsupported image verification provider %q", config.Provider)
+	}
+
+	return v.Validate(ctx, ref, config, pullSecrets...)
+}
+
 // NewCosignValidator returns a new CosignValidator.
 func NewCosignValidator(c client.Reader, k kubernetes.Interface, namespace, serviceAccount string) (*CosignValidator, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), fetchCertTimeout)
@@ -130,27 +144,21 @@ func (c *CosignValidator) Validate(ctx context.Context, ref name.Reference, conf
 			return nil
 		}
 
-		// If there are attestations to be verified, check if the attestation
-		// is valid for at least one of the resulting/checked
-		// signatures/attestations.
+		// If there are attestations to be verified, check that each one is
+		// satisfied, including its policies, by at least one of the
+		// resulting/checked signatures/attestations.
+		verified := true
+
 		for _, att := range a.Attestations {
-			for _, s := range res {
-				b, _, err := attestationToPayloadJSON(ctx, att.PredicateType, s)
-				if err != nil {
-					errs = append(errs, errors.Errorf("authority %q: cannot convert attestation %q to payload JSON: %v", a.Name, att.Name, err))
-					continue
-				}
-
-				if len(b) == 0 {
-					errs = append(errs, errors.Errorf("authority %q: no attestation of type %q found for %q", a.Name, att.PredicateType, att.Name))
-					continue
-				}
-				// If the attestation is valid for at least one of the resulting
-				// payloads, return nil. Otherwise, continue with the next
-				// signature.
-				return nil
+			if err := verifyAttestation(ctx, att, res); err != nil {
+				errs = append(errs, errors.Errorf("authority %q: %v", a.Name, err))
+				verified = false
 			}
 		}
+
+		if verified {
+			return nil
+		}
 	}
 
 	// If we reach this point, none of the authorities were able to verify the
@@ -204,6 +212,10 @@ func (c *CosignValidator) buildCosignCheckOpts(ctx context.Context, a v1beta1.Co
 		if err != nil {
 			return nil, errors.Wrap(err, "cannot load signature verifier")
 		}
+
+		if kr.InsecureIgnoreTlog != nil {
+			opts.IgnoreTlog = *kr.InsecureIgnoreTlog
+		}
 	}
 
 	return &opts, nil
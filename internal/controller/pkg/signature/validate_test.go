@@ -0,0 +1,108 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package signature
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/v2/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/test"
+
+	"github.com/crossplane/crossplane/v2/apis/pkg/v1beta1"
+)
+
+func TestBuildCosignCheckOpts(t *testing.T) {
+	k, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pub, err := cryptoutils.MarshalPublicKeyToPEM(k.Public())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := &test.MockClient{
+		MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+			s := obj.(*corev1.Secret)
+			s.Data = map[string][]byte{"cosign.pub": pub}
+
+			return nil
+		}),
+	}
+
+	key := func(ignoreTlog *bool) *v1beta1.KeyRef {
+		return &v1beta1.KeyRef{
+			SecretRef:          v1beta1.LocalSecretKeySelector{LocalSecretReference: xpv1.LocalSecretReference{Name: "cosign"}, Key: "cosign.pub"},
+			HashAlgorithm:      "sha256",
+			InsecureIgnoreTlog: ignoreTlog,
+		}
+	}
+
+	type want struct {
+		ignoreTlog bool
+		ignoreSCT  bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		a      v1beta1.CosignAuthority
+		want   want
+	}{
+		"KeyChecksTlog": {
+			reason: "A key authority should check the transparency log by default.",
+			a:      v1beta1.CosignAuthority{Key: key(nil)},
+			want:   want{},
+		},
+		"KeyIgnoresTlog": {
+			reason: "A key authority should skip the transparency log if it opts in.",
+			a:      v1beta1.CosignAuthority{Key: key(ptr.To(true))},
+			want:   want{ignoreTlog: true},
+		},
+		"KeylessIgnoresSCT": {
+			reason: "A keyless authority should skip checking for an embedded SCT if it opts in.",
+			a:      v1beta1.CosignAuthority{Keyless: &v1beta1.KeylessRef{InsecureIgnoreSCT: ptr.To(true)}},
+			want:   want{ignoreSCT: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			v := &CosignValidator{client: c}
+
+			co, err := v.buildCosignCheckOpts(context.Background(), tc.a)
+			if err != nil {
+				t.Fatalf("\n%s\nbuildCosignCheckOpts(...): %v", tc.reason, err)
+			}
+
+			got := want{ignoreTlog: co.IgnoreTlog, ignoreSCT: co.IgnoreSCT}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\nbuildCosignCheckOpts(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
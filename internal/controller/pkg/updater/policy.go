@@ -0,0 +1,118 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/Masterminds/semver"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+
+	v1 "github.com/crossplane/crossplane/v2/apis/pkg/v1"
+	"github.com/crossplane/crossplane/v2/internal/controller/pkg/resolver"
+)
+
+const (
+	errInvalidConstraint = "invalid update policy constraint"
+	errInvalidStart      = "invalid maintenance window start time"
+)
+
+// FindUpdate returns the newest of the supplied tags the update policy allows
+// a package at the supplied version to be updated to. It returns an empty
+// string if there is no newer version to update to.
+func FindUpdate(p *v1.UpdatePolicy, current *semver.Version, tags []string) (string, error) {
+	var channel string
+
+	switch p.Channel {
+	case v1.UpdateChannelMajor:
+		channel = fmt.Sprintf(">%s", current)
+	case v1.UpdateChannelMinor:
+		channel = fmt.Sprintf(">%s, <%d.0.0", current, current.Major()+1)
+	default:
+		// Patch is the default channel.
+		channel = fmt.Sprintf(">%s, <%d.%d.0", current, current.Major(), current.Minor()+1)
+	}
+
+	c, err := semver.NewConstraint(channel)
+	if err != nil {
+		return "", errors.Wrap(err, errInvalidConstraint)
+	}
+
+	// The user's constraint might contain an OR (||), so we can't simply
+	// append it to the channel's constraint.
+	if p.Constraint != nil {
+		uc, err := semver.NewConstraint(*p.Constraint)
+		if err != nil {
+			return "", errors.Wrap(err, errInvalidConstraint)
+		}
+
+		tags = slices.DeleteFunc(slices.Clone(tags), func(t string) bool {
+			v, err := semver.NewVersion(t)
+			return err != nil || !uc.Check(v)
+		})
+	}
+
+	return resolver.FindVersionToInstall(c, tags), nil
+}
+
+// InMaintenanceWindow returns true if the supplied time is inside the supplied
+// maintenance window. If it isn't, it also returns how long until the window
+// next opens.
+func InMaintenanceWindow(w *v1.MaintenanceWindow, now time.Time) (bool, time.Duration, error) {
+	start, err := time.Parse("15:04", w.Start)
+	if err != nil {
+		return false, 0, errors.Wrap(err, errInvalidStart)
+	}
+
+	now = now.UTC()
+
+	allowed := func(d time.Weekday) bool {
+		return len(w.Days) == 0 || slices.Contains(w.Days, d.String())
+	}
+
+	// Find the most recent time the window opened today or earlier. A window
+	// that stays open for more than a day may have opened several days ago.
+	today := time.Date(now.Year(), now.Month(), now.Day(), start.Hour(), start.Minute(), 0, 0, time.UTC)
+	lookback := int(w.Duration.Hours()/24) + 1
+
+	for i := range lookback + 1 {
+		open := today.AddDate(0, 0, -i)
+		if !allowed(open.Weekday()) || open.After(now) {
+			continue
+		}
+
+		if now.Before(open.Add(w.Duration.Duration)) {
+			return true, 0, nil
+		}
+	}
+
+	// Find the next time the window opens. It opens at least once a week.
+	for i := range 8 {
+		open := today.AddDate(0, 0, i)
+		if !allowed(open.Weekday()) || !open.After(now) {
+			continue
+		}
+
+		return false, open.Sub(now), nil
+	}
+
+	// Unreachable unless the days are all invalid.
+	return false, 24 * time.Hour, nil
+}
@@ -0,0 +1,208 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Masterminds/semver"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	v1 "github.com/crossplane/crossplane/v2/apis/pkg/v1"
+)
+
+func TestFindUpdate(t *testing.T) {
+	tags := []string{"v1.0.0", "v1.0.1", "v1.0.2", "v1.1.0", "v1.2.0-rc.1", "v1.2", "v2.0.0", "latest"}
+
+	type args struct {
+		p       *v1.UpdatePolicy
+		current string
+	}
+
+	type want struct {
+		v   string
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"PatchChannel": {
+			reason: "The patch channel should update to the newest version with the same major and minor version.",
+			args: args{
+				p:       &v1.UpdatePolicy{Channel: v1.UpdateChannelPatch},
+				current: "v1.0.0",
+			},
+			want: want{v: "v1.0.2"},
+		},
+		"DefaultChannel": {
+			reason: "The patch channel should be the default.",
+			args: args{
+				p:       &v1.UpdatePolicy{},
+				current: "v1.0.1",
+			},
+			want: want{v: "v1.0.2"},
+		},
+		"MinorChannel": {
+			reason: "The minor channel should update to the newest complete, stable version with the same major version.",
+			args: args{
+				p:       &v1.UpdatePolicy{Channel: v1.UpdateChannelMinor},
+				current: "v1.0.0",
+			},
+			want: want{v: "v1.1.0"},
+		},
+		"MajorChannel": {
+			reason: "The major channel should update to the newest version.",
+			args: args{
+				p:       &v1.UpdatePolicy{Channel: v1.UpdateChannelMajor},
+				current: "v1.0.0",
+			},
+			want: want{v: "v2.0.0"},
+		},
+		"Constraint": {
+			reason: "Versions should also satisfy the policy's constraint.",
+			args: args{
+				p:       &v1.UpdatePolicy{Channel: v1.UpdateChannelMajor, Constraint: ptr.To("<v1.1.0 || >=v3.0.0")},
+				current: "v1.0.0",
+			},
+			want: want{v: "v1.0.2"},
+		},
+		"UpToDate": {
+			reason: "We shouldn't return a version if the package is already at the newest allowed version.",
+			args: args{
+				p:       &v1.UpdatePolicy{Channel: v1.UpdateChannelPatch},
+				current: "v1.0.2",
+			},
+			want: want{v: ""},
+		},
+		"InvalidConstraint": {
+			reason: "We should return an error if the policy's constraint is invalid.",
+			args: args{
+				p:       &v1.UpdatePolicy{Constraint: ptr.To("nope")},
+				current: "v1.0.0",
+			},
+			want: want{err: cmpopts.AnyError},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			v, err := FindUpdate(tc.args.p, semver.MustParse(tc.args.current), tags)
+			if diff := cmp.Diff(tc.want.err, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nFindUpdate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+
+			if diff := cmp.Diff(tc.want.v, v); diff != "" {
+				t.Errorf("\n%s\nFindUpdate(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestInMaintenanceWindow(t *testing.T) {
+	// A Saturday.
+	sat := time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	type args struct {
+		w   *v1.MaintenanceWindow
+		now time.Time
+	}
+
+	type want struct {
+		open bool
+		wait time.Duration
+		err  error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"OpenEveryDay": {
+			reason: "A window with no days should open every day.",
+			args: args{
+				w:   &v1.MaintenanceWindow{Start: "02:00", Duration: metav1.Duration{Duration: 2 * time.Hour}},
+				now: sat.Add(3 * time.Hour),
+			},
+			want: want{open: true},
+		},
+		"NotOpenYet": {
+			reason: "We should return how long until the window opens later today.",
+			args: args{
+				w:   &v1.MaintenanceWindow{Start: "02:00", Duration: metav1.Duration{Duration: 2 * time.Hour}},
+				now: sat.Add(1 * time.Hour),
+			},
+			want: want{wait: 1 * time.Hour},
+		},
+		"Closed": {
+			reason: "We should return how long until the window opens tomorrow once it has closed today.",
+			args: args{
+				w:   &v1.MaintenanceWindow{Start: "02:00", Duration: metav1.Duration{Duration: 2 * time.Hour}},
+				now: sat.Add(4 * time.Hour),
+			},
+			want: want{wait: 22 * time.Hour},
+		},
+		"OpenOvernight": {
+			reason: "A window that opened yesterday should still be open if it hasn't closed yet.",
+			args: args{
+				w:   &v1.MaintenanceWindow{Days: []string{"Friday"}, Start: "22:00", Duration: metav1.Duration{Duration: 4 * time.Hour}},
+				now: sat.Add(1 * time.Hour),
+			},
+			want: want{open: true},
+		},
+		"WrongDay": {
+			reason: "We should return how long until the window opens on the next allowed day.",
+			args: args{
+				w:   &v1.MaintenanceWindow{Days: []string{"Monday"}, Start: "00:00", Duration: metav1.Duration{Duration: time.Hour}},
+				now: sat,
+			},
+			want: want{wait: 48 * time.Hour},
+		},
+		"InvalidStart": {
+			reason: "We should return an error if the window's start time is invalid.",
+			args: args{
+				w:   &v1.MaintenanceWindow{Start: "25:00"},
+				now: sat,
+			},
+			want: want{err: cmpopts.AnyError},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			open, wait, err := InMaintenanceWindow(tc.args.w, tc.args.now)
+			if diff := cmp.Diff(tc.want.err, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nInMaintenanceWindow(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+
+			if diff := cmp.Diff(tc.want.open, open); diff != "" {
+				t.Errorf("\n%s\nInMaintenanceWindow(...): -want open, +got open:\n%s", tc.reason, diff)
+			}
+
+			if diff := cmp.Diff(tc.want.wait, wait); diff != "" {
+				t.Errorf("\n%s\nInMaintenanceWindow(...): -want wait, +got wait:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,359 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package updater implements a controller that automatically updates packages
+// to newer versions published to their registry.
+package updater
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/semver"
+	"github.com/google/go-containerregistry/pkg/name"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+
+	v1 "github.com/crossplane/crossplane/v2/apis/pkg/v1"
+	"github.com/crossplane/crossplane/v2/internal/controller/pkg/controller"
+	"github.com/crossplane/crossplane/v2/internal/xpkg"
+)
+
+const (
+	reconcileTimeout = 1 * time.Minute
+
+	// defaultInterval is how often we check for updates if the update policy
+	// doesn't specify an interval.
+	defaultInterval = 1 * time.Hour
+)
+
+const (
+	errGetPackage        = "cannot get package"
+	errParsePackage      = "cannot parse package"
+	errRewriteImage      = "cannot rewrite image path using config"
+	errGetPullConfig     = "cannot get image pull secret from config"
+	errFetchTags         = "cannot fetch package tags"
+	errFindUpdate        = "cannot find version to update to"
+	errMaintenanceWindow = "cannot determine whether maintenance window is open"
+	errUpdatePackage     = "cannot update package"
+	errUpdateStatus      = "cannot update package status"
+
+	errFmtNotSemver = "cannot automatically update package: tag %q is not a semantic version"
+)
+
+// Event reasons.
+const (
+	reasonUpdate event.Reason = "AutoUpdatePackage"
+)
+
+// ReconcilerOption is used to configure the Reconciler.
+type ReconcilerOption func(*Reconciler)
+
+// WithNewPackageFn determines the type of package being reconciled.
+func WithNewPackageFn(f func() v1.Package) ReconcilerOption {
+	return func(r *Reconciler) {
+		r.newPackage = f
+	}
+}
+
+// WithFetcher specifies how the Reconciler should fetch package tags.
+func WithFetcher(f xpkg.Fetcher) ReconcilerOption {
+	return func(r *Reconciler) {
+		r.fetcher = f
+	}
+}
+
+// WithConfigStore specifies the image config store to use.
+func WithConfigStore(c xpkg.ConfigStore) ReconcilerOption {
+	return func(r *Reconciler) {
+		r.config = c
+	}
+}
+
+// WithLogger specifies how the Reconciler should log messages.
+func WithLogger(log logging.Logger) ReconcilerOption {
+	return func(r *Reconciler) {
+		r.log = log
+	}
+}
+
+// WithRecorder specifies how the Reconciler should record Kubernetes events.
+func WithRecorder(er event.Recorder) ReconcilerOption {
+	return func(r *Reconciler) {
+		r.record = er
+	}
+}
+
+// WithClock specifies how the Reconciler should tell the time.
+func WithClock(now func() time.Time) ReconcilerOption {
+	return func(r *Reconciler) {
+		r.now = now
+	}
+}
+
+// Reconciler automatically updates packages.
+type Reconciler struct {
+	client  client.Client
+	fetcher xpkg.Fetcher
+	config  xpkg.ConfigStore
+	log     logging.Logger
+	record  event.Recorder
+	now     func() time.Time
+
+	newPackage func() v1.Package
+}
+
+// SetupProvider adds a controller that automatically updates Providers.
+func SetupProvider(mgr ctrl.Manager, o controller.Options) error {
+	return setup(mgr, o, v1.ProviderGroupKind, func() v1.Package { return &v1.Provider{} })
+}
+
+// SetupConfiguration adds a controller that automatically updates
+// Configurations.
+func SetupConfiguration(mgr ctrl.Manager, o controller.Options) error {
+	return setup(mgr, o, v1.ConfigurationGroupKind, func() v1.Package { return &v1.Configuration{} })
+}
+
+// SetupFunction adds a controller that automatically updates Functions.
+func SetupFunction(mgr ctrl.Manager, o controller.Options) error {
+	return setup(mgr, o, v1.FunctionGroupKind, func() v1.Package { return &v1.Function{} })
+}
+
+func setup(mgr ctrl.Manager, o controller.Options, gk string, np func() v1.Package) error {
+	name := "updater/" + strings.ToLower(gk)
+
+	cs, err := kubernetes.NewForConfig(mgr.GetConfig())
+	if err != nil {
+		return errors.Wrap(err, "failed to initialize clientset")
+	}
+
+	f, err := xpkg.NewK8sFetcher(cs, append(o.FetcherOptions, xpkg.WithNamespace(o.Namespace), xpkg.WithServiceAccount(o.ServiceAccount), xpkg.WithImageConfigStore(xpkg.NewImageConfigStore(mgr.GetClient(), o.Namespace)))...)
+	if err != nil {
+		return errors.Wrap(err, "cannot build fetcher")
+	}
+
+	r := NewReconciler(mgr,
+		WithNewPackageFn(np),
+		WithFetcher(f),
+		WithConfigStore(xpkg.NewImageConfigStore(mgr.GetClient(), o.Namespace)),
+		WithLogger(o.Logger.WithValues("controller", name)),
+		WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name), o.EventFilterFunctions...)),
+	)
+
+	// We only need to reconcile when the spec or annotations change, for
+	// example when the package is unpaused. Otherwise we requeue ourselves
+	// every update interval.
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(np(), builder.WithPredicates(predicate.Or(predicate.GenerationChangedPredicate{}, predicate.AnnotationChangedPredicate{}))).
+		WithOptions(o.ForControllerRuntime()).
+		Complete(errors.WithSilentRequeueOnConflict(r))
+}
+
+// NewReconciler creates a new package updater reconciler.
+func NewReconciler(mgr ctrl.Manager, opts ...ReconcilerOption) *Reconciler {
+	r := &Reconciler{
+		client:  mgr.GetClient(),
+		fetcher: xpkg.NewNopFetcher(),
+		log:     logging.NewNopLogger(),
+		record:  event.NewNopRecorder(),
+		now:     time.Now,
+	}
+
+	for _, f := range opts {
+		f(r)
+	}
+
+	return r
+}
+
+// Reconcile a package's update policy.
+func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	log := r.log.WithValues("request", req)
+	log.Debug("Reconciling")
+
+	ctx, cancel := context.WithTimeout(ctx, reconcileTimeout)
+	defer cancel()
+
+	p := r.newPackage()
+	if err := r.client.Get(ctx, req.NamespacedName, p); err != nil {
+		// There's no need to requeue if we no longer exist. Otherwise we'll be
+		// requeued implicitly because we return an error.
+		log.Debug(errGetPackage, "error", err)
+		return reconcile.Result{}, errors.Wrap(resource.IgnoreNotFound(err), errGetPackage)
+	}
+
+	pol := p.GetUpdatePolicy()
+	if pol == nil || meta.WasDeleted(p) {
+		return reconcile.Result{}, nil
+	}
+
+	interval := defaultInterval
+	if pol.Interval != nil && pol.Interval.Duration > 0 {
+		interval = pol.Interval.Duration
+	}
+
+	// We're also triggered when the paused annotation is removed, but we
+	// keep checking in case we miss it.
+	if meta.IsPaused(p) {
+		log.Debug("Package is paused")
+		return reconcile.Result{RequeueAfter: interval}, nil
+	}
+
+	if pol.MaintenanceWindow != nil {
+		open, wait, err := InMaintenanceWindow(pol.MaintenanceWindow, r.now())
+		if err != nil {
+			err = errors.Wrap(err, errMaintenanceWindow)
+			r.record.Event(p, event.Warning(reasonUpdate, err))
+
+			return reconcile.Result{}, err
+		}
+
+		if !open {
+			log.Debug("Waiting for maintenance window to open", "wait", wait)
+			return reconcile.Result{RequeueAfter: wait}, nil
+		}
+	}
+
+	ref, err := name.ParseReference(p.GetSource(), name.StrictValidation)
+	if err != nil {
+		err = errors.Wrap(err, errParsePackage)
+		r.record.Event(p, event.Warning(reasonUpdate, err))
+
+		return reconcile.Result{}, err
+	}
+
+	// We never update a package that is pinned to a digest. A spec change
+	// will trigger a new reconcile.
+	tag, ok := ref.(name.Tag)
+	if !ok {
+		log.Debug("Not updating package pinned to a digest")
+		return reconcile.Result{}, nil
+	}
+
+	current, err := semver.NewVersion(tag.TagStr())
+	if err != nil {
+		r.record.Event(p, event.Warning(reasonUpdate, errors.Errorf(errFmtNotSemver, tag.TagStr())))
+		return reconcile.Result{}, nil
+	}
+
+	tags, err := r.tags(ctx, p, ref)
+	if err != nil {
+		r.record.Event(p, event.Warning(reasonUpdate, err))
+		return reconcile.Result{}, err
+	}
+
+	v, err := FindUpdate(pol, current, tags)
+	if err != nil {
+		err = errors.Wrap(err, errFindUpdate)
+		r.record.Event(p, event.Warning(reasonUpdate, err))
+
+		return reconcile.Result{}, err
+	}
+
+	if v == "" {
+		log.Debug("Package is up to date", "version", tag.TagStr())
+		return reconcile.Result{RequeueAfter: interval}, nil
+	}
+
+	from := p.GetSource()
+	to := strings.TrimSuffix(from, tag.TagStr()) + v
+
+	// Record the update before we make it. If we made it first we might fail
+	// to record it, and we'd never try again because the package would be up
+	// to date. If we fail to make the update after recording it we'll record
+	// it again when we retry.
+	p.SetLastAutoUpdate(&v1.PackageUpdate{From: from, To: to, Time: metav1.NewTime(r.now())})
+
+	if err := r.client.Status().Update(ctx, p); err != nil {
+		if kerrors.IsConflict(err) {
+			return reconcile.Result{Requeue: true}, nil
+		}
+
+		err = errors.Wrap(err, errUpdateStatus)
+		r.record.Event(p, event.Warning(reasonUpdate, err))
+
+		return reconcile.Result{}, err
+	}
+
+	// Updating the status refreshes the package, so we set the new source
+	// afterwards.
+	p.SetSource(to)
+
+	if err := r.client.Update(ctx, p); err != nil {
+		if kerrors.IsConflict(err) {
+			return reconcile.Result{Requeue: true}, nil
+		}
+
+		err = errors.Wrap(err, errUpdatePackage)
+		r.record.Event(p, event.Warning(reasonUpdate, err))
+
+		return reconcile.Result{}, err
+	}
+
+	r.record.Event(p, event.Normal(reasonUpdate, fmt.Sprintf("Updated package from %s to %s", from, to)))
+	log.Debug("Updated package", "from", from, "to", to)
+
+	return reconcile.Result{RequeueAfter: interval}, nil
+}
+
+// tags returns the tags of the supplied package. It fetches them from the
+// package's rewritten path if an ImageConfig rewrites it.
+func (r *Reconciler) tags(ctx context.Context, p v1.Package, ref name.Reference) ([]string, error) {
+	if r.config != nil {
+		_, newPath, err := r.config.RewritePath(ctx, ref.String())
+		if err != nil {
+			return nil, errors.Wrap(err, errRewriteImage)
+		}
+
+		if newPath != "" {
+			if ref, err = name.ParseReference(newPath, name.StrictValidation); err != nil {
+				return nil, errors.Wrap(err, errParsePackage)
+			}
+		}
+	}
+
+	secrets := v1.RefNames(p.GetPackagePullSecrets())
+
+	if r.config != nil {
+		_, ps, err := r.config.PullSecretFor(ctx, ref.String())
+		if err != nil {
+			return nil, errors.Wrap(err, errGetPullConfig)
+		}
+
+		if ps != "" {
+			secrets = append(secrets, ps)
+		}
+	}
+
+	tags, err := r.fetcher.Tags(ctx, ref, secrets...)
+
+	return tags, errors.Wrap(err, errFetchTags)
+}
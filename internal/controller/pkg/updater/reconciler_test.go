@@ -0,0 +1,319 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updater
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/test"
+
+	v1 "github.com/crossplane/crossplane/v2/apis/pkg/v1"
+	fakexpkg "github.com/crossplane/crossplane/v2/internal/xpkg/fake"
+)
+
+func TestReconcile(t *testing.T) {
+	errBoom := errors.New("boom")
+	now := time.Date(2025, time.March, 1, 3, 0, 0, 0, time.UTC)
+
+	provider := func(source string, pol *v1.UpdatePolicy) test.MockGetFn {
+		return test.NewMockGetFn(nil, func(o client.Object) error {
+			p := o.(*v1.Provider)
+			p.SetSource(source)
+			p.SetUpdatePolicy(pol)
+
+			return nil
+		})
+	}
+
+	type args struct {
+		client  *test.MockClient
+		fetcher *fakexpkg.MockFetcher
+	}
+
+	type want struct {
+		r      reconcile.Result
+		err    error
+		source string
+		update *v1.PackageUpdate
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"NotFound": {
+			reason: "We should not return an error if the package was not found.",
+			args: args{
+				client: &test.MockClient{
+					MockGet: test.NewMockGetFn(kerrors.NewNotFound(schema.GroupResource{}, "")),
+				},
+			},
+			want: want{},
+		},
+		"NoUpdatePolicy": {
+			reason: "We should not check for updates if the package has no update policy.",
+			args: args{
+				client: &test.MockClient{
+					MockGet: provider("xpkg.crossplane.io/cool/provider:v1.0.0", nil),
+				},
+			},
+			want: want{},
+		},
+		"Paused": {
+			reason: "We should not check for updates while the package is paused, but should check again after the update interval.",
+			args: args{
+				client: &test.MockClient{
+					MockGet: test.NewMockGetFn(nil, func(o client.Object) error {
+						p := o.(*v1.Provider)
+						p.SetSource("xpkg.crossplane.io/cool/provider:v1.0.0")
+						p.SetUpdatePolicy(&v1.UpdatePolicy{})
+						meta.AddAnnotations(p, map[string]string{meta.AnnotationKeyReconciliationPaused: "true"})
+
+						return nil
+					}),
+				},
+			},
+			want: want{r: reconcile.Result{RequeueAfter: defaultInterval}},
+		},
+		"MaintenanceWindowClosed": {
+			reason: "We should wait for the maintenance window to open before checking for updates.",
+			args: args{
+				client: &test.MockClient{
+					MockGet: provider("xpkg.crossplane.io/cool/provider:v1.0.0", &v1.UpdatePolicy{
+						MaintenanceWindow: &v1.MaintenanceWindow{Start: "04:00", Duration: metav1.Duration{Duration: time.Hour}},
+					}),
+				},
+			},
+			want: want{r: reconcile.Result{RequeueAfter: time.Hour}},
+		},
+		"Digest": {
+			reason: "We should not update a package that is pinned to a digest.",
+			args: args{
+				client: &test.MockClient{
+					MockGet: provider("xpkg.crossplane.io/cool/provider@sha256:ecc25c121431dfc7058754427f97c034ecde26d4aafa0da16d6fc8d8fa0f4e74", &v1.UpdatePolicy{}),
+				},
+			},
+			want: want{},
+		},
+		"NotSemver": {
+			reason: "We should not update a package whose tag is not a semantic version.",
+			args: args{
+				client: &test.MockClient{
+					MockGet: provider("xpkg.crossplane.io/cool/provider:latest", &v1.UpdatePolicy{}),
+				},
+			},
+			want: want{},
+		},
+		"FetchTagsError": {
+			reason: "We should return an error if we can't fetch the package's tags.",
+			args: args{
+				client: &test.MockClient{
+					MockGet: provider("xpkg.crossplane.io/cool/provider:v1.0.0", &v1.UpdatePolicy{}),
+				},
+				fetcher: &fakexpkg.MockFetcher{MockTags: fakexpkg.NewMockTagsFn(nil, errBoom)},
+			},
+			want: want{err: errors.Wrap(errBoom, errFetchTags)},
+		},
+		"UpToDate": {
+			reason: "We should check again after the update interval if there's no update.",
+			args: args{
+				client: &test.MockClient{
+					MockGet: provider("xpkg.crossplane.io/cool/provider:v1.0.0", &v1.UpdatePolicy{Interval: &metav1.Duration{Duration: 5 * time.Minute}}),
+				},
+				fetcher: &fakexpkg.MockFetcher{MockTags: fakexpkg.NewMockTagsFn([]string{"v1.0.0", "v1.1.0"}, nil)},
+			},
+			want: want{r: reconcile.Result{RequeueAfter: 5 * time.Minute}},
+		},
+		"UpdateStatusError": {
+			reason: "We should return an error, and not update the package, if we can't record the update.",
+			args: args{
+				client: &test.MockClient{
+					MockGet:          provider("xpkg.crossplane.io/cool/provider:v1.0.0", &v1.UpdatePolicy{}),
+					MockUpdate:       test.NewMockUpdateFn(errors.New("package should not be updated")),
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(errBoom),
+				},
+				fetcher: &fakexpkg.MockFetcher{MockTags: fakexpkg.NewMockTagsFn([]string{"v1.0.0", "v1.0.1"}, nil)},
+			},
+			want: want{err: errors.Wrap(errBoom, errUpdateStatus)},
+		},
+		"UpdateStatusConflict": {
+			reason: "We should requeue, and not update the package, if recording the update conflicts.",
+			args: args{
+				client: &test.MockClient{
+					MockGet:          provider("xpkg.crossplane.io/cool/provider:v1.0.0", &v1.UpdatePolicy{}),
+					MockUpdate:       test.NewMockUpdateFn(errors.New("package should not be updated")),
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(kerrors.NewConflict(schema.GroupResource{}, "", errBoom)),
+				},
+				fetcher: &fakexpkg.MockFetcher{MockTags: fakexpkg.NewMockTagsFn([]string{"v1.0.0", "v1.0.1"}, nil)},
+			},
+			want: want{r: reconcile.Result{Requeue: true}},
+		},
+		"UpdatePackageError": {
+			reason: "We should return an error if we can't update the package.",
+			args: args{
+				client: &test.MockClient{
+					MockGet:          provider("xpkg.crossplane.io/cool/provider:v1.0.0", &v1.UpdatePolicy{}),
+					MockUpdate:       test.NewMockUpdateFn(errBoom),
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				fetcher: &fakexpkg.MockFetcher{MockTags: fakexpkg.NewMockTagsFn([]string{"v1.0.0", "v1.0.1"}, nil)},
+			},
+			want: want{err: errors.Wrap(errBoom, errUpdatePackage)},
+		},
+		"UpdatePackageConflict": {
+			reason: "We should requeue if updating the package conflicts, so that we make the update we recorded.",
+			args: args{
+				client: &test.MockClient{
+					MockGet:          provider("xpkg.crossplane.io/cool/provider:v1.0.0", &v1.UpdatePolicy{}),
+					MockUpdate:       test.NewMockUpdateFn(kerrors.NewConflict(schema.GroupResource{}, "", errBoom)),
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				fetcher: &fakexpkg.MockFetcher{MockTags: fakexpkg.NewMockTagsFn([]string{"v1.0.0", "v1.0.1"}, nil)},
+			},
+			want: want{r: reconcile.Result{Requeue: true}},
+		},
+		"Updated": {
+			reason: "We should update the package and record the update in its status.",
+			args: args{
+				client: &test.MockClient{
+					MockGet:          provider("xpkg.crossplane.io/cool/provider:v1.0.0", &v1.UpdatePolicy{Channel: v1.UpdateChannelMinor}),
+					MockUpdate:       test.NewMockUpdateFn(nil),
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				fetcher: &fakexpkg.MockFetcher{MockTags: fakexpkg.NewMockTagsFn([]string{"v1.0.0", "v1.0.1", "v1.1.0", "v2.0.0"}, nil)},
+			},
+			want: want{
+				r:      reconcile.Result{RequeueAfter: defaultInterval},
+				source: "xpkg.crossplane.io/cool/provider:v1.1.0",
+				update: &v1.PackageUpdate{
+					From: "xpkg.crossplane.io/cool/provider:v1.0.0",
+					To:   "xpkg.crossplane.io/cool/provider:v1.1.0",
+					Time: metav1.NewTime(now),
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			p := &v1.Provider{}
+
+			r := &Reconciler{
+				client:     tc.args.client,
+				fetcher:    tc.args.fetcher,
+				log:        logging.NewNopLogger(),
+				record:     event.NewNopRecorder(),
+				now:        func() time.Time { return now },
+				newPackage: func() v1.Package { return p },
+			}
+
+			got, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "cool-provider"}})
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+
+			if diff := cmp.Diff(tc.want.r, got); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want, +got:\n%s", tc.reason, diff)
+			}
+
+			if tc.want.source == "" {
+				return
+			}
+
+			if diff := cmp.Diff(tc.want.source, p.GetSource()); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want source, +got source:\n%s", tc.reason, diff)
+			}
+
+			if diff := cmp.Diff(tc.want.update, p.GetLastAutoUpdate()); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want last update, +got last update:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestReconcilePausedThenUnpaused(t *testing.T) {
+	now := time.Date(2025, time.March, 1, 3, 0, 0, 0, time.UTC)
+	paused := true
+
+	c := &test.MockClient{
+		MockGet: test.NewMockGetFn(nil, func(o client.Object) error {
+			p := o.(*v1.Provider)
+			p.SetSource("xpkg.crossplane.io/cool/provider:v1.0.0")
+			p.SetUpdatePolicy(&v1.UpdatePolicy{})
+			p.SetAnnotations(nil)
+
+			if paused {
+				meta.AddAnnotations(p, map[string]string{meta.AnnotationKeyReconciliationPaused: "true"})
+			}
+
+			return nil
+		}),
+		MockUpdate:       test.NewMockUpdateFn(nil),
+		MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+	}
+
+	p := &v1.Provider{}
+
+	r := &Reconciler{
+		client:     c,
+		fetcher:    &fakexpkg.MockFetcher{MockTags: fakexpkg.NewMockTagsFn([]string{"v1.0.0", "v1.0.1"}, nil)},
+		log:        logging.NewNopLogger(),
+		record:     event.NewNopRecorder(),
+		now:        func() time.Time { return now },
+		newPackage: func() v1.Package { return p },
+	}
+
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "cool-provider"}}
+
+	got, err := r.Reconcile(context.Background(), req)
+	if err != nil {
+		t.Fatalf("r.Reconcile(...): paused: %v", err)
+	}
+
+	if diff := cmp.Diff(reconcile.Result{RequeueAfter: defaultInterval}, got); diff != "" {
+		t.Errorf("r.Reconcile(...): paused: -want, +got:\n%s", diff)
+	}
+
+	if diff := cmp.Diff("xpkg.crossplane.io/cool/provider:v1.0.0", p.GetSource()); diff != "" {
+		t.Errorf("r.Reconcile(...): paused: -want source, +got source:\n%s", diff)
+	}
+
+	paused = false
+
+	if _, err := r.Reconcile(context.Background(), req); err != nil {
+		t.Fatalf("r.Reconcile(...): unpaused: %v", err)
+	}
+
+	if diff := cmp.Diff("xpkg.crossplane.io/cool/provider:v1.0.1", p.GetSource()); diff != "" {
+		t.Errorf("r.Reconcile(...): unpaused: -want source, +got source:\n%s", diff)
+	}
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	v1 "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -74,6 +75,7 @@ const (
 	errRemoveFinalizer      = "cannot remove finalizer"
 	errUpdateStatus         = "cannot update status of usage"
 	errParseAPIVersion      = "cannot parse APIVersion"
+	errDeleteExpired        = "cannot delete expired usage"
 )
 
 // Event reasons.
@@ -89,6 +91,7 @@ const (
 	reasonAddFinalizer     event.Reason = "AddFinalizer"
 	reasonRemoveFinalizer  event.Reason = "RemoveFinalizer"
 	reasonReplayDeletion   event.Reason = "ReplayDeletion"
+	reasonDeleteExpired    event.Reason = "DeleteExpiredUsage"
 
 	reasonUsageConfigured event.Reason = "UsageConfigured"
 	reasonWaitUsing       event.Reason = "WaitingUsingDeleted"
@@ -422,6 +425,27 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 		return reconcile.Result{}, nil
 	}
 
+	// An expired Usage no longer blocks deletion of the used resource. Delete
+	// it, so that it doesn't need to be cleaned up manually. We don't delete
+	// composed Usages. Their controller (e.g. a composite resource) would
+	// just create them again. They stay around, without blocking deletion,
+	// until their controller stops composing them.
+	if usage.Expired(u, time.Now()) && metav1.GetControllerOf(u) == nil {
+		log.Debug("Usage has expired, deleting it", "expiresAt", usage.ExpiresAt(u))
+
+		if err := r.client.Delete(ctx, u); xpresource.IgnoreNotFound(err) != nil {
+			log.Debug(errDeleteExpired, "error", err)
+			err = errors.Wrap(err, errDeleteExpired)
+			r.record.Event(u, event.Warning(reasonDeleteExpired, err))
+
+			return reconcile.Result{}, err
+		}
+
+		r.record.Event(u, event.Normal(reasonDeleteExpired, "Usage expired and was deleted."))
+
+		return reconcile.Result{}, nil
+	}
+
 	// Add finalizer for Usage resource.
 	if err := r.usage.AddFinalizer(ctx, u); err != nil {
 		log.Debug(errAddFinalizer, "error", err)
@@ -532,10 +556,26 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 	// or used resources are still there.
 	if !cmp.Equal(u, orig) {
 		r.record.Event(u, event.Normal(reasonUsageConfigured, "Usage configured successfully."))
-		return reconcile.Result{RequeueAfter: r.pollInterval}, errors.Wrap(r.client.Status().Update(ctx, u), errUpdateStatus)
+		return reconcile.Result{RequeueAfter: r.requeueAfter(u)}, errors.Wrap(r.client.Status().Update(ctx, u), errUpdateStatus)
+	}
+
+	return reconcile.Result{RequeueAfter: r.requeueAfter(u)}, nil
+}
+
+// requeueAfter returns how long to wait before reconciling the supplied Usage
+// again. Usages that expire are reconciled again when they expire, if that's
+// sooner than the poll interval.
+func (r *Reconciler) requeueAfter(u protection.Usage) time.Duration {
+	t := usage.ExpiresAt(u)
+	if t == nil {
+		return r.pollInterval
+	}
+
+	if d := time.Until(*t); r.pollInterval == 0 || d < r.pollInterval {
+		return d
 	}
 
-	return reconcile.Result{RequeueAfter: r.pollInterval}, nil
+	return r.pollInterval
 }
 
 func detailsAnnotation(u protection.Usage) string {
@@ -477,6 +477,111 @@ func TestReconcile(t *testing.T) {
 				r: reconcile.Result{},
 			},
 		},
+		"CannotDeleteExpired": {
+			reason: "We should return an error if we cannot delete an expired usage.",
+			args: args{
+				mgr: &fake.Manager{},
+				u:   &v1beta1.Usage{},
+				opts: []ReconcilerOption{
+					WithClientApplicator(xpresource.ClientApplicator{
+						Client: &test.MockClient{
+							MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+								o := obj.(*v1beta1.Usage)
+								o.Spec.Of.ResourceRef = &v1beta1.NamespacedResourceRef{Name: "cool"}
+								o.Spec.Reason = &reason
+								o.SetCreationTimestamp(metav1.NewTime(now.Add(-2 * time.Hour)))
+								o.Spec.TTL = &metav1.Duration{Duration: time.Hour}
+								return nil
+							}),
+							MockDelete: test.NewMockDeleteFn(errBoom),
+						},
+					}),
+					WithSelectorResolver(fakeSelectorResolver{
+						resourceSelectorFn: func(_ context.Context, _ protection.Usage) error {
+							return nil
+						},
+					}),
+					WithFinalizer(xpresource.FinalizerFns{AddFinalizerFn: func(_ context.Context, _ xpresource.Object) error {
+						t.Fatalf("expected expired usage to be deleted before adding a finalizer")
+						return nil
+					}}),
+				},
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errDeleteExpired),
+			},
+		},
+		"SuccessfulDeleteExpired": {
+			reason: "We should delete a usage once its TTL has passed.",
+			args: args{
+				mgr: &fake.Manager{},
+				u:   &v1beta1.Usage{},
+				opts: []ReconcilerOption{
+					WithClientApplicator(xpresource.ClientApplicator{
+						Client: &test.MockClient{
+							MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+								o := obj.(*v1beta1.Usage)
+								o.Spec.Of.ResourceRef = &v1beta1.NamespacedResourceRef{Name: "cool"}
+								o.Spec.Reason = &reason
+								o.SetCreationTimestamp(metav1.NewTime(now.Add(-2 * time.Hour)))
+								o.Spec.TTL = &metav1.Duration{Duration: time.Hour}
+								return nil
+							}),
+							MockDelete: test.NewMockDeleteFn(nil),
+						},
+					}),
+					WithSelectorResolver(fakeSelectorResolver{
+						resourceSelectorFn: func(_ context.Context, _ protection.Usage) error {
+							return nil
+						},
+					}),
+					WithFinalizer(xpresource.FinalizerFns{AddFinalizerFn: func(_ context.Context, _ xpresource.Object) error {
+						t.Fatalf("expected expired usage to be deleted before adding a finalizer")
+						return nil
+					}}),
+				},
+			},
+			want: want{
+				r: reconcile.Result{},
+			},
+		},
+		"ExpiredComposedUsage": {
+			reason: "We shouldn't delete an expired usage that is controlled by a composite resource, because it would just be composed again.",
+			args: args{
+				mgr: &fake.Manager{},
+				u:   &v1beta1.Usage{},
+				opts: []ReconcilerOption{
+					WithClientApplicator(xpresource.ClientApplicator{
+						Client: &test.MockClient{
+							MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+								o := obj.(*v1beta1.Usage)
+								o.Spec.Of.ResourceRef = &v1beta1.NamespacedResourceRef{Name: "cool"}
+								o.Spec.Reason = &reason
+								o.SetCreationTimestamp(metav1.NewTime(now.Add(-2 * time.Hour)))
+								o.Spec.TTL = &metav1.Duration{Duration: time.Hour}
+								o.SetOwnerReferences([]metav1.OwnerReference{{APIVersion: "example.org/v1", Kind: "XR", Name: "cool-xr", UID: "cool-uid", Controller: ptr.To(true)}})
+								return nil
+							}),
+							MockDelete: func(_ context.Context, _ client.Object, _ ...client.DeleteOption) error {
+								t.Errorf("expected expired composed usage not to be deleted")
+								return nil
+							},
+						},
+					}),
+					WithSelectorResolver(fakeSelectorResolver{
+						resourceSelectorFn: func(_ context.Context, _ protection.Usage) error {
+							return nil
+						},
+					}),
+					WithFinalizer(xpresource.FinalizerFns{AddFinalizerFn: func(_ context.Context, _ xpresource.Object) error {
+						return errBoom
+					}}),
+				},
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errAddFinalizer),
+			},
+		},
 		"CannotRemoveFinalizerOnDelete": {
 			reason: "We should return an error if we cannot remove the finalizer on delete.",
 			args: args{
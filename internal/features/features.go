@@ -42,6 +42,27 @@ const (
 	// EnableAlphaOperations enables alpha support for Operations, including
 	// CronOperations and WatchOperations.
 	EnableAlphaOperations feature.Flag = "EnableAlphaOperations"
+
+	// EnableAlphaFunctionCanaryActivation enables alpha support for rolling
+	// out new Function revisions as canaries.
+	EnableAlphaFunctionCanaryActivation feature.Flag = "EnableAlphaFunctionCanaryActivation"
+
+	// EnableAlphaPackageAutoUpdates enables alpha support for automatically
+	// updating packages with an update policy.
+	EnableAlphaPackageAutoUpdates feature.Flag = "EnableAlphaPackageAutoUpdates"
+
+	// EnableAlphaPackageHealthGates enables alpha support for observing newly
+	// activated package revisions and rolling back those that are unhealthy.
+	EnableAlphaPackageHealthGates feature.Flag = "EnableAlphaPackageHealthGates"
+
+	// EnableAlphaBreakingChangeRejection enables alpha support for refusing
+	// to apply XRD schema changes that would break existing composite
+	// resources. Breaking changes are only warned about when it's disabled.
+	EnableAlphaBreakingChangeRejection feature.Flag = "EnableAlphaBreakingChangeRejection"
+
+	// EnableAlphaConversionFunctions enables alpha support for converting
+	// composite resources between versions by calling a function.
+	EnableAlphaConversionFunctions feature.Flag = "EnableAlphaConversionFunctions"
 )
 
 // Beta Feature Flags.
@@ -0,0 +1,125 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package audit records completed Operations before they're garbage
+// collected.
+package audit
+
+import (
+	"context"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/v2/apis/common/v1"
+
+	"github.com/crossplane/crossplane/v2/apis/ops/v1alpha1"
+)
+
+// A Record of a completed Operation.
+type Record struct {
+	// RecordedAt is when the record was created.
+	RecordedAt metav1.Time `json:"recordedAt"`
+
+	// Name of the Operation.
+	Name string `json:"name"`
+
+	// UID of the Operation.
+	UID types.UID `json:"uid"`
+
+	// Labels of the Operation. These identify the CronOperation or
+	// WatchOperation that created it, if any.
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Annotations of the Operation. These identify the watched resource that
+	// triggered it, if any.
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// CreatedAt is when the Operation was created.
+	CreatedAt metav1.Time `json:"createdAt"`
+
+	// Spec of the Operation.
+	Spec v1alpha1.OperationSpec `json:"spec"`
+
+	// Succeeded is the Operation's Succeeded condition. Its status, reason
+	// and message explain whether and why the Operation failed.
+	Succeeded xpv1.Condition `json:"succeeded"`
+
+	// Failures is the number of times the Operation failed.
+	Failures int64 `json:"failures,omitempty"`
+
+	// Pipeline contains the output of each pipeline step.
+	Pipeline []v1alpha1.PipelineStepStatus `json:"pipeline,omitempty"`
+
+	// AppliedResourceRefs references the resources the Operation applied.
+	AppliedResourceRefs []v1alpha1.AppliedResourceRef `json:"appliedResourceRefs,omitempty"`
+
+	// Targets contains the result of each target of a FanOut Operation.
+	Targets []v1alpha1.TargetStatus `json:"targets,omitempty"`
+}
+
+// NewRecord returns a record of the supplied Operation.
+func NewRecord(op *v1alpha1.Operation) *Record {
+	op = op.DeepCopy()
+	return &Record{
+		RecordedAt:          metav1.NewTime(time.Now()),
+		Name:                op.GetName(),
+		UID:                 op.GetUID(),
+		Labels:              op.GetLabels(),
+		Annotations:         op.GetAnnotations(),
+		CreatedAt:           op.GetCreationTimestamp(),
+		Spec:                op.Spec,
+		Succeeded:           op.GetCondition(v1alpha1.TypeSucceeded),
+		Failures:            op.Status.Failures,
+		Pipeline:            op.Status.Pipeline,
+		AppliedResourceRefs: op.Status.AppliedResourceRefs,
+		Targets:             op.Status.Targets,
+	}
+}
+
+// A Sink durably stores records of completed Operations. Operations are only
+// garbage collected once a Sink has successfully stored their record.
+type Sink interface {
+	Store(ctx context.Context, r *Record) error
+}
+
+// A SinkFn is a function that satisfies Sink.
+type SinkFn func(ctx context.Context, r *Record) error
+
+// Store the supplied record.
+func (fn SinkFn) Store(ctx context.Context, r *Record) error {
+	return fn(ctx, r)
+}
+
+// A NopSink discards all records.
+type NopSink struct{}
+
+// Store does nothing.
+func (NopSink) Store(_ context.Context, _ *Record) error { return nil }
+
+// A MultiSink stores records in several sinks.
+type MultiSink []Sink
+
+// Store the supplied record in every sink, stopping at the first error.
+func (s MultiSink) Store(ctx context.Context, r *Record) error {
+	for _, sink := range s {
+		if err := sink.Store(ctx, r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+
+	"github.com/crossplane/crossplane/v2/apis/ops/v1alpha1"
+)
+
+func TestNewRecord(t *testing.T) {
+	op := &v1alpha1.Operation{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "cool-op",
+			UID:    "cool-uid",
+			Labels: map[string]string{v1alpha1.LabelCronOperationName: "cool-cron"},
+		},
+		Spec: v1alpha1.OperationSpec{
+			Mode: v1alpha1.OperationModePipeline,
+			Pipeline: []v1alpha1.PipelineStep{
+				{Step: "one", FunctionRef: v1alpha1.FunctionReference{Name: "function-cool"}},
+			},
+		},
+		Status: v1alpha1.OperationStatus{
+			Failures: 1,
+			Pipeline: []v1alpha1.PipelineStepStatus{
+				{Step: "one", Output: &runtime.RawExtension{Raw: []byte(`{"cool":true}`)}},
+			},
+			AppliedResourceRefs: []v1alpha1.AppliedResourceRef{
+				{APIVersion: "example.org/v1", Kind: "XR", Name: "cool-xr"},
+			},
+		},
+	}
+	op.SetConditions(v1alpha1.Failed("boom"))
+
+	want := &Record{
+		Name:                "cool-op",
+		UID:                 "cool-uid",
+		Labels:              map[string]string{v1alpha1.LabelCronOperationName: "cool-cron"},
+		Spec:                op.Spec,
+		Succeeded:           op.GetCondition(v1alpha1.TypeSucceeded),
+		Failures:            1,
+		Pipeline:            op.Status.Pipeline,
+		AppliedResourceRefs: op.Status.AppliedResourceRefs,
+	}
+
+	got := NewRecord(op)
+	if diff := cmp.Diff(want, got, cmpopts.IgnoreFields(Record{}, "RecordedAt")); diff != "" {
+		t.Errorf("NewRecord(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestMultiSink(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	cases := map[string]struct {
+		reason string
+		sinks  MultiSink
+		want   error
+	}{
+		"AllSucceed": {
+			reason: "We should return nil if all sinks store the record.",
+			sinks:  MultiSink{NopSink{}, NopSink{}},
+		},
+		"OneFails": {
+			reason: "We should return an error if any sink fails to store the record.",
+			sinks: MultiSink{NopSink{}, SinkFn(func(_ context.Context, _ *Record) error {
+				return errBoom
+			})},
+			want: errBoom,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.sinks.Store(context.Background(), &Record{})
+			if diff := cmp.Diff(tc.want, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nStore(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestFileSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	s := NewFileSink(path)
+
+	want := []*Record{
+		{Name: "one", UID: "uid-one"},
+		{Name: "two", UID: "uid-two"},
+	}
+
+	for _, r := range want {
+		if err := s.Store(context.Background(), r); err != nil {
+			t.Fatalf("Store(...): %s", err)
+		}
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("os.Open(...): %s", err)
+	}
+	defer f.Close() //nolint:errcheck // It's only a test.
+
+	got := make([]*Record, 0)
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		r := &Record{}
+		if err := json.Unmarshal(sc.Bytes(), r); err != nil {
+			t.Fatalf("json.Unmarshal(...): %s", err)
+		}
+		got = append(got, r)
+	}
+
+	if diff := cmp.Diff(want, got, cmpopts.IgnoreFields(Record{}, "RecordedAt", "CreatedAt", "Succeeded")); diff != "" {
+		t.Errorf("FileSink.Store(...): -want records, +got records:\n%s", diff)
+	}
+}
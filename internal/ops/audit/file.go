@@ -0,0 +1,67 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"sync"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+)
+
+// A FileSink appends records to a file as JSON lines.
+type FileSink struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileSink returns a sink that appends records to the file at the supplied
+// path, creating it if it doesn't exist.
+func NewFileSink(path string) *FileSink {
+	return &FileSink{path: path}
+}
+
+// Store the supplied record by appending it to the file as a single line of
+// JSON. The file is synced to disk before Store returns.
+func (s *FileSink) Store(_ context.Context, r *Record) error {
+	j, err := json.Marshal(r)
+	if err != nil {
+		return errors.Wrap(err, "cannot marshal audit record to JSON")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return errors.Wrapf(err, "cannot open audit file %q", s.path)
+	}
+
+	if _, err := f.Write(append(j, '\n')); err != nil {
+		_ = f.Close()
+		return errors.Wrapf(err, "cannot write to audit file %q", s.path)
+	}
+
+	if err := f.Sync(); err != nil {
+		_ = f.Close()
+		return errors.Wrapf(err, "cannot sync audit file %q", s.path)
+	}
+
+	return errors.Wrapf(f.Close(), "cannot close audit file %q", s.path)
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+)
+
+const defaultWebhookTimeout = 10 * time.Second
+
+// A WebhookSink sends records to an HTTP endpoint.
+type WebhookSink struct {
+	url    string
+	client *http.Client
+}
+
+// A WebhookSinkOption configures a WebhookSink.
+type WebhookSinkOption func(s *WebhookSink)
+
+// WithHTTPClient configures the HTTP client used to send records.
+func WithHTTPClient(c *http.Client) WebhookSinkOption {
+	return func(s *WebhookSink) {
+		s.client = c
+	}
+}
+
+// NewWebhookSink returns a sink that POSTs each record as JSON to the supplied
+// URL.
+func NewWebhookSink(url string, o ...WebhookSinkOption) *WebhookSink {
+	s := &WebhookSink{url: url, client: &http.Client{Timeout: defaultWebhookTimeout}}
+	for _, fn := range o {
+		fn(s)
+	}
+	return s
+}
+
+// Store the supplied record by POSTing it as JSON to the webhook. The webhook
+// must return a 2xx status code for the record to be considered stored.
+func (s *WebhookSink) Store(ctx context.Context, r *Record) error {
+	j, err := json.Marshal(r)
+	if err != nil {
+		return errors.Wrap(err, "cannot marshal audit record to JSON")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(j))
+	if err != nil {
+		return errors.Wrap(err, "cannot create audit webhook request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	rsp, err := s.client.Do(req)
+	if err != nil {
+		// The HTTP client includes the URL in its errors. Don't leak any
+		// credentials it contains into events and logs.
+		ue := &url.Error{}
+		if errors.As(err, &ue) {
+			ue.URL = RedactURL(ue.URL)
+		}
+		return errors.Wrap(err, "cannot send audit record to webhook")
+	}
+	defer rsp.Body.Close() //nolint:errcheck // Nothing useful to do with this error.
+
+	// Drain the body so the connection can be reused.
+	_, _ = io.Copy(io.Discard, rsp.Body)
+
+	if rsp.StatusCode < 200 || rsp.StatusCode > 299 {
+		return errors.Errorf("audit webhook returned unexpected status %q", rsp.Status)
+	}
+
+	return nil
+}
+
+// RedactURL returns the supplied URL with any user information and query
+// string redacted, so it can be safely logged. Webhook URLs often embed
+// credentials in either. Unparseable URLs are redacted entirely.
+func RedactURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "REDACTED"
+	}
+	if u.User != nil {
+		u.User = url.User("REDACTED")
+	}
+	if u.RawQuery != "" {
+		u.RawQuery = "REDACTED"
+	}
+	u.Fragment = ""
+	return u.String()
+}
@@ -18,6 +18,8 @@ limitations under the License.
 package protection
 
 import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
 	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
 )
 
@@ -92,6 +94,32 @@ type Reasoned interface {
 	SetReason(reason *string)
 }
 
+// Condition is a condition of a resource.
+type Condition struct {
+	// Type of the condition.
+	Type string
+
+	// Status of the condition.
+	Status string
+}
+
+// An Expiring resource may expire, either at a fixed time or some time after
+// it was created.
+type Expiring interface {
+	GetExpiresAt() *metav1.Time
+	SetExpiresAt(t *metav1.Time)
+
+	GetTTL() *metav1.Duration
+	SetTTL(ttl *metav1.Duration)
+}
+
+// A Conditional resource only applies while the resource that uses another
+// resource has a condition.
+type Conditional interface {
+	GetWhile() *Condition
+	SetWhile(c *Condition)
+}
+
 // A Usage represents that a resource is in use.
 type Usage interface { //nolint:interfacebloat // This represents an API type - it has to be large.
 	resource.Object
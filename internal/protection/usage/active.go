@@ -0,0 +1,109 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package usage
+
+import (
+	"context"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	kunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/v2/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/fieldpath"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+
+	"github.com/crossplane/crossplane/v2/internal/protection"
+)
+
+// ExpiresAt returns the time at which the supplied usage expires, or nil if it
+// never expires. A usage with a TTL expires that long after it was created.
+func ExpiresAt(u protection.Usage) *time.Time {
+	e, ok := u.(protection.Expiring)
+	if !ok {
+		return nil
+	}
+
+	if t := e.GetExpiresAt(); t != nil {
+		return &t.Time
+	}
+
+	if ttl := e.GetTTL(); ttl != nil {
+		t := u.GetCreationTimestamp().Add(ttl.Duration)
+		return &t
+	}
+
+	return nil
+}
+
+// Expired returns true if the supplied usage has expired at the supplied time.
+func Expired(u protection.Usage, now time.Time) bool {
+	t := ExpiresAt(u)
+	return t != nil && !now.Before(*t)
+}
+
+// Blocking returns true if the supplied usage blocks deletion of the resource
+// it uses at the supplied time. An expired usage doesn't block deletion. A
+// conditional usage only blocks deletion while the resource that uses the
+// other resource exists and has the usage's condition.
+func Blocking(ctx context.Context, c client.Reader, u protection.Usage, now time.Time) (bool, error) {
+	if Expired(u, now) {
+		return false, nil
+	}
+
+	cu, ok := u.(protection.Conditional)
+	if !ok || cu.GetWhile() == nil {
+		return true, nil
+	}
+
+	by := u.GetUsedBy()
+	if by == nil || by.ResourceRef == nil {
+		// The using resource hasn't been resolved yet. Err on the side of
+		// blocking deletion.
+		return true, nil
+	}
+
+	using := &kunstructured.Unstructured{}
+	using.SetAPIVersion(by.APIVersion)
+	using.SetKind(by.Kind)
+
+	// The using resource will always be cluster scoped or in the same
+	// namespace as the usage.
+	nn := types.NamespacedName{Namespace: u.GetNamespace(), Name: by.ResourceRef.Name}
+	if err := c.Get(ctx, nn, using); err != nil {
+		if resource.IgnoreNotFound(err) == nil {
+			return false, nil
+		}
+		return false, errors.Wrapf(err, "cannot get using resource %s %q", by.Kind, nn.String())
+	}
+
+	w := cu.GetWhile()
+	want := corev1.ConditionStatus(w.Status)
+	if want == "" {
+		want = corev1.ConditionTrue
+	}
+
+	cs := xpv1.ConditionedStatus{}
+	// An error here means there are no conditions, or they're not the shape
+	// we expect. Either way, the resource doesn't have the condition.
+	_ = fieldpath.Pave(using.Object).GetValueInto("status", &cs)
+
+	return cs.GetCondition(xpv1.ConditionType(w.Type)).Status == want, nil
+}
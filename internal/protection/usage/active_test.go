@@ -0,0 +1,130 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package usage
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/test"
+
+	legacy "github.com/crossplane/crossplane/v2/apis/apiextensions/v1beta1"
+	"github.com/crossplane/crossplane/v2/apis/protection/v1beta1"
+	"github.com/crossplane/crossplane/v2/internal/protection"
+)
+
+func TestBlocking(t *testing.T) {
+	now := time.Now()
+
+	withStatus := func(status string) test.MockGetFn {
+		return test.NewMockGetFn(nil, func(obj client.Object) error {
+			obj.(*unstructured.Unstructured).Object["status"] = map[string]any{
+				"conditions": []any{map[string]any{"type": "Ready", "status": status}},
+			}
+			return nil
+		})
+	}
+
+	conditional := &v1beta1.Usage{
+		Spec: v1beta1.UsageSpec{
+			By:    &v1beta1.Resource{APIVersion: "example.org/v1", Kind: "App", ResourceRef: &v1beta1.ResourceRef{Name: "app"}},
+			While: &v1beta1.UsageCondition{Type: "Ready", Status: "True"},
+		},
+	}
+
+	type want struct {
+		blocking bool
+		err      error
+	}
+
+	cases := map[string]struct {
+		reason string
+		get    test.MockGetFn
+		u      protection.Usage
+		want   want
+	}{
+		"Legacy": {
+			reason: "A legacy Usage can't expire or be conditional, so it always blocks.",
+			u:      &legacy.Usage{}, //nolint:staticcheck // It's deprecated, but we still need to support it.
+			want:   want{blocking: true},
+		},
+		"ExpiredAt": {
+			reason: "A Usage should not block after it expires.",
+			u:      &v1beta1.Usage{Spec: v1beta1.UsageSpec{ExpiresAt: &metav1.Time{Time: now.Add(-time.Minute)}}},
+			want:   want{blocking: false},
+		},
+		"ExpiredTTL": {
+			reason: "A Usage should not block once its TTL has passed.",
+			u: &v1beta1.ClusterUsage{
+				ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(now.Add(-2 * time.Hour))},
+				Spec:       v1beta1.ClusterUsageSpec{TTL: &metav1.Duration{Duration: time.Hour}},
+			},
+			want: want{blocking: false},
+		},
+		"NotYetExpired": {
+			reason: "A Usage should block until it expires.",
+			u:      &v1beta1.Usage{Spec: v1beta1.UsageSpec{ExpiresAt: &metav1.Time{Time: now.Add(time.Minute)}}},
+			want:   want{blocking: true},
+		},
+		"ConditionHolds": {
+			reason: "A conditional Usage should block while the using resource has the condition.",
+			get:    withStatus("True"),
+			u:      conditional,
+			want:   want{blocking: true},
+		},
+		"ConditionDoesNotHold": {
+			reason: "A conditional Usage should not block while the using resource doesn't have the condition.",
+			get:    withStatus("False"),
+			u:      conditional,
+			want:   want{blocking: false},
+		},
+		"UsingResourceGone": {
+			reason: "A conditional Usage should not block if the using resource doesn't exist.",
+			get:    test.NewMockGetFn(kerrors.NewNotFound(schema.GroupResource{}, "app")),
+			u:      conditional,
+			want:   want{blocking: false},
+		},
+		"GetUsingResourceError": {
+			reason: "We should return an error if we can't get the using resource.",
+			get:    test.NewMockGetFn(errors.New("boom")),
+			u:      conditional,
+			want:   want{err: cmpopts.AnyError},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := Blocking(context.Background(), &test.MockClient{MockGet: tc.get}, tc.u, now)
+			if diff := cmp.Diff(tc.want.err, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nBlocking(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.blocking, got); diff != "" {
+				t.Errorf("\n%s\nBlocking(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,269 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package conversion contains the Handler for the composite resource conversion
+// webhook.
+package conversion
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"google.golang.org/protobuf/types/known/structpb"
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/logging"
+
+	v1 "github.com/crossplane/crossplane/v2/apis/apiextensions/v1"
+	"github.com/crossplane/crossplane/v2/internal/xfn"
+	fnv1 "github.com/crossplane/crossplane/v2/proto/fn/v1"
+)
+
+// Path at which the composite resource conversion webhook is served.
+const Path = "/convert-composite"
+
+// Error strings.
+const (
+	errDecodeReview       = "cannot decode ConversionReview"
+	errNoRequest          = "ConversionReview has no request"
+	errListXRDs           = "cannot list CompositeResourceDefinitions"
+	errUnmarshalInput     = "cannot unmarshal conversion function input"
+	errNoDesiredComposite = "conversion function returned no desired composite resource"
+	errFmtNoXRD           = "no CompositeResourceDefinition defines %s"
+	errFmtNoFunction      = "CompositeResourceDefinition %s has no conversion function"
+	errFmtDecodeObject    = "cannot decode object %d"
+	errFmtConvertObject   = "cannot convert %s %q"
+	errFmtRunFunction     = "cannot run conversion function %q"
+	errFmtFatalResult     = "conversion function %q returned a fatal result: %s"
+)
+
+// SetupWebhookWithManager sets up the webhook with the manager.
+func SetupWebhookWithManager(mgr ctrl.Manager, r xfn.FunctionRunner, options controller.Options) {
+	h := NewHandler(mgr.GetClient(), r, WithLogger(options.Logger.WithValues("webhook", "composite-conversion")))
+	mgr.GetWebhookServer().Register(Path, h)
+}
+
+// Handler serves the conversion webhook of composite resources whose XRD has a
+// conversion function. It converts each object by calling the function.
+type Handler struct {
+	client client.Reader
+	runner xfn.FunctionRunner
+	log    logging.Logger
+}
+
+// HandlerOption is used to configure the Handler.
+type HandlerOption func(*Handler)
+
+// WithLogger configures the logger for the Handler.
+func WithLogger(l logging.Logger) HandlerOption {
+	return func(h *Handler) {
+		h.log = l
+	}
+}
+
+// NewHandler returns a new Handler.
+func NewHandler(c client.Reader, r xfn.FunctionRunner, opts ...HandlerOption) *Handler {
+	h := &Handler{
+		client: c,
+		runner: r,
+		log:    logging.NewNopLogger(),
+	}
+
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	return h
+}
+
+// ServeHTTP handles a ConversionReview.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	review := &extv1.ConversionReview{}
+	if err := json.NewDecoder(io.LimitReader(r.Body, 32<<20)).Decode(review); err != nil {
+		h.log.Debug(errDecodeReview, "error", err)
+		http.Error(w, errors.Wrap(err, errDecodeReview).Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	if review.Request == nil {
+		http.Error(w, errNoRequest, http.StatusBadRequest)
+		return
+	}
+
+	review.Response = h.Convert(r.Context(), review.Request)
+	review.Request = nil
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(review); err != nil {
+		h.log.Debug("Cannot encode ConversionReview", "error", err)
+	}
+}
+
+// Convert the objects of the supplied request to the desired API version.
+func (h *Handler) Convert(ctx context.Context, req *extv1.ConversionRequest) *extv1.ConversionResponse {
+	rsp := &extv1.ConversionResponse{UID: req.UID}
+
+	converted, err := h.convert(ctx, req)
+	if err != nil {
+		h.log.Debug("Cannot convert composite resources", "error", err, "uid", req.UID, "desiredAPIVersion", req.DesiredAPIVersion)
+		rsp.Result = metav1.Status{Status: metav1.StatusFailure, Message: err.Error()}
+
+		return rsp
+	}
+
+	rsp.ConvertedObjects = converted
+	rsp.Result = metav1.Status{Status: metav1.StatusSuccess}
+
+	return rsp
+}
+
+func (h *Handler) convert(ctx context.Context, req *extv1.ConversionRequest) ([]runtime.RawExtension, error) {
+	out := make([]runtime.RawExtension, 0, len(req.Objects))
+
+	var xrd *v1.CompositeResourceDefinition
+
+	for i, raw := range req.Objects {
+		u := &kunstructured.Unstructured{}
+		if err := u.UnmarshalJSON(raw.Raw); err != nil {
+			return nil, errors.Wrapf(err, errFmtDecodeObject, i)
+		}
+
+		// Every object in a request is the same kind, so they're all
+		// converted by the same XRD's function.
+		if xrd == nil {
+			d, err := h.definition(ctx, u.GroupVersionKind().GroupKind())
+			if err != nil {
+				return nil, err
+			}
+
+			xrd = d
+		}
+
+		c, err := Convert(ctx, h.runner, xrd, u, req.DesiredAPIVersion)
+		if err != nil {
+			return nil, errors.Wrapf(err, errFmtConvertObject, u.GetKind(), u.GetName())
+		}
+
+		b, err := c.MarshalJSON()
+		if err != nil {
+			return nil, errors.Wrapf(err, errFmtConvertObject, u.GetKind(), u.GetName())
+		}
+
+		out = append(out, runtime.RawExtension{Raw: b})
+	}
+
+	return out, nil
+}
+
+// definition returns the XRD that defines the supplied kind.
+func (h *Handler) definition(ctx context.Context, gk schema.GroupKind) (*v1.CompositeResourceDefinition, error) {
+	l := &v1.CompositeResourceDefinitionList{}
+	if err := h.client.List(ctx, l); err != nil {
+		return nil, errors.Wrap(err, errListXRDs)
+	}
+
+	for i := range l.Items {
+		xrd := &l.Items[i]
+		if xrd.Spec.Group == gk.Group && xrd.Spec.Names.Kind == gk.Kind {
+			return xrd, nil
+		}
+	}
+
+	return nil, errors.Errorf(errFmtNoXRD, gk)
+}
+
+// Convert the supplied composite resource to the desired API version by
+// calling the XRD's conversion function.
+//
+// The function is sent the composite resource as its observed composite
+// resource. Its desired composite resource is a copy of the observed composite
+// resource with the desired API version. The function returns the converted
+// composite resource as its desired composite resource. Conversion may not
+// change a composite resource's metadata, so Crossplane restores it.
+func Convert(ctx context.Context, r xfn.FunctionRunner, xrd *v1.CompositeResourceDefinition, xr *kunstructured.Unstructured, apiVersion string) (*kunstructured.Unstructured, error) {
+	if xr.GetAPIVersion() == apiVersion {
+		return xr, nil
+	}
+
+	cf := xrd.Spec.ConversionFunction
+	if cf == nil {
+		return nil, errors.Errorf(errFmtNoFunction, xrd.GetName())
+	}
+
+	observed, err := xfn.AsStruct(xr)
+	if err != nil {
+		return nil, err
+	}
+
+	d := xr.DeepCopy()
+	d.SetAPIVersion(apiVersion)
+
+	desired, err := xfn.AsStruct(d)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &fnv1.RunFunctionRequest{
+		Observed: &fnv1.State{Composite: &fnv1.Resource{Resource: observed}},
+		Desired:  &fnv1.State{Composite: &fnv1.Resource{Resource: desired}},
+	}
+
+	if cf.Input != nil {
+		in := &structpb.Struct{}
+		if err := in.UnmarshalJSON(cf.Input.Raw); err != nil {
+			return nil, errors.Wrap(err, errUnmarshalInput)
+		}
+
+		req.Input = in
+	}
+
+	req.Meta = &fnv1.RequestMeta{Tag: xfn.Tag(req)}
+
+	rsp, err := r.RunFunction(ctx, cf.FunctionRef.Name, req)
+	if err != nil {
+		return nil, errors.Wrapf(err, errFmtRunFunction, cf.FunctionRef.Name)
+	}
+
+	for _, rs := range rsp.GetResults() {
+		if rs.GetSeverity() == fnv1.Severity_SEVERITY_FATAL {
+			return nil, errors.Errorf(errFmtFatalResult, cf.FunctionRef.Name, rs.GetMessage())
+		}
+	}
+
+	s := rsp.GetDesired().GetComposite().GetResource()
+	if s == nil {
+		return nil, errors.New(errNoDesiredComposite)
+	}
+
+	out := &kunstructured.Unstructured{Object: s.AsMap()}
+	out.SetAPIVersion(apiVersion)
+	out.SetKind(xr.GetKind())
+	out.Object["metadata"] = xr.DeepCopy().Object["metadata"]
+
+	return out, nil
+}
@@ -0,0 +1,248 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conversion
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/test"
+
+	v1 "github.com/crossplane/crossplane/v2/apis/apiextensions/v1"
+	"github.com/crossplane/crossplane/v2/internal/xfn"
+	fnv1 "github.com/crossplane/crossplane/v2/proto/fn/v1"
+)
+
+func xr(apiVersion string, spec map[string]any) *kunstructured.Unstructured {
+	return &kunstructured.Unstructured{Object: map[string]any{
+		"apiVersion": apiVersion,
+		"kind":       "XDatabase",
+		"metadata": map[string]any{
+			"name":      "cool-db",
+			"namespace": "default",
+			"uid":       "cool-uid",
+		},
+		"spec": spec,
+	}}
+}
+
+// renameSize converts a v1alpha1 XDatabase, which has spec.size, to a v1
+// XDatabase, which has spec.storageGB.
+func renameSize(_ context.Context, _ string, req *fnv1.RunFunctionRequest) (*fnv1.RunFunctionResponse, error) {
+	d := req.GetDesired().GetComposite().GetResource().AsMap()
+
+	spec, _ := d["spec"].(map[string]any)
+	spec["storageGB"] = spec["size"]
+	delete(spec, "size")
+
+	// Conversion functions can't change metadata.
+	d["metadata"] = map[string]any{"name": "renamed"}
+
+	s, err := xfn.AsStruct(&kunstructured.Unstructured{Object: d})
+	if err != nil {
+		return nil, err
+	}
+
+	return &fnv1.RunFunctionResponse{Desired: &fnv1.State{Composite: &fnv1.Resource{Resource: s}}}, nil
+}
+
+func TestConvert(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	xrd := &v1.CompositeResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: "xdatabases.example.org"},
+		Spec: v1.CompositeResourceDefinitionSpec{
+			ConversionFunction: &v1.ConversionFunction{
+				FunctionRef: v1.FunctionReference{Name: "function-convert"},
+			},
+		},
+	}
+
+	type args struct {
+		r          xfn.FunctionRunner
+		xrd        *v1.CompositeResourceDefinition
+		xr         *kunstructured.Unstructured
+		apiVersion string
+	}
+
+	type want struct {
+		xr  *kunstructured.Unstructured
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"SameVersion": {
+			reason: "We shouldn't call the function if the XR is already the desired version.",
+			args: args{
+				xrd:        xrd,
+				xr:         xr("example.org/v1", map[string]any{"storageGB": 10.0}),
+				apiVersion: "example.org/v1",
+			},
+			want: want{
+				xr: xr("example.org/v1", map[string]any{"storageGB": 10.0}),
+			},
+		},
+		"NoConversionFunction": {
+			reason: "We should return an error if the XRD has no conversion function.",
+			args: args{
+				xrd:        &v1.CompositeResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "xdatabases.example.org"}},
+				xr:         xr("example.org/v1alpha1", map[string]any{"size": 10.0}),
+				apiVersion: "example.org/v1",
+			},
+			want: want{
+				err: errors.Errorf(errFmtNoFunction, "xdatabases.example.org"),
+			},
+		},
+		"RunFunctionError": {
+			reason: "We should return an error if we can't run the function.",
+			args: args{
+				r: xfn.FunctionRunnerFn(func(_ context.Context, _ string, _ *fnv1.RunFunctionRequest) (*fnv1.RunFunctionResponse, error) {
+					return nil, errBoom
+				}),
+				xrd:        xrd,
+				xr:         xr("example.org/v1alpha1", map[string]any{"size": 10.0}),
+				apiVersion: "example.org/v1",
+			},
+			want: want{
+				err: errors.Wrapf(errBoom, errFmtRunFunction, "function-convert"),
+			},
+		},
+		"FatalResult": {
+			reason: "We should return an error if the function returns a fatal result.",
+			args: args{
+				r: xfn.FunctionRunnerFn(func(_ context.Context, _ string, _ *fnv1.RunFunctionRequest) (*fnv1.RunFunctionResponse, error) {
+					return &fnv1.RunFunctionResponse{Results: []*fnv1.Result{{Severity: fnv1.Severity_SEVERITY_FATAL, Message: "unsupported version"}}}, nil
+				}),
+				xrd:        xrd,
+				xr:         xr("example.org/v1alpha1", map[string]any{"size": 10.0}),
+				apiVersion: "example.org/v1",
+			},
+			want: want{
+				err: errors.Errorf(errFmtFatalResult, "function-convert", "unsupported version"),
+			},
+		},
+		"NoDesiredComposite": {
+			reason: "We should return an error if the function doesn't return a desired XR.",
+			args: args{
+				r: xfn.FunctionRunnerFn(func(_ context.Context, _ string, _ *fnv1.RunFunctionRequest) (*fnv1.RunFunctionResponse, error) {
+					return &fnv1.RunFunctionResponse{}, nil
+				}),
+				xrd:        xrd,
+				xr:         xr("example.org/v1alpha1", map[string]any{"size": 10.0}),
+				apiVersion: "example.org/v1",
+			},
+			want: want{
+				err: errors.New(errNoDesiredComposite),
+			},
+		},
+		"Converted": {
+			reason: "We should return the function's desired XR, with the desired API version and the original metadata.",
+			args: args{
+				r:          xfn.FunctionRunnerFn(renameSize),
+				xrd:        xrd,
+				xr:         xr("example.org/v1alpha1", map[string]any{"size": 10.0}),
+				apiVersion: "example.org/v1",
+			},
+			want: want{
+				xr: xr("example.org/v1", map[string]any{"storageGB": 10.0}),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := Convert(context.Background(), tc.args.r, tc.args.xrd, tc.args.xr, tc.args.apiVersion)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nConvert(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+
+			if diff := cmp.Diff(tc.want.xr, got); diff != "" {
+				t.Errorf("\n%s\nConvert(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestServeHTTP(t *testing.T) {
+	c := &test.MockClient{
+		MockList: test.NewMockListFn(nil, func(o client.ObjectList) error {
+			l := o.(*v1.CompositeResourceDefinitionList)
+			l.Items = []v1.CompositeResourceDefinition{{
+				ObjectMeta: metav1.ObjectMeta{Name: "xdatabases.example.org"},
+				Spec: v1.CompositeResourceDefinitionSpec{
+					Group:              "example.org",
+					Names:              extv1.CustomResourceDefinitionNames{Kind: "XDatabase"},
+					ConversionFunction: &v1.ConversionFunction{FunctionRef: v1.FunctionReference{Name: "function-convert"}},
+				},
+			}}
+
+			return nil
+		}),
+	}
+
+	in, _ := xr("example.org/v1alpha1", map[string]any{"size": 10.0}).MarshalJSON()
+	out, _ := xr("example.org/v1", map[string]any{"storageGB": 10.0}).MarshalJSON()
+
+	review := &extv1.ConversionReview{
+		TypeMeta: metav1.TypeMeta{APIVersion: "apiextensions.k8s.io/v1", Kind: "ConversionReview"},
+		Request: &extv1.ConversionRequest{
+			UID:               types.UID("cool-review"),
+			DesiredAPIVersion: "example.org/v1",
+			Objects:           []runtime.RawExtension{{Raw: in}},
+		},
+	}
+
+	body, _ := json.Marshal(review)
+
+	w := httptest.NewRecorder()
+	NewHandler(c, xfn.FunctionRunnerFn(renameSize)).ServeHTTP(w, httptest.NewRequest(http.MethodPost, Path, bytes.NewReader(body)))
+
+	got := &extv1.ConversionReview{}
+	if err := json.Unmarshal(w.Body.Bytes(), got); err != nil {
+		t.Fatalf("json.Unmarshal(...): %v", err)
+	}
+
+	want := &extv1.ConversionReview{
+		TypeMeta: metav1.TypeMeta{APIVersion: "apiextensions.k8s.io/v1", Kind: "ConversionReview"},
+		Response: &extv1.ConversionResponse{
+			UID:              types.UID("cool-review"),
+			ConvertedObjects: []runtime.RawExtension{{Raw: bytes.TrimSpace(out)}},
+			Result:           metav1.Status{Status: metav1.StatusSuccess},
+		},
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ServeHTTP(...): -want, +got:\n%s", diff)
+	}
+}
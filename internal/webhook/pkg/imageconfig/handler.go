@@ -0,0 +1,121 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package imageconfig contains the Handler for the ImageConfig webhook.
+package imageconfig
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/logging"
+
+	"github.com/crossplane/crossplane/v2/apis/pkg/v1beta1"
+	"github.com/crossplane/crossplane/v2/internal/controller/pkg/signature"
+)
+
+// Error strings.
+const (
+	errFmtUnexpectedOp = "unexpected operation %q, expected \"CREATE\" or \"UPDATE\""
+)
+
+// SetupWebhookWithManager sets up the webhook with the manager.
+func SetupWebhookWithManager(mgr ctrl.Manager, options controller.Options) {
+	h := NewHandler(WithLogger(options.Logger.WithValues("webhook", "imageconfigs")))
+	mgr.GetWebhookServer().Register("/validate-imageconfigs", &webhook.Admission{Handler: h})
+}
+
+// Handler implements the admission Handler for ImageConfig.
+type Handler struct {
+	log logging.Logger
+}
+
+// HandlerOption is used to configure the Handler.
+type HandlerOption func(*Handler)
+
+// WithLogger configures the logger for the Handler.
+func WithLogger(l logging.Logger) HandlerOption {
+	return func(h *Handler) {
+		h.log = l
+	}
+}
+
+// NewHandler returns a new Handler.
+func NewHandler(opts ...HandlerOption) *Handler {
+	h := &Handler{
+		log: logging.NewNopLogger(),
+	}
+
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	return h
+}
+
+// Handle handles the admission request, validating the ImageConfig's
+// attestation policies compile.
+func (h *Handler) Handle(_ context.Context, request admission.Request) admission.Response {
+	switch request.Operation {
+	case admissionv1.Create, admissionv1.Update:
+		ic := &v1beta1.ImageConfig{}
+		if err := json.Unmarshal(request.Object.Raw, ic); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+
+		if errs := validatePolicies(ic); len(errs) > 0 {
+			h.log.Debug("Invalid ImageConfig", "name", ic.GetName(), "err", errs.ToAggregate())
+			return admission.Denied(errs.ToAggregate().Error())
+		}
+
+		return admission.Allowed("")
+	default:
+		return admission.Errored(http.StatusBadRequest, errors.Errorf(errFmtUnexpectedOp, request.Operation))
+	}
+}
+
+// validatePolicies returns an error for each of the supplied ImageConfig's
+// attestation policies that doesn't compile.
+func validatePolicies(ic *v1beta1.ImageConfig) field.ErrorList {
+	v := ic.Spec.Verification
+	if v == nil || v.Cosign == nil {
+		return nil
+	}
+
+	var errs field.ErrorList
+
+	for i, a := range v.Cosign.Authorities {
+		for j, att := range a.Attestations {
+			for k, p := range att.Policies {
+				if _, err := signature.CompileAttestationPolicy(p.Expression); err != nil {
+					path := field.NewPath("spec", "verification", "cosign", "authorities").Index(i).Child("attestations").Index(j).Child("policies").Index(k).Child("expression")
+					errs = append(errs, field.Invalid(path, p.Expression, err.Error()))
+				}
+			}
+		}
+	}
+
+	return errs
+}
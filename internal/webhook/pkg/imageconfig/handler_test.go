@@ -0,0 +1,112 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package imageconfig
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/crossplane/crossplane/v2/apis/pkg/v1beta1"
+)
+
+var _ admission.Handler = &Handler{}
+
+func TestHandle(t *testing.T) {
+	withPolicy := func(expr string) *v1beta1.ImageConfig {
+		return &v1beta1.ImageConfig{
+			Spec: v1beta1.ImageConfigSpec{
+				Verification: &v1beta1.ImageVerification{
+					Provider: v1beta1.ImageVerificationProviderCosign,
+					Cosign: &v1beta1.CosignVerificationConfig{
+						Authorities: []v1beta1.CosignAuthority{{
+							Name: "acme",
+							Attestations: []v1beta1.Attestation{{
+								Name:          "provenance",
+								PredicateType: "slsaprovenance",
+								Policies:      []v1beta1.AttestationPolicy{{Name: "trusted-builder", Expression: expr}},
+							}},
+						}},
+					},
+				},
+			},
+		}
+	}
+
+	type args struct {
+		op admissionv1.Operation
+		ic *v1beta1.ImageConfig
+	}
+
+	cases := map[string]struct {
+		reason  string
+		args    args
+		allowed bool
+	}{
+		"UnexpectedDelete": {
+			reason: "We should not allow a request that isn't a create or update.",
+			args: args{
+				op: admissionv1.Delete,
+				ic: &v1beta1.ImageConfig{},
+			},
+			allowed: false,
+		},
+		"NoVerification": {
+			reason:  "We should allow an ImageConfig that doesn't verify images.",
+			args:    args{op: admissionv1.Create, ic: &v1beta1.ImageConfig{}},
+			allowed: true,
+		},
+		"ValidPolicy": {
+			reason:  "We should allow an ImageConfig whose attestation policies compile.",
+			args:    args{op: admissionv1.Update, ic: withPolicy("self.predicate.builder.id.startsWith('https://github.com/crossplane/')")},
+			allowed: true,
+		},
+		"InvalidPolicy": {
+			reason:  "We should deny an ImageConfig with an attestation policy that doesn't compile.",
+			args:    args{op: admissionv1.Create, ic: withPolicy("self.predicate.builder.id.startsWith(")},
+			allowed: false,
+		},
+		"PolicyNotBool": {
+			reason:  "We should deny an ImageConfig with an attestation policy that doesn't return a bool.",
+			args:    args{op: admissionv1.Create, ic: withPolicy("'nope'")},
+			allowed: false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			raw, err := json.Marshal(tc.args.ic)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			req := admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+				Operation: tc.args.op,
+				Object:    runtime.RawExtension{Raw: raw},
+			}}
+
+			got := NewHandler().Handle(context.Background(), req)
+			if got.Allowed != tc.allowed {
+				t.Errorf("\n%s\nHandle(...): want allowed %t, got %t: %v", tc.reason, tc.allowed, got.Allowed, got.Result)
+			}
+		})
+	}
+}
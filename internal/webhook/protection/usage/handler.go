@@ -21,6 +21,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"time"
 
 	admissionv1 "k8s.io/api/admission/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -119,12 +120,27 @@ func (h *Handler) Handle(ctx context.Context, request admission.Request) admissi
 
 		log.Debug("Validating no usages")
 
-		usages, err := h.resource.FindUsageOf(ctx, u)
+		found, err := h.resource.FindUsageOf(ctx, u)
 		if err != nil {
 			log.Debug("Error when getting usages", "err", err)
 			return admission.Errored(http.StatusInternalServerError, err)
 		}
 
+		// Usages that have expired, or whose condition doesn't hold, don't
+		// block deletion.
+		now := time.Now()
+		usages := make([]protection.Usage, 0, len(found))
+		for _, us := range found {
+			blocking, err := usage.Blocking(ctx, h.client, us, now)
+			if err != nil {
+				log.Debug("Error when checking whether usage blocks deletion", "err", err, "usage", us.GetName())
+				return admission.Errored(http.StatusInternalServerError, err)
+			}
+			if blocking {
+				usages = append(usages, us)
+			}
+		}
+
 		if len(usages) == 0 {
 			log.Debug("No usages found, deletion allowed")
 			return admission.Allowed("")
@@ -20,10 +20,12 @@ import (
 	"context"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	admissionv1 "k8s.io/api/admission/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
@@ -360,6 +362,163 @@ func TestHandle(t *testing.T) {
 				},
 			},
 		},
+		"DeleteAllowedExpiredUsage": {
+			reason: "We should allow a delete request if all usages for the given object have expired.",
+			params: params{
+				f: FinderFn(func(_ context.Context, _ usage.Object) ([]protection.Usage, error) {
+					return []protection.Usage{
+						&v1beta1.Usage{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: "expired",
+							},
+							Spec: v1beta1.UsageSpec{
+								Of: v1beta1.NamespacedResource{
+									APIVersion:  "nop.crossplane.io/v1alpha1",
+									Kind:        "NopResource",
+									ResourceRef: &v1beta1.NamespacedResourceRef{Name: "used-resource"},
+								},
+								Reason:    &protected,
+								ExpiresAt: &metav1.Time{Time: time.Now().Add(-1 * time.Hour)},
+							},
+						},
+					}, nil
+				}),
+			},
+			args: args{
+				request: admission.Request{
+					AdmissionRequest: admissionv1.AdmissionRequest{
+						Operation: admissionv1.Delete,
+						OldObject: runtime.RawExtension{
+							Raw: []byte(`{
+								"apiVersion": "nop.crossplane.io/v1alpha1",
+								"kind": "NopResource",
+								"metadata": {
+									"name": "used-resource"
+								}}`),
+						},
+					},
+				},
+			},
+			want: want{
+				resp: admission.Allowed(""),
+			},
+		},
+		"DeleteAllowedConditionNotMet": {
+			reason: "We should allow a delete request if the using resource doesn't have a conditional usage's condition.",
+			params: params{
+				client: &test.MockClient{
+					MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+						obj.(*unstructured.Unstructured).Object["status"] = map[string]any{
+							"conditions": []any{map[string]any{"type": "Ready", "status": "False"}},
+						}
+						return nil
+					}),
+					MockPatch: test.NewMockPatchFn(nil),
+				},
+				f: FinderFn(func(_ context.Context, _ usage.Object) ([]protection.Usage, error) {
+					return []protection.Usage{
+						&v1beta1.Usage{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: "used-while-ready",
+							},
+							Spec: v1beta1.UsageSpec{
+								Of: v1beta1.NamespacedResource{
+									APIVersion:  "nop.crossplane.io/v1alpha1",
+									Kind:        "NopResource",
+									ResourceRef: &v1beta1.NamespacedResourceRef{Name: "used-resource"},
+								},
+								By: &v1beta1.Resource{
+									APIVersion:  "nop.crossplane.io/v1alpha1",
+									Kind:        "NopResource",
+									ResourceRef: &v1beta1.ResourceRef{Name: "using-resource"},
+								},
+								While: &v1beta1.UsageCondition{Type: "Ready", Status: "True"},
+							},
+						},
+					}, nil
+				}),
+			},
+			args: args{
+				request: admission.Request{
+					AdmissionRequest: admissionv1.AdmissionRequest{
+						Operation: admissionv1.Delete,
+						OldObject: runtime.RawExtension{
+							Raw: []byte(`{
+								"apiVersion": "nop.crossplane.io/v1alpha1",
+								"kind": "NopResource",
+								"metadata": {
+									"name": "used-resource"
+								}}`),
+						},
+					},
+				},
+			},
+			want: want{
+				resp: admission.Allowed(""),
+			},
+		},
+		"DeleteBlockedConditionMet": {
+			reason: "We should reject a delete request if the using resource has a conditional usage's condition.",
+			params: params{
+				client: &test.MockClient{
+					MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+						obj.(*unstructured.Unstructured).Object["status"] = map[string]any{
+							"conditions": []any{map[string]any{"type": "Ready", "status": "True"}},
+						}
+						return nil
+					}),
+					MockPatch: test.NewMockPatchFn(nil),
+				},
+				f: FinderFn(func(_ context.Context, _ usage.Object) ([]protection.Usage, error) {
+					return []protection.Usage{
+						&v1beta1.Usage{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: "used-while-ready",
+							},
+							Spec: v1beta1.UsageSpec{
+								Of: v1beta1.NamespacedResource{
+									APIVersion:  "nop.crossplane.io/v1alpha1",
+									Kind:        "NopResource",
+									ResourceRef: &v1beta1.NamespacedResourceRef{Name: "used-resource"},
+								},
+								By: &v1beta1.Resource{
+									APIVersion:  "nop.crossplane.io/v1alpha1",
+									Kind:        "NopResource",
+									ResourceRef: &v1beta1.ResourceRef{Name: "using-resource"},
+								},
+								While: &v1beta1.UsageCondition{Type: "Ready", Status: "True"},
+							},
+						},
+					}, nil
+				}),
+			},
+			args: args{
+				request: admission.Request{
+					AdmissionRequest: admissionv1.AdmissionRequest{
+						Operation: admissionv1.Delete,
+						OldObject: runtime.RawExtension{
+							Raw: []byte(`{
+								"apiVersion": "nop.crossplane.io/v1alpha1",
+								"kind": "NopResource",
+								"metadata": {
+									"name": "used-resource"
+								}}`),
+						},
+					},
+				},
+			},
+			want: want{
+				resp: admission.Response{
+					AdmissionResponse: admissionv1.AdmissionResponse{
+						Allowed: false,
+						Result: &metav1.Status{
+							Code:   int32(http.StatusConflict),
+							Reason: metav1.StatusReason("This resource is in-use by 1 usage(s), including the *v1beta1.Usage \"used-while-ready\" by resource NopResource/using-resource."),
+						},
+					},
+				},
+			},
+		},
 	}
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
@@ -0,0 +1,426 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package xcrd
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+
+	v1 "github.com/crossplane/crossplane/v2/apis/apiextensions/v1"
+)
+
+const errFmtDenyBreaking = "refusing to apply CustomResourceDefinition (set the %s annotation to \"true\" to allow breaking changes)"
+
+// AnnotationKeyAllowBreakingChanges allows an XRD to make breaking changes to
+// the schemas of the CRDs it defines when set to "true".
+const AnnotationKeyAllowBreakingChanges = "apiextensions.crossplane.io/allow-breaking-changes"
+
+// AllowsBreakingChanges returns true if the supplied object is annotated to
+// allow breaking schema changes.
+func AllowsBreakingChanges(o metav1.Object) bool {
+	return o.GetAnnotations()[AnnotationKeyAllowBreakingChanges] == "true"
+}
+
+// A SchemaChange is a change between two OpenAPI v3 schemas.
+type SchemaChange struct {
+	// Path of the changed field, e.g. spec.parameters.region. Array items
+	// and map values are represented as [*].
+	Path string `json:"path"`
+
+	// Message describing the change.
+	Message string `json:"message"`
+
+	// Breaking is true if objects that were valid under the old schema may
+	// be invalid under the new schema.
+	Breaking bool `json:"breaking"`
+}
+
+// Breaking returns the breaking changes of the supplied changes.
+func Breaking(changes []SchemaChange) []SchemaChange {
+	out := make([]SchemaChange, 0, len(changes))
+
+	for _, c := range changes {
+		if c.Breaking {
+			out = append(out, c)
+		}
+	}
+
+	return out
+}
+
+// CompareSchemas returns the changes between the supplied old and new
+// schemas, sorted by path. A change is breaking if objects that were valid
+// under the old schema may be invalid under the new schema. Removing a field,
+// changing its type, making it required, narrowing its enum, and tightening
+// its validation are breaking changes. Descriptions and defaults are ignored.
+func CompareSchemas(o, n *extv1.JSONSchemaProps) []SchemaChange {
+	return compareAt("", o, n)
+}
+
+func compareAt(path string, o, n *extv1.JSONSchemaProps) []SchemaChange {
+	c := &comparer{}
+	c.compare(path, orEmpty(o), orEmpty(n))
+
+	sort.SliceStable(c.changes, func(i, j int) bool {
+		return c.changes[i].Path < c.changes[j].Path
+	})
+
+	return c.changes
+}
+
+// A VersionChange is a change to a version of a CRD.
+type VersionChange struct {
+	// Version of the CRD that changed.
+	Version string `json:"version"`
+
+	SchemaChange
+}
+
+// CompareCRDs returns the changes between the spec schemas of the served
+// versions of the supplied old and new CRDs. A version that's no longer served
+// is a breaking change. Versions that are newly served aren't changes.
+// Crossplane owns the schemas of metadata, status, and the spec fields it
+// injects into the CRDs XRDs define, so changes to them are ignored.
+func CompareCRDs(o, n *extv1.CustomResourceDefinition) []VersionChange {
+	newVersions := map[string]extv1.CustomResourceDefinitionVersion{}
+	for _, v := range n.Spec.Versions {
+		newVersions[v.Name] = v
+	}
+
+	var changes []VersionChange
+
+	for _, ov := range o.Spec.Versions {
+		if !ov.Served {
+			continue
+		}
+
+		nv, ok := newVersions[ov.Name]
+		if !ok || !nv.Served {
+			changes = append(changes, VersionChange{Version: ov.Name, SchemaChange: SchemaChange{Message: "version is no longer served", Breaking: true}})
+			continue
+		}
+
+		for _, c := range compareAt("spec", specSchema(ov), specSchema(nv)) {
+			changes = append(changes, VersionChange{Version: ov.Name, SchemaChange: c})
+		}
+	}
+
+	return changes
+}
+
+// specSchema returns the spec schema of the supplied version, without the
+// fields Crossplane injects.
+func specSchema(v extv1.CustomResourceDefinitionVersion) *extv1.JSONSchemaProps {
+	if v.Schema == nil || v.Schema.OpenAPIV3Schema == nil {
+		return nil
+	}
+
+	spec, ok := v.Schema.OpenAPIV3Schema.Properties["spec"]
+	if !ok {
+		return nil
+	}
+
+	spec = *spec.DeepCopy()
+	for _, name := range injectedSpecProps() {
+		delete(spec.Properties, name)
+	}
+
+	return &spec
+}
+
+// injectedSpecProps returns the names of the spec fields Crossplane injects
+// into composite resource and claim CRDs, regardless of their scope.
+func injectedSpecProps() []string {
+	names := GetPropFields(CompositeResourceSpecProps(v1.CompositeResourceScopeLegacyCluster, nil))
+	names = append(names, GetPropFields(CompositeResourceSpecProps(v1.CompositeResourceScopeNamespaced, nil))...)
+
+	return append(names, GetPropFields(CompositeResourceClaimSpecProps(nil))...)
+}
+
+// BreakingChangesError is returned when a CRD would make breaking changes to
+// its schema.
+type BreakingChangesError struct {
+	Changes []VersionChange
+}
+
+// Error returns the breaking changes.
+func (e BreakingChangesError) Error() string {
+	msgs := make([]string, 0, len(e.Changes))
+
+	for _, c := range e.Changes {
+		if c.Path == "" {
+			msgs = append(msgs, fmt.Sprintf("%s: %s", c.Version, c.Message))
+			continue
+		}
+
+		msgs = append(msgs, fmt.Sprintf("%s: %s: %s", c.Version, c.Path, c.Message))
+	}
+
+	return "breaking schema changes: " + strings.Join(msgs, "; ")
+}
+
+// breakingChanges returns an error if the desired CRD would make breaking
+// changes to the current CRD.
+func breakingChanges(current, desired runtime.Object) error {
+	c, ok := current.(*extv1.CustomResourceDefinition)
+	if !ok {
+		return nil
+	}
+
+	d, ok := desired.(*extv1.CustomResourceDefinition)
+	if !ok {
+		return nil
+	}
+
+	var breaking []VersionChange
+
+	for _, vc := range CompareCRDs(c, d) {
+		if vc.Breaking {
+			breaking = append(breaking, vc)
+		}
+	}
+
+	if len(breaking) == 0 {
+		return nil
+	}
+
+	return BreakingChangesError{Changes: breaking}
+}
+
+// DenyBreakingChanges returns an ApplyOption that returns a wrapped
+// BreakingChangesError if the desired CRD would make breaking changes to the
+// schema of a version the current CRD serves.
+func DenyBreakingChanges() resource.ApplyOption {
+	return func(_ context.Context, current, desired runtime.Object) error {
+		return errors.Wrapf(breakingChanges(current, desired), errFmtDenyBreaking, AnnotationKeyAllowBreakingChanges)
+	}
+}
+
+// WarnBreakingChanges returns an ApplyOption that calls the supplied function
+// with a BreakingChangesError if the desired CRD would make breaking changes
+// to the schema of a version the current CRD serves. The CRD is still
+// applied.
+func WarnBreakingChanges(fn func(err error)) resource.ApplyOption {
+	return func(_ context.Context, current, desired runtime.Object) error {
+		if err := breakingChanges(current, desired); err != nil {
+			fn(err)
+		}
+
+		return nil
+	}
+}
+
+func orEmpty(s *extv1.JSONSchemaProps) *extv1.JSONSchemaProps {
+	if s == nil {
+		return &extv1.JSONSchemaProps{}
+	}
+
+	return s
+}
+
+type comparer struct {
+	changes []SchemaChange
+}
+
+func (c *comparer) add(path string, breaking bool, format string, args ...any) {
+	c.changes = append(c.changes, SchemaChange{Path: path, Message: fmt.Sprintf(format, args...), Breaking: breaking})
+}
+
+func (c *comparer) compare(path string, o, n *extv1.JSONSchemaProps) {
+	if o.Type != n.Type {
+		c.add(path, true, "type changed from %q to %q", o.Type, n.Type)
+		// Other changes aren't meaningful once the type has changed.
+		return
+	}
+
+	if o.XPreserveUnknownFields != nil && *o.XPreserveUnknownFields && (n.XPreserveUnknownFields == nil || !*n.XPreserveUnknownFields) {
+		c.add(path, true, "unknown fields are no longer preserved")
+	}
+
+	c.compareEnum(path, o, n)
+	c.compareValidation(path, o, n)
+	c.compareProperties(path, o, n)
+
+	if o.Items != nil && o.Items.Schema != nil && n.Items != nil && n.Items.Schema != nil {
+		c.compare(path+"[*]", o.Items.Schema, n.Items.Schema)
+	}
+
+	if o.AdditionalProperties != nil && o.AdditionalProperties.Schema != nil && n.AdditionalProperties != nil && n.AdditionalProperties.Schema != nil {
+		c.compare(path+"[*]", o.AdditionalProperties.Schema, n.AdditionalProperties.Schema)
+	}
+}
+
+func (c *comparer) compareProperties(path string, o, n *extv1.JSONSchemaProps) {
+	oldRequired := sets.New(o.Required...)
+	newRequired := sets.New(n.Required...)
+
+	for _, name := range sortedKeys(o.Properties) {
+		p := join(path, name)
+
+		np, ok := n.Properties[name]
+		if !ok {
+			c.add(p, true, "field removed")
+			continue
+		}
+
+		op := o.Properties[name]
+		c.compare(p, &op, &np)
+
+		switch {
+		case newRequired.Has(name) && !oldRequired.Has(name):
+			c.add(p, true, "field is now required")
+		case oldRequired.Has(name) && !newRequired.Has(name):
+			c.add(p, false, "field is no longer required")
+		}
+	}
+
+	for _, name := range sortedKeys(n.Properties) {
+		if _, ok := o.Properties[name]; ok {
+			continue
+		}
+
+		if newRequired.Has(name) {
+			c.add(join(path, name), true, "required field added")
+			continue
+		}
+
+		c.add(join(path, name), false, "field added")
+	}
+}
+
+func (c *comparer) compareEnum(path string, o, n *extv1.JSONSchemaProps) {
+	oldEnum := enumValues(o.Enum)
+	newEnum := enumValues(n.Enum)
+
+	switch {
+	case len(oldEnum) == 0 && len(newEnum) > 0:
+		c.add(path, true, "values restricted to enum %s", strings.Join(sets.List(newEnum), ", "))
+	case len(oldEnum) > 0 && len(newEnum) == 0:
+		c.add(path, false, "enum removed")
+	default:
+		if removed := oldEnum.Difference(newEnum); removed.Len() > 0 {
+			c.add(path, true, "enum values removed: %s", strings.Join(sets.List(removed), ", "))
+		}
+
+		if added := newEnum.Difference(oldEnum); added.Len() > 0 {
+			c.add(path, false, "enum values added: %s", strings.Join(sets.List(added), ", "))
+		}
+	}
+}
+
+func (c *comparer) compareValidation(path string, o, n *extv1.JSONSchemaProps) {
+	if o.Pattern != n.Pattern {
+		c.add(path, n.Pattern != "", "pattern changed from %q to %q", o.Pattern, n.Pattern)
+	}
+
+	if o.Format != n.Format {
+		c.add(path, n.Format != "", "format changed from %q to %q", o.Format, n.Format)
+	}
+
+	// A tighter upper bound is breaking, as is a new one.
+	upper := func(name string, ov, nv *float64) {
+		switch {
+		case ov == nil && nv != nil:
+			c.add(path, true, "%s of %v added", name, *nv)
+		case ov != nil && nv == nil:
+			c.add(path, false, "%s removed", name)
+		case ov != nil && nv != nil && *nv != *ov:
+			c.add(path, *nv < *ov, "%s changed from %v to %v", name, *ov, *nv)
+		}
+	}
+
+	// A tighter lower bound is breaking, as is a new one.
+	lower := func(name string, ov, nv *float64) {
+		switch {
+		case ov == nil && nv != nil:
+			c.add(path, true, "%s of %v added", name, *nv)
+		case ov != nil && nv == nil:
+			c.add(path, false, "%s removed", name)
+		case ov != nil && nv != nil && *nv != *ov:
+			c.add(path, *nv > *ov, "%s changed from %v to %v", name, *ov, *nv)
+		}
+	}
+
+	upper("maximum", o.Maximum, n.Maximum)
+	lower("minimum", o.Minimum, n.Minimum)
+	upper("maxLength", float(o.MaxLength), float(n.MaxLength))
+	lower("minLength", float(o.MinLength), float(n.MinLength))
+	upper("maxItems", float(o.MaxItems), float(n.MaxItems))
+	lower("minItems", float(o.MinItems), float(n.MinItems))
+	upper("maxProperties", float(o.MaxProperties), float(n.MaxProperties))
+	lower("minProperties", float(o.MinProperties), float(n.MinProperties))
+
+	oldRules := sets.New[string]()
+	for _, r := range o.XValidations {
+		oldRules.Insert(r.Rule)
+	}
+
+	for _, r := range n.XValidations {
+		if !oldRules.Has(r.Rule) {
+			c.add(path, true, "validation rule added: %s", r.Rule)
+		}
+	}
+}
+
+func float(i *int64) *float64 {
+	if i == nil {
+		return nil
+	}
+
+	f := float64(*i)
+
+	return &f
+}
+
+func enumValues(enum []extv1.JSON) sets.Set[string] {
+	out := sets.New[string]()
+	for _, e := range enum {
+		out.Insert(string(e.Raw))
+	}
+
+	return out
+}
+
+func join(path, name string) string {
+	if path == "" {
+		return name
+	}
+
+	return path + "." + name
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	slices.Sort(keys)
+
+	return keys
+}
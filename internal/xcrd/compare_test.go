@@ -0,0 +1,274 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package xcrd
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"sigs.k8s.io/yaml"
+)
+
+func schemaFrom(t *testing.T, y string) *extv1.JSONSchemaProps {
+	t.Helper()
+
+	s := &extv1.JSONSchemaProps{}
+	if err := yaml.Unmarshal([]byte(y), s); err != nil {
+		t.Fatal(err)
+	}
+
+	return s
+}
+
+func TestCompareSchemas(t *testing.T) {
+	base := `
+type: object
+properties:
+  spec:
+    type: object
+    required: [region]
+    properties:
+      region:
+        type: string
+        enum: [us-east-1, us-west-2]
+      size:
+        type: integer
+        maximum: 10
+      tags:
+        type: array
+        items:
+          type: string
+`
+
+	type args struct {
+		old string
+		new string
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   []SchemaChange
+	}{
+		"Unchanged": {
+			reason: "Identical schemas should have no changes.",
+			args:   args{old: base, new: base},
+			want:   nil,
+		},
+		"NonBreaking": {
+			reason: "Adding optional fields, widening enums, and loosening validation aren't breaking changes.",
+			args: args{
+				old: base,
+				new: `
+type: object
+properties:
+  spec:
+    type: object
+    properties:
+      region:
+        type: string
+        enum: [us-east-1, us-west-2, eu-west-1]
+      size:
+        type: integer
+        maximum: 20
+      tags:
+        type: array
+        items:
+          type: string
+      zone:
+        type: string
+`,
+			},
+			want: []SchemaChange{
+				{Path: "spec.region", Message: "enum values added: \"eu-west-1\"", Breaking: false},
+				{Path: "spec.region", Message: "field is no longer required", Breaking: false},
+				{Path: "spec.size", Message: "maximum changed from 10 to 20", Breaking: false},
+				{Path: "spec.zone", Message: "field added", Breaking: false},
+			},
+		},
+		"Breaking": {
+			reason: "Removing fields, changing types, narrowing enums, adding required fields, and tightening validation are breaking changes.",
+			args: args{
+				old: base,
+				new: `
+type: object
+properties:
+  spec:
+    type: object
+    required: [region, zone]
+    properties:
+      region:
+        type: string
+        enum: [us-east-1]
+      size:
+        type: integer
+        maximum: 5
+      tags:
+        type: array
+        items:
+          type: integer
+      zone:
+        type: string
+`,
+			},
+			want: []SchemaChange{
+				{Path: "spec.region", Message: "enum values removed: \"us-west-2\"", Breaking: true},
+				{Path: "spec.size", Message: "maximum changed from 10 to 5", Breaking: true},
+				{Path: "spec.tags[*]", Message: "type changed from \"string\" to \"integer\"", Breaking: true},
+				{Path: "spec.zone", Message: "required field added", Breaking: true},
+			},
+		},
+		"FieldRemoved": {
+			reason: "Removing a field is a breaking change.",
+			args: args{
+				old: base,
+				new: `
+type: object
+properties:
+  spec:
+    type: object
+    required: [region]
+    properties:
+      region:
+        type: string
+        enum: [us-east-1, us-west-2]
+      size:
+        type: integer
+        maximum: 10
+`,
+			},
+			want: []SchemaChange{
+				{Path: "spec.tags", Message: "field removed", Breaking: true},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := CompareSchemas(schemaFrom(t, tc.args.old), schemaFrom(t, tc.args.new))
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nCompareSchemas(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCompareCRDs(t *testing.T) {
+	crd := func(vs ...extv1.CustomResourceDefinitionVersion) *extv1.CustomResourceDefinition {
+		return &extv1.CustomResourceDefinition{Spec: extv1.CustomResourceDefinitionSpec{Versions: vs}}
+	}
+
+	version := func(name string, served bool, s string) extv1.CustomResourceDefinitionVersion {
+		return extv1.CustomResourceDefinitionVersion{
+			Name:   name,
+			Served: served,
+			Schema: &extv1.CustomResourceValidation{OpenAPIV3Schema: schemaFrom(t, s)},
+		}
+	}
+
+	withSize := `
+type: object
+properties:
+  spec:
+    type: object
+    properties:
+      size:
+        type: integer
+`
+
+	withoutSize := `
+type: object
+properties:
+  spec:
+    type: object
+`
+
+	type args struct {
+		old *extv1.CustomResourceDefinition
+		new *extv1.CustomResourceDefinition
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   []VersionChange
+	}{
+		"NewVersion": {
+			reason: "Serving a new version isn't a change.",
+			args: args{
+				old: crd(version("v1", true, withSize)),
+				new: crd(version("v1", true, withSize), version("v2", true, withSize)),
+			},
+			want: nil,
+		},
+		"NoLongerServed": {
+			reason: "A version that's no longer served is a breaking change.",
+			args: args{
+				old: crd(version("v1alpha1", true, withSize), version("v1", true, withSize)),
+				new: crd(version("v1alpha1", false, withSize), version("v1", true, withSize)),
+			},
+			want: []VersionChange{
+				{Version: "v1alpha1", SchemaChange: SchemaChange{Message: "version is no longer served", Breaking: true}},
+			},
+		},
+		"SchemaChanged": {
+			reason: "We should return the schema changes of each served version.",
+			args: args{
+				old: crd(version("v1alpha1", false, withSize), version("v1", true, withSize)),
+				new: crd(version("v1alpha1", false, withoutSize), version("v1", true, withoutSize)),
+			},
+			want: []VersionChange{
+				{Version: "v1", SchemaChange: SchemaChange{Path: "spec.size", Message: "field removed", Breaking: true}},
+			},
+		},
+		"CrossplaneFieldsIgnored": {
+			reason: "Changes to status and to the spec fields Crossplane injects aren't changes to the XRD's schema.",
+			args: args{
+				old: crd(version("v1", true, `
+type: object
+properties:
+  spec:
+    type: object
+    properties:
+      compositionRef:
+        type: object
+      crossplane:
+        type: object
+      size:
+        type: integer
+  status:
+    type: object
+    properties:
+      ready:
+        type: boolean
+`)),
+				new: crd(version("v1", true, withSize)),
+			},
+			want: nil,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := CompareCRDs(tc.args.old, tc.args.new)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nCompareCRDs(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package xfn
+
+import (
+	"context"
+	"hash/fnv"
+
+	"google.golang.org/protobuf/types/known/structpb"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+
+	apiextensionsv1 "github.com/crossplane/crossplane/v2/apis/apiextensions/v1"
+	pkgv1 "github.com/crossplane/crossplane/v2/apis/pkg/v1"
+	fnv1 "github.com/crossplane/crossplane/v2/proto/fn/v1"
+)
+
+// The percentage of composite resources routed to a canary FunctionRevision if
+// the Function doesn't specify a weight.
+const defaultCanaryWeight = 10
+
+const (
+	errGetFunction         = "cannot get Function"
+	errGetComposition      = "cannot get Composition"
+	errCompositionSelector = "cannot parse canary Composition selector"
+)
+
+// routeToCanary returns true if the supplied request should be routed to the
+// named Function's canary FunctionRevision. Only requests made on behalf of a
+// composite resource are routed to a canary.
+func (r *PackagedFunctionRunner) routeToCanary(ctx context.Context, name string, req *fnv1.RunFunctionRequest) (bool, error) {
+	xr := req.GetObserved().GetComposite().GetResource()
+	if xr == nil {
+		return false, nil
+	}
+
+	f := &pkgv1.Function{}
+	if err := r.client.Get(ctx, types.NamespacedName{Name: name}, f); err != nil {
+		return false, errors.Wrap(err, errGetFunction)
+	}
+
+	c := f.Spec.Canary
+	if c == nil {
+		c = &pkgv1.FunctionCanarySpec{}
+	}
+
+	if c.CompositionSelector != nil {
+		s, err := metav1.LabelSelectorAsSelector(c.CompositionSelector)
+		if err != nil {
+			return false, errors.Wrap(err, errCompositionSelector)
+		}
+
+		// Composite resources with the v2 schema nest their composition
+		// reference under spec.crossplane.
+		comp := stringField(xr, "spec", "crossplane", "compositionRef", "name")
+		if comp == "" {
+			comp = stringField(xr, "spec", "compositionRef", "name")
+		}
+
+		if comp == "" {
+			return false, nil
+		}
+
+		cmp := &apiextensionsv1.Composition{}
+		if err := r.client.Get(ctx, types.NamespacedName{Name: comp}, cmp); err != nil {
+			return false, errors.Wrap(err, errGetComposition)
+		}
+
+		return s.Matches(labels.Set(cmp.GetLabels())), nil
+	}
+
+	weight := int32(defaultCanaryWeight)
+	if c.Weight != nil {
+		weight = *c.Weight
+	}
+
+	uid := stringField(xr, "metadata", "uid")
+	if uid == "" {
+		return false, nil
+	}
+
+	return canaryBucket(uid) < weight, nil
+}
+
+// canaryBucket deterministically assigns the supplied UID to one of 100
+// buckets, so a composite resource is always routed to the same revision.
+func canaryBucket(uid string) int32 {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(uid))
+
+	return int32(h.Sum32() % 100) //nolint:gosec // Can't overflow; it's less than 100.
+}
+
+// stringField returns the string at the supplied path of the supplied Struct, or
+// an empty string if there isn't one.
+func stringField(s *structpb.Struct, path ...string) string {
+	for i, p := range path {
+		v, ok := s.GetFields()[p]
+		if !ok {
+			return ""
+		}
+
+		if i == len(path)-1 {
+			return v.GetStringValue()
+		}
+
+		s = v.GetStructValue()
+	}
+
+	return ""
+}
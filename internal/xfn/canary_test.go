@@ -0,0 +1,163 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package xfn
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/types/known/structpb"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/test"
+
+	apiextensionsv1 "github.com/crossplane/crossplane/v2/apis/apiextensions/v1"
+	pkgv1 "github.com/crossplane/crossplane/v2/apis/pkg/v1"
+	fnv1 "github.com/crossplane/crossplane/v2/proto/fn/v1"
+)
+
+func TestRouteToCanary(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	// The UIDs below were chosen for the buckets they hash to.
+	low := "xr-uid-6"  // Bucket 3.
+	high := "xr-uid-9" // Bucket 98.
+
+	xr := func(uid, composition string) *fnv1.RunFunctionRequest {
+		s, _ := structpb.NewStruct(map[string]any{
+			"metadata": map[string]any{"uid": uid},
+			"spec": map[string]any{
+				"crossplane": map[string]any{
+					"compositionRef": map[string]any{"name": composition},
+				},
+			},
+		})
+
+		return &fnv1.RunFunctionRequest{Observed: &fnv1.State{Composite: &fnv1.Resource{Resource: s}}}
+	}
+
+	get := func(canary *pkgv1.FunctionCanarySpec, compLabels map[string]string) test.MockGetFn {
+		return func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+			switch o := obj.(type) {
+			case *pkgv1.Function:
+				o.Spec.Canary = canary
+			case *apiextensionsv1.Composition:
+				o.SetLabels(compLabels)
+			}
+
+			return nil
+		}
+	}
+
+	type args struct {
+		get test.MockGetFn
+		req *fnv1.RunFunctionRequest
+	}
+
+	type want struct {
+		route bool
+		err   error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"NoCompositeResource": {
+			reason: "Requests not made on behalf of a composite resource, like Operations, should never be routed to a canary.",
+			args: args{
+				get: get(nil, nil),
+				req: &fnv1.RunFunctionRequest{},
+			},
+			want: want{route: false},
+		},
+		"GetFunctionError": {
+			reason: "We should return an error if we can't get the Function.",
+			args: args{
+				get: test.NewMockGetFn(errBoom),
+				req: xr(low, "cool-comp"),
+			},
+			want: want{err: errors.Wrap(errBoom, errGetFunction)},
+		},
+		"DefaultWeightInBucket": {
+			reason: "A composite resource in a bucket below the default weight should be routed to the canary.",
+			args: args{
+				get: get(nil, nil),
+				req: xr(low, "cool-comp"),
+			},
+			want: want{route: true},
+		},
+		"DefaultWeightNotInBucket": {
+			reason: "A composite resource in a bucket above the default weight shouldn't be routed to the canary.",
+			args: args{
+				get: get(nil, nil),
+				req: xr(high, "cool-comp"),
+			},
+			want: want{route: false},
+		},
+		"FullWeight": {
+			reason: "Every composite resource should be routed to the canary if the weight is 100.",
+			args: args{
+				get: get(&pkgv1.FunctionCanarySpec{Weight: ptr.To[int32](100)}, nil),
+				req: xr(high, "cool-comp"),
+			},
+			want: want{route: true},
+		},
+		"CompositionSelected": {
+			reason: "A composite resource that uses a selected Composition should be routed to the canary.",
+			args: args{
+				get: get(&pkgv1.FunctionCanarySpec{
+					Weight:              ptr.To[int32](0),
+					CompositionSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"canary": "true"}},
+				}, map[string]string{"canary": "true"}),
+				req: xr(high, "cool-comp"),
+			},
+			want: want{route: true},
+		},
+		"CompositionNotSelected": {
+			reason: "A composite resource that uses a Composition that isn't selected shouldn't be routed to the canary.",
+			args: args{
+				get: get(&pkgv1.FunctionCanarySpec{
+					Weight:              ptr.To[int32](100),
+					CompositionSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"canary": "true"}},
+				}, nil),
+				req: xr(low, "cool-comp"),
+			},
+			want: want{route: false},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := NewPackagedFunctionRunner(&test.MockClient{MockGet: tc.args.get}, WithCanaryRouting())
+
+			route, err := r.routeToCanary(context.Background(), "cool-fn", tc.args.req)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nr.routeToCanary(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+
+			if diff := cmp.Diff(tc.want.route, route); diff != "" {
+				t.Errorf("\n%s\nr.routeToCanary(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -97,8 +97,12 @@ type PackagedFunctionRunner struct {
 	creds        credentials.TransportCredentials
 	interceptors []InterceptorCreator
 
-	connsMx sync.RWMutex
-	conns   map[string]*grpc.ClientConn
+	// canary enables routing some calls to canary FunctionRevisions.
+	canary bool
+
+	connsMx     sync.RWMutex
+	conns       map[string]*grpc.ClientConn
+	canaryConns map[string]*grpc.ClientConn
 
 	log logging.Logger
 }
@@ -136,14 +140,23 @@ func WithInterceptorCreators(ics ...InterceptorCreator) PackagedFunctionRunnerOp
 	}
 }
 
+// WithCanaryRouting configures the PackagedFunctionRunner to route some calls
+// to a Function's canary FunctionRevision, if it has one.
+func WithCanaryRouting() PackagedFunctionRunnerOption {
+	return func(r *PackagedFunctionRunner) {
+		r.canary = true
+	}
+}
+
 // NewPackagedFunctionRunner returns a FunctionRunner that runs a Function by
 // making a gRPC call to a Function package's runtime.
 func NewPackagedFunctionRunner(c client.Reader, o ...PackagedFunctionRunnerOption) *PackagedFunctionRunner {
 	r := &PackagedFunctionRunner{
-		client: c,
-		creds:  insecure.NewCredentials(),
-		conns:  make(map[string]*grpc.ClientConn),
-		log:    logging.NewNopLogger(),
+		client:      c,
+		creds:       insecure.NewCredentials(),
+		conns:       make(map[string]*grpc.ClientConn),
+		canaryConns: make(map[string]*grpc.ClientConn),
+		log:         logging.NewNopLogger(),
 	}
 
 	for _, fn := range o {
@@ -156,7 +169,7 @@ func NewPackagedFunctionRunner(c client.Reader, o ...PackagedFunctionRunnerOptio
 // RunFunction sends the supplied RunFunctionRequest to the named Function. The
 // function is expected to be an installed Function.pkg.crossplane.io package.
 func (r *PackagedFunctionRunner) RunFunction(ctx context.Context, name string, req *fnv1.RunFunctionRequest) (*fnv1.RunFunctionResponse, error) {
-	conn, err := r.getClientConn(ctx, name)
+	conn, err := r.getClientConn(ctx, name, req)
 	if err != nil {
 		return nil, errors.Wrapf(err, errFmtGetClientConn, name)
 	}
@@ -189,7 +202,12 @@ func (r *PackagedFunctionRunner) RunFunction(ctx context.Context, name string, r
 // cost of listing and iterating over FunctionRevisions from cache. The default
 // RevisionHistoryLimit is 1, so for most Functions we'd expect there to be two
 // revisions in the cache (one active, and one previously active).
-func (r *PackagedFunctionRunner) getClientConn(ctx context.Context, name string) (*grpc.ClientConn, error) {
+//
+// A Function that is rolling out a new revision as a canary has a third,
+// inactive revision with its own endpoint. If canary routing is enabled we
+// route the supplied request to the canary when the Function's canary
+// configuration selects the request's composite resource.
+func (r *PackagedFunctionRunner) getClientConn(ctx context.Context, name string, req *fnv1.RunFunctionRequest) (*grpc.ClientConn, error) {
 	log := r.log.WithValues("function", name)
 
 	l := &pkgv1.FunctionRevisionList{}
@@ -197,11 +215,27 @@ func (r *PackagedFunctionRunner) getClientConn(ctx context.Context, name string)
 		return nil, errors.Wrapf(err, errListFunctionRevisions)
 	}
 
-	var active *pkgv1.FunctionRevision
+	var active, canary *pkgv1.FunctionRevision
 	for i := range l.Items {
-		if l.Items[i].GetDesiredState() == pkgv1.PackageRevisionActive {
+		if active == nil && l.Items[i].GetDesiredState() == pkgv1.PackageRevisionActive {
 			active = &l.Items[i]
-			break
+		}
+
+		if pkgv1.IsCanary(&l.Items[i]) && l.Items[i].Status.Endpoint != "" {
+			canary = &l.Items[i]
+		}
+	}
+
+	if r.canary && canary != nil {
+		route, err := r.routeToCanary(ctx, name, req)
+		if err != nil {
+			// Fall back to the active revision. It's safer to skip the
+			// canary than to fail the call.
+			log.Debug("Cannot determine whether to route to canary FunctionRevision", "error", err)
+		}
+
+		if route {
+			return r.clientConn(log, r.canaryConns, name, canary)
 		}
 	}
 
@@ -213,11 +247,17 @@ func (r *PackagedFunctionRunner) getClientConn(ctx context.Context, name string)
 		return nil, errors.Errorf(errFmtEmptyEndpoint, active.GetName())
 	}
 
+	return r.clientConn(log, r.conns, name, active)
+}
+
+// clientConn returns a gRPC client connection to the supplied FunctionRevision
+// of the named Function. It caches the connection in the supplied map.
+func (r *PackagedFunctionRunner) clientConn(log logging.Logger, conns map[string]*grpc.ClientConn, name string, rev *pkgv1.FunctionRevision) (*grpc.ClientConn, error) {
 	// If we have a connection for the up-to-date endpoint, return it.
 	r.connsMx.RLock()
 
-	conn, ok := r.conns[name]
-	if ok && conn.Target() == active.Status.Endpoint {
+	conn, ok := conns[name]
+	if ok && conn.Target() == rev.Status.Endpoint {
 		defer r.connsMx.RUnlock()
 		return conn, nil
 	}
@@ -230,38 +270,38 @@ func (r *PackagedFunctionRunner) getClientConn(ctx context.Context, name string)
 
 	// Another Goroutine might have updated the connections between when we
 	// released the read lock and took the write lock, so check again.
-	conn, ok = r.conns[name]
+	conn, ok = conns[name]
 	if ok {
 		// We now have a connection for the up-to-date endpoint.
-		if conn.Target() == active.Status.Endpoint {
+		if conn.Target() == rev.Status.Endpoint {
 			return conn, nil
 		}
 
 		// This connection is to an old endpoint. We need to close it and create
 		// a new connection. Close only returns an error is if the connection is
 		// already closed or in the process of closing.
-		log.Debug("Closing gRPC client connection with stale target", "old-target", conn.Target(), "new-target", active.Status.Endpoint)
+		log.Debug("Closing gRPC client connection with stale target", "old-target", conn.Target(), "new-target", rev.Status.Endpoint)
 		_ = conn.Close()
 
-		delete(r.conns, name)
+		delete(conns, name)
 	}
 
 	is := make([]grpc.UnaryClientInterceptor, len(r.interceptors))
 	for i := range r.interceptors {
-		is[i] = r.interceptors[i].CreateInterceptor(name, active.Spec.Package)
+		is[i] = r.interceptors[i].CreateInterceptor(name, rev.Spec.Package)
 	}
 
-	conn, err := grpc.NewClient(active.Status.Endpoint,
+	conn, err := grpc.NewClient(rev.Status.Endpoint,
 		grpc.WithTransportCredentials(r.creds),
 		grpc.WithDefaultServiceConfig(svcConfig),
 		grpc.WithChainUnaryInterceptor(is...))
 	if err != nil {
-		return nil, errors.Wrapf(err, errFmtDialFunction, active.Status.Endpoint, active.GetName())
+		return nil, errors.Wrapf(err, errFmtDialFunction, rev.Status.Endpoint, rev.GetName())
 	}
 
-	r.conns[name] = conn
+	conns[name] = conn
 
-	log.Debug("Created new gRPC client connection", "target", active.Status.Endpoint)
+	log.Debug("Created new gRPC client connection", "target", rev.Status.Endpoint, "revision", rev.GetName())
 
 	return conn, nil
 }
@@ -287,8 +327,9 @@ func (r *PackagedFunctionRunner) GarbageCollectConnections(ctx context.Context,
 }
 
 // GarbageCollectConnectionsNow immediately garbage collects any gRPC client
-// connections to Functions that are no longer installed. It returns the number
-// of connections garbage collected.
+// connections to Functions that are no longer installed, and to canary
+// FunctionRevisions that are no longer rolling out. It returns the number of
+// connections garbage collected.
 func (r *PackagedFunctionRunner) GarbageCollectConnectionsNow(ctx context.Context) (int, error) {
 	// We try to take the write lock for as little time as possible,
 	// because while we have it RunFunction will block. In the happy
@@ -298,7 +339,7 @@ func (r *PackagedFunctionRunner) GarbageCollectConnectionsNow(ctx context.Contex
 	// No need to take a write lock or list Functions if there's no work to do.
 	r.connsMx.RLock()
 
-	if len(r.conns) == 0 {
+	if len(r.conns) == 0 && len(r.canaryConns) == 0 {
 		defer r.connsMx.RUnlock()
 		return 0, nil
 	}
@@ -336,6 +377,38 @@ func (r *PackagedFunctionRunner) GarbageCollectConnectionsNow(ctx context.Contex
 		r.log.Debug("Closed gRPC client connection to Function that is no longer installed", "function", name)
 	}
 
+	if len(r.canaryConns) == 0 {
+		return closed, nil
+	}
+
+	// A canary connection is stale once its canary is promoted or rolled
+	// back, even if its Function still exists.
+	rl := &pkgv1.FunctionRevisionList{}
+	if err := r.client.List(ctx, rl); err != nil {
+		return closed, errors.Wrap(err, errListFunctionRevisions)
+	}
+
+	canaryEndpoints := map[string]bool{}
+
+	for i := range rl.Items {
+		if pkgv1.IsCanary(&rl.Items[i]) {
+			canaryEndpoints[rl.Items[i].Status.Endpoint] = true
+		}
+	}
+
+	for name, conn := range r.canaryConns {
+		if functionExists[name] && canaryEndpoints[conn.Target()] {
+			continue
+		}
+
+		_ = conn.Close()
+		delete(r.canaryConns, name)
+
+		closed++
+
+		r.log.Debug("Closed gRPC client connection to canary FunctionRevision that is no longer rolling out", "function", name)
+	}
+
 	return closed, nil
 }
 
@@ -17,6 +17,7 @@ package xfn
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -32,6 +33,15 @@ type PrometheusMetrics struct {
 	requests  *prometheus.CounterVec
 	responses *prometheus.CounterVec
 	duration  *prometheus.HistogramVec
+
+	// Response counts by gRPC target, used to analyze canary revisions.
+	targetsMx sync.RWMutex
+	targets   map[string]*responseCount
+}
+
+type responseCount struct {
+	total  int64
+	failed int64
 }
 
 // NewPrometheusMetrics creates metrics for function runs.
@@ -55,6 +65,49 @@ func NewPrometheusMetrics() *PrometheusMetrics {
 			Help:      "Histogram of RunFunctionResponse latency (seconds).",
 			Buckets:   prometheus.DefBuckets,
 		}, []string{"function_name", "function_package", "grpc_target", "grpc_method", "grpc_code", "result_severity"}),
+
+		targets: make(map[string]*responseCount),
+	}
+}
+
+// Responses returns the number of RunFunctionResponses received from the
+// supplied gRPC target, and how many of them failed. A response failed if the
+// RPC returned an error, or if the response contained a fatal result.
+func (m *PrometheusMetrics) Responses(target string) (total, failed int64) {
+	m.targetsMx.RLock()
+	defer m.targetsMx.RUnlock()
+
+	c, ok := m.targets[target]
+	if !ok {
+		return 0, 0
+	}
+
+	return c.total, c.failed
+}
+
+// Forget discards the responses received from the supplied gRPC target. The
+// package manager calls it when it's done analyzing a canary revision, so that
+// the counts of canary endpoints that no longer exist don't accumulate.
+func (m *PrometheusMetrics) Forget(target string) {
+	m.targetsMx.Lock()
+	defer m.targetsMx.Unlock()
+
+	delete(m.targets, target)
+}
+
+func (m *PrometheusMetrics) countResponse(target string, failed bool) {
+	m.targetsMx.Lock()
+	defer m.targetsMx.Unlock()
+
+	c, ok := m.targets[target]
+	if !ok {
+		c = &responseCount{}
+		m.targets[target] = c
+	}
+
+	c.total++
+	if failed {
+		c.failed++
 	}
 }
 
@@ -116,6 +169,7 @@ func (m *PrometheusMetrics) CreateInterceptor(name, pkg string) grpc.UnaryClient
 
 		m.responses.With(l).Inc()
 		m.duration.With(l).Observe(duration.Seconds())
+		m.countResponse(cc.Target(), err != nil || l["result_severity"] == "Fatal")
 
 		return err
 	}
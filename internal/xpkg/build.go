@@ -21,11 +21,14 @@ import (
 	"context"
 	"io"
 	"os"
+	"sort"
 	"strings"
+	"time"
 
 	v1 "github.com/google/go-containerregistry/pkg/v1"
 	"github.com/google/go-containerregistry/pkg/v1/empty"
 	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/serializer/json"
 
@@ -49,6 +52,7 @@ const (
 	errConfigFile        = "failed to get config file from image"
 	errMutateConfig      = "failed to mutate config for image"
 	errBuildObjectScheme = "failed to build scheme for package encoder"
+	errSetCreated        = "failed to set creation time of image"
 )
 
 // annotatedTeeReadCloser is a copy of io.TeeReader that implements
@@ -112,7 +116,9 @@ func New(packageSource, exampleSource parser.Backend, packageParser parser.Parse
 }
 
 type buildOpts struct {
-	base v1.Image
+	base    v1.Image
+	lock    []pkgmetav1.LockedDependency
+	created time.Time
 }
 
 // A BuildOpt modifies how a package is built.
@@ -125,10 +131,27 @@ func WithBase(img v1.Image) BuildOpt {
 	}
 }
 
+// WithLock embeds the supplied dependency lock in the package's meta.
+func WithLock(lock []pkgmetav1.LockedDependency) BuildOpt {
+	return func(o *buildOpts) {
+		o.lock = lock
+	}
+}
+
+// WithCreated sets the creation time of the package, and the modification
+// time of the files in its layers. Packages are created at the Unix epoch by
+// default, so that building identical sources produces identical digests.
+func WithCreated(t time.Time) BuildOpt {
+	return func(o *buildOpts) {
+		o.created = t
+	}
+}
+
 // Build compiles a Crossplane package from an on-disk package.
 func (b *Builder) Build(ctx context.Context, opts ...BuildOpt) (v1.Image, runtime.Object, error) {
 	bOpts := &buildOpts{
-		base: empty.Image,
+		base:    empty.Image,
+		created: time.Unix(0, 0),
 	}
 	for _, o := range opts {
 		o(bOpts)
@@ -184,6 +207,14 @@ func (b *Builder) Build(ctx context.Context, opts ...BuildOpt) (v1.Image, runtim
 		return nil, nil, errors.Wrap(err, errLintPackage)
 	}
 
+	if len(bOpts.lock) > 0 {
+		if meta, err = embedLock(meta, bOpts.lock); err != nil {
+			return nil, nil, err
+		}
+
+		metas[0] = meta
+	}
+
 	layers := make([]v1.Layer, 0)
 
 	cfgFile, err := bOpts.base.ConfigFile()
@@ -201,7 +232,7 @@ func (b *Builder) Build(ctx context.Context, opts ...BuildOpt) (v1.Image, runtim
 		return nil, nil, errors.Wrap(err, errConfigFile)
 	}
 
-	pkgLayer, err := Layer(pkgBytes, StreamFile, PackageAnnotation, int64(pkgBytes.Len()), StreamFileMode, &cfg)
+	pkgLayer, err := Layer(pkgBytes, StreamFile, PackageAnnotation, int64(pkgBytes.Len()), StreamFileMode, &cfg, WithModTime(bOpts.created))
 	if err != nil {
 		return nil, nil, err
 	}
@@ -215,7 +246,7 @@ func (b *Builder) Build(ctx context.Context, opts ...BuildOpt) (v1.Image, runtim
 			return nil, nil, errors.Wrap(err, errParserExample)
 		}
 
-		exLayer, err := Layer(exBuf, XpkgExamplesFile, ExamplesAnnotation, int64(exBuf.Len()), StreamFileMode, &cfg)
+		exLayer, err := Layer(exBuf, XpkgExamplesFile, ExamplesAnnotation, int64(exBuf.Len()), StreamFileMode, &cfg, WithModTime(bOpts.created))
 		if err != nil {
 			return nil, nil, err
 		}
@@ -235,11 +266,18 @@ func (b *Builder) Build(ctx context.Context, opts ...BuildOpt) (v1.Image, runtim
 		return nil, nil, errors.Wrap(err, errMutateConfig)
 	}
 
+	bOpts.base, err = mutate.CreatedAt(bOpts.base, v1.Time{Time: bOpts.created.UTC()})
+	if err != nil {
+		return nil, nil, errors.Wrap(err, errSetCreated)
+	}
+
 	return bOpts.base, meta, nil
 }
 
 // encode encodes a package as a YAML stream.  Does not check meta existence
-// or quantity i.e. it should be linted first to ensure that it is valid.
+// or quantity i.e. it should be linted first to ensure that it is valid. The
+// meta is encoded first, followed by the objects sorted by apiVersion, kind,
+// namespace, and name, so that the stream doesn't depend on parse order.
 func encode(pkg parser.Lintable) (*bytes.Buffer, error) {
 	pkgBuf := new(bytes.Buffer)
 
@@ -258,7 +296,7 @@ func encode(pkg parser.Lintable) (*bytes.Buffer, error) {
 
 	pkgBuf.WriteString("---\n")
 
-	for _, o := range pkg.GetObjects() {
+	for _, o := range sortedObjects(pkg.GetObjects()) {
 		if err = do.Encode(o, pkgBuf); err != nil {
 			return nil, errors.Wrap(err, errBuildObjectScheme)
 		}
@@ -269,6 +307,29 @@ func encode(pkg parser.Lintable) (*bytes.Buffer, error) {
 	return pkgBuf, nil
 }
 
+// sortedObjects returns a sorted copy of the supplied objects.
+func sortedObjects(objs []runtime.Object) []runtime.Object {
+	out := make([]runtime.Object, len(objs))
+	copy(out, objs)
+
+	key := func(o runtime.Object) string {
+		gvk := o.GetObjectKind().GroupVersionKind()
+
+		k := gvk.GroupVersion().String() + "/" + gvk.Kind
+		if a, ok := o.(metav1.Object); ok {
+			k += "/" + a.GetNamespace() + "/" + a.GetName()
+		}
+
+		return k
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		return key(out[i]) < key(out[j])
+	})
+
+	return out
+}
+
 // SkipContains supplies a FilterFn that skips paths that contain the give pattern.
 func SkipContains(pattern string) parser.FilterFn {
 	return func(path string, _ os.FileInfo) (bool, error) {
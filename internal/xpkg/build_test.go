@@ -18,11 +18,13 @@ package xpkg
 
 import (
 	"archive/tar"
+	"bytes"
 	"context"
 	"io"
 	"os"
 	"sort"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -287,6 +289,102 @@ func TestBuildExamples(t *testing.T) {
 	}
 }
 
+func TestBuildReproducible(t *testing.T) {
+	pkgp, _ := yamlParser()
+
+	otherCRD := bytes.ReplaceAll(testCRD, []byte("helm.crossplane.io"), []byte("other.crossplane.io"))
+	created := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// Each fs contains the same objects, but in a different parse order.
+	fs := func(first, second []byte) afero.Fs {
+		fs := afero.NewMemMapFs()
+		_ = afero.WriteFile(fs, "/ws/crossplane.yaml", testMeta, os.ModePerm)
+		_ = afero.WriteFile(fs, "/ws/crds/a.yaml", first, os.ModePerm)
+		_ = afero.WriteFile(fs, "/ws/crds/b.yaml", second, os.ModePerm)
+		_ = afero.WriteFile(fs, "/ws/examples/provider.yaml", testEx4, os.ModePerm)
+		return fs
+	}
+
+	type build struct {
+		fs   afero.Fs
+		opts []BuildOpt
+	}
+
+	type want struct {
+		same    bool
+		created time.Time
+	}
+
+	cases := map[string]struct {
+		reason string
+		a      build
+		b      build
+		want   want
+	}{
+		"SameSources": {
+			reason: "Building the same sources twice should produce the same digest.",
+			a:      build{fs: fs(testCRD, otherCRD)},
+			b:      build{fs: fs(testCRD, otherCRD)},
+			want:   want{same: true, created: time.Unix(0, 0).UTC()},
+		},
+		"DifferentParseOrder": {
+			reason: "Building the same objects parsed in a different order should produce the same digest.",
+			a:      build{fs: fs(testCRD, otherCRD)},
+			b:      build{fs: fs(otherCRD, testCRD)},
+			want:   want{same: true, created: time.Unix(0, 0).UTC()},
+		},
+		"DifferentCreated": {
+			reason: "Building the same sources with a different creation time should produce a different digest.",
+			a:      build{fs: fs(testCRD, otherCRD)},
+			b:      build{fs: fs(testCRD, otherCRD), opts: []BuildOpt{WithCreated(created)}},
+			want:   want{same: false, created: created},
+		},
+	}
+
+	digest := func(t *testing.T, b build) (v1.Hash, time.Time) {
+		t.Helper()
+
+		builder := New(
+			parser.NewFsBackend(b.fs, parser.FsDir("/ws"), parser.FsFilters(parser.SkipDirs(), parser.SkipNotYAML(), parser.SkipEmpty(), SkipContains("examples/"))),
+			parser.NewFsBackend(b.fs, parser.FsDir("/ws/examples"), parser.FsFilters(parser.SkipDirs(), parser.SkipNotYAML(), parser.SkipEmpty())),
+			pkgp,
+			examples.New(),
+		)
+
+		img, _, err := builder.Build(context.TODO(), b.opts...)
+		if err != nil {
+			t.Fatalf("Build(...): %v", err)
+		}
+
+		h, err := img.Digest()
+		if err != nil {
+			t.Fatalf("Digest(): %v", err)
+		}
+
+		cfg, err := img.ConfigFile()
+		if err != nil {
+			t.Fatalf("ConfigFile(): %v", err)
+		}
+
+		return h, cfg.Created.Time
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			a, _ := digest(t, tc.a)
+			b, created := digest(t, tc.b)
+
+			if diff := cmp.Diff(tc.want.same, a == b); diff != "" {
+				t.Errorf("\n%s\nBuild(...): -want same digest, +got same digest:\n%s", tc.reason, diff)
+			}
+
+			if diff := cmp.Diff(tc.want.created, created.UTC()); diff != "" {
+				t.Errorf("\n%s\nBuild(...): -want created, +got created:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
 type xpkgContents struct {
 	labels   []string
 	pkgBytes []byte
@@ -2,7 +2,6 @@ package xpkg
 
 import (
 	"context"
-	"strings"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -29,6 +28,9 @@ type ConfigStore interface {
 	// RuntimeConfigFor returns the name of the selected image config and the
 	// runtime config for a given image.
 	RuntimeConfigFor(ctx context.Context, image string) (imageConfig string, runtimeConfig *v1beta1.ImageRuntime, err error)
+	// MirrorsFor returns the name of the selected image config and the
+	// paths of the given image's mirrors, in order, based on that config.
+	MirrorsFor(ctx context.Context, image string) (imageConfig string, mirrors []string, err error)
 }
 
 // isValidConfig is a function that determines if an ImageConfig is valid while
@@ -92,9 +94,15 @@ func (s *ImageConfigStore) ImageVerificationConfigFor(ctx context.Context, image
 		return "", nil, nil
 	}
 
-	if config.Spec.Verification.Cosign == nil {
-		// Only cosign verification is supported for now.
-		return config.Name, nil, errors.New("cosign verification config is missing")
+	switch v := config.Spec.Verification; v.Provider {
+	case v1beta1.ImageVerificationProviderNotation:
+		if v.Notation == nil {
+			return config.Name, nil, errors.New("notation verification config is missing")
+		}
+	default:
+		if v.Cosign == nil {
+			return config.Name, nil, errors.New("cosign verification config is missing")
+		}
 	}
 
 	return config.Name, config.Spec.Verification, nil
@@ -116,26 +124,16 @@ func (s *ImageConfigStore) RewritePath(ctx context.Context, image string) (image
 		return "", "", nil
 	}
 
-	rewritePrefix := config.Spec.RewriteImage.Prefix
-	if rewritePrefix == "" {
-		return config.Name, "", errors.New("rewrite prefix is missing")
+	// Find the most specific match in the selected image config; this is
+	// what we'll rewrite.
+	m, ok := BestImageMatch(image, config.Spec.MatchImages...)
+	if !ok {
+		return config.Name, "", errors.Errorf("image %q does not match image config %q", image, config.Name)
 	}
 
-	// Find the longest prefix match in the selected image config; this is what
-	// we'll replace.
-	matchPrefix := ""
-
-	for _, m := range config.Spec.MatchImages {
-		if !strings.HasPrefix(image, m.Prefix) {
-			continue
-		}
-
-		if len(m.Prefix) > len(matchPrefix) {
-			matchPrefix = m.Prefix
-		}
-	}
+	newPath, err = m.Rewrite(image, config.Spec.RewriteImage)
 
-	return config.Name, rewritePrefix + strings.TrimPrefix(image, matchPrefix), nil
+	return config.Name, newPath, err
 }
 
 // RuntimeConfigFor returns the name of the selected image config and the
@@ -157,8 +155,40 @@ func (s *ImageConfigStore) RuntimeConfigFor(ctx context.Context, image string) (
 	return config.Name, config.Spec.Runtime, nil
 }
 
+// MirrorsFor returns the name of the selected image config and the paths of
+// the given image's mirrors, in order, based on that config.
+func (s *ImageConfigStore) MirrorsFor(ctx context.Context, image string) (imageConfig string, mirrors []string, err error) {
+	config, err := s.bestMatch(ctx, image, func(c *v1beta1.ImageConfig) bool {
+		return len(c.Spec.Mirrors) > 0
+	})
+	if err != nil {
+		return "", nil, errors.Wrap(err, errFindBestMatch)
+	}
+
+	if config == nil {
+		// No ImageConfig with mirrors found for this image, this is not an
+		// error.
+		return "", nil, nil
+	}
+
+	m, ok := BestImageMatch(image, config.Spec.MatchImages...)
+	if !ok {
+		return config.Name, nil, errors.Errorf("image %q does not match image config %q", image, config.Name)
+	}
+
+	mirrors = make([]string, len(config.Spec.Mirrors))
+	for i := range config.Spec.Mirrors {
+		mirrors[i], err = m.Rewrite(image, &config.Spec.Mirrors[i])
+		if err != nil {
+			return config.Name, nil, errors.Wrapf(err, "cannot rewrite image path for mirror %d", i)
+		}
+	}
+
+	return config.Name, mirrors, nil
+}
+
 // bestMatch finds the best matching ImageConfig for an image based on the
-// longest prefix match.
+// most specific match. See ImageMatchResult.MoreSpecificThan.
 func (s *ImageConfigStore) bestMatch(ctx context.Context, image string, valid isValidConfig) (*v1beta1.ImageConfig, error) {
 	l := &v1beta1.ImageConfigList{}
 
@@ -167,8 +197,8 @@ func (s *ImageConfigStore) bestMatch(ctx context.Context, image string, valid is
 	}
 
 	var (
-		config  *v1beta1.ImageConfig
-		longest int
+		config *v1beta1.ImageConfig
+		best   ImageMatchResult
 	)
 
 	for _, c := range l.Items {
@@ -176,11 +206,14 @@ func (s *ImageConfigStore) bestMatch(ctx context.Context, image string, valid is
 			continue
 		}
 
-		for _, m := range c.Spec.MatchImages {
-			if strings.HasPrefix(image, m.Prefix) && len(m.Prefix) > longest {
-				longest = len(m.Prefix)
-				config = &c
-			}
+		m, ok := BestImageMatch(image, c.Spec.MatchImages...)
+		if !ok {
+			continue
+		}
+
+		if config == nil || m.MoreSpecificThan(best) {
+			best = m
+			config = &c
 		}
 	}
 
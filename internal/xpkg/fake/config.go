@@ -15,6 +15,7 @@ type MockConfigStore struct {
 	MockImageVerificationConfigFor func(ctx context.Context, image string) (imageConfig string, verificationConfig *v1beta1.ImageVerification, err error)
 	MockRewritePath                func(ctx context.Context, image string) (imageConfig, newPath string, err error)
 	MockRuntimeConfigFor           func(ctx context.Context, image string) (imageConfig string, runtimeConfig *v1beta1.ImageRuntime, err error)
+	MockMirrorsFor                 func(ctx context.Context, image string) (imageConfig string, mirrors []string, err error)
 }
 
 // PullSecretFor calls the underlying MockPullSecretFor.
@@ -37,6 +38,15 @@ func (s *MockConfigStore) RuntimeConfigFor(ctx context.Context, image string) (i
 	return s.MockRuntimeConfigFor(ctx, image)
 }
 
+// MirrorsFor calls the underlying MockMirrorsFor. It returns no mirrors if
+// MockMirrorsFor is not set.
+func (s *MockConfigStore) MirrorsFor(ctx context.Context, image string) (imageConfig string, mirrors []string, err error) {
+	if s.MockMirrorsFor == nil {
+		return "", nil, nil
+	}
+	return s.MockMirrorsFor(ctx, image)
+}
+
 // NewMockConfigStorePullSecretForFn creates a new MockPullSecretFor function for MockConfigStore.
 func NewMockConfigStorePullSecretForFn(imageConfig, pullSecret string, err error) func(context.Context, string) (string, string, error) {
 	return func(context.Context, string) (string, string, error) {
@@ -66,3 +76,11 @@ func NewMockRuntimeConfigForFn(imageConfig string, runtimeConfig *v1beta1.ImageR
 		return imageConfig, runtimeConfig, err
 	}
 }
+
+// NewMockMirrorsForFn creates a new MockMirrorsFor function for
+// MockConfigStore.
+func NewMockMirrorsForFn(imageConfig string, mirrors []string, err error) func(context.Context, string) (string, []string, error) {
+	return func(_ context.Context, _ string) (string, []string, error) {
+		return imageConfig, mirrors, err
+	}
+}
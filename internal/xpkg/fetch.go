@@ -22,6 +22,8 @@ import (
 	"crypto/x509"
 	"io"
 	"net/http"
+	"slices"
+	"strings"
 
 	"github.com/google/go-containerregistry/pkg/authn/k8schain"
 	"github.com/google/go-containerregistry/pkg/name"
@@ -57,6 +59,8 @@ type K8sFetcher struct {
 	serviceAccount string
 	transport      http.RoundTripper
 	userAgent      string
+	config         ConfigStore
+	health         *RegistryHealth
 }
 
 // FetcherOpt can be used to add optional parameters to NewK8sFetcher.
@@ -107,6 +111,27 @@ func WithServiceAccount(sa string) FetcherOpt {
 	}
 }
 
+// WithImageConfigStore is a FetcherOpt that sets the ImageConfig store used to
+// find mirrors of package images. Mirrors are tried, in order, if an image
+// can't be fetched from its own path.
+func WithImageConfigStore(s ConfigStore) FetcherOpt {
+	return func(k *K8sFetcher) error {
+		k.config = s
+		return nil
+	}
+}
+
+// WithRegistryHealth is a FetcherOpt that sets the tracker used to determine
+// which registries are healthy. Mirrors at unhealthy registries are tried
+// after mirrors at healthy registries. Fetchers that share a tracker share
+// what they learn about registry health.
+func WithRegistryHealth(h *RegistryHealth) FetcherOpt {
+	return func(k *K8sFetcher) error {
+		k.health = h
+		return nil
+	}
+}
+
 // NewK8sFetcher creates a new K8sFetcher.
 func NewK8sFetcher(client kubernetes.Interface, opts ...FetcherOpt) (*K8sFetcher, error) {
 	dt, ok := remote.DefaultTransport.(*http.Transport)
@@ -117,6 +142,7 @@ func NewK8sFetcher(client kubernetes.Interface, opts ...FetcherOpt) (*K8sFetcher
 	k := &K8sFetcher{
 		client:    client,
 		transport: dt.Clone(),
+		health:    NewRegistryHealth(),
 	}
 
 	for _, o := range opts {
@@ -128,8 +154,153 @@ func NewK8sFetcher(client kubernetes.Interface, opts ...FetcherOpt) (*K8sFetcher
 	return k, nil
 }
 
-// Fetch fetches a package image.
+// Fetch fetches a package image, falling back to its mirrors if it can't be
+// fetched.
 func (i *K8sFetcher) Fetch(ctx context.Context, ref name.Reference, secrets ...string) (v1.Image, error) {
+	var img v1.Image
+
+	err := i.try(ctx, ref, secrets, func(s Source) error {
+		var err error
+		img, err = i.fetch(ctx, s.ref, s.secrets...)
+		return err
+	})
+
+	return img, err
+}
+
+// Head fetches a package descriptor, falling back to the package's mirrors if
+// it can't be fetched.
+func (i *K8sFetcher) Head(ctx context.Context, ref name.Reference, secrets ...string) (*v1.Descriptor, error) {
+	var d *v1.Descriptor
+
+	err := i.try(ctx, ref, secrets, func(s Source) error {
+		var err error
+		d, err = i.head(ctx, s.ref, s.secrets...)
+		return err
+	})
+
+	return d, err
+}
+
+// Tags fetches a package's tags, falling back to the package's mirrors if they
+// can't be fetched.
+func (i *K8sFetcher) Tags(ctx context.Context, ref name.Reference, secrets ...string) ([]string, error) {
+	var tags []string
+
+	err := i.try(ctx, ref, secrets, func(s Source) error {
+		var err error
+		tags, err = i.tags(ctx, s.ref, s.secrets...)
+		return err
+	})
+
+	return tags, err
+}
+
+// ResolveSource returns the first source the supplied image can be fetched
+// from. Sources at healthy registries are tried first. It returns the image
+// itself if it has no mirrors, or if it can't be fetched from any source. The
+// sources of an image are probed at most once per probe period; the source
+// the image last resolved to is returned in between.
+func (i *K8sFetcher) ResolveSource(ctx context.Context, image string, secrets ...string) Source {
+	ref, err := name.ParseReference(image)
+	if err != nil {
+		return Source{Image: image}
+	}
+
+	srcs := i.sources(ctx, ref, secrets)
+
+	// Preserve the image exactly as supplied, rather than as parsed.
+	srcs[0].Image = image
+
+	if len(srcs) == 1 {
+		return srcs[0]
+	}
+
+	key := strings.Join(append([]string{image}, secrets...), ",")
+	if s, ok := i.health.Resolved(key); ok {
+		return s
+	}
+
+	for _, s := range i.health.Order(srcs) {
+		_, err := i.head(ctx, s.ref, s.secrets...)
+		i.health.Observe(s.ref.Context().RegistryStr(), err)
+
+		if err == nil {
+			i.health.Resolve(key, s)
+			return s
+		}
+	}
+
+	i.health.Resolve(key, srcs[0])
+
+	return srcs[0]
+}
+
+// try calls the supplied function for each source of the supplied reference,
+// until it succeeds.
+func (i *K8sFetcher) try(ctx context.Context, ref name.Reference, secrets []string, fn func(s Source) error) error {
+	srcs := i.sources(ctx, ref, secrets)
+	if len(srcs) == 1 {
+		// No need to track health if there's nothing to fall back to.
+		return fn(srcs[0])
+	}
+
+	errs := make([]error, 0, len(srcs))
+
+	for _, s := range i.health.Order(srcs) {
+		err := fn(s)
+		i.health.Observe(s.ref.Context().RegistryStr(), err)
+
+		if err == nil {
+			return nil
+		}
+
+		errs = append(errs, errors.Wrapf(err, "cannot fetch from %s", s.Image))
+	}
+
+	return errors.Join(errs...)
+}
+
+// sources returns the supplied reference, followed by its mirrors.
+func (i *K8sFetcher) sources(ctx context.Context, ref name.Reference, secrets []string) []Source {
+	srcs := []Source{{Image: ref.String(), ref: ref, secrets: secrets}}
+
+	if i.config == nil {
+		return srcs
+	}
+
+	// We can still fetch from the reference itself if we can't determine
+	// its mirrors, so we don't return an error here.
+	cfg, mirrors, err := i.config.MirrorsFor(ctx, ref.String())
+	if err != nil || len(mirrors) == 0 {
+		return srcs
+	}
+
+	// Callers usually supply the reference's pull secret from its image
+	// config, but may not have when resolving a source.
+	if _, ps, err := i.config.PullSecretFor(ctx, ref.String()); err == nil && ps != "" && !slices.Contains(secrets, ps) {
+		srcs[0].secrets = append(append([]string{}, secrets...), ps)
+	}
+
+	for _, m := range mirrors {
+		mref, err := name.ParseReference(m)
+		if err != nil {
+			continue
+		}
+
+		// The mirror may need a different pull secret than the reference.
+		ms := append([]string{}, secrets...)
+		if _, ps, err := i.config.PullSecretFor(ctx, m); err == nil && ps != "" {
+			ms = append(ms, ps)
+		}
+
+		srcs = append(srcs, Source{Image: m, ImageConfig: cfg, ref: mref, secrets: ms})
+	}
+
+	return srcs
+}
+
+func (i *K8sFetcher) fetch(ctx context.Context, ref name.Reference, secrets ...string) (v1.Image, error) {
 	auth, err := k8schain.New(ctx, i.client, k8schain.Options{
 		Namespace:          i.namespace,
 		ServiceAccountName: i.serviceAccount,
@@ -147,8 +318,7 @@ func (i *K8sFetcher) Fetch(ctx context.Context, ref name.Reference, secrets ...s
 	)
 }
 
-// Head fetches a package descriptor.
-func (i *K8sFetcher) Head(ctx context.Context, ref name.Reference, secrets ...string) (*v1.Descriptor, error) {
+func (i *K8sFetcher) head(ctx context.Context, ref name.Reference, secrets ...string) (*v1.Descriptor, error) {
 	auth, err := k8schain.New(ctx, i.client, k8schain.Options{
 		Namespace:          i.namespace,
 		ServiceAccountName: i.serviceAccount,
@@ -181,8 +351,7 @@ func (i *K8sFetcher) Head(ctx context.Context, ref name.Reference, secrets ...st
 	return d, nil
 }
 
-// Tags fetches a package's tags.
-func (i *K8sFetcher) Tags(ctx context.Context, ref name.Reference, secrets ...string) ([]string, error) {
+func (i *K8sFetcher) tags(ctx context.Context, ref name.Reference, secrets ...string) ([]string, error) {
 	auth, err := k8schain.New(ctx, i.client, k8schain.Options{
 		Namespace:          i.namespace,
 		ServiceAccountName: i.serviceAccount,
@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"time"
 
 	v1 "github.com/google/go-containerregistry/pkg/v1"
 	"github.com/google/go-containerregistry/pkg/v1/empty"
@@ -37,16 +38,37 @@ const (
 	errDigest = "cannot get image digest"
 )
 
+type layerOpts struct {
+	modTime time.Time
+}
+
+// A LayerOpt modifies how a layer is created.
+type LayerOpt func(*layerOpts)
+
+// WithModTime sets the modification time of the layer's file. Layers use the
+// Unix epoch by default, so that identical contents produce identical digests.
+func WithModTime(t time.Time) LayerOpt {
+	return func(o *layerOpts) {
+		o.modTime = t
+	}
+}
+
 // Layer creates a v1.Layer that represents the layer contents for the xpkg and
 // adds a corresponding label to the image Config for the layer.
-func Layer(r io.Reader, fileName, annotation string, fileSize int64, mode os.FileMode, cfg *v1.Config) (v1.Layer, error) {
+func Layer(r io.Reader, fileName, annotation string, fileSize int64, mode os.FileMode, cfg *v1.Config, opts ...LayerOpt) (v1.Layer, error) {
+	lo := &layerOpts{modTime: time.Unix(0, 0)}
+	for _, o := range opts {
+		o(lo)
+	}
+
 	tarBuf := new(bytes.Buffer)
 	tw := tar.NewWriter(tarBuf)
 
 	exHdr := &tar.Header{
-		Name: fileName,
-		Mode: int64(mode),
-		Size: fileSize,
+		Name:    fileName,
+		Mode:    int64(mode),
+		Size:    fileSize,
+		ModTime: lo.modTime.UTC(),
 	}
 
 	if err := writeLayer(tw, exHdr, r); err != nil {
@@ -0,0 +1,220 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package xpkg
+
+import (
+	"os"
+
+	"github.com/Masterminds/semver"
+	conregv1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/spf13/afero"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/yaml"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+
+	pkgmetav1 "github.com/crossplane/crossplane/v2/apis/pkg/meta/v1"
+	"github.com/crossplane/crossplane/v2/apis/pkg/v1beta1"
+)
+
+// LockfileName is the name of the file that pins a package's dependencies to
+// exact digests. It lives alongside the package's crossplane.yaml.
+const LockfileName = "crossplane.lock"
+
+const (
+	errReadLockfile  = "cannot read lockfile"
+	errParseLockfile = "cannot parse lockfile"
+	errWriteLockfile = "cannot write lockfile"
+	errEmbedLock     = "cannot embed lock in package meta"
+	errStaleLock     = "lockfile is out of date with the package's dependencies, run crossplane xpkg lock to update it"
+
+	errFmtNotLocked      = "dependency %s isn't locked"
+	errFmtLockedDigest   = "dependency %s is locked to digest %s, but depends on %s"
+	errFmtLockedVersion  = "dependency %s is locked to version %s, which doesn't satisfy constraint %s"
+	errFmtLockConstraint = "cannot parse version constraint %q of dependency %s"
+
+	errInvalidDependency = "package dependencies must specify either a valid type, or an explicit apiVersion, kind, and package"
+)
+
+const lockfileHeader = "# Generated by crossplane xpkg lock. DO NOT EDIT.\n"
+
+// A Lockfile pins a package's dependencies, and their dependencies, to exact
+// digests.
+type Lockfile struct {
+	// Lock is the package's locked dependencies.
+	Lock []pkgmetav1.LockedDependency `json:"lock"`
+}
+
+// ReadLockfile reads the lockfile at the supplied path. It returns nil if the
+// lockfile doesn't exist.
+func ReadLockfile(fs afero.Fs, path string) (*Lockfile, error) {
+	b, err := afero.ReadFile(fs, path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, errors.Wrap(err, errReadLockfile)
+	}
+
+	l := &Lockfile{}
+
+	return l, errors.Wrap(yaml.UnmarshalStrict(b, l), errParseLockfile)
+}
+
+// WriteLockfile writes the supplied lockfile to the supplied path.
+func WriteLockfile(fs afero.Fs, path string, l *Lockfile) error {
+	b, err := yaml.Marshal(l)
+	if err != nil {
+		return errors.Wrap(err, errWriteLockfile)
+	}
+
+	return errors.Wrap(afero.WriteFile(fs, path, append([]byte(lockfileHeader), b...), 0o644), errWriteLockfile)
+}
+
+// embedLock embeds the supplied lock in the supplied package meta. Meta that
+// isn't a v1 package meta type is converted to one.
+func embedLock(meta runtime.Object, lock []pkgmetav1.LockedDependency) (runtime.Object, error) {
+	m, ok := TryConvertToPkg(meta, &pkgmetav1.Provider{}, &pkgmetav1.Configuration{}, &pkgmetav1.Function{})
+	if !ok {
+		return nil, errors.New(errEmbedLock)
+	}
+
+	if err := checkLock(m.GetDependencies(), lock); err != nil {
+		return nil, errors.Wrap(err, errStaleLock)
+	}
+
+	switch p := m.(type) {
+	case *pkgmetav1.Configuration:
+		p.Spec.Lock = lock
+	case *pkgmetav1.Provider:
+		p.Spec.Lock = lock
+	case *pkgmetav1.Function:
+		p.Spec.Lock = lock
+	default:
+		return nil, errors.New(errEmbedLock)
+	}
+
+	return m, nil
+}
+
+// checkLock returns an error if the supplied lock doesn't pin every one of
+// the supplied dependencies to a version that satisfies its constraint.
+func checkLock(deps []pkgmetav1.Dependency, lock []pkgmetav1.LockedDependency) error {
+	ds, err := ToDependencies(deps)
+	if err != nil {
+		return err
+	}
+
+	locked := make(map[string]pkgmetav1.LockedDependency, len(lock))
+	for _, l := range lock {
+		locked[l.Package] = l
+	}
+
+	for _, d := range ds {
+		l, ok := locked[d.Package]
+		if !ok {
+			return errors.Errorf(errFmtNotLocked, d.Package)
+		}
+
+		if _, err := conregv1.NewHash(d.Constraints); err == nil {
+			if l.Digest != d.Constraints {
+				return errors.Errorf(errFmtLockedDigest, d.Package, l.Digest, d.Constraints)
+			}
+
+			continue
+		}
+
+		c, err := semver.NewConstraint(d.Constraints)
+		if err != nil {
+			return errors.Wrapf(err, errFmtLockConstraint, d.Constraints, d.Package)
+		}
+
+		v, err := semver.NewVersion(l.Version)
+		if err != nil || !c.Check(v) {
+			return errors.Errorf(errFmtLockedVersion, d.Package, l.Version, d.Constraints)
+		}
+	}
+
+	return nil
+}
+
+// ToDependencies converts package meta dependencies to lock dependencies the
+// same way the package revision controller does.
+func ToDependencies(deps []pkgmetav1.Dependency) ([]v1beta1.Dependency, error) {
+	out := make([]v1beta1.Dependency, len(deps))
+
+	for i, dep := range deps {
+		pdep := v1beta1.Dependency{}
+
+		switch {
+		// If the GVK and package are specified explicitly they take precedence.
+		case dep.APIVersion != nil && dep.Kind != nil && dep.Package != nil:
+			pdep.APIVersion = dep.APIVersion
+			pdep.Kind = dep.Kind
+			pdep.Package = *dep.Package
+		case dep.Configuration != nil:
+			pdep.Package = *dep.Configuration
+			pdep.Type = ptr.To(v1beta1.ConfigurationPackageType)
+		case dep.Provider != nil:
+			pdep.Package = *dep.Provider
+			pdep.Type = ptr.To(v1beta1.ProviderPackageType)
+		case dep.Function != nil:
+			pdep.Package = *dep.Function
+			pdep.Type = ptr.To(v1beta1.FunctionPackageType)
+		default:
+			return nil, errors.New(errInvalidDependency)
+		}
+
+		pdep.Constraints = dep.Version
+		out[i] = pdep
+	}
+
+	return out, nil
+}
+
+// PinDependencies pins the supplied dependencies to the digests in the supplied
+// package lock. Locked dependencies that aren't direct dependencies are the
+// package's transitive dependencies. They're added to the returned
+// dependencies so that they're installed at their pinned digests too.
+func PinDependencies(deps []v1beta1.Dependency, lock []pkgmetav1.LockedDependency) []v1beta1.Dependency {
+	if len(lock) == 0 {
+		return deps
+	}
+
+	direct := make(map[string]int, len(deps))
+	for i, dep := range deps {
+		direct[dep.Package] = i
+	}
+
+	for _, l := range lock {
+		if i, ok := direct[l.Package]; ok {
+			deps[i].Constraints = l.Digest
+			continue
+		}
+
+		deps = append(deps, v1beta1.Dependency{
+			APIVersion:  ptr.To(l.APIVersion),
+			Kind:        ptr.To(l.Kind),
+			Package:     l.Package,
+			Constraints: l.Digest,
+		})
+	}
+
+	return deps
+}
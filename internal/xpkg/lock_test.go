@@ -0,0 +1,150 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package xpkg
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/spf13/afero"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+
+	pkgmetav1 "github.com/crossplane/crossplane/v2/apis/pkg/meta/v1"
+	pkgmetav1alpha1 "github.com/crossplane/crossplane/v2/apis/pkg/meta/v1alpha1"
+	pkgmetav1beta1 "github.com/crossplane/crossplane/v2/apis/pkg/meta/v1beta1"
+)
+
+var testLock = []pkgmetav1.LockedDependency{{
+	APIVersion: "pkg.crossplane.io/v1",
+	Kind:       "Provider",
+	Package:    "xpkg.crossplane.io/crossplane-contrib/provider-nop",
+	Version:    "v0.4.0",
+	Digest:     "sha256:ecc25c121431dfc7058754427f97c034ecde26d4aafa0da16d50bd80c9c84a05",
+}}
+
+func TestLockfile(t *testing.T) {
+	fs := afero.NewMemMapFs()
+
+	got, err := ReadLockfile(fs, LockfileName)
+	if err != nil {
+		t.Fatalf("ReadLockfile(...): %v", err)
+	}
+
+	if got != nil {
+		t.Errorf("ReadLockfile(...): want nil for a missing lockfile, got %v", got)
+	}
+
+	want := &Lockfile{Lock: testLock}
+	if err := WriteLockfile(fs, LockfileName, want); err != nil {
+		t.Fatalf("WriteLockfile(...): %v", err)
+	}
+
+	got, err = ReadLockfile(fs, LockfileName)
+	if err != nil {
+		t.Fatalf("ReadLockfile(...): %v", err)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ReadLockfile(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestEmbedLock(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		meta   runtime.Object
+	}{
+		"V1Configuration": {
+			reason: "The lock should be embedded in v1 meta.",
+			meta:   &pkgmetav1.Configuration{ObjectMeta: metav1.ObjectMeta{Name: "a"}},
+		},
+		"V1Alpha1Provider": {
+			reason: "Older meta should be converted to v1 to embed the lock.",
+			meta:   &pkgmetav1alpha1.Provider{ObjectMeta: metav1.ObjectMeta{Name: "a"}},
+		},
+		"V1Beta1Function": {
+			reason: "Older meta should be converted to v1 to embed the lock.",
+			meta:   &pkgmetav1beta1.Function{ObjectMeta: metav1.ObjectMeta{Name: "a"}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := embedLock(tc.meta, testLock)
+			if err != nil {
+				t.Fatalf("\n%s\nembedLock(...): %v", tc.reason, err)
+			}
+
+			p, ok := got.(pkgmetav1.Pkg)
+			if !ok {
+				t.Fatalf("\n%s\nembedLock(...): want pkgmetav1.Pkg, got %T", tc.reason, got)
+			}
+
+			if diff := cmp.Diff(testLock, p.GetLock()); diff != "" {
+				t.Errorf("\n%s\nembedLock(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCheckLock(t *testing.T) {
+	provider := "xpkg.crossplane.io/crossplane-contrib/provider-nop"
+
+	cases := map[string]struct {
+		reason  string
+		deps    []pkgmetav1.Dependency
+		wantErr bool
+	}{
+		"NoDependencies": {
+			reason: "A lock is never stale for a package without dependencies.",
+		},
+		"SatisfiesConstraint": {
+			reason: "A dependency locked to a version that satisfies its constraint is up to date.",
+			deps:   []pkgmetav1.Dependency{{Provider: &provider, Version: ">=v0.3.0"}},
+		},
+		"SameDigest": {
+			reason: "A dependency locked to the digest it depends on is up to date.",
+			deps:   []pkgmetav1.Dependency{{Provider: &provider, Version: testLock[0].Digest}},
+		},
+		"NotLocked": {
+			reason:  "A dependency that isn't locked means the lock is stale.",
+			deps:    []pkgmetav1.Dependency{{Provider: ptr.To("xpkg.crossplane.io/crossplane-contrib/provider-other"), Version: ">=v0.1.0"}},
+			wantErr: true,
+		},
+		"UnsatisfiedConstraint": {
+			reason:  "A dependency locked to a version that doesn't satisfy its constraint means the lock is stale.",
+			deps:    []pkgmetav1.Dependency{{Provider: &provider, Version: ">=v0.5.0"}},
+			wantErr: true,
+		},
+		"DifferentDigest": {
+			reason:  "A dependency locked to a different digest than it depends on means the lock is stale.",
+			deps:    []pkgmetav1.Dependency{{Provider: &provider, Version: "sha256:0000000000000000000000000000000000000000000000000000000000000000"}},
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := checkLock(tc.deps, testLock)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("\n%s\ncheckLock(...): want error %t, got %v", tc.reason, tc.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,257 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package xpkg
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+
+	"github.com/crossplane/crossplane/v2/apis/pkg/v1beta1"
+)
+
+// An ImageMatchResult is the result of matching an image against an
+// ImageMatch rule.
+type ImageMatchResult struct {
+	// Rule is the rule that matched.
+	Rule v1beta1.ImageMatch
+
+	// Specificity of the match. Prefix matches are scored by their length,
+	// and Glob matches by the number of literal characters in their pattern.
+	// Regex matches have no literal score.
+	Specificity int
+
+	re *regexp.Regexp
+}
+
+// MoreSpecificThan returns true if this match is more specific than the
+// supplied match. Prefix and Glob matches are always more specific than Regex
+// matches. Otherwise a match with a higher specificity is more specific, and a
+// Prefix match is more specific than a Glob match of the same specificity.
+// Regex matches with longer patterns are more specific.
+func (r ImageMatchResult) MoreSpecificThan(o ImageMatchResult) bool {
+	rx, ox := matchType(r.Rule) == v1beta1.Regex, matchType(o.Rule) == v1beta1.Regex
+	if rx != ox {
+		return !rx
+	}
+
+	if rx {
+		return len(r.Rule.Regex) > len(o.Rule.Regex)
+	}
+
+	if r.Specificity != o.Specificity {
+		return r.Specificity > o.Specificity
+	}
+
+	return matchType(r.Rule) == v1beta1.Prefix && matchType(o.Rule) != v1beta1.Prefix
+}
+
+// Rewrite the supplied image, which must be the image that was matched.
+func (r ImageMatchResult) Rewrite(image string, rw *v1beta1.ImageRewrite) (string, error) {
+	if matchType(r.Rule) == v1beta1.Prefix {
+		if rw.Prefix == "" {
+			return "", errors.New("rewrite prefix is missing")
+		}
+
+		return rw.Prefix + strings.TrimPrefix(image, r.Rule.Prefix), nil
+	}
+
+	if rw.Replacement == "" {
+		return "", errors.New("rewrite replacement is missing")
+	}
+
+	idx := r.re.FindStringSubmatchIndex(image)
+	if idx == nil {
+		return "", errors.Errorf("image %q does not match", image)
+	}
+
+	return string(r.re.ExpandString(nil, rw.Replacement, image, idx)), nil
+}
+
+// MatchImage returns the result of matching the supplied image against the
+// supplied rule, and true if it matched. Rules with an invalid pattern never
+// match.
+func MatchImage(m v1beta1.ImageMatch, image string) (ImageMatchResult, bool) {
+	switch matchType(m) {
+	case v1beta1.Prefix:
+		if !strings.HasPrefix(image, m.Prefix) {
+			return ImageMatchResult{}, false
+		}
+
+		return ImageMatchResult{Rule: m, Specificity: len(m.Prefix)}, true
+	case v1beta1.Glob:
+		re, literal := patterns.Glob(m.Glob)
+		if !re.MatchString(image) {
+			return ImageMatchResult{}, false
+		}
+
+		return ImageMatchResult{Rule: m, Specificity: literal, re: re}, true
+	case v1beta1.Regex:
+		re, err := patterns.Regex(m.Regex)
+		if err != nil || !re.MatchString(image) {
+			return ImageMatchResult{}, false
+		}
+
+		return ImageMatchResult{Rule: m, re: re}, true
+	}
+
+	return ImageMatchResult{}, false
+}
+
+// ImageMatches returns true if the supplied image matches any of the supplied
+// rules.
+func ImageMatches(image string, rules ...v1beta1.ImageMatch) bool {
+	for _, m := range rules {
+		if _, ok := MatchImage(m, image); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// BestImageMatch returns the most specific of the supplied rules that matches
+// the supplied image, and true if any rule matched.
+func BestImageMatch(image string, rules ...v1beta1.ImageMatch) (ImageMatchResult, bool) {
+	var (
+		best  ImageMatchResult
+		found bool
+	)
+
+	for _, m := range rules {
+		r, ok := MatchImage(m, image)
+		if !ok {
+			continue
+		}
+
+		if !found || r.MoreSpecificThan(best) {
+			best, found = r, true
+		}
+	}
+
+	return best, found
+}
+
+// maxCachedPatterns is the maximum number of compiled patterns we cache.
+// Patterns come from ImageConfigs, so we expect far fewer than this. If there
+// are more we start again with an empty cache.
+const maxCachedPatterns = 1024
+
+// A compiledPattern is a compiled Regex or Glob pattern.
+type compiledPattern struct {
+	re      *regexp.Regexp
+	literal int
+	err     error
+}
+
+// A patternCache caches compiled patterns. We match every package image
+// against every ImageConfig's rules each time we pull, so we avoid compiling
+// the same patterns over and over.
+type patternCache struct {
+	mu       sync.RWMutex
+	compiled map[string]compiledPattern
+}
+
+var patterns = &patternCache{compiled: map[string]compiledPattern{}}
+
+// Regex returns the supplied regular expression, compiled and anchored so that
+// it must match an entire image.
+func (c *patternCache) Regex(regex string) (*regexp.Regexp, error) {
+	p := c.get("regex:"+regex, func() compiledPattern {
+		re, err := regexp.Compile("^(?:" + regex + ")$")
+		return compiledPattern{re: re, err: err}
+	})
+
+	return p.re, p.err
+}
+
+// Glob returns the supplied glob pattern, compiled per compileGlob.
+func (c *patternCache) Glob(glob string) (*regexp.Regexp, int) {
+	p := c.get("glob:"+glob, func() compiledPattern {
+		re, literal := compileGlob(glob)
+		return compiledPattern{re: re, literal: literal}
+	})
+
+	return p.re, p.literal
+}
+
+func (c *patternCache) get(key string, compile func() compiledPattern) compiledPattern {
+	c.mu.RLock()
+	p, ok := c.compiled[key]
+	c.mu.RUnlock()
+
+	if ok {
+		return p
+	}
+
+	p = compile()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.compiled) >= maxCachedPatterns {
+		c.compiled = map[string]compiledPattern{}
+	}
+
+	c.compiled[key] = p
+
+	return p
+}
+
+func matchType(m v1beta1.ImageMatch) v1beta1.MatchType {
+	if m.Type == "" {
+		return v1beta1.Prefix
+	}
+
+	return m.Type
+}
+
+// compileGlob compiles the supplied glob pattern to an anchored regular
+// expression in which each wildcard is a capture group. It also returns the
+// number of literal characters in the pattern.
+func compileGlob(glob string) (*regexp.Regexp, int) {
+	var (
+		b       strings.Builder
+		literal int
+	)
+
+	b.WriteString("^")
+
+	r := []rune(glob)
+	for i := 0; i < len(r); i++ {
+		switch {
+		case r[i] == '*' && i+1 < len(r) && r[i+1] == '*':
+			b.WriteString("(.*)")
+			i++
+		case r[i] == '*':
+			b.WriteString("([^/]*)")
+		case r[i] == '?':
+			b.WriteString("([^/])")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(r[i])))
+			literal++
+		}
+	}
+
+	b.WriteString("$")
+
+	// The pattern only contains quoted literals and valid groups, so it
+	// always compiles.
+	return regexp.MustCompile(b.String()), literal
+}
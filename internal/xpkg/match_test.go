@@ -0,0 +1,329 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package xpkg
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/test"
+
+	"github.com/crossplane/crossplane/v2/apis/pkg/v1beta1"
+)
+
+func TestMatchImage(t *testing.T) {
+	type args struct {
+		rule  v1beta1.ImageMatch
+		image string
+	}
+
+	type want struct {
+		specificity int
+		ok          bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"PrefixDefault": {
+			reason: "A rule with no type should be treated as a prefix match.",
+			args: args{
+				rule:  v1beta1.ImageMatch{Prefix: "xpkg.io/acme"},
+				image: "xpkg.io/acme/provider-foo:v1.0.0",
+			},
+			want: want{specificity: 12, ok: true},
+		},
+		"PrefixNoMatch": {
+			reason: "A prefix rule should not match an image without the prefix.",
+			args: args{
+				rule:  v1beta1.ImageMatch{Type: v1beta1.Prefix, Prefix: "xpkg.io/acme"},
+				image: "xpkg.io/other/provider-foo:v1.0.0",
+			},
+		},
+		"Glob": {
+			reason: "A glob rule should match the entire image and be scored by its literal characters.",
+			args: args{
+				rule:  v1beta1.ImageMatch{Type: v1beta1.Glob, Glob: "registry-*.acme.io/crossplane/**"},
+				image: "registry-eu.acme.io/crossplane/provider-foo:v1.0.0",
+			},
+			want: want{specificity: 29, ok: true},
+		},
+		"GlobStarDoesNotMatchSlash": {
+			reason: "A single '*' in a glob should not match a '/'.",
+			args: args{
+				rule:  v1beta1.ImageMatch{Type: v1beta1.Glob, Glob: "xpkg.io/*:v1"},
+				image: "xpkg.io/acme/provider-foo:v1",
+			},
+		},
+		"GlobQuotesMeta": {
+			reason: "Regular expression metacharacters in a glob should be matched literally.",
+			args: args{
+				rule:  v1beta1.ImageMatch{Type: v1beta1.Glob, Glob: "xpkg.io/**"},
+				image: "xpkgXio/acme/provider-foo",
+			},
+		},
+		"Regex": {
+			reason: "A regex rule should match the entire image.",
+			args: args{
+				rule:  v1beta1.ImageMatch{Type: v1beta1.Regex, Regex: `registry-(us|eu)\.acme\.io/(.*)`},
+				image: "registry-us.acme.io/crossplane/provider-foo:v1.0.0",
+			},
+			want: want{ok: true},
+		},
+		"RegexAnchored": {
+			reason: "A regex rule should not match part of an image.",
+			args: args{
+				rule:  v1beta1.ImageMatch{Type: v1beta1.Regex, Regex: `acme\.io/crossplane`},
+				image: "registry-us.acme.io/crossplane/provider-foo:v1.0.0",
+			},
+		},
+		"RegexInvalid": {
+			reason: "A regex rule with an invalid pattern should never match.",
+			args: args{
+				rule:  v1beta1.ImageMatch{Type: v1beta1.Regex, Regex: `(`},
+				image: "(",
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, ok := MatchImage(tc.args.rule, tc.args.image)
+
+			if diff := cmp.Diff(tc.want.ok, ok); diff != "" {
+				t.Errorf("\n%s\nMatchImage(...): -want ok, +got ok:\n%s", tc.reason, diff)
+			}
+
+			if diff := cmp.Diff(tc.want.specificity, got.Specificity); diff != "" {
+				t.Errorf("\n%s\nMatchImage(...): -want specificity, +got specificity:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestBestImageMatch(t *testing.T) {
+	type args struct {
+		rules []v1beta1.ImageMatch
+		image string
+	}
+
+	type want struct {
+		rule v1beta1.ImageMatch
+		ok   bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"NoMatch": {
+			reason: "No rule should be returned if none match.",
+			args: args{
+				rules: []v1beta1.ImageMatch{{Prefix: "xpkg.io/other"}},
+				image: "xpkg.io/acme/provider-foo",
+			},
+		},
+		"LongestPrefix": {
+			reason: "The longest prefix should take precedence over shorter prefixes.",
+			args: args{
+				rules: []v1beta1.ImageMatch{{Prefix: "xpkg.io/"}, {Prefix: "xpkg.io/acme/"}},
+				image: "xpkg.io/acme/provider-foo",
+			},
+			want: want{rule: v1beta1.ImageMatch{Prefix: "xpkg.io/acme/"}, ok: true},
+		},
+		"GlobWithMoreLiterals": {
+			reason: "A glob with more literal characters should take precedence over a shorter prefix.",
+			args: args{
+				rules: []v1beta1.ImageMatch{
+					{Prefix: "xpkg.io/"},
+					{Type: v1beta1.Glob, Glob: "xpkg.io/*/provider-foo"},
+				},
+				image: "xpkg.io/acme/provider-foo",
+			},
+			want: want{rule: v1beta1.ImageMatch{Type: v1beta1.Glob, Glob: "xpkg.io/*/provider-foo"}, ok: true},
+		},
+		"PrefixBeatsEquallySpecificGlob": {
+			reason: "A prefix should take precedence over a glob with the same number of literal characters.",
+			args: args{
+				rules: []v1beta1.ImageMatch{
+					{Type: v1beta1.Glob, Glob: "xpkg.io/**"},
+					{Prefix: "xpkg.io/"},
+				},
+				image: "xpkg.io/acme/provider-foo",
+			},
+			want: want{rule: v1beta1.ImageMatch{Prefix: "xpkg.io/"}, ok: true},
+		},
+		"PrefixBeatsRegex": {
+			reason: "Any prefix should take precedence over a regex.",
+			args: args{
+				rules: []v1beta1.ImageMatch{
+					{Type: v1beta1.Regex, Regex: `xpkg\.io/acme/provider-foo`},
+					{Prefix: "x"},
+				},
+				image: "xpkg.io/acme/provider-foo",
+			},
+			want: want{rule: v1beta1.ImageMatch{Prefix: "x"}, ok: true},
+		},
+		"LongestRegex": {
+			reason: "The longest regex should take precedence over shorter regexes.",
+			args: args{
+				rules: []v1beta1.ImageMatch{
+					{Type: v1beta1.Regex, Regex: `.*`},
+					{Type: v1beta1.Regex, Regex: `xpkg\.io/.*`},
+				},
+				image: "xpkg.io/acme/provider-foo",
+			},
+			want: want{rule: v1beta1.ImageMatch{Type: v1beta1.Regex, Regex: `xpkg\.io/.*`}, ok: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, ok := BestImageMatch(tc.args.image, tc.args.rules...)
+
+			if diff := cmp.Diff(tc.want.ok, ok); diff != "" {
+				t.Errorf("\n%s\nBestImageMatch(...): -want ok, +got ok:\n%s", tc.reason, diff)
+			}
+
+			if diff := cmp.Diff(tc.want.rule, got.Rule); diff != "" {
+				t.Errorf("\n%s\nBestImageMatch(...): -want rule, +got rule:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestImageMatchResultRewrite(t *testing.T) {
+	type args struct {
+		rule    v1beta1.ImageMatch
+		rewrite *v1beta1.ImageRewrite
+		image   string
+	}
+
+	type want struct {
+		image string
+		err   error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"Prefix": {
+			reason: "A prefix match should replace the matched prefix.",
+			args: args{
+				rule:    v1beta1.ImageMatch{Prefix: "xpkg.io/acme/"},
+				rewrite: &v1beta1.ImageRewrite{Prefix: "mirror.acme.io/"},
+				image:   "xpkg.io/acme/provider-foo:v1.0.0",
+			},
+			want: want{image: "mirror.acme.io/provider-foo:v1.0.0"},
+		},
+		"PrefixMissing": {
+			reason: "A prefix match requires a rewrite prefix.",
+			args: args{
+				rule:    v1beta1.ImageMatch{Prefix: "xpkg.io/acme/"},
+				rewrite: &v1beta1.ImageRewrite{Replacement: "mirror.acme.io/$1"},
+				image:   "xpkg.io/acme/provider-foo:v1.0.0",
+			},
+			want: want{err: errors.New("rewrite prefix is missing")},
+		},
+		"RegexCaptureGroups": {
+			reason: "A regex match should expand capture groups in the replacement.",
+			args: args{
+				rule:    v1beta1.ImageMatch{Type: v1beta1.Regex, Regex: `registry-(?P<region>us|eu)\.acme\.io/(.*)`},
+				rewrite: &v1beta1.ImageRewrite{Replacement: "mirror.acme.io/${region}/$2"},
+				image:   "registry-eu.acme.io/crossplane/provider-foo:v1.0.0",
+			},
+			want: want{image: "mirror.acme.io/eu/crossplane/provider-foo:v1.0.0"},
+		},
+		"GlobCaptureGroups": {
+			reason: "A glob match should expand its wildcards as capture groups in the replacement.",
+			args: args{
+				rule:    v1beta1.ImageMatch{Type: v1beta1.Glob, Glob: "registry-*.acme.io/**"},
+				rewrite: &v1beta1.ImageRewrite{Replacement: "mirror.acme.io/${1}/$2"},
+				image:   "registry-us.acme.io/crossplane/provider-foo:v1.0.0",
+			},
+			want: want{image: "mirror.acme.io/us/crossplane/provider-foo:v1.0.0"},
+		},
+		"ReplacementMissing": {
+			reason: "A regex or glob match requires a rewrite replacement.",
+			args: args{
+				rule:    v1beta1.ImageMatch{Type: v1beta1.Glob, Glob: "xpkg.io/**"},
+				rewrite: &v1beta1.ImageRewrite{Prefix: "mirror.acme.io/"},
+				image:   "xpkg.io/acme/provider-foo:v1.0.0",
+			},
+			want: want{err: errors.New("rewrite replacement is missing")},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			m, ok := MatchImage(tc.args.rule, tc.args.image)
+			if !ok {
+				t.Fatalf("\n%s\nMatchImage(...): image %q does not match", tc.reason, tc.args.image)
+			}
+
+			got, err := m.Rewrite(tc.args.image, tc.args.rewrite)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nRewrite(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+
+			if diff := cmp.Diff(tc.want.image, got); diff != "" {
+				t.Errorf("\n%s\nRewrite(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestPatternCache(t *testing.T) {
+	c := &patternCache{compiled: map[string]compiledPattern{}}
+
+	re1, err := c.Regex("registry-(us|eu)[.]example[.]com/(.*)")
+	if err != nil {
+		t.Fatalf("c.Regex(...): %s", err)
+	}
+
+	re2, _ := c.Regex("registry-(us|eu)[.]example[.]com/(.*)")
+	if re1 != re2 {
+		t.Errorf("c.Regex(...): want the cached regular expression, got a newly compiled one")
+	}
+
+	if _, err := c.Regex("registry-(us|eu"); err == nil {
+		t.Errorf("c.Regex(...): want error compiling an invalid regular expression, got nil")
+	}
+
+	g1, _ := c.Glob("registry-*.example.com/**")
+	g2, _ := c.Glob("registry-*.example.com/**")
+	if g1 != g2 {
+		t.Errorf("c.Glob(...): want the cached glob, got a newly compiled one")
+	}
+
+	for i := range maxCachedPatterns {
+		c.Glob(strconv.Itoa(i))
+	}
+
+	if got := len(c.compiled); got > maxCachedPatterns {
+		t.Errorf("len(c.compiled): want at most %d cached patterns, got %d", maxCachedPatterns, got)
+	}
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package xpkg
+
+import (
+	"archive/tar"
+	"context"
+	"io"
+
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/parser"
+
+	pkgmetav1 "github.com/crossplane/crossplane/v2/apis/pkg/meta/v1"
+)
+
+const (
+	errGetManifest             = "cannot get package image manifest"
+	errFetchLayer              = "cannot fetch annotated base layer"
+	errGetUncompressed         = "cannot get uncompressed contents from layer"
+	errMultipleAnnotatedLayers = "package is invalid due to multiple annotated base layers"
+	errOpenPackageStream       = "cannot open package stream file"
+)
+
+// PackageMeta returns the meta file of the supplied package image.
+func PackageMeta(ctx context.Context, img v1.Image) (pkgmetav1.Pkg, error) {
+	manifest, err := img.Manifest()
+	if err != nil {
+		return nil, errors.Wrap(err, errGetManifest)
+	}
+
+	var tarc io.ReadCloser
+
+	for _, l := range manifest.Layers {
+		if a, ok := l.Annotations[AnnotationKey]; !ok || a != PackageAnnotation {
+			continue
+		}
+
+		if tarc != nil {
+			return nil, errors.New(errMultipleAnnotatedLayers)
+		}
+
+		layer, err := img.LayerByDigest(l.Digest)
+		if err != nil {
+			return nil, errors.Wrap(err, errFetchLayer)
+		}
+
+		tarc, err = layer.Uncompressed()
+		if err != nil {
+			return nil, errors.Wrap(err, errGetUncompressed)
+		}
+	}
+
+	// If there's no annotated layer we need to flatten the image filesystem.
+	if tarc == nil {
+		tarc = mutate.Extract(img)
+	}
+	defer tarc.Close() //nolint:errcheck // Only open for reading.
+
+	t := tar.NewReader(tarc)
+
+	for {
+		h, err := t.Next()
+		if err != nil {
+			return nil, errors.Wrap(err, errOpenPackageStream)
+		}
+
+		if h.Name == StreamFile {
+			break
+		}
+	}
+
+	metaScheme, err := BuildMetaScheme()
+	if err != nil {
+		return nil, err
+	}
+
+	objScheme, err := BuildObjectScheme()
+	if err != nil {
+		return nil, err
+	}
+
+	pkg, err := parser.New(metaScheme, objScheme).Parse(ctx, io.NopCloser(t))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(pkg.GetMeta()) != 1 {
+		return nil, errors.New(errNotExactlyOneMeta)
+	}
+
+	meta, ok := TryConvertToPkg(pkg.GetMeta()[0], &pkgmetav1.Provider{}, &pkgmetav1.Configuration{}, &pkgmetav1.Function{})
+	if !ok {
+		return nil, errors.New(errNotMeta)
+	}
+
+	return meta, nil
+}
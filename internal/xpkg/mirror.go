@@ -0,0 +1,208 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package xpkg
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+)
+
+const (
+	// DefaultUnhealthyPeriod is how long a registry that failed is
+	// considered unhealthy.
+	DefaultUnhealthyPeriod = 1 * time.Minute
+
+	// DefaultProbePeriod is how long the source an image resolved to is
+	// reused before its sources are probed again.
+	DefaultProbePeriod = 1 * time.Minute
+)
+
+// A Source is a location a package image can be pulled from.
+type Source struct {
+	// Image is the path of the image at this source.
+	Image string
+
+	// ImageConfig is the name of the ImageConfig that configured this source
+	// as a mirror. It's empty if the source isn't a mirror.
+	ImageConfig string
+
+	ref     name.Reference
+	secrets []string
+}
+
+// A SourceResolver resolves the source a package image should be pulled from.
+type SourceResolver interface {
+	// ResolveSource returns the source the supplied image should be pulled
+	// from. This is either the image itself, or one of its mirrors. It
+	// returns the image itself if no source is available.
+	ResolveSource(ctx context.Context, image string, secrets ...string) Source
+}
+
+// NopSourceResolver always resolves an image to itself.
+type NopSourceResolver struct{}
+
+// ResolveSource returns the supplied image.
+func (NopSourceResolver) ResolveSource(_ context.Context, image string, _ ...string) Source {
+	return Source{Image: image}
+}
+
+// RegistryHealth tracks which registries recently failed, and which source
+// each image recently resolved to. It's safe for concurrent use, and is
+// intended to be shared by all fetchers so that a failure observed by one
+// controller is known to all of them.
+type RegistryHealth struct {
+	mu             sync.Mutex
+	unhealthyUntil map[string]time.Time
+	resolved       map[string]resolution
+	period         time.Duration
+	probePeriod    time.Duration
+	now            func() time.Time
+}
+
+type resolution struct {
+	source Source
+	until  time.Time
+}
+
+// A RegistryHealthOption configures a RegistryHealth.
+type RegistryHealthOption func(h *RegistryHealth)
+
+// WithUnhealthyPeriod sets how long a registry that failed is considered
+// unhealthy.
+func WithUnhealthyPeriod(d time.Duration) RegistryHealthOption {
+	return func(h *RegistryHealth) {
+		h.period = d
+	}
+}
+
+// WithProbePeriod sets how long the source an image resolved to is reused
+// before its sources are probed again.
+func WithProbePeriod(d time.Duration) RegistryHealthOption {
+	return func(h *RegistryHealth) {
+		h.probePeriod = d
+	}
+}
+
+// NewRegistryHealth returns a new RegistryHealth.
+func NewRegistryHealth(o ...RegistryHealthOption) *RegistryHealth {
+	h := &RegistryHealth{
+		unhealthyUntil: make(map[string]time.Time),
+		resolved:       make(map[string]resolution),
+		period:         DefaultUnhealthyPeriod,
+		probePeriod:    DefaultProbePeriod,
+		now:            time.Now,
+	}
+
+	for _, fn := range o {
+		fn(h)
+	}
+
+	return h
+}
+
+// Healthy returns true if the supplied registry didn't recently fail.
+func (h *RegistryHealth) Healthy(registry string) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return !h.now().Before(h.unhealthyUntil[registry])
+}
+
+// Observe the result of a request to the supplied registry. Only errors that
+// suggest the registry is unavailable make it unhealthy. A registry that
+// doesn't have an image, or that rejected our credentials, is up.
+func (h *RegistryHealth) Observe(registry string, err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if !unavailable(err) {
+		delete(h.unhealthyUntil, registry)
+		return
+	}
+
+	h.unhealthyUntil[registry] = h.now().Add(h.period)
+}
+
+func unavailable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	terr := &transport.Error{}
+	if errors.As(err, &terr) {
+		return terr.StatusCode >= http.StatusInternalServerError || terr.StatusCode == http.StatusTooManyRequests
+	}
+
+	// Anything else is most likely a network error.
+	return true
+}
+
+// Order the supplied sources so that sources at healthy registries come first.
+// The order is otherwise preserved.
+func (h *RegistryHealth) Order(sources []Source) []Source {
+	healthy := make([]Source, 0, len(sources))
+	unhealthy := make([]Source, 0)
+
+	for _, s := range sources {
+		if h.Healthy(s.ref.Context().RegistryStr()) {
+			healthy = append(healthy, s)
+			continue
+		}
+
+		unhealthy = append(unhealthy, s)
+	}
+
+	return append(healthy, unhealthy...)
+}
+
+// Resolved returns the source the supplied key recently resolved to, if any.
+func (h *RegistryHealth) Resolved(key string) (Source, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	r, ok := h.resolved[key]
+	if !ok || !h.now().Before(r.until) {
+		return Source{}, false
+	}
+
+	return r.source, true
+}
+
+// Resolve records that the supplied key resolved to the supplied source.
+func (h *RegistryHealth) Resolve(key string, s Source) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	now := h.now()
+
+	// Drop stale resolutions so images that are no longer resolved don't
+	// accumulate.
+	for k, r := range h.resolved {
+		if !now.Before(r.until) {
+			delete(h.resolved, k)
+		}
+	}
+
+	h.resolved[key] = resolution{source: s, until: now.Add(h.probePeriod)}
+}
@@ -0,0 +1,381 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package xpkg
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	"k8s.io/client-go/kubernetes/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/test"
+
+	"github.com/crossplane/crossplane/v2/apis/pkg/v1beta1"
+)
+
+// A mirrorStore is a ConfigStore that returns the same mirrors for any image.
+type mirrorStore struct {
+	ConfigStore
+
+	config  string
+	mirrors []string
+}
+
+func (s *mirrorStore) MirrorsFor(_ context.Context, _ string) (string, []string, error) {
+	return s.config, s.mirrors, nil
+}
+
+func (s *mirrorStore) PullSecretFor(_ context.Context, _ string) (string, string, error) {
+	return "", "", nil
+}
+
+// A flakyRegistry is an in-process registry that can be taken down.
+type flakyRegistry struct {
+	*httptest.Server
+
+	down     atomic.Bool
+	requests atomic.Int32
+}
+
+func newFlakyRegistry(t *testing.T) *flakyRegistry {
+	t.Helper()
+
+	r := &flakyRegistry{}
+	reg := registry.New()
+	r.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		r.requests.Add(1)
+		if r.down.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		reg.ServeHTTP(w, req)
+	}))
+	t.Cleanup(r.Close)
+
+	return r
+}
+
+func (r *flakyRegistry) Host() string {
+	return strings.TrimPrefix(r.URL, "http://")
+}
+
+func (r *flakyRegistry) Push(t *testing.T, repo string) name.Reference {
+	t.Helper()
+
+	ref, err := name.ParseReference(r.Host() + "/" + repo + ":v1.0.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	img, err := random.Image(64, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := remote.Write(ref, img); err != nil {
+		t.Fatal(err)
+	}
+
+	return ref
+}
+
+func TestK8sFetcherMirrors(t *testing.T) {
+	primary := newFlakyRegistry(t)
+	mirror := newFlakyRegistry(t)
+
+	ref := primary.Push(t, "crossplane/provider-foo")
+	mref := mirror.Push(t, "crossplane/provider-foo")
+
+	newFetcher := func(t *testing.T) *K8sFetcher {
+		t.Helper()
+
+		f, err := NewK8sFetcher(fake.NewClientset(), WithImageConfigStore(&mirrorStore{config: "mirrors", mirrors: []string{mref.String()}}))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return f
+	}
+
+	t.Run("PrimaryUp", func(t *testing.T) {
+		primary.down.Store(false)
+		f := newFetcher(t)
+
+		got := f.ResolveSource(context.Background(), ref.String())
+		if diff := cmp.Diff(ref.String(), got.Image); diff != "" {
+			t.Errorf("ResolveSource(...): -want, +got:\n%s", diff)
+		}
+
+		if diff := cmp.Diff("", got.ImageConfig); diff != "" {
+			t.Errorf("ResolveSource(...): -want image config, +got image config:\n%s", diff)
+		}
+	})
+
+	t.Run("PrimaryDown", func(t *testing.T) {
+		primary.down.Store(true)
+		defer primary.down.Store(false)
+
+		f := newFetcher(t)
+
+		got := f.ResolveSource(context.Background(), ref.String())
+		if diff := cmp.Diff(mref.String(), got.Image); diff != "" {
+			t.Errorf("ResolveSource(...): -want, +got:\n%s", diff)
+		}
+
+		if diff := cmp.Diff("mirrors", got.ImageConfig); diff != "" {
+			t.Errorf("ResolveSource(...): -want image config, +got image config:\n%s", diff)
+		}
+
+		img, err := f.Fetch(context.Background(), ref)
+		if err != nil {
+			t.Fatalf("Fetch(...): %v", err)
+		}
+
+		d, err := f.Head(context.Background(), ref)
+		if err != nil {
+			t.Fatalf("Head(...): %v", err)
+		}
+
+		want, _ := img.Digest()
+		if diff := cmp.Diff(want, d.Digest); diff != "" {
+			t.Errorf("Head(...): -want digest from Fetch(...), +got digest:\n%s", diff)
+		}
+
+		tags, err := f.Tags(context.Background(), ref)
+		if err != nil {
+			t.Fatalf("Tags(...): %v", err)
+		}
+
+		if diff := cmp.Diff([]string{"v1.0.0"}, tags); diff != "" {
+			t.Errorf("Tags(...): -want, +got:\n%s", diff)
+		}
+	})
+
+	t.Run("UnhealthyTriedLast", func(t *testing.T) {
+		primary.down.Store(true)
+
+		f := newFetcher(t)
+
+		// Trip the primary's health.
+		if _, err := f.Head(context.Background(), ref); err != nil {
+			t.Fatalf("Head(...): %v", err)
+		}
+
+		// The primary is back, but we shouldn't try it again until its
+		// unhealthy period elapses.
+		primary.down.Store(false)
+		before := primary.requests.Load()
+
+		if _, err := f.Head(context.Background(), ref); err != nil {
+			t.Fatalf("Head(...): %v", err)
+		}
+
+		if got := primary.requests.Load() - before; got != 0 {
+			t.Errorf("Head(...): want no requests to unhealthy primary, got %d", got)
+		}
+	})
+
+	t.Run("SharedHealth", func(t *testing.T) {
+		primary.down.Store(true)
+
+		h := NewRegistryHealth()
+		store := &mirrorStore{config: "mirrors", mirrors: []string{mref.String()}}
+
+		a, err := NewK8sFetcher(fake.NewClientset(), WithImageConfigStore(store), WithRegistryHealth(h))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		b, err := NewK8sFetcher(fake.NewClientset(), WithImageConfigStore(store), WithRegistryHealth(h))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Trip the primary's health using one fetcher.
+		if _, err := a.Head(context.Background(), ref); err != nil {
+			t.Fatalf("Head(...): %v", err)
+		}
+
+		primary.down.Store(false)
+		before := primary.requests.Load()
+
+		// The other fetcher should know the primary is unhealthy.
+		if _, err := b.Head(context.Background(), ref); err != nil {
+			t.Fatalf("Head(...): %v", err)
+		}
+
+		if got := primary.requests.Load() - before; got != 0 {
+			t.Errorf("Head(...): want no requests to primary known unhealthy by a shared tracker, got %d", got)
+		}
+	})
+
+	t.Run("ProbesRateLimited", func(t *testing.T) {
+		f := newFetcher(t)
+
+		first := f.ResolveSource(context.Background(), ref.String())
+
+		before := primary.requests.Load() + mirror.requests.Load()
+
+		// Resolving again within the probe period shouldn't probe any source.
+		second := f.ResolveSource(context.Background(), ref.String())
+
+		if got := primary.requests.Load() + mirror.requests.Load() - before; got != 0 {
+			t.Errorf("ResolveSource(...): want no probes within the probe period, got %d", got)
+		}
+
+		if diff := cmp.Diff(first.Image, second.Image); diff != "" {
+			t.Errorf("ResolveSource(...): -want first resolution, +got:\n%s", diff)
+		}
+	})
+
+	t.Run("AllDown", func(t *testing.T) {
+		primary.down.Store(true)
+		mirror.down.Store(true)
+
+		defer primary.down.Store(false)
+		defer mirror.down.Store(false)
+
+		f := newFetcher(t)
+
+		if _, err := f.Head(context.Background(), ref); err == nil {
+			t.Errorf("Head(...): want error when all sources are down")
+		}
+
+		got := f.ResolveSource(context.Background(), ref.String())
+		if diff := cmp.Diff(ref.String(), got.Image); diff != "" {
+			t.Errorf("ResolveSource(...): -want, +got:\n%s", diff)
+		}
+	})
+}
+
+func TestRegistryHealth(t *testing.T) {
+	now := time.Now()
+
+	h := NewRegistryHealth()
+	h.now = func() time.Time { return now }
+
+	cases := map[string]struct {
+		reason string
+		err    error
+		want   bool
+	}{
+		"Success": {
+			reason: "A successful request should leave a registry healthy.",
+			want:   true,
+		},
+		"NotFound": {
+			reason: "A registry that doesn't have an image is still healthy.",
+			err:    &transport.Error{StatusCode: http.StatusNotFound},
+			want:   true,
+		},
+		"ServerError": {
+			reason: "A registry that returns a server error is unhealthy.",
+			err:    &transport.Error{StatusCode: http.StatusBadGateway},
+			want:   false,
+		},
+		"NetworkError": {
+			reason: "A registry that can't be reached is unhealthy.",
+			err:    errors.New("connection refused"),
+			want:   false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			h.Observe(name, tc.err)
+
+			if diff := cmp.Diff(tc.want, h.Healthy(name)); diff != "" {
+				t.Errorf("\n%s\nHealthy(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+
+	t.Run("Recovers", func(t *testing.T) {
+		h.Observe("recovers", errors.New("connection refused"))
+		now = now.Add(time.Minute)
+
+		if !h.Healthy("recovers") {
+			t.Errorf("Healthy(...): want registry to be healthy after its unhealthy period")
+		}
+	})
+}
+
+func TestRegistryHealthResolved(t *testing.T) {
+	now := time.Now()
+
+	h := NewRegistryHealth(WithProbePeriod(time.Minute))
+	h.now = func() time.Time { return now }
+
+	want := Source{Image: "xpkg.io/crossplane/provider-foo:v1.0.0", ImageConfig: "mirrors"}
+	h.Resolve("image", want)
+
+	got, ok := h.Resolved("image")
+	if !ok {
+		t.Fatalf("Resolved(...): want a resolution within the probe period")
+	}
+
+	if diff := cmp.Diff(want, got, cmpopts.IgnoreUnexported(Source{})); diff != "" {
+		t.Errorf("Resolved(...): -want, +got:\n%s", diff)
+	}
+
+	now = now.Add(time.Minute)
+
+	if _, ok := h.Resolved("image"); ok {
+		t.Errorf("Resolved(...): want no resolution after the probe period")
+	}
+}
+
+func TestImageConfigStoreMirrorsFor(t *testing.T) {
+	s := &ImageConfigStore{client: &test.MockClient{
+		MockList: func(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+			*list.(*v1beta1.ImageConfigList) = v1beta1.ImageConfigList{Items: []v1beta1.ImageConfig{{
+				Spec: v1beta1.ImageConfigSpec{
+					MatchImages: []v1beta1.ImageMatch{{Prefix: "internal.acme.io/"}},
+					Mirrors: []v1beta1.ImageRewrite{
+						{Prefix: "backup.acme.io/"},
+						{Prefix: "xpkg.io/"},
+					},
+				},
+			}}}
+			return nil
+		},
+	}}
+
+	_, got, err := s.MirrorsFor(context.Background(), "internal.acme.io/crossplane/provider-foo:v1.0.0")
+	if err != nil {
+		t.Fatalf("MirrorsFor(...): %v", err)
+	}
+
+	want := []string{"backup.acme.io/crossplane/provider-foo:v1.0.0", "xpkg.io/crossplane/provider-foo:v1.0.0"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("MirrorsFor(...): -want, +got:\n%s", diff)
+	}
+}
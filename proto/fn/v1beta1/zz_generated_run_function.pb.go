@@ -268,6 +268,16 @@ func (Status) EnumDescriptor() ([]byte, []int) {
 }
 
 // A RunFunctionRequest requests that the function be run.
+//
+// Crossplane also runs functions with the "conversion" capability to convert a
+// composite resource between the versions of its XRD. When converting, the
+// observed composite resource is the object to convert, and the desired
+// composite resource is a copy of it set to the apiVersion to convert to. The
+// function returns the converted object as its desired composite resource.
+// Crossplane keeps the object's kind and metadata, and sets its apiVersion,
+// regardless of what the function returns. A fatal result fails the
+// conversion. Crossplane ignores composed resources, context, and requirements
+// when converting.
 type RunFunctionRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Metadata pertaining to this request.